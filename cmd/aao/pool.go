@@ -7,6 +7,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"time"
 
 	awsv1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
 	"github.com/openshift/osdctl/pkg/printer"
@@ -14,14 +15,31 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
 // newCmdPool gets the current status of the AWS Account Operator AccountPool
 func newCmdPool(client client.Client) *cobra.Command {
 	ops := newPoolOptions(client)
 	poolCmd := &cobra.Command{
-		Use:               "pool",
-		Short:             "Get the status of the AWS Account Operator AccountPool",
+		Use:   "pool",
+		Short: "Get the status of the AWS Account Operator AccountPool",
+		Long: `Get the status of the AWS Account Operator AccountPool
+
+  Counts the accounts of the default and fm-accountpool pools by legal entity, lists accounts
+  which have been Creating, Failed or Reused for longer than --stuck-threshold and projects
+  when the default pool runs out per region, based on the rate accounts were claimed within
+  --claim-window. Regions requiring opt-in can only use accounts with the region enabled, so
+  they are projected separately from the rest of the pool.
+
+  With --history, the pool counts of each run are recorded in a local history file and the
+  trend of the most recent runs is printed.`,
+		Example: `
+  # Get the status of the account pool
+  osdctl aao pool
+
+  # Record the pool counts and show their trend across runs
+  osdctl aao pool --history`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -30,6 +48,11 @@ func newCmdPool(client client.Client) *cobra.Command {
 		},
 	}
 
+	poolCmd.Flags().DurationVar(&ops.stuckThreshold, "stuck-threshold", defaultStuckThreshold, "List accounts which have been Creating, Failed or Reused for longer than this")
+	poolCmd.Flags().DurationVar(&ops.claimWindow, "claim-window", defaultClaimWindow, "Project the pool exhaustion from the claims created within this window")
+	poolCmd.Flags().BoolVar(&ops.history, "history", false, "Record the pool counts in a local history file and print their trend")
+	poolCmd.Flags().StringVar(&ops.historyFile, "history-file", "", "Path of the history file used by --history (defaults to aao/pool-history.json in the osdctl cache directory)")
+
 	return poolCmd
 }
 
@@ -37,15 +60,45 @@ func newCmdPool(client client.Client) *cobra.Command {
 type poolOptions struct {
 	genericclioptions.IOStreams
 	kubeCli client.Client
+
+	stuckThreshold time.Duration
+	claimWindow    time.Duration
+	history        bool
+	historyFile    string
+	// cluster is the API server of the cluster running the operator, which separates the history of each cluster
+	cluster string
 }
 
 func newPoolOptions(client client.Client) *poolOptions {
 	return &poolOptions{
-		kubeCli: client,
+		kubeCli:        client,
+		stuckThreshold: defaultStuckThreshold,
+		claimWindow:    defaultClaimWindow,
 	}
 }
 
 func (o *poolOptions) complete(cmd *cobra.Command) error {
+	if o.stuckThreshold <= 0 || o.claimWindow <= 0 {
+		return cmdutil.UsageErrorf(cmd, "--stuck-threshold and --claim-window must be positive durations")
+	}
+	if !o.history {
+		return nil
+	}
+
+	if o.historyFile == "" {
+		path, err := defaultPoolHistoryPath()
+		if err != nil {
+			return err
+		}
+		o.historyFile = path
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return cmdutil.UsageErrorf(cmd, "could not find KUBECONFIG, please make sure you are logged into the cluster running the AWS Account Operator")
+	}
+	o.cluster = cfg.Host
+
 	return nil
 }
 
@@ -66,6 +119,11 @@ func (o *poolOptions) run() error {
 		return err
 	}
 
+	var claims awsv1alpha1.AccountClaimList
+	if err := o.kubeCli.List(ctx, &claims); err != nil {
+		return err
+	}
+
 	// mapping legalentityid to count
 	defaultMap := make(map[string]legalEntityStats)
 	fmMap := make(map[string]legalEntityStats)
@@ -81,7 +139,7 @@ func (o *poolOptions) run() error {
 			continue
 		}
 
-		if account.Spec.AccountPool == "fm-accountpool" { // non-default accountpool
+		if account.Spec.AccountPool == fmAccountPool { // non-default accountpool
 			handlePoolCounting(fmMap, account)
 		} else {
 			handlePoolCounting(defaultMap, account)
@@ -98,6 +156,37 @@ func (o *poolOptions) run() error {
 	fmt.Fprintln(o.IOStreams.Out, "========================================================================================================================")
 	printSortedCount(getSortedCount(fmMap, 10), o.IOStreams.Out)
 
+	now := time.Now()
+	stuck := findStuckAccounts(accounts.Items, o.stuckThreshold, now)
+	printStuckAccounts(stuck, o.stuckThreshold, now, o.IOStreams.Out)
+	printForecast(forecastExhaustion(accounts.Items, claims.Items, o.claimWindow, now), o.claimWindow, o.IOStreams.Out)
+
+	if !o.history {
+		return nil
+	}
+
+	recentClaims := 0
+	for _, claim := range claims.Items {
+		if isPoolClaim(claim) && now.Sub(claim.CreationTimestamp.Time) <= o.claimWindow {
+			recentClaims++
+		}
+	}
+
+	history, err := loadPoolHistory(o.historyFile)
+	if err != nil {
+		return err
+	}
+	history.record(o.cluster, poolSnapshot{
+		Time:      now,
+		Available: availabilityCount,
+		Stuck:     len(stuck),
+		Claims:    recentClaims,
+	})
+	if err := history.save(o.historyFile); err != nil {
+		return err
+	}
+	printPoolTrend(history.Clusters[o.cluster], o.IOStreams.Out)
+
 	return nil
 }
 
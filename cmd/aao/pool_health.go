@@ -0,0 +1,228 @@
+package aao
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	awsv1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
+	"github.com/openshift/osdctl/pkg/printer"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const (
+	fmAccountPool = "fm-accountpool"
+
+	defaultStuckThreshold = 24 * time.Hour
+	defaultClaimWindow    = 7 * 24 * time.Hour
+)
+
+// stuckAccountStates are the transitional states an account should only be in for a short time
+var stuckAccountStates = map[string]bool{
+	string(awsv1alpha1.AccountCreating): true,
+	string(awsv1alpha1.AccountFailed):   true,
+	string(awsv1alpha1.AccountReused):   true,
+}
+
+type stuckAccount struct {
+	name  string
+	state string
+	since time.Time
+}
+
+// accountState returns the state of an account, treating unclaimed reused accounts which aren't ready yet as Reused
+func accountState(account awsv1alpha1.Account) string {
+	if account.Status.Reused && !account.Status.Claimed && account.Status.State != string(awsv1alpha1.AccountReady) {
+		return string(awsv1alpha1.AccountReused)
+	}
+	return account.Status.State
+}
+
+// stateSince returns when an account entered a state, based on its latest condition of the same type, falling back
+// to when the account was created
+func stateSince(account awsv1alpha1.Account, state string) time.Time {
+	since := account.CreationTimestamp.Time
+	for _, condition := range account.Status.Conditions {
+		if string(condition.Type) == state && condition.LastTransitionTime.After(since) {
+			since = condition.LastTransitionTime.Time
+		}
+	}
+	return since
+}
+
+// findStuckAccounts returns the accounts which have been in a transitional state for longer than the threshold,
+// the longest stuck first
+func findStuckAccounts(accounts []awsv1alpha1.Account, threshold time.Duration, now time.Time) []stuckAccount {
+	var stuck []stuckAccount
+	for _, account := range accounts {
+		state := accountState(account)
+		if !stuckAccountStates[state] {
+			continue
+		}
+		since := stateSince(account, state)
+		if now.Sub(since) < threshold {
+			continue
+		}
+		stuck = append(stuck, stuckAccount{name: account.Name, state: state, since: since})
+	}
+
+	sort.Slice(stuck, func(i, j int) bool {
+		if !stuck[i].since.Equal(stuck[j].since) {
+			return stuck[i].since.Before(stuck[j].since)
+		}
+		return stuck[i].name < stuck[j].name
+	})
+	return stuck
+}
+
+// isPoolAccount returns whether an account is an unclaimed account of the default pool
+func isPoolAccount(account awsv1alpha1.Account) bool {
+	return !account.Status.Claimed && account.Spec.LegalEntity.ID == "" && !account.Spec.BYOC && account.Spec.AccountPool != fmAccountPool
+}
+
+// isPoolClaim returns whether a claim is served by the default pool, CCS claims bring their own account
+func isPoolClaim(claim awsv1alpha1.AccountClaim) bool {
+	return !claim.Spec.BYOC && claim.Spec.AccountPool != fmAccountPool
+}
+
+type regionForecast struct {
+	region string
+	// optIn is set for regions which can only use accounts with the region enabled
+	optIn bool
+	// available is the number of ready accounts which can serve claims in the region
+	available int
+	// claimsPerDay is the rate the accounts available to the region are claimed at
+	claimsPerDay float64
+	// exhaustion is the projected date the accounts run out, zero if they aren't being claimed
+	exhaustion time.Time
+}
+
+// forecastExhaustion projects when the default pool runs out per region, based on the claims created within the
+// window. Regions requiring opt-in can only be served by accounts with the region enabled, so they are forecast
+// on their own. All other regions draw from the same accounts, so they run out at the combined claim rate.
+func forecastExhaustion(accounts []awsv1alpha1.Account, claims []awsv1alpha1.AccountClaim, window time.Duration, now time.Time) []regionForecast {
+	// Opt-in regions are found across all accounts, so a region whose opt-in accounts were all claimed is still
+	// forecast on its own
+	available := 0
+	optInAvailable := map[string]int{}
+	for _, account := range accounts {
+		for region := range account.Status.OptInRegions {
+			if _, ok := optInAvailable[region]; !ok {
+				optInAvailable[region] = 0
+			}
+		}
+	}
+	for _, account := range accounts {
+		if !isPoolAccount(account) || account.Status.State != string(awsv1alpha1.AccountReady) {
+			continue
+		}
+		available++
+		for region, status := range account.Status.OptInRegions {
+			if status != nil && status.Status == awsv1alpha1.OptInRequestEnabled {
+				optInAvailable[region]++
+			}
+		}
+	}
+
+	days := window.Hours() / 24
+	regionClaims := map[string]int{}
+	sharedClaims := 0
+	for _, claim := range claims {
+		if !isPoolClaim(claim) || now.Sub(claim.CreationTimestamp.Time) > window {
+			continue
+		}
+		shared := false
+		for _, region := range claim.Spec.Aws.Regions {
+			regionClaims[region.Name]++
+			if _, ok := optInAvailable[region.Name]; !ok {
+				shared = true
+			}
+		}
+		if shared {
+			sharedClaims++
+		}
+	}
+
+	var forecasts []regionForecast
+	for region, count := range regionClaims {
+		f := regionForecast{region: region}
+		if n, ok := optInAvailable[region]; ok {
+			f.optIn = true
+			f.available = n
+			f.claimsPerDay = float64(count) / days
+		} else {
+			f.available = available
+			f.claimsPerDay = float64(sharedClaims) / days
+		}
+		if f.claimsPerDay > 0 {
+			f.exhaustion = now.Add(time.Duration(float64(f.available) / f.claimsPerDay * float64(24*time.Hour)))
+		}
+		forecasts = append(forecasts, f)
+	}
+
+	sort.Slice(forecasts, func(i, j int) bool {
+		if forecasts[i].exhaustion.IsZero() != forecasts[j].exhaustion.IsZero() {
+			return !forecasts[i].exhaustion.IsZero()
+		}
+		if !forecasts[i].exhaustion.Equal(forecasts[j].exhaustion) {
+			return forecasts[i].exhaustion.Before(forecasts[j].exhaustion)
+		}
+		return forecasts[i].region < forecasts[j].region
+	})
+	return forecasts
+}
+
+func printStuckAccounts(stuck []stuckAccount, threshold time.Duration, now time.Time, out io.Writer) {
+	fmt.Fprintln(out, "========================================================================================================================")
+	fmt.Fprintf(out, "Accounts stuck in Creating, Failed or Reused for more than %s\n", duration.HumanDuration(threshold))
+	fmt.Fprintln(out, "========================================================================================================================")
+	if len(stuck) == 0 {
+		fmt.Fprintln(out, "No stuck accounts")
+		fmt.Fprintln(out)
+		return
+	}
+
+	table := printer.NewTablePrinter(out, 20, 1, 3, ' ')
+	table.AddRow([]string{"Name", "State", "Since", "Age"})
+	for _, s := range stuck {
+		table.AddRow([]string{s.name, s.state, s.since.Format(time.RFC3339), duration.HumanDuration(now.Sub(s.since))})
+	}
+	table.AddRow([]string{})
+	if err := table.Flush(); err != nil {
+		fmt.Fprintln(out, "error while flushing table: ", err.Error())
+	}
+}
+
+func printForecast(forecasts []regionForecast, window time.Duration, out io.Writer) {
+	fmt.Fprintln(out, "========================================================================================================================")
+	fmt.Fprintf(out, "Projected Default Account Pool exhaustion, based on the claims of the last %s\n", duration.HumanDuration(window))
+	fmt.Fprintln(out, "========================================================================================================================")
+	if len(forecasts) == 0 {
+		fmt.Fprintln(out, "No accounts claimed from the pool")
+		fmt.Fprintln(out)
+		return
+	}
+
+	table := printer.NewTablePrinter(out, 20, 1, 3, ' ')
+	table.AddRow([]string{"Region", "Available", "Claims/Day", "Exhaustion", "Opt-In"})
+	for _, f := range forecasts {
+		exhaustion := "never"
+		if !f.exhaustion.IsZero() {
+			exhaustion = f.exhaustion.Format("2006-01-02")
+		}
+		table.AddRow([]string{
+			f.region,
+			strconv.Itoa(f.available),
+			strconv.FormatFloat(math.Round(f.claimsPerDay*10)/10, 'f', 1, 64),
+			exhaustion,
+			strconv.FormatBool(f.optIn),
+		})
+	}
+	table.AddRow([]string{})
+	if err := table.Flush(); err != nil {
+		fmt.Fprintln(out, "error while flushing table: ", err.Error())
+	}
+}
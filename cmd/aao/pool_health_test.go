@@ -0,0 +1,114 @@
+package aao
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	v1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newAccount(name, state string, created time.Time) v1alpha1.Account {
+	return v1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Status:     v1alpha1.AccountStatus{State: state},
+	}
+}
+
+func newClaim(name string, created time.Time, regions ...string) v1alpha1.AccountClaim {
+	claim := v1alpha1.AccountClaim{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	for _, region := range regions {
+		claim.Spec.Aws.Regions = append(claim.Spec.Aws.Regions, v1alpha1.AwsRegions{Name: region})
+	}
+	return claim
+}
+
+func TestFindStuckAccounts(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	failedRecently := newAccount("failed-recently", "Failed", now.Add(-72*time.Hour))
+	failedRecently.Status.Conditions = []v1alpha1.AccountCondition{
+		{Type: v1alpha1.AccountFailed, LastTransitionTime: metav1.NewTime(now.Add(-time.Hour))},
+	}
+
+	reused := newAccount("reused", "PendingVerification", now.Add(-96*time.Hour))
+	reused.Status.Reused = true
+	reused.Status.Conditions = []v1alpha1.AccountCondition{
+		{Type: v1alpha1.AccountReused, LastTransitionTime: metav1.NewTime(now.Add(-48 * time.Hour))},
+	}
+
+	accounts := []v1alpha1.Account{
+		newAccount("ready", "Ready", now.Add(-72*time.Hour)),
+		newAccount("creating", "Creating", now.Add(-30*time.Hour)),
+		newAccount("creating-recently", "Creating", now.Add(-time.Hour)),
+		failedRecently,
+		reused,
+	}
+
+	stuck := findStuckAccounts(accounts, 24*time.Hour, now)
+	assert.Equal(t, []stuckAccount{
+		{name: "reused", state: "Reused", since: now.Add(-48 * time.Hour)},
+		{name: "creating", state: "Creating", since: now.Add(-30 * time.Hour)},
+	}, stuck)
+}
+
+func TestForecastExhaustion(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	var accounts []v1alpha1.Account
+	for i := 0; i < 10; i++ {
+		accounts = append(accounts, newAccount("ready", "Ready", now))
+	}
+	// Two of the ready accounts have an opt-in region enabled
+	for i := 0; i < 2; i++ {
+		accounts[i].Status.OptInRegions = v1alpha1.OptInRegions{
+			"ap-east-1": &v1alpha1.OptInRegionStatus{Status: v1alpha1.OptInRequestEnabled},
+		}
+	}
+	claimed := newAccount("claimed", "Ready", now)
+	claimed.Status.Claimed = true
+	ccs := newAccount("ccs", "Ready", now)
+	ccs.Spec.BYOC = true
+	accounts = append(accounts, claimed, ccs)
+
+	ccsClaim := newClaim("ccs", now.Add(-time.Hour), "us-east-1")
+	ccsClaim.Spec.BYOC = true
+	claims := []v1alpha1.AccountClaim{
+		newClaim("a", now.Add(-time.Hour), "us-east-1"),
+		newClaim("b", now.Add(-2*time.Hour), "us-east-1"),
+		newClaim("c", now.Add(-3*time.Hour), "eu-west-1"),
+		newClaim("d", now.Add(-4*time.Hour), "ap-east-1"),
+		newClaim("old", now.Add(-30*24*time.Hour), "us-east-1"),
+		ccsClaim,
+	}
+
+	forecasts := forecastExhaustion(accounts, claims, 2*24*time.Hour, now)
+	require.Len(t, forecasts, 3)
+
+	// 2 accounts claimed at 0.5 per day
+	assert.Equal(t, regionForecast{region: "ap-east-1", optIn: true, available: 2, claimsPerDay: 0.5, exhaustion: now.Add(4 * 24 * time.Hour)}, forecasts[0])
+	// 10 shared accounts claimed at 1.5 per day by all regions not requiring opt-in
+	assert.Equal(t, "eu-west-1", forecasts[1].region)
+	assert.Equal(t, "us-east-1", forecasts[2].region)
+	for _, f := range forecasts[1:] {
+		assert.False(t, f.optIn)
+		assert.Equal(t, 10, f.available)
+		assert.Equal(t, 1.5, f.claimsPerDay)
+		assert.Equal(t, now.Add(time.Duration(10/1.5*float64(24*time.Hour))), f.exhaustion)
+	}
+}
+
+func TestPrintForecast(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	out := &bytes.Buffer{}
+	printForecast([]regionForecast{
+		{region: "us-east-1", available: 10, claimsPerDay: 1.5, exhaustion: now.Add(7 * 24 * time.Hour)},
+	}, defaultClaimWindow, out)
+
+	assert.Contains(t, out.String(), "us-east-1")
+	assert.Contains(t, out.String(), "2026-01-17")
+	assert.Contains(t, out.String(), "1.5")
+}
@@ -0,0 +1,102 @@
+package aao
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+)
+
+// poolHistoryLimit is the number of snapshots kept in the history file
+const poolHistoryLimit = 500
+
+// poolTrendRows is the number of most recent snapshots printed in the trend
+const poolTrendRows = 10
+
+// poolHistory is the locally recorded history of the account pool, per cluster running the AWS Account Operator
+type poolHistory struct {
+	Clusters map[string][]poolSnapshot `json:"clusters"`
+}
+
+// poolSnapshot holds the pool counts of a single run
+type poolSnapshot struct {
+	Time      time.Time `json:"time"`
+	Available int       `json:"available"`
+	Stuck     int       `json:"stuck"`
+	// Claims is the number of pool claims created within the claim window
+	Claims int `json:"claims"`
+}
+
+// defaultPoolHistoryPath returns the path of the history file in the osdctl cache directory
+func defaultPoolHistoryPath() (string, error) {
+	cacheDir, err := utils.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "aao", "pool-history.json"), nil
+}
+
+// loadPoolHistory reads the history file, returning an empty history if it doesn't exist yet
+func loadPoolHistory(path string) (*poolHistory, error) {
+	history := &poolHistory{}
+	if _, err := utils.LoadJSON(path, history); err != nil {
+		return nil, fmt.Errorf("failed to load pool history: %w", err)
+	}
+	if history.Clusters == nil {
+		history.Clusters = map[string][]poolSnapshot{}
+	}
+	return history, nil
+}
+
+// save writes the history file
+func (h *poolHistory) save(path string) error {
+	if err := utils.SaveJSONAtomic(path, h); err != nil {
+		return fmt.Errorf("failed to save pool history: %w", err)
+	}
+	return nil
+}
+
+// record appends a snapshot to a cluster's history, dropping the oldest snapshots beyond poolHistoryLimit
+func (h *poolHistory) record(cluster string, snapshot poolSnapshot) {
+	snapshots := append(h.Clusters[cluster], snapshot)
+	if len(snapshots) > poolHistoryLimit {
+		snapshots = snapshots[len(snapshots)-poolHistoryLimit:]
+	}
+	h.Clusters[cluster] = snapshots
+}
+
+// printPoolTrend prints the most recent snapshots with the change in available accounts since the previous one
+func printPoolTrend(snapshots []poolSnapshot, out io.Writer) {
+	fmt.Fprintln(out, "========================================================================================================================")
+	fmt.Fprintln(out, "Default Account Pool Trend")
+	fmt.Fprintln(out, "========================================================================================================================")
+
+	start := 0
+	if len(snapshots) > poolTrendRows {
+		start = len(snapshots) - poolTrendRows
+	}
+
+	table := printer.NewTablePrinter(out, 20, 1, 3, ' ')
+	table.AddRow([]string{"Time", "Available", "Change", "Stuck", "Recent Claims"})
+	for i := start; i < len(snapshots); i++ {
+		change := ""
+		if i > 0 {
+			change = fmt.Sprintf("%+d", snapshots[i].Available-snapshots[i-1].Available)
+		}
+		table.AddRow([]string{
+			snapshots[i].Time.Format(time.RFC3339),
+			strconv.Itoa(snapshots[i].Available),
+			change,
+			strconv.Itoa(snapshots[i].Stuck),
+			strconv.Itoa(snapshots[i].Claims),
+		})
+	}
+	table.AddRow([]string{})
+	if err := table.Flush(); err != nil {
+		fmt.Fprintln(out, "error while flushing table: ", err.Error())
+	}
+}
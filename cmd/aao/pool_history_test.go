@@ -0,0 +1,52 @@
+package aao
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolHistorySaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aao", "pool-history.json")
+
+	history, err := loadPoolHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, history.Clusters)
+
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	history.record("https://api.hive-a:6443", poolSnapshot{Time: now, Available: 40, Claims: 3})
+	require.NoError(t, history.save(path))
+
+	loaded, err := loadPoolHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []poolSnapshot{{Time: now, Available: 40, Claims: 3}}, loaded.Clusters["https://api.hive-a:6443"])
+}
+
+func TestPoolHistoryRecordLimit(t *testing.T) {
+	history := &poolHistory{Clusters: map[string][]poolSnapshot{}}
+	for i := 0; i < poolHistoryLimit+5; i++ {
+		history.record("hive", poolSnapshot{Available: i})
+	}
+
+	snapshots := history.Clusters["hive"]
+	require.Len(t, snapshots, poolHistoryLimit)
+	assert.Equal(t, 5, snapshots[0].Available)
+	assert.Equal(t, poolHistoryLimit+4, snapshots[len(snapshots)-1].Available)
+}
+
+func TestPrintPoolTrend(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	out := &bytes.Buffer{}
+	printPoolTrend([]poolSnapshot{
+		{Time: now, Available: 40},
+		{Time: now.Add(24 * time.Hour), Available: 35},
+		{Time: now.Add(48 * time.Hour), Available: 37},
+	}, out)
+
+	assert.Contains(t, out.String(), "-5")
+	assert.Contains(t, out.String(), "+2")
+}
@@ -2,6 +2,7 @@ package mgmt
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/openshift/osdctl/pkg/printer"
 	awsprovider "github.com/openshift/osdctl/pkg/provider/aws"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 )
@@ -27,13 +29,17 @@ func newCmdAccountUnassign(streams genericclioptions.IOStreams) *cobra.Command {
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(ops.complete(cmd, args))
-			cmdutil.CheckErr(ops.run())
+			cmdutil.CheckErr(ops.run(cmd.Context()))
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			awsprovider.PrintRequestMetrics(streams.ErrOut)
 		},
 	}
 	ops.printFlags.AddFlags(accountUnassignCmd)
 	accountUnassignCmd.Flags().StringVarP(&ops.payerAccount, "payer-account", "p", "", "Payer account type")
 	accountUnassignCmd.Flags().StringVarP(&ops.username, "username", "u", "", "LDAP username")
 	accountUnassignCmd.Flags().StringVarP(&ops.accountID, "account-id", "i", "", "Account ID")
+	accountUnassignCmd.Flags().BoolVar(&ops.verbose, "verbose", false, "Print timing of every AWS API call and a summary at the end")
 	return accountUnassignCmd
 }
 
@@ -42,6 +48,7 @@ type accountUnassignOptions struct {
 	username     string
 	payerAccount string
 	accountID    string
+	verbose      bool
 	printFlags   *printer.PrintFlags
 	genericclioptions.IOStreams
 }
@@ -61,7 +68,7 @@ func (o *accountUnassignOptions) complete(cmd *cobra.Command, _ []string) error
 	}
 	return nil
 }
-func (o *accountUnassignOptions) run() error {
+func (o *accountUnassignOptions) run(ctx context.Context) error {
 	var (
 		accountUsername      string
 		accountIdList        []string
@@ -71,8 +78,10 @@ func (o *accountUnassignOptions) run() error {
 		allUsers             []string
 	)
 
+	viper.Set(awsprovider.RequestMetricsConfigKey, o.verbose)
+
 	// Instantiate Aws client
-	awsClient, err := awsprovider.NewAwsClient(o.payerAccount, "us-east-1", "")
+	awsClient, err := awsprovider.NewAwsContextClient(ctx, o.payerAccount, "us-east-1", "")
 	if err != nil {
 		return err
 	}
@@ -86,7 +95,7 @@ func (o *accountUnassignOptions) run() error {
 		return fmt.Errorf("invalid payer account provided")
 	}
 
-	o.awsClient = awsClient
+	o.awsClient = awsprovider.BindContext(ctx, awsClient)
 
 	if o.accountID != "" {
 		// Check aws tag to see if it's a ccs acct, if it's not return name of owner
@@ -138,7 +147,7 @@ func (o *accountUnassignOptions) run() error {
 			return err
 		}
 		// instantiate new client with AssumeRole
-		assumedRoleAwsClient, err = o.assumeRoleForAccount(id)
+		assumedRoleAwsClient, err = o.assumeRoleForAccount(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -204,7 +213,7 @@ func (o *accountUnassignOptions) run() error {
 	return nil
 }
 
-func (o *accountUnassignOptions) assumeRoleForAccount(accountId string) (awsprovider.Client, error) {
+func (o *accountUnassignOptions) assumeRoleForAccount(ctx context.Context, accountId string) (awsprovider.Client, error) {
 
 	roleArn := fmt.Sprintf("arn:aws:iam::%s:role/OrganizationAccountAccessRole", accountId)
 
@@ -225,12 +234,12 @@ func (o *accountUnassignOptions) assumeRoleForAccount(accountId string) (awsprov
 		Region:          "us-east-1",
 	}
 
-	newAWSClient, err := awsprovider.NewAwsClientWithInput(newAwsClientInput)
+	newAWSClient, err := awsprovider.NewAwsContextClientWithInput(ctx, newAwsClientInput)
 	if err != nil {
 		return nil, err
 	}
 
-	return newAWSClient, nil
+	return awsprovider.BindContext(ctx, newAWSClient), nil
 }
 
 func listUsersFromAccount(newAWSClient awsprovider.Client) ([]string, error) {
@@ -1,6 +1,7 @@
 package mgmt
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -47,7 +48,7 @@ func TestAssumeRoleForAccount(t *testing.T) {
 
 	o := &accountUnassignOptions{}
 	o.awsClient = mockAWSClient
-	returnVal, err := o.assumeRoleForAccount(accountId)
+	returnVal, err := o.assumeRoleForAccount(context.Background(), accountId)
 	if err != nil {
 		t.Errorf("failed to assume role")
 	}
@@ -55,7 +55,7 @@ func NewCmdAccess(streams genericclioptions.IOStreams, client *k8s.LazyClient) *
 	accessCmd := &cobra.Command{
 		Use:               "break-glass --cluster-id <cluster-identifier>",
 		Short:             "Emergency access to a cluster",
-		Long:              "Obtain emergency credentials to access the given cluster. You must be logged into the cluster's hive shard",
+		Long:              "Obtain emergency credentials to access the given cluster. You must be logged into the cluster's hive shard.\n\nEach session is recorded on this machine with who created it and why, see 'break-glass list'. With\n--expire-after, every osdctl invocation warns about expired sessions until they are removed with\n'break-glass cleanup --expired'. Only jump pods are terminated by the cluster when the session expires, the\nkubeconfigs of kubeconfig and private-api sessions stay usable until the session is cleaned up.",
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		Run: func(_ *cobra.Command, _ []string) {
@@ -64,8 +64,10 @@ func NewCmdAccess(streams genericclioptions.IOStreams, client *k8s.LazyClient) *
 		},
 	}
 	accessCmd.AddCommand(newCmdCleanup(client, streams))
+	accessCmd.AddCommand(newCmdList(streams))
 	accessCmd.Flags().StringVar(&ops.reason, "reason", "", "The reason for this command, which requires elevation, to be run (usualy an OHSS or PD ticket)")
 	accessCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "Provide the internal ID of the cluster")
+	accessCmd.Flags().DurationVar(&ops.expireAfter, "expire-after", 0, "(optional) Expire the session after this duration, e.g. 2h. Only jump pods are terminated when it expires, kubeconfigs stay usable until 'break-glass cleanup'")
 	accessCmd.Flags().StringVar(&ops.hiveOcmUrl, "hive-ocm-url", "", "(optional) OCM environment URL for hive operations. Aliases: 'production', 'staging', 'integration'. If not specified, uses the same OCM environment as the target cluster.")
 	_ = accessCmd.MarkFlagRequired("reason")
 	_ = accessCmd.MarkFlagRequired("cluster-id")
@@ -75,9 +77,15 @@ func NewCmdAccess(streams genericclioptions.IOStreams, client *k8s.LazyClient) *
 
 // clusterAccessOptions contains the objects and information required to access a cluster
 type clusterAccessOptions struct {
-	reason     string
-	clusterID  string
-	hiveOcmUrl string
+	reason      string
+	clusterID   string
+	hiveOcmUrl  string
+	expireAfter time.Duration
+
+	// registryPath is the session registry the session is recorded in, sessions aren't recorded if it is empty
+	registryPath string
+	// user is the SRE creating the session
+	user string
 
 	genericclioptions.IOStreams
 }
@@ -118,6 +126,11 @@ func (c *clusterAccessOptions) accessCmdComplete() error {
 		return err
 	}
 
+	// Jump pods expire through activeDeadlineSeconds, which has a granularity of a second
+	if c.expireAfter < 0 || (c.expireAfter > 0 && c.expireAfter < time.Second) {
+		return fmt.Errorf("--expire-after must be at least 1s, got %s", c.expireAfter)
+	}
+
 	// Validate --hive-ocm-url if provided
 	if c.hiveOcmUrl != "" {
 		_, err := osdctlutil.ValidateAndResolveOcmUrl(c.hiveOcmUrl)
@@ -152,6 +165,12 @@ func (c *clusterAccessOptions) Run(ctx context.Context) error {
 	c.Println(fmt.Sprintf("Internal Cluster ID: %s", cluster.ID()))
 	c.Println(fmt.Sprintf("Retrieving Kubeconfig for cluster '%s'", c.clusterID))
 
+	c.registryPath, err = defaultSessionRegistryPath()
+	if err != nil {
+		return err
+	}
+	c.user = sessionUser(conn)
+
 	if c.hiveOcmUrl != "" {
 		// Multi-environment path - enables staging/integration testing
 		hiveOCM, err := osdctlutil.CreateConnectionWithUrl(c.hiveOcmUrl)
@@ -222,7 +241,6 @@ func (c *clusterAccessOptions) createJumpPodAccess(ctx context.Context, kubeCli
 
 	c.Println(fmt.Sprintf("Jump pod created. Waiting for it to start"))
 	c.Println("")
-
 	err = waitForJumpPod(ctx, kubeCli, pod, jumpPodPollInterval, jumpPodPollTimeout)
 	if err != nil {
 		c.Errorln("Timed out waiting for pod to start.")
@@ -230,6 +248,7 @@ func (c *clusterAccessOptions) createJumpPodAccess(ctx context.Context, kubeCli
 		c.Println("Once the pod is running:")
 	} else {
 		c.Println("Pod detected as running")
+		c.recordSession(cluster, breakGlassSession{Method: sessionMethodJumpPod, JumpPod: pod.Namespace + "/" + pod.Name}, jumpPodLifespan*time.Second)
 	}
 	c.Println(fmt.Sprintf("Use \n\n    ocm backplane login %s --manager\n    oc exec -it --as %s -n %s %s -- /bin/bash\n\nto run commands in the pod. All 'oc' commands run within the pod will be executed against the cluster '%s' (this can be verified by running `oc cluster-info` in the pod)", cluster.ID(), impersonateUser, pod.Namespace, pod.Name, cluster.Name()))
 	return err
@@ -270,7 +289,7 @@ func (c *clusterAccessOptions) createLocalKubeconfigAccess(cluster *clustersmgmt
 		c.Errorln("\nFailed to determine if the cluster is private.\nIf you're not able to access the cluster, try modifying the resulting kubeconfig according to the SOP: https://github.com/openshift/ops-sop/blob/master/v4/howto/break-glass-kubeadmin.md#for-clusters-with-private-api")
 	} else if listening == clustersmgmtv1.ListeningMethodInternal {
 		// If the cluster has a private API, it must be accessed using a special API url from one of the bastions
-		return c.createPrivateAPIAccess(rawKubeconfig, kubeconfigFilePath)
+		return c.createPrivateAPIAccess(cluster, rawKubeconfig, kubeconfigFilePath)
 	}
 
 	// Write the kubeconfig to the temp filesystem
@@ -280,6 +299,7 @@ func (c *clusterAccessOptions) createLocalKubeconfigAccess(cluster *clustersmgmt
 		c.Errorln("Failed to save kubeconfig")
 		return err
 	}
+	c.recordSession(cluster, breakGlassSession{Method: sessionMethodKubeconfig, Kubeconfig: kubeconfigFilePath}, 0)
 
 	c.Println("")
 	c.Println(fmt.Sprintf("Kubeconfig successfully written to '%s'", kubeconfigFilePath))
@@ -331,7 +351,7 @@ func (c *clusterAccessOptions) createLocalKubeconfigAccess(cluster *clustersmgmt
 }
 
 // createPrivateAPIAccess provides the necessary changes to access clusters with Private APIs
-func (c *clusterAccessOptions) createPrivateAPIAccess(rawKubeconfig []byte, kubeconfigFilePath string) error {
+func (c *clusterAccessOptions) createPrivateAPIAccess(cluster *clustersmgmtv1.Cluster, rawKubeconfig []byte, kubeconfigFilePath string) error {
 	c.Println("Cluster is private. Updating kubeconfig to execute commands against the rh-api")
 
 	formattedKubeconfig := clientcmdapiv1.Config{}
@@ -368,6 +388,7 @@ func (c *clusterAccessOptions) createPrivateAPIAccess(rawKubeconfig []byte, kube
 		c.Errorln("Failed to save kubeconfig")
 		return err
 	}
+	c.recordSession(cluster, breakGlassSession{Method: sessionMethodPrivateAPI, Kubeconfig: kubeconfigFilePath}, 0)
 
 	c.Println("")
 	c.Println(fmt.Sprintf("Kubeconfig successfully written to '%s'", kubeconfigFilePath))
@@ -383,6 +404,37 @@ func (c *clusterAccessOptions) createPrivateAPIAccess(rawKubeconfig []byte, kube
 	return nil
 }
 
+// recordSession records the session in the local session registry, expiring it after --expire-after or maxLifespan,
+// whichever is shorter. Access is still granted if the session can't be recorded.
+func (c *clusterAccessOptions) recordSession(cluster *clustersmgmtv1.Cluster, session breakGlassSession, maxLifespan time.Duration) {
+	if c.registryPath == "" {
+		return
+	}
+
+	now := time.Now()
+	session.ClusterID = cluster.ID()
+	session.ClusterName = cluster.Name()
+	session.User = c.user
+	session.Reason = c.reason
+	session.CreatedAt = now
+	for _, ttl := range []time.Duration{c.expireAfter, maxLifespan} {
+		if ttl <= 0 {
+			continue
+		}
+		if expiresAt := now.Add(ttl); session.ExpiresAt == nil || expiresAt.Before(*session.ExpiresAt) {
+			session.ExpiresAt = &expiresAt
+		}
+	}
+
+	if err := recordSession(c.registryPath, session); err != nil {
+		c.Errorln(fmt.Sprintf("Failed to record the break-glass session: %v", err))
+		return
+	}
+	if session.ExpiresAt != nil {
+		c.Println(fmt.Sprintf("Session recorded, it expires at %s", session.ExpiresAt.Format(time.RFC3339)))
+	}
+}
+
 // getKubeConfigSecret returns the first secret in the given namespace which contains the "hive.openshift.io/secret-type: kubeconfig" label
 func (c *clusterAccessOptions) getKubeConfigSecret(kubeCli kclient.Client, ns corev1.Namespace) (corev1.Secret, error) {
 	secretList := corev1.SecretList{}
@@ -455,6 +507,11 @@ func (c *clusterAccessOptions) createJumpPod(ctx context.Context, kubeCli kclien
 			},
 		},
 	}
+	if c.expireAfter > 0 {
+		// The pod is terminated by the cluster once the session expires
+		deadline := int64(c.expireAfter.Seconds())
+		deploy.Spec.ActiveDeadlineSeconds = &deadline
+	}
 	err := kubeCli.Create(ctx, &deploy)
 	return deploy, err
 }
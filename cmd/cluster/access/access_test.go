@@ -445,6 +445,7 @@ func TestClusterAccessOptions_accessCmdComplete(t *testing.T) {
 		name        string
 		clusterID   string
 		hiveOcmUrl  string
+		expireAfter time.Duration
 		expectErr   bool
 		errContains string
 	}{
@@ -485,6 +486,26 @@ func TestClusterAccessOptions_accessCmdComplete(t *testing.T) {
 			expectErr:   true,
 			errContains: "invalid --hive-ocm-url",
 		},
+		{
+			name:        "Valid cluster ID, expire-after",
+			clusterID:   "test-cluster-123",
+			expireAfter: 2 * time.Hour,
+			expectErr:   false,
+		},
+		{
+			name:        "Valid cluster ID, negative expire-after",
+			clusterID:   "test-cluster-123",
+			expireAfter: -time.Hour,
+			expectErr:   true,
+			errContains: "--expire-after must be at least 1s",
+		},
+		{
+			name:        "Valid cluster ID, sub-second expire-after",
+			clusterID:   "test-cluster-123",
+			expireAfter: 500 * time.Millisecond,
+			expectErr:   true,
+			errContains: "--expire-after must be at least 1s",
+		},
 		{
 			name:        "Empty cluster ID",
 			clusterID:   "",
@@ -497,8 +518,9 @@ func TestClusterAccessOptions_accessCmdComplete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &clusterAccessOptions{
-				clusterID:  tt.clusterID,
-				hiveOcmUrl: tt.hiveOcmUrl,
+				clusterID:   tt.clusterID,
+				hiveOcmUrl:  tt.hiveOcmUrl,
+				expireAfter: tt.expireAfter,
 			}
 
 			err := c.accessCmdComplete()
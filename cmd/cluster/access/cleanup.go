@@ -6,6 +6,7 @@ import (
 	"os"
 	fpath "path/filepath"
 	"strings"
+	"time"
 
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osdctl/pkg/k8s"
@@ -23,9 +24,9 @@ import (
 func newCmdCleanup(client *k8s.LazyClient, streams genericclioptions.IOStreams) *cobra.Command {
 	ops := newCleanupAccessOptions(client, streams)
 	cleanupCmd := &cobra.Command{
-		Use:               "cleanup --cluster-id <cluster-identifier>",
+		Use:               "cleanup (--cluster-id <cluster-identifier> | --expired)",
 		Short:             "Drop emergency access to a cluster",
-		Long:              "Relinquish emergency access from the given cluster. If the cluster is PrivateLink, it deletes\nall jump pods in the cluster's namespace (because of this, you must be logged into the hive shard\nwhen dropping access for PrivateLink clusters). For non-PrivateLink clusters, the $KUBECONFIG\nenvironment variable is unset, if applicable.",
+		Long:              "Relinquish emergency access from the given cluster. If the cluster is PrivateLink, it deletes\nall jump pods in the cluster's namespace (because of this, you must be logged into the hive shard\nwhen dropping access for PrivateLink clusters). For non-PrivateLink clusters, the $KUBECONFIG\nenvironment variable is unset, if applicable. The recorded break-glass sessions of the cluster are\nremoved and the kubeconfigs written for them are deleted.\n\nWith --expired, the sessions which outlived their --expire-after are removed from all clusters instead\nand their kubeconfigs deleted. Their jump pods have already been terminated by the cluster.",
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -35,14 +36,18 @@ func newCmdCleanup(client *k8s.LazyClient, streams genericclioptions.IOStreams)
 	}
 	cleanupCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "[Mandatory] Provide the Internal ID of the cluster")
 	cleanupCmd.Flags().StringVar(&ops.reason, "reason", "", "[Mandatory for PrivateLink clusters] The reason for this command, which requires elevation, to be run (usualy an OHSS or PD ticket)")
+	cleanupCmd.Flags().BoolVar(&ops.expired, "expired", false, "Remove the expired break-glass sessions of all clusters instead of dropping access to a cluster")
 
-	_ = cleanupCmd.MarkFlagRequired("cluster-id")
+	cleanupCmd.MarkFlagsMutuallyExclusive("cluster-id", "expired")
 
 	return cleanupCmd
 }
 
 func cleanupCmdComplete(cmd *cobra.Command) error {
 	clusterID, _ := cmd.Flags().GetString("cluster-id")
+	if expired, _ := cmd.Flags().GetBool("expired"); expired {
+		return nil
+	}
 	if clusterID == "" {
 		return cmdutil.UsageErrorf(cmd, "The cluster-id flag is required")
 	}
@@ -53,6 +58,10 @@ func cleanupCmdComplete(cmd *cobra.Command) error {
 type cleanupAccessOptions struct {
 	reason    string
 	clusterID string
+	expired   bool
+
+	// registryPath is the session registry the sessions of the cluster are removed from, it is not updated if empty
+	registryPath string
 
 	genericclioptions.IOStreams
 	kubeCli *k8s.LazyClient
@@ -91,6 +100,16 @@ func (c *cleanupAccessOptions) Readln() (string, error) {
 
 // Run executes the 'cleanup' access subcommand
 func (c *cleanupAccessOptions) Run(cmd *cobra.Command) error {
+	registryPath, err := defaultSessionRegistryPath()
+	if err != nil {
+		return err
+	}
+	c.registryPath = registryPath
+
+	if c.expired {
+		return c.cleanupExpired(time.Now())
+	}
+
 	conn, err := osdctlutil.CreateConnection()
 	if err != nil {
 		return err
@@ -107,10 +126,42 @@ func (c *cleanupAccessOptions) Run(cmd *cobra.Command) error {
 	if cluster.AWS().PrivateLink() {
 		return c.dropPrivateLinkAccess(cluster)
 	} else {
-		return c.dropLocalAccess(cluster)
+		if err := c.dropLocalAccess(cluster); err != nil {
+			return err
+		}
+		return c.forgetClusterSessions(cluster)
 	}
 }
 
+// forgetClusterSessions removes the recorded sessions of the cluster once access has been dropped
+func (c *cleanupAccessOptions) forgetClusterSessions(cluster *clustersmgmtv1.Cluster) error {
+	if c.registryPath == "" {
+		return nil
+	}
+	_, err := forgetSessions(c.registryPath, c.Out, func(s breakGlassSession) bool {
+		return s.ClusterID == cluster.ID()
+	})
+	return err
+}
+
+// cleanupExpired removes the expired sessions of all clusters and deletes their kubeconfigs
+func (c *cleanupAccessOptions) cleanupExpired(now time.Time) error {
+	removed, err := forgetSessions(c.registryPath, c.Out, func(s breakGlassSession) bool {
+		return s.expired(now)
+	})
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		c.Println("No expired break-glass sessions found.")
+		return nil
+	}
+	for _, s := range removed {
+		c.Println(fmt.Sprintf("Removed expired %s session on cluster '%s' (%s) created by %s", s.Method, s.ClusterName, s.ClusterID, s.User))
+	}
+	return nil
+}
+
 // dropPrivateLinkAccess removes access to a PrivateLink cluster.
 // This primarily consists of deleting any jump pods found to be running against the cluster in hive.
 func (c *cleanupAccessOptions) dropPrivateLinkAccess(cluster *clustersmgmtv1.Cluster) error {
@@ -147,7 +198,7 @@ func (c *cleanupAccessOptions) dropPrivateLinkAccess(cluster *clustersmgmtv1.Clu
 	if numPods == 0 {
 		c.Println(fmt.Sprintf("No jump pods found running in namespace '%s'.", ns.Name))
 		c.Println("Access has been dropped.")
-		return nil
+		return c.forgetClusterSessions(cluster)
 	}
 
 	c.Println("")
@@ -187,6 +238,9 @@ func (c *cleanupAccessOptions) dropPrivateLinkAccess(cluster *clustersmgmtv1.Clu
 			return err
 		}
 		c.Println("Access has been dropped.")
+		if err := c.forgetClusterSessions(cluster); err != nil {
+			return err
+		}
 	} else {
 		c.Println("Access has not been dropped.")
 	}
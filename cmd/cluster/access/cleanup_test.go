@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	fpath "path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/openshift/osdctl/pkg/k8s"
 	corev1 "k8s.io/api/core/v1"
@@ -135,3 +137,50 @@ func TestCleanupAccessOptions_dropPrivateLinkAccess(t *testing.T) {
 		}
 	}
 }
+
+func TestCleanupAccessOptions_cleanupExpired(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	kubeconfig := fpath.Join(dir, "kubeconfig-expired")
+	if err := os.WriteFile(kubeconfig, []byte("kubeconfig"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	expired := newTestSession("expired", now.Add(-3*time.Hour), time.Hour)
+	expired.Kubeconfig = kubeconfig
+	registry := &sessionRegistry{Sessions: []breakGlassSession{expired, newTestSession("active", now, time.Hour)}}
+	path := fpath.Join(dir, "break-glass-sessions.json")
+	if err := registry.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	cleanupAccess := newCleanupAccessOptions(nil, streams)
+	cleanupAccess.registryPath = path
+
+	if err := cleanupAccess.cleanupExpired(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Removed expired kubeconfig session on cluster 'name-expired' (expired) created by sre") {
+		t.Errorf("expected the expired session to be reported, got %q", out.String())
+	}
+	if _, err := os.Stat(kubeconfig); !os.IsNotExist(err) {
+		t.Errorf("expected kubeconfig %s to be deleted", kubeconfig)
+	}
+
+	registry, err := loadSessionRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registry.Sessions) != 1 || registry.Sessions[0].ClusterID != "active" {
+		t.Errorf("expected only the active session to remain, got %v", registry.Sessions)
+	}
+
+	out.Reset()
+	if err := cleanupAccess.cleanupExpired(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No expired break-glass sessions found.") {
+		t.Errorf("expected no expired sessions to be found, got %q", out.String())
+	}
+}
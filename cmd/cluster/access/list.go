@@ -0,0 +1,89 @@
+package access
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func newCmdList(streams genericclioptions.IOStreams) *cobra.Command {
+	ops := newListSessionsOptions(streams)
+	listCmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List recorded break-glass sessions",
+		Long:              "List the break-glass sessions recorded on this machine which have not been cleaned up yet, along with who\ncreated them, why, and when they expire.",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Run: func(cmd *cobra.Command, _ []string) {
+			cmdutil.CheckErr(ops.complete(cmd))
+			cmdutil.CheckErr(ops.Run(time.Now()))
+		},
+	}
+	listCmd.Flags().StringVarP(&ops.output, "output", "o", "table", "Output format: table or json")
+
+	return listCmd
+}
+
+// listSessionsOptions contains the information required to list break-glass sessions
+type listSessionsOptions struct {
+	output       string
+	registryPath string
+
+	genericclioptions.IOStreams
+}
+
+// newListSessionsOptions creates a listSessionsOptions object
+func newListSessionsOptions(streams genericclioptions.IOStreams) listSessionsOptions {
+	return listSessionsOptions{IOStreams: streams}
+}
+
+func (l *listSessionsOptions) complete(cmd *cobra.Command) error {
+	if l.output != "table" && l.output != "json" {
+		return cmdutil.UsageErrorf(cmd, "unsupported output format %q, must be one of: table, json", l.output)
+	}
+	path, err := defaultSessionRegistryPath()
+	if err != nil {
+		return err
+	}
+	l.registryPath = path
+	return nil
+}
+
+// Run executes the 'list' break-glass subcommand
+func (l *listSessionsOptions) Run(now time.Time) error {
+	registry, err := loadSessionRegistry(l.registryPath)
+	if err != nil {
+		return err
+	}
+
+	if l.output == "json" {
+		encoder := json.NewEncoder(l.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(registry.Sessions)
+	}
+
+	if len(registry.Sessions) == 0 {
+		_, err := fmt.Fprintln(l.Out, "No break-glass sessions recorded")
+		return err
+	}
+
+	p := printer.NewTablePrinter(l.Out, 20, 1, 3, ' ')
+	p.AddRow([]string{"CLUSTER ID", "CLUSTER NAME", "USER", "REASON", "METHOD", "AGE", "EXPIRES", "STATUS", "ACCESS"})
+	for _, s := range registry.Sessions {
+		expires, status := "never", "active"
+		if s.ExpiresAt != nil {
+			expires = s.ExpiresAt.Format(time.RFC3339)
+		}
+		if s.expired(now) {
+			status = "expired"
+		}
+		p.AddRow([]string{s.ClusterID, s.ClusterName, s.User, s.Reason, s.Method, duration.HumanDuration(now.Sub(s.CreatedAt)), expires, status, s.access()})
+	}
+	return p.Flush()
+}
@@ -0,0 +1,170 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	fpath "path/filepath"
+	"slices"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	osdctlutil "github.com/openshift/osdctl/pkg/utils"
+)
+
+const (
+	sessionMethodKubeconfig = "kubeconfig"
+	sessionMethodPrivateAPI = "private-api"
+	sessionMethodJumpPod    = "jump-pod"
+)
+
+// breakGlassSession is a break-glass access recorded in the local session registry
+type breakGlassSession struct {
+	ClusterID   string `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+	User        string `json:"user"`
+	Reason      string `json:"reason"`
+	Method      string `json:"method"`
+	// Kubeconfig is the local path of the kubeconfig written for kubeconfig and private-api sessions
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// JumpPod is the <namespace>/<name> of the jump pod on hive for jump-pod sessions
+	JumpPod   string     `json:"jumpPod,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// expired returns whether the session has outlived its TTL, sessions without one never expire
+func (s breakGlassSession) expired(now time.Time) bool {
+	return s.ExpiresAt != nil && now.After(*s.ExpiresAt)
+}
+
+// access returns where the session's credentials can be used from
+func (s breakGlassSession) access() string {
+	if s.JumpPod != "" {
+		return s.JumpPod
+	}
+	return s.Kubeconfig
+}
+
+// sessionRegistry is the locally recorded list of break-glass sessions which have not been cleaned up
+type sessionRegistry struct {
+	Sessions []breakGlassSession `json:"sessions"`
+}
+
+// defaultSessionRegistryPath returns the path of the session registry in the osdctl cache directory
+func defaultSessionRegistryPath() (string, error) {
+	cacheDir, err := osdctlutil.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return fpath.Join(cacheDir, "break-glass", "sessions.json"), nil
+}
+
+// loadSessionRegistry reads the session registry, returning an empty registry if it doesn't exist yet
+func loadSessionRegistry(path string) (*sessionRegistry, error) {
+	registry := &sessionRegistry{}
+	if _, err := osdctlutil.LoadJSON(path, registry); err != nil {
+		return nil, fmt.Errorf("failed to load break-glass sessions: %w", err)
+	}
+	return registry, nil
+}
+
+// save writes the session registry
+func (r *sessionRegistry) save(path string) error {
+	if err := osdctlutil.SaveJSONAtomic(path, r); err != nil {
+		return fmt.Errorf("failed to save break-glass sessions: %w", err)
+	}
+	return nil
+}
+
+// remove removes the sessions matching the predicate from the registry and returns them
+func (r *sessionRegistry) remove(match func(breakGlassSession) bool) []breakGlassSession {
+	var removed []breakGlassSession
+	r.Sessions = slices.DeleteFunc(r.Sessions, func(s breakGlassSession) bool {
+		if match(s) {
+			removed = append(removed, s)
+			return true
+		}
+		return false
+	})
+	return removed
+}
+
+// recordSession adds a session to the registry at path
+func recordSession(path string, session breakGlassSession) error {
+	registry, err := loadSessionRegistry(path)
+	if err != nil {
+		return err
+	}
+	registry.Sessions = append(registry.Sessions, session)
+	return registry.save(path)
+}
+
+// forgetSessions removes the sessions matching the predicate from the registry at path, deletes the local
+// kubeconfigs written for them and returns them
+func forgetSessions(path string, w io.Writer, match func(breakGlassSession) bool) ([]breakGlassSession, error) {
+	registry, err := loadSessionRegistry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := registry.remove(match)
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	for _, s := range removed {
+		if s.Kubeconfig == "" {
+			continue
+		}
+		err := os.Remove(s.Kubeconfig)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete kubeconfig %s: %w", s.Kubeconfig, err)
+		}
+		_, _ = fmt.Fprintf(w, "Deleted kubeconfig '%s'\n", s.Kubeconfig)
+	}
+	return removed, registry.save(path)
+}
+
+// sessionUser returns the OCM username of the SRE creating a session, falling back to the local username
+func sessionUser(conn *sdk.Connection) string {
+	if conn != nil {
+		account, err := conn.AccountsMgmt().V1().CurrentAccount().Get().Send()
+		if err == nil && account.Body().Username() != "" {
+			return account.Body().Username()
+		}
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
+
+// WarnExpiredSessions warns about recorded break-glass sessions which have outlived their TTL and haven't been
+// cleaned up yet. It is run on every osdctl invocation, so it never fails.
+func WarnExpiredSessions(w io.Writer, now time.Time) {
+	path, err := defaultSessionRegistryPath()
+	if err != nil {
+		return
+	}
+	warnExpiredSessions(w, path, now)
+}
+
+func warnExpiredSessions(w io.Writer, path string, now time.Time) {
+	registry, err := loadSessionRegistry(path)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "WARN: %v\n", err)
+		return
+	}
+
+	for _, s := range registry.Sessions {
+		if s.expired(now) {
+			_, _ = fmt.Fprintf(w, "WARN: break-glass session on cluster '%s' (%s) expired %s ago and has not been cleaned up. Run 'osdctl cluster break-glass cleanup --expired'\n",
+				s.ClusterName, s.ClusterID, now.Sub(*s.ExpiresAt).Round(time.Minute))
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package access
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	fpath "path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestSession(clusterID string, createdAt time.Time, ttl time.Duration) breakGlassSession {
+	s := breakGlassSession{
+		ClusterID:   clusterID,
+		ClusterName: "name-" + clusterID,
+		User:        "sre",
+		Reason:      "OHSS-1",
+		Method:      sessionMethodKubeconfig,
+		CreatedAt:   createdAt,
+	}
+	if ttl > 0 {
+		expiresAt := createdAt.Add(ttl)
+		s.ExpiresAt = &expiresAt
+	}
+	return s
+}
+
+func TestBreakGlassSession_expired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		session  breakGlassSession
+		expected bool
+	}{
+		{name: "No TTL", session: newTestSession("a", now.Add(-48*time.Hour), 0), expected: false},
+		{name: "Within TTL", session: newTestSession("a", now.Add(-time.Hour), 2*time.Hour), expected: false},
+		{name: "Outlived TTL", session: newTestSession("a", now.Add(-3*time.Hour), 2*time.Hour), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.session.expired(now); got != tt.expected {
+				t.Errorf("expected expired to be %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRecordAndForgetSessions(t *testing.T) {
+	dir := t.TempDir()
+	path := fpath.Join(dir, "osdctl", "break-glass-sessions.json")
+	now := time.Now()
+
+	// A missing registry is empty
+	registry, err := loadSessionRegistry(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading a missing registry: %v", err)
+	}
+	if len(registry.Sessions) != 0 {
+		t.Fatalf("expected an empty registry, got %d sessions", len(registry.Sessions))
+	}
+
+	kubeconfig := fpath.Join(dir, "kubeconfig-a")
+	if err := os.WriteFile(kubeconfig, []byte("kubeconfig"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	first := newTestSession("a", now, time.Hour)
+	first.Kubeconfig = kubeconfig
+	second := newTestSession("b", now, 0)
+	second.Method, second.JumpPod = sessionMethodJumpPod, "uhc-production-b/jump"
+
+	for _, s := range []breakGlassSession{first, second} {
+		if err := recordSession(path, s); err != nil {
+			t.Fatalf("unexpected error recording session: %v", err)
+		}
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatalf("registry was not written: %v", err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected registry permissions 0600, got %v", info.Mode().Perm())
+	}
+
+	var out bytes.Buffer
+	removed, err := forgetSessions(path, &out, func(s breakGlassSession) bool { return s.ClusterID == "a" })
+	if err != nil {
+		t.Fatalf("unexpected error forgetting sessions: %v", err)
+	}
+	if len(removed) != 1 || removed[0].ClusterID != "a" {
+		t.Errorf("expected the session of cluster 'a' to be removed, got %v", removed)
+	}
+	if _, err := os.Stat(kubeconfig); !os.IsNotExist(err) {
+		t.Errorf("expected kubeconfig %s to be deleted", kubeconfig)
+	}
+	if !strings.Contains(out.String(), "Deleted kubeconfig '"+kubeconfig+"'") {
+		t.Errorf("expected the deleted kubeconfig to be reported, got %q", out.String())
+	}
+
+	registry, err = loadSessionRegistry(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading registry: %v", err)
+	}
+	if len(registry.Sessions) != 1 || registry.Sessions[0].ClusterID != "b" {
+		t.Errorf("expected only the session of cluster 'b' to remain, got %v", registry.Sessions)
+	}
+}
+
+func TestWarnExpiredSessions(t *testing.T) {
+	path := fpath.Join(t.TempDir(), "break-glass-sessions.json")
+	now := time.Now()
+
+	registry := &sessionRegistry{Sessions: []breakGlassSession{
+		newTestSession("expired", now.Add(-3*time.Hour), time.Hour),
+		newTestSession("active", now, time.Hour),
+		newTestSession("no-ttl", now.Add(-72*time.Hour), 0),
+	}}
+	if err := registry.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	warnExpiredSessions(&out, path, now)
+
+	expected := "WARN: break-glass session on cluster 'name-expired' (expired) expired 2h0m0s ago and has not been cleaned up. Run 'osdctl cluster break-glass cleanup --expired'\n"
+	if out.String() != expected {
+		t.Errorf("unexpected warning:\nexpected: %q\ngot:      %q", expected, out.String())
+	}
+}
+
+func TestListSessionsOptions_Run(t *testing.T) {
+	path := fpath.Join(t.TempDir(), "break-glass-sessions.json")
+	now := time.Now()
+
+	registry := &sessionRegistry{Sessions: []breakGlassSession{
+		newTestSession("expired", now.Add(-3*time.Hour), time.Hour),
+		newTestSession("active", now, time.Hour),
+	}}
+	if err := registry.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	streams, _, out, _ := genericclioptions.NewTestIOStreams()
+	l := newListSessionsOptions(streams)
+	l.registryPath = path
+	l.output = "table"
+	if err := l.Run(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 sessions, got:\n%s", out.String())
+	}
+	if !strings.Contains(lines[1], "expired") || !strings.Contains(lines[1], "OHSS-1") {
+		t.Errorf("expected the first session to be expired, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "active") {
+		t.Errorf("expected the second session to be active, got %q", lines[2])
+	}
+
+	out.Reset()
+	l.output = "json"
+	if err := l.Run(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var sessions []breakGlassSession
+	if err := json.Unmarshal(out.Bytes(), &sessions); err != nil {
+		t.Fatalf("failed to parse json output: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("expected 2 sessions, got %d", len(sessions))
+	}
+}
+
+func TestClusterAccessOptions_recordSession(t *testing.T) {
+	cluster, err := clustersmgmtv1.NewCluster().ID("fake-cluster-uuid-123456").Name("fake-cluster").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		expireAfter time.Duration
+		maxLifespan time.Duration
+		expectedTTL time.Duration
+	}{
+		{name: "No TTL", expireAfter: 0, maxLifespan: 0, expectedTTL: 0},
+		{name: "expire-after only", expireAfter: time.Hour, maxLifespan: 0, expectedTTL: time.Hour},
+		{name: "Shorter expire-after", expireAfter: time.Hour, maxLifespan: 8 * time.Hour, expectedTTL: time.Hour},
+		{name: "Shorter max lifespan", expireAfter: 24 * time.Hour, maxLifespan: 8 * time.Hour, expectedTTL: 8 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			c := newClusterAccessOptions(streams)
+			c.registryPath = fpath.Join(t.TempDir(), "break-glass-sessions.json")
+			c.expireAfter = tt.expireAfter
+			c.user = "sre"
+			c.reason = "OHSS-1"
+
+			c.recordSession(cluster, breakGlassSession{Method: sessionMethodJumpPod, JumpPod: "ns/jump"}, tt.maxLifespan)
+
+			registry, err := loadSessionRegistry(c.registryPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(registry.Sessions) != 1 {
+				t.Fatalf("expected 1 session to be recorded, got %d", len(registry.Sessions))
+			}
+			s := registry.Sessions[0]
+			if s.ClusterID != cluster.ID() || s.ClusterName != cluster.Name() || s.User != "sre" || s.Reason != "OHSS-1" {
+				t.Errorf("session not recorded with the cluster, user and reason: %+v", s)
+			}
+			if tt.expectedTTL == 0 {
+				if s.ExpiresAt != nil {
+					t.Errorf("expected the session not to expire, got %v", s.ExpiresAt)
+				}
+				return
+			}
+			if s.ExpiresAt == nil {
+				t.Fatalf("expected the session to expire after %v", tt.expectedTTL)
+			}
+			if ttl := s.ExpiresAt.Sub(s.CreatedAt); ttl != tt.expectedTTL {
+				t.Errorf("expected a TTL of %v, got %v", tt.expectedTTL, ttl)
+			}
+		})
+	}
+}
+
+func TestClusterAccessOptions_createJumpPodExpireAfter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add corev1 to scheme: %v", err)
+	}
+	client := k8s.NewFakeClient(fake.NewClientBuilder().WithScheme(scheme))
+	streams, _, _, _ := genericclioptions.NewTestIOStreams()
+	access := newClusterAccessOptions(streams)
+	access.expireAfter = 90 * time.Minute
+
+	secret, _ := generateKubeconfigSecretObjectForTesting("kubeconfig-secret", "uhc-production-testclusterns", "kubeconfig", "https://api.test-cluster.fakedomain.devshift.org:6443")
+	pod, err := access.createJumpPod(context.TODO(), client, secret, "fake-cluster-uuid-123456")
+	if err != nil {
+		t.Fatalf("error while creating pod: %v", err)
+	}
+
+	if pod.Spec.ActiveDeadlineSeconds == nil || *pod.Spec.ActiveDeadlineSeconds != 5400 {
+		t.Errorf("expected activeDeadlineSeconds to be 5400, got %v", pod.Spec.ActiveDeadlineSeconds)
+	}
+}
@@ -13,5 +13,8 @@ func NewCmdCad() *cobra.Command {
 	}
 
 	cadCmd.AddCommand(newCmdRun())
+	cadCmd.AddCommand(newCmdStatus())
+	cadCmd.AddCommand(newCmdList())
+	cadCmd.AddCommand(newCmdInvestigations())
 	return cadCmd
 }
@@ -0,0 +1,209 @@
+package cad
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/pkg/printer"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// catalogConfigMapName is the ConfigMap in the CAD namespace which holds the investigation catalog
+	catalogConfigMapName = "cad-investigation-catalog"
+	catalogConfigMapKey  = "investigations.yaml"
+
+	catalogSourceBuiltIn = "built-in"
+
+	clusterTypeClassic = "classic"
+	clusterTypeHCP     = "hcp"
+
+	// investigationParametersParam is the PipelineRun parameter holding the investigation-specific
+	// parameters as a JSON object
+	investigationParametersParam = "investigation-parameters"
+)
+
+//go:embed investigations.yaml
+var builtInCatalog []byte
+
+// investigationParameter is a parameter accepted by an investigation
+type investigationParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// investigation is an investigation CAD can run manually
+type investigation struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// ClusterTypes restricts the investigation to these cluster types, it can run against any cluster if empty.
+	// Only set it where CAD itself refuses to run the investigation against the other cluster type.
+	ClusterTypes []string                 `json:"clusterTypes,omitempty"`
+	Parameters   []investigationParameter `json:"parameters,omitempty"`
+}
+
+type investigationCatalog struct {
+	Investigations []investigation `json:"investigations"`
+}
+
+// parseInvestigationCatalog parses and validates a YAML or JSON investigation catalog
+func parseInvestigationCatalog(data []byte) (*investigationCatalog, error) {
+	catalog := &investigationCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse investigation catalog: %w", err)
+	}
+	if len(catalog.Investigations) == 0 {
+		return nil, fmt.Errorf("investigation catalog doesn't contain any investigations")
+	}
+
+	seen := map[string]bool{}
+	for _, inv := range catalog.Investigations {
+		if inv.Name == "" {
+			return nil, fmt.Errorf("investigation catalog contains an investigation without a name")
+		}
+		if seen[inv.Name] {
+			return nil, fmt.Errorf("investigation %s is defined more than once", inv.Name)
+		}
+		seen[inv.Name] = true
+
+		for _, clusterType := range inv.ClusterTypes {
+			if clusterType != clusterTypeClassic && clusterType != clusterTypeHCP {
+				return nil, fmt.Errorf("investigation %s has unsupported cluster type %q, must be %s or %s", inv.Name, clusterType, clusterTypeClassic, clusterTypeHCP)
+			}
+		}
+		for _, param := range inv.Parameters {
+			if param.Name == "" {
+				return nil, fmt.Errorf("investigation %s has a parameter without a name", inv.Name)
+			}
+		}
+	}
+
+	return catalog, nil
+}
+
+func defaultInvestigationCatalog() (*investigationCatalog, error) {
+	return parseInvestigationCatalog(builtInCatalog)
+}
+
+// loadInvestigationCatalog reads the catalog from the CAD cluster, falling back to the built-in
+// catalog when the cluster doesn't provide one. It returns where the catalog was loaded from.
+func (c *cadCluster) loadInvestigationCatalog(ctx context.Context) (*investigationCatalog, string, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: catalogConfigMapName}, cm)
+	if apierrors.IsNotFound(err) {
+		catalog, err := defaultInvestigationCatalog()
+		return catalog, catalogSourceBuiltIn, err
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", c.namespace, catalogConfigMapName, err)
+	}
+
+	data, ok := cm.Data[catalogConfigMapKey]
+	if !ok {
+		return nil, "", fmt.Errorf("ConfigMap %s/%s has no %s key", c.namespace, catalogConfigMapName, catalogConfigMapKey)
+	}
+
+	catalog, err := parseInvestigationCatalog([]byte(data))
+	return catalog, fmt.Sprintf("configmap/%s/%s", c.namespace, catalogConfigMapName), err
+}
+
+// get returns the investigation with the given name
+func (c *investigationCatalog) get(name string) (*investigation, error) {
+	for i := range c.Investigations {
+		if c.Investigations[i].Name == name {
+			return &c.Investigations[i], nil
+		}
+	}
+	return nil, fmt.Errorf("invalid investigation %q, must be one of: %v", name, c.names())
+}
+
+func (c *investigationCatalog) names() []string {
+	names := make([]string, 0, len(c.Investigations))
+	for _, inv := range c.Investigations {
+		names = append(names, inv.Name)
+	}
+	return names
+}
+
+// validate checks that the investigation can run against a cluster of the given type with the given parameters
+func (i *investigation) validate(clusterType string, params map[string]string) error {
+	if len(i.ClusterTypes) > 0 && !slices.Contains(i.ClusterTypes, clusterType) {
+		return fmt.Errorf("investigation %s doesn't support %s clusters, supported cluster types: %s", i.Name, clusterType, strings.Join(i.ClusterTypes, ", "))
+	}
+
+	declared := map[string]bool{}
+	var missing []string
+	for _, param := range i.Parameters {
+		declared[param.Name] = true
+		if _, ok := params[param.Name]; param.Required && !ok {
+			missing = append(missing, param.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("investigation %s requires parameter(s): %s", i.Name, strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range params {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("investigation %s doesn't accept parameter(s): %s", i.Name, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// clusterTypeOf returns the catalog cluster type of an OCM cluster
+func clusterTypeOf(cluster *cmv1.Cluster) string {
+	if cluster.Hypershift().Enabled() {
+		return clusterTypeHCP
+	}
+	return clusterTypeClassic
+}
+
+// encodeInvestigationParameters returns the investigation parameters as passed to the PipelineRun
+func encodeInvestigationParameters(params map[string]string) string {
+	// Marshalling a map of strings can't fail
+	data, _ := json.Marshal(params)
+	return string(data)
+}
+
+func printInvestigationCatalog(w io.Writer, catalog *investigationCatalog) error {
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Investigation", "Cluster Types", "Parameters", "Description"})
+	for _, inv := range catalog.Investigations {
+		clusterTypes := strings.Join(inv.ClusterTypes, ", ")
+		if clusterTypes == "" {
+			clusterTypes = "any"
+		}
+		params := make([]string, 0, len(inv.Parameters))
+		for _, param := range inv.Parameters {
+			name := param.Name
+			if param.Required {
+				name += " (required)"
+			}
+			params = append(params, name)
+		}
+		paramsColumn := strings.Join(params, ", ")
+		if paramsColumn == "" {
+			paramsColumn = "-"
+		}
+		table.AddRow([]string{inv.Name, clusterTypes, paramsColumn, inv.Description})
+	}
+	return table.Flush()
+}
@@ -0,0 +1,119 @@
+package cad
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCatalog = `
+investigations:
+  - name: chgm
+    description: Change Management
+    clusterTypes: [classic]
+  - name: must-gather
+    description: Must-Gather Collection
+    parameters:
+      - name: TARGET
+        description: What to gather
+        required: true
+      - name: SINCE
+`
+
+func TestParseInvestigationCatalog(t *testing.T) {
+	tests := []struct {
+		name      string
+		catalog   string
+		expectErr bool
+	}{
+		{name: "valid catalog", catalog: testCatalog},
+		{name: "empty catalog", catalog: `investigations: []`, expectErr: true},
+		{name: "missing name", catalog: `investigations: [{clusterTypes: [classic]}]`, expectErr: true},
+		{name: "duplicate name", catalog: `investigations: [{name: a, clusterTypes: [hcp]}, {name: a, clusterTypes: [hcp]}]`, expectErr: true},
+		{name: "unsupported cluster type", catalog: `investigations: [{name: a, clusterTypes: [rosa]}]`, expectErr: true},
+		{name: "unnamed parameter", catalog: `investigations: [{name: a, parameters: [{required: true}]}]`, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, err := parseInvestigationCatalog([]byte(tt.catalog))
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"chgm", "must-gather"}, catalog.names())
+		})
+	}
+}
+
+func TestDefaultInvestigationCatalog(t *testing.T) {
+	catalog, err := defaultInvestigationCatalog()
+	assert.NoError(t, err)
+	assert.Contains(t, catalog.names(), "chgm")
+}
+
+func TestInvestigationValidate(t *testing.T) {
+	catalog, err := parseInvestigationCatalog([]byte(testCatalog))
+	assert.NoError(t, err)
+
+	_, err = catalog.get("unknown")
+	assert.Error(t, err)
+
+	chgm, err := catalog.get("chgm")
+	assert.NoError(t, err)
+	assert.NoError(t, chgm.validate(clusterTypeClassic, map[string]string{}))
+	assert.ErrorContains(t, chgm.validate(clusterTypeHCP, map[string]string{}), "doesn't support hcp clusters")
+	assert.ErrorContains(t, chgm.validate(clusterTypeClassic, map[string]string{"FOO": "bar"}), "doesn't accept parameter(s): FOO")
+
+	mustGather, err := catalog.get("must-gather")
+	assert.NoError(t, err)
+	assert.NoError(t, mustGather.validate(clusterTypeClassic, map[string]string{"TARGET": "etcd"}))
+	assert.NoError(t, mustGather.validate(clusterTypeHCP, map[string]string{"TARGET": "etcd", "SINCE": "1h"}))
+	assert.ErrorContains(t, mustGather.validate(clusterTypeHCP, map[string]string{"SINCE": "1h"}), "requires parameter(s): TARGET")
+}
+
+func TestPipelineRunTemplateInvestigationParameters(t *testing.T) {
+	opts := &cadRunOptions{
+		clusterID:           "test-cluster-123",
+		investigation:       "must-gather",
+		investigationParams: map[string]string{"TARGET": "etcd"},
+	}
+
+	spec := opts.pipelineRunTemplate(cadNamespaceProd).Object["spec"].(map[string]interface{})
+	params := spec["params"].([]map[string]interface{})
+	assert.Len(t, params, 4)
+	assert.Equal(t, investigationParametersParam, params[3]["name"])
+	assert.Equal(t, `{"TARGET":"etcd"}`, params[3]["value"])
+
+	opts.investigationParams = map[string]string{}
+	spec = opts.pipelineRunTemplate(cadNamespaceProd).Object["spec"].(map[string]interface{})
+	assert.Len(t, spec["params"].([]map[string]interface{}), 3)
+}
+
+func TestRunValidateParams(t *testing.T) {
+	opts := &cadRunOptions{
+		clusterID:       "test-cluster-123",
+		investigation:   "must-gather",
+		environment:     "production",
+		elevationReason: "OHSS-12345",
+		params:          []string{"TARGET=etcd", "SINCE=1h"},
+	}
+	assert.NoError(t, opts.validate())
+	assert.Equal(t, map[string]string{"TARGET": "etcd", "SINCE": "1h"}, opts.investigationParams)
+
+	opts.params = []string{"TARGET"}
+	assert.Error(t, opts.validate())
+}
+
+func TestPrintInvestigationCatalog(t *testing.T) {
+	catalog, err := parseInvestigationCatalog([]byte(testCatalog))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, printInvestigationCatalog(buf, catalog))
+	assert.Contains(t, buf.String(), "classic")
+	assert.Contains(t, buf.String(), "any")
+	assert.Contains(t, buf.String(), "TARGET (required), SINCE")
+}
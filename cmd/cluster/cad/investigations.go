@@ -0,0 +1,109 @@
+package cad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type cadInvestigationsOptions struct {
+	environment     string
+	elevationReason string
+	output          string
+}
+
+func newCmdInvestigations() *cobra.Command {
+	opts := &cadInvestigationsOptions{}
+
+	investigationsCmd := &cobra.Command{
+		Use:   "investigations",
+		Short: "List the investigations CAD can run manually",
+		Long: `List the investigations which can be run with 'osdctl cluster cad run', together with the
+cluster types they support and the parameters they accept.
+
+The catalog is read from the ` + catalogConfigMapName + ` ConfigMap on the CAD cluster of the given environment.
+Without --environment, or when the CAD cluster doesn't provide the ConfigMap, the catalog built into osdctl is shown.
+
+Examples:
+` + "```bash" + `
+# Show the catalog built into osdctl
+osdctl cluster cad investigations
+
+# Show the catalog of the production CAD cluster, including parameter descriptions
+osdctl cluster cad investigations --environment production --reason "OHSS-12345" -o json
+` + "```",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.Context())
+		},
+	}
+
+	investigationsCmd.Flags().StringVarP(&opts.environment, "environment", "e", "", "Environment of the CAD cluster to read the catalog from. Allowed values: \"stage\" or \"production\"")
+	investigationsCmd.Flags().StringVar(&opts.elevationReason, "reason", "", "Provide a reason for accessing the CAD cluster, used for backplane. Eg: 'OHSS-XXXX', or '#ITN-2024-XXXXX.")
+	investigationsCmd.Flags().StringVarP(&opts.output, "output", "o", "text", "Output format: text or json")
+
+	_ = investigationsCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validEnvironments, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return investigationsCmd
+}
+
+func (o *cadInvestigationsOptions) run(ctx context.Context) error {
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %q, must be text or json", o.output)
+	}
+
+	catalog, source, err := o.loadCatalog(ctx)
+	if err != nil {
+		return err
+	}
+
+	if o.output == "json" {
+		bytes, err := json.MarshalIndent(catalog, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal investigation catalog: %w", err)
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	fmt.Printf("Investigation catalog (%s):\n\n", source)
+	return printInvestigationCatalog(os.Stdout, catalog)
+}
+
+func (o *cadInvestigationsOptions) loadCatalog(ctx context.Context) (*investigationCatalog, string, error) {
+	if o.environment == "" {
+		catalog, err := defaultInvestigationCatalog()
+		return catalog, catalogSourceBuiltIn, err
+	}
+
+	if !slices.Contains(validEnvironments, o.environment) {
+		return nil, "", fmt.Errorf("invalid environment %q, must be one of: %v", o.environment, validEnvironments)
+	}
+
+	// CAD clusters are always in production OCM, so explicitly create a production connection
+	ocmConn, err := utils.CreateConnectionWithUrl("production")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create production OCM connection: %w", err)
+	}
+	defer ocmConn.Close()
+
+	var elevationReasons []string
+	if o.elevationReason != "" {
+		elevationReasons = []string{o.elevationReason, "Need elevation for cad cluster in order to read the investigation catalog"}
+	}
+
+	cad, err := newCADCluster(ocmConn, o.environment, elevationReasons...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return cad.loadInvestigationCatalog(ctx)
+}
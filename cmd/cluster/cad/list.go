@@ -0,0 +1,102 @@
+package cad
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type cadListOptions struct {
+	clusterID       string
+	environment     string
+	elevationReason string
+	last            int
+}
+
+func newCmdList() *cobra.Command {
+	opts := &cadListOptions{}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent manual investigations against a cluster",
+		Long: `List the manual investigations scheduled with 'osdctl cluster cad run' against a cluster, newest first.
+
+Prerequisites:
+  - Connected to the target cluster's OCM environment (production or stage), which is used to resolve the cluster ID
+
+Examples:
+` + "```bash" + `
+# List the last 5 investigations of a production cluster
+osdctl cluster cad list --cluster-id 1a2b3c4d5e6f7g8h9i0j --environment production --reason "OHSS-12345" -l 5
+` + "```",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.Context())
+		},
+	}
+
+	listCmd.Flags().StringVarP(&opts.clusterID, "cluster-id", "C", "", "Cluster ID (internal or external)")
+	listCmd.Flags().StringVarP(&opts.environment, "environment", "e", "", "Environment in which the target cluster runs. Allowed values: \"stage\" or \"production\"")
+	listCmd.Flags().StringVar(&opts.elevationReason, "reason", "", "Provide a reason for accessing the CAD cluster, used for backplane. Eg: 'OHSS-XXXX', or '#ITN-2024-XXXXX.")
+	listCmd.Flags().IntVarP(&opts.last, "last", "l", 10, "Number of most recent investigations to show")
+
+	_ = listCmd.MarkFlagRequired("cluster-id")
+	_ = listCmd.MarkFlagRequired("environment")
+	_ = listCmd.MarkFlagRequired("reason")
+
+	_ = listCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validEnvironments, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return listCmd
+}
+
+func (o *cadListOptions) run(ctx context.Context) error {
+	if !slices.Contains(validEnvironments, o.environment) {
+		return fmt.Errorf("invalid environment %q, must be one of: %v", o.environment, validEnvironments)
+	}
+
+	// Investigations may have been scheduled with either the internal or the external cluster ID
+	targetConn, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	cluster, err := utils.GetCluster(targetConn, o.clusterID)
+	targetConn.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster %s: %w", o.clusterID, err)
+	}
+
+	// CAD clusters are always in production OCM, so explicitly create a production connection
+	ocmConn, err := utils.CreateConnectionWithUrl("production")
+	if err != nil {
+		return fmt.Errorf("failed to create production OCM connection: %w", err)
+	}
+	defer ocmConn.Close()
+
+	cad, err := newCADCluster(ocmConn, o.environment, o.elevationReason, "Need elevation for cad cluster in order to read Tekton pipeline runs")
+	if err != nil {
+		return err
+	}
+
+	pipelineRuns, err := cad.listPipelineRuns(ctx)
+	if err != nil {
+		return err
+	}
+
+	pipelineRuns = filterPipelineRuns(pipelineRuns, o.clusterID, cluster.ID(), cluster.ExternalID(), cluster.Name())
+	if len(pipelineRuns) == 0 {
+		fmt.Printf("No investigations found for cluster %s\n", o.clusterID)
+		return nil
+	}
+	if o.last > 0 && len(pipelineRuns) > o.last {
+		pipelineRuns = pipelineRuns[:o.last]
+	}
+
+	return printPipelineRuns(os.Stdout, pipelineRuns)
+}
@@ -0,0 +1,350 @@
+package cad
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift/osdctl/cmd/common"
+	"github.com/openshift/osdctl/pkg/backplane"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pipelineRunStatusPending   = "Pending"
+	pipelineRunStatusRunning   = "Running"
+	pipelineRunStatusSucceeded = "Succeeded"
+	pipelineRunStatusFailed    = "Failed"
+
+	pipelineRunPollInterval = 10 * time.Second
+	// pipelineRunWaitTimeout is slightly longer than the timeout set in pipelineRunTemplate
+	pipelineRunWaitTimeout = 35 * time.Minute
+)
+
+var (
+	pipelineRunGVK = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "PipelineRun"}
+	taskRunGVK     = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1beta1", Kind: "TaskRun"}
+)
+
+// cadCluster holds the clients used to manage PipelineRuns on a CAD cluster
+type cadCluster struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	namespace string
+}
+
+// newCADCluster connects to the CAD cluster of the environment as backplane-cluster-admin.
+// ocmConn must be a production OCM connection, as the CAD clusters are always in production OCM.
+func newCADCluster(ocmConn *sdk.Connection, environment string, elevationReasons ...string) (*cadCluster, error) {
+	cadClusterID, cadNamespace := getCADClusterConfig(environment)
+
+	k8sClient, _, clientset, err := common.GetKubeConfigAndClientWithConn(cadClusterID, ocmConn, elevationReasons...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8s client: %w", err)
+	}
+
+	return &cadCluster{
+		client:    k8sClient,
+		clientset: clientset,
+		namespace: cadNamespace,
+	}, nil
+}
+
+func getCADClusterConfig(environment string) (clusterID, namespace string) {
+	if environment == "stage" {
+		return cadClusterIDStage, cadNamespaceStage
+	}
+	return cadClusterIDProd, cadNamespaceProd
+}
+
+func (c *cadCluster) getPipelineRun(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(pipelineRunGVK)
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: name}, u); err != nil {
+		return nil, fmt.Errorf("failed to get PipelineRun %s: %w", name, err)
+	}
+	return u, nil
+}
+
+func (c *cadCluster) listPipelineRuns(ctx context.Context) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(pipelineRunGVK.GroupVersion().WithKind(pipelineRunGVK.Kind + "List"))
+	if err := c.client.List(ctx, list, client.InNamespace(c.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list PipelineRuns: %w", err)
+	}
+	return list.Items, nil
+}
+
+// waitForPipelineRun polls the PipelineRun until it has finished, streaming the logs of its
+// TaskRuns to w as they become available
+func (c *cadCluster) waitForPipelineRun(ctx context.Context, name string, w io.Writer) (*unstructured.Unstructured, error) {
+	streamed := map[string]bool{}
+	var pipelineRun *unstructured.Unstructured
+
+	err := wait.PollUntilContextTimeout(ctx, pipelineRunPollInterval, pipelineRunWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		pipelineRun, err = c.getPipelineRun(ctx, name)
+		if err != nil {
+			return false, err
+		}
+
+		for _, taskRun := range taskRunNames(pipelineRun) {
+			c.streamTaskRunLogs(ctx, taskRun, streamed, w)
+		}
+
+		status, _ := pipelineRunStatus(pipelineRun)
+		return status == pipelineRunStatusSucceeded || status == pipelineRunStatusFailed, nil
+	})
+	if err != nil {
+		return pipelineRun, fmt.Errorf("failed waiting for PipelineRun %s to finish: %w", name, err)
+	}
+
+	return pipelineRun, nil
+}
+
+// streamTaskRunLogs follows the logs of every step of the TaskRun's pod which hasn't been
+// streamed yet. Steps which haven't started yet are picked up by a later call.
+func (c *cadCluster) streamTaskRunLogs(ctx context.Context, taskRunName string, streamed map[string]bool, w io.Writer) {
+	taskRun := &unstructured.Unstructured{}
+	taskRun.SetGroupVersionKind(taskRunGVK)
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: taskRunName}, taskRun); err != nil {
+		return
+	}
+
+	podName, _, _ := unstructured.NestedString(taskRun.Object, "status", "podName")
+	if podName == "" {
+		return
+	}
+
+	pod, err := c.clientset.CoreV1().Pods(c.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+
+	for _, container := range pod.Spec.Containers {
+		key := podName + "/" + container.Name
+		if streamed[key] || !containerStarted(pod, container.Name) {
+			continue
+		}
+
+		stream, err := c.clientset.CoreV1().Pods(c.namespace).GetLogs(podName, &corev1.PodLogOptions{Container: container.Name, Follow: true}).Stream(ctx)
+		if err != nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "==> %s/%s <==\n", taskRunName, strings.TrimPrefix(container.Name, "step-"))
+		_, _ = io.Copy(w, stream)
+		_ = stream.Close()
+		streamed[key] = true
+	}
+}
+
+func containerStarted(pod *corev1.Pod, containerName string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status.State.Running != nil || status.State.Terminated != nil
+		}
+	}
+	return false
+}
+
+// pipelineRunStatus returns the status of the PipelineRun based on its Succeeded condition,
+// together with the condition's message
+func pipelineRunStatus(pipelineRun *unstructured.Unstructured) (status string, message string) {
+	conditions, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+		message, _ = condition["message"].(string)
+		switch condition["status"] {
+		case "True":
+			return pipelineRunStatusSucceeded, message
+		case "False":
+			return pipelineRunStatusFailed, message
+		default:
+			return pipelineRunStatusRunning, message
+		}
+	}
+	return pipelineRunStatusPending, ""
+}
+
+// pipelineRunParam returns the value of a parameter of the PipelineRun as a string
+func pipelineRunParam(pipelineRun *unstructured.Unstructured, name string) string {
+	params, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "params")
+	for _, p := range params {
+		param, ok := p.(map[string]interface{})
+		if !ok || param["name"] != name {
+			continue
+		}
+		return fmt.Sprint(param["value"])
+	}
+	return ""
+}
+
+// pipelineRunTime returns the time stored in the PipelineRun's status field, or the zero time
+func pipelineRunTime(pipelineRun *unstructured.Unstructured, field string) time.Time {
+	value, _, _ := unstructured.NestedString(pipelineRun.Object, "status", field)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// taskRunNames returns the names of the TaskRuns created for the PipelineRun, in the order
+// they were started
+func taskRunNames(pipelineRun *unstructured.Unstructured) []string {
+	var names []string
+
+	childReferences, _, _ := unstructured.NestedSlice(pipelineRun.Object, "status", "childReferences")
+	for _, c := range childReferences {
+		child, ok := c.(map[string]interface{})
+		if !ok || child["kind"] != "TaskRun" {
+			continue
+		}
+		if name, ok := child["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+
+	// Older Tekton versions embed the TaskRuns in the status instead of referencing them
+	taskRuns, _, _ := unstructured.NestedMap(pipelineRun.Object, "status", "taskRuns")
+	for name := range taskRuns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// filterPipelineRuns returns the PipelineRuns investigating one of the clusterIDs, newest first
+func filterPipelineRuns(pipelineRuns []unstructured.Unstructured, clusterIDs ...string) []unstructured.Unstructured {
+	var filtered []unstructured.Unstructured
+	for _, pipelineRun := range pipelineRuns {
+		target := pipelineRunParam(&pipelineRun, "cluster-id")
+		for _, clusterID := range clusterIDs {
+			if clusterID != "" && target == clusterID {
+				filtered = append(filtered, pipelineRun)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].GetCreationTimestamp().After(filtered[j].GetCreationTimestamp().Time)
+	})
+	return filtered
+}
+
+func formatPipelineRunTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func printPipelineRuns(w io.Writer, pipelineRuns []unstructured.Unstructured) error {
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Name", "Investigation", "Dry Run", "Status", "Started", "Completed"})
+	for _, pipelineRun := range pipelineRuns {
+		status, _ := pipelineRunStatus(&pipelineRun)
+		table.AddRow([]string{
+			pipelineRun.GetName(),
+			pipelineRunParam(&pipelineRun, "investigation"),
+			pipelineRunParam(&pipelineRun, "dry-run"),
+			status,
+			formatPipelineRunTime(pipelineRunTime(&pipelineRun, "startTime")),
+			formatPipelineRunTime(pipelineRunTime(&pipelineRun, "completionTime")),
+		})
+	}
+	return table.Flush()
+}
+
+func printPipelineRunStatus(w io.Writer, pipelineRun *unstructured.Unstructured) error {
+	status, message := pipelineRunStatus(pipelineRun)
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Name", pipelineRun.GetName()})
+	table.AddRow([]string{"Cluster ID", pipelineRunParam(pipelineRun, "cluster-id")})
+	table.AddRow([]string{"Investigation", pipelineRunParam(pipelineRun, "investigation")})
+	table.AddRow([]string{"Dry Run", pipelineRunParam(pipelineRun, "dry-run")})
+	table.AddRow([]string{"Status", status})
+	if message != "" {
+		table.AddRow([]string{"Message", message})
+	}
+	table.AddRow([]string{"Started", formatPipelineRunTime(pipelineRunTime(pipelineRun, "startTime"))})
+	table.AddRow([]string{"Completed", formatPipelineRunTime(pipelineRunTime(pipelineRun, "completionTime"))})
+	table.AddRow([]string{"TaskRuns", strings.Join(taskRunNames(pipelineRun), ", ")})
+	return table.Flush()
+}
+
+// printInvestigationReport prints the newest backplane report of the cluster created after the
+// PipelineRun was scheduled. The target cluster's OCM environment is used to reach backplane-api.
+func printInvestigationReport(ctx context.Context, w io.Writer, clusterID string, pipelineRun *unstructured.Unstructured) error {
+	ocmClient, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	defer ocmClient.Close()
+
+	internalClusterID, err := utils.GetInternalClusterID(ocmClient, clusterID)
+	if err != nil {
+		return err
+	}
+
+	backplaneClient, err := backplane.NewClient(internalClusterID)
+	if err != nil {
+		return fmt.Errorf("failed to create backplane client: %w", err)
+	}
+
+	reports, err := backplaneClient.ListReports(ctx, 10)
+	if err != nil {
+		return err
+	}
+
+	scheduledAt := pipelineRun.GetCreationTimestamp().Time
+	var reportID string
+	var newest time.Time
+	for _, report := range reports.Reports {
+		if report.ReportId == nil || report.CreatedAt == nil || report.CreatedAt.Before(scheduledAt) {
+			continue
+		}
+		if report.CreatedAt.After(newest) {
+			newest = *report.CreatedAt
+			reportID = *report.ReportId
+		}
+	}
+	if reportID == "" {
+		return fmt.Errorf("no report was created for cluster %s after %s", clusterID, scheduledAt.UTC().Format(time.RFC3339))
+	}
+
+	report, err := backplaneClient.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	decodedData, err := base64.StdEncoding.DecodeString(report.Data)
+	if err != nil {
+		return fmt.Errorf("failed to decode report data: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(w, "📒Report Details for Report %s created at %s\n\n", report.ReportId, report.CreatedAt.Format(time.RFC3339))
+	_, err = fmt.Fprintln(w, string(decodedData))
+	return err
+}
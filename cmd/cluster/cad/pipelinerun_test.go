@@ -0,0 +1,134 @@
+package cad
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestPipelineRun(name, clusterID string, created time.Time, status map[string]interface{}) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"params": []interface{}{
+				map[string]interface{}{"name": "cluster-id", "value": clusterID},
+				map[string]interface{}{"name": "investigation", "value": "chgm"},
+				map[string]interface{}{"name": "dry-run", "value": false},
+			},
+		},
+		"status": status,
+	}}
+	u.SetName(name)
+	u.SetCreationTimestamp(metav1.NewTime(created))
+	return u
+}
+
+func TestPipelineRunStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		conditions      []interface{}
+		expectedStatus  string
+		expectedMessage string
+	}{
+		{
+			name:           "no conditions",
+			expectedStatus: pipelineRunStatusPending,
+		},
+		{
+			name: "running",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": "Unknown", "message": "Tasks Completed: 0"},
+			},
+			expectedStatus:  pipelineRunStatusRunning,
+			expectedMessage: "Tasks Completed: 0",
+		},
+		{
+			name: "succeeded",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": "True"},
+			},
+			expectedStatus: pipelineRunStatusSucceeded,
+		},
+		{
+			name: "failed",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": "False", "message": "task failed"},
+			},
+			expectedStatus:  pipelineRunStatusFailed,
+			expectedMessage: "task failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineRun := newTestPipelineRun("cad-manual-abc", "cluster", time.Now(), map[string]interface{}{"conditions": tt.conditions})
+			status, message := pipelineRunStatus(&pipelineRun)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
+
+func TestPipelineRunParam(t *testing.T) {
+	pipelineRun := newTestPipelineRun("cad-manual-abc", "cluster-123", time.Now(), nil)
+	assert.Equal(t, "cluster-123", pipelineRunParam(&pipelineRun, "cluster-id"))
+	assert.Equal(t, "chgm", pipelineRunParam(&pipelineRun, "investigation"))
+	assert.Equal(t, "false", pipelineRunParam(&pipelineRun, "dry-run"))
+	assert.Empty(t, pipelineRunParam(&pipelineRun, "missing"))
+}
+
+func TestTaskRunNames(t *testing.T) {
+	childReferences := newTestPipelineRun("cad-manual-abc", "cluster", time.Now(), map[string]interface{}{
+		"childReferences": []interface{}{
+			map[string]interface{}{"kind": "TaskRun", "name": "cad-manual-abc-investigate"},
+			map[string]interface{}{"kind": "Run", "name": "cad-manual-abc-custom"},
+			map[string]interface{}{"kind": "TaskRun", "name": "cad-manual-abc-report"},
+		},
+	})
+	assert.Equal(t, []string{"cad-manual-abc-investigate", "cad-manual-abc-report"}, taskRunNames(&childReferences))
+
+	embedded := newTestPipelineRun("cad-manual-abc", "cluster", time.Now(), map[string]interface{}{
+		"taskRuns": map[string]interface{}{
+			"cad-manual-abc-report":      map[string]interface{}{},
+			"cad-manual-abc-investigate": map[string]interface{}{},
+		},
+	})
+	assert.Equal(t, []string{"cad-manual-abc-investigate", "cad-manual-abc-report"}, taskRunNames(&embedded))
+}
+
+func TestFilterPipelineRuns(t *testing.T) {
+	now := time.Now()
+	pipelineRuns := []unstructured.Unstructured{
+		newTestPipelineRun("oldest", "internal-id", now.Add(-2*time.Hour), nil),
+		newTestPipelineRun("other-cluster", "other-id", now.Add(-time.Hour), nil),
+		newTestPipelineRun("newest", "external-id", now, nil),
+	}
+
+	filtered := filterPipelineRuns(pipelineRuns, "internal-id", "external-id", "")
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "newest", filtered[0].GetName())
+	assert.Equal(t, "oldest", filtered[1].GetName())
+
+	assert.Empty(t, filterPipelineRuns(pipelineRuns, ""))
+}
+
+func TestPrintPipelineRunStatus(t *testing.T) {
+	pipelineRun := newTestPipelineRun("cad-manual-abc", "cluster-123", time.Now(), map[string]interface{}{
+		"startTime":      "2024-01-01T10:00:00Z",
+		"completionTime": "2024-01-01T10:05:00Z",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Succeeded", "status": "True", "message": "Tasks Completed: 2"},
+		},
+	})
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, printPipelineRunStatus(buf, &pipelineRun))
+
+	output := buf.String()
+	assert.Contains(t, output, "cluster-123")
+	assert.Contains(t, output, pipelineRunStatusSucceeded)
+	assert.Contains(t, output, "2024-01-01T10:05:00Z")
+}
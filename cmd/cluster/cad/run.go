@@ -3,16 +3,15 @@ package cad
 import (
 	"context"
 	"fmt"
+	"os"
 	"slices"
 
 	"github.com/openshift/osdctl/cmd/setup"
-	"github.com/openshift/osdctl/pkg/k8s"
+	"github.com/openshift/osdctl/internal/templates"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -22,20 +21,6 @@ const (
 	cadNamespaceStage = "configuration-anomaly-detection-stage"
 )
 
-var validInvestigations = []string{
-	"chgm",
-	"cmbb",
-	"can-not-retrieve-updates",
-	"ai",
-	"cpd",
-	"etcd-quota-low",
-	"insightsoperatordown",
-	"machine-health-check",
-	"must-gather",
-	"upgrade-config",
-	"restart-controlplane",
-}
-
 var validEnvironments = []string{
 	"stage",
 	"production",
@@ -47,6 +32,10 @@ type cadRunOptions struct {
 	elevationReason string
 	environment     string
 	isDryRun        bool
+	wait            bool
+	params          []string
+
+	investigationParams map[string]string
 }
 
 func newCmdRun() *cobra.Command {
@@ -65,8 +54,9 @@ Prerequisites:
   - The CAD clusters themselves are always in production OCM
 
 Available Investigations:
-  chgm, cmbb, can-not-retrieve-updates, ai, cpd, etcd-quota-low,
-  insightsoperatordown, machine-health-check, must-gather, upgrade-config
+  The investigations, their parameters and the cluster types they support are listed by
+  'osdctl cluster cad investigations'. The investigation and its parameters are validated
+  against this catalog before the PipelineRun is scheduled.
 
 Examples:
 ` + "```bash" + `
@@ -84,6 +74,22 @@ osdctl cluster cad run \
   --environment production \
   --reason "OHSS-12345" \
   --dry-run
+
+# Run an investigation which takes investigation-specific parameters
+osdctl cluster cad run \
+  --cluster-id 1a2b3c4d5e6f7g8h9i0j \
+  --investigation must-gather \
+  --environment production \
+  --reason "OHSS-12345" \
+  --param KEY=VALUE
+
+# Wait for the investigation to finish, streaming its logs and printing the resulting report
+osdctl cluster cad run \
+  --cluster-id 1a2b3c4d5e6f7g8h9i0j \
+  --investigation chgm \
+  --environment production \
+  --reason "OHSS-12345" \
+  --wait
 ` + "```" + `
 
 Note:
@@ -92,11 +98,13 @@ Note:
 osdctl cluster reports list -C <cluster-id> -l 1
 ` + "```" + `
 
+  The progress of scheduled investigations can be checked with 'osdctl cluster cad status' and 'osdctl cluster cad list'.
+
   You must be connected to the target cluster's OCM environment to view its reports.`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return opts.run()
+			return opts.run(cmd.Context())
 		},
 	}
 
@@ -104,6 +112,8 @@ osdctl cluster reports list -C <cluster-id> -l 1
 	runCmd.Flags().StringVarP(&opts.investigation, "investigation", "i", "", "Investigation name")
 	runCmd.Flags().StringVarP(&opts.environment, "environment", "e", "", "Environment in which the target cluster runs. Allowed values: \"stage\" or \"production\"")
 	runCmd.Flags().BoolVarP(&opts.isDryRun, "dry-run", "d", false, "Dry-Run: Run the investigation with the dry-run flag. This will not create a report.")
+	runCmd.Flags().StringArrayVarP(&opts.params, "param", "p", []string{}, "Investigation-specific parameter in the form NAME=VALUE, may be repeated. See 'osdctl cluster cad investigations'")
+	runCmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "Wait for the investigation to finish, stream its logs and print the resulting report")
 	runCmd.Flags().StringVar(&opts.elevationReason, "reason", "", "Provide a reason for running a manual investigation, used for backplane. Eg: 'OHSS-XXXX', or '#ITN-2024-XXXXX.")
 
 	_ = runCmd.MarkFlagRequired("cluster-id")
@@ -112,7 +122,11 @@ osdctl cluster reports list -C <cluster-id> -l 1
 	_ = runCmd.MarkFlagRequired("reason")
 
 	_ = runCmd.RegisterFlagCompletionFunc("investigation", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return validInvestigations, cobra.ShellCompDirectiveNoFileComp
+		catalog, err := defaultInvestigationCatalog()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return catalog.names(), cobra.ShellCompDirectiveNoFileComp
 	})
 
 	_ = runCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -122,7 +136,7 @@ osdctl cluster reports list -C <cluster-id> -l 1
 	return runCmd
 }
 
-func (o *cadRunOptions) run() error {
+func (o *cadRunOptions) run(ctx context.Context) error {
 	if err := o.validate(); err != nil {
 		return err
 	}
@@ -130,7 +144,18 @@ func (o *cadRunOptions) run() error {
 	grafanaURL := viper.GetString(setup.CADGrafanaURL)
 	awsAccountID := viper.GetString(setup.CADAWSAccountID)
 
-	cadClusterID, cadNamespace := o.getCADClusterConfig()
+	_, cadNamespace := o.getCADClusterConfig()
+
+	// The target cluster is resolved in the current OCM environment to check its type
+	targetConn, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	cluster, err := utils.GetCluster(targetConn, o.clusterID)
+	targetConn.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster %s: %w", o.clusterID, err)
+	}
 
 	// CAD clusters are always in production OCM, so explicitly create a production connection
 	ocmConn, err := utils.CreateConnectionWithUrl("production")
@@ -139,14 +164,26 @@ func (o *cadRunOptions) run() error {
 	}
 	defer ocmConn.Close()
 
-	k8sClient, err := k8s.NewAsBackplaneClusterAdminWithConn(cadClusterID, client.Options{}, ocmConn, o.elevationReason, "Need elevation for cad cluster in order to schedule a Tekton pipeline run")
+	cad, err := newCADCluster(ocmConn, o.environment, o.elevationReason, "Need elevation for cad cluster in order to schedule a Tekton pipeline run")
 	if err != nil {
-		return fmt.Errorf("failed to create k8s client: %w", err)
+		return err
+	}
+
+	catalog, _, err := cad.loadInvestigationCatalog(ctx)
+	if err != nil {
+		return err
+	}
+	inv, err := catalog.get(o.investigation)
+	if err != nil {
+		return err
+	}
+	if err := inv.validate(clusterTypeOf(cluster), o.investigationParams); err != nil {
+		return err
 	}
 
 	u := o.pipelineRunTemplate(cadNamespace)
 
-	err = k8sClient.Create(context.Background(), u)
+	err = cad.client.Create(ctx, u)
 	if err != nil {
 		return fmt.Errorf("failed to schedule task: %w", err)
 	}
@@ -159,6 +196,10 @@ func (o *cadRunOptions) run() error {
 		logsLink = fmt.Sprintf("%s/explore?schemaVersion=1&panes=%%7B%%22buh%%22:%%7B%%22datasource%%22:%%22P1A97A9592CB7F392%%22,%%22queries%%22:%%5B%%7B%%22id%%22:%%22%%22,%%22region%%22:%%22us-east-1%%22,%%22namespace%%22:%%22%%22,%%22refId%%22:%%22A%%22,%%22datasource%%22:%%7B%%22type%%22:%%22cloudwatch%%22,%%22uid%%22:%%22P1A97A9592CB7F392%%22%%7D,%%22queryMode%%22:%%22Logs%%22,%%22logGroups%%22:%%5B%%7B%%22arn%%22:%%22arn:aws:logs:us-east-1:%[2]s:log-group:cads01ue1.configuration-anomaly-detection-stage:%%2A%%22,%%22name%%22:%%22cads01ue1.configuration-anomaly-detection-stage%%22,%%22accountId%%22:%%22%[2]s%%22%%7D,%%7B%%22arn%%22:%%22arn:aws:logs:us-east-1:%[2]s:log-group:cadp01ue1.configuration-anomaly-detection-production:%%2A%%22,%%22name%%22:%%22cadp01ue1.configuration-anomaly-detection-production%%22,%%22accountId%%22:%%22%[2]s%%22%%7D%%5D,%%22expression%%22:%%22fields%%20message%%5Cn%%7C%%20filter%%20kubernetes.pod_name%%20like%%20%%5C%%22%s%%5C%%22%%22,%%22statsGroups%%22:%%5B%%5D%%7D%%5D,%%22range%%22:%%7B%%22from%%22:%%22now-1h%%22,%%22to%%22:%%22now%%22%%7D,%%22panelsState%%22:%%7B%%22logs%%22:%%7B%%22visualisationType%%22:%%22logs%%22%%7D%%7D%%7D%%7D&orgId=1", grafanaURL, awsAccountID, pipelineRunName)
 	}
 
+	if o.wait {
+		return o.waitForInvestigation(ctx, cad, pipelineRunName, logsLink)
+	}
+
 	if !o.isDryRun {
 		reportCmd := fmt.Sprintf("'osdctl cluster reports list -C %s -l 1'", o.clusterID)
 		msg := "Successfully scheduled manual investigation. It can take several minutes until a report is available. \n" +
@@ -181,13 +222,52 @@ func (o *cadRunOptions) run() error {
 	return nil
 }
 
+// waitForInvestigation follows the PipelineRun until it has finished and prints the report it created
+func (o *cadRunOptions) waitForInvestigation(ctx context.Context, cad *cadCluster, pipelineRunName, logsLink string) error {
+	fmt.Printf("Scheduled PipelineRun %s, waiting for it to finish...\n", pipelineRunName)
+
+	pipelineRun, err := cad.waitForPipelineRun(ctx, pipelineRunName, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if err := printPipelineRunStatus(os.Stdout, pipelineRun); err != nil {
+		return err
+	}
+
+	if status, _ := pipelineRunStatus(pipelineRun); status != pipelineRunStatusSucceeded {
+		if logsLink != "" {
+			fmt.Println("Check the TaskRun pod logs here: ", logsLink)
+		}
+		return fmt.Errorf("investigation %s did not succeed", pipelineRunName)
+	}
+
+	if o.isDryRun {
+		fmt.Println("Dry-run investigation finished, no report was created.")
+		return nil
+	}
+
+	fmt.Println()
+	return printInvestigationReport(ctx, os.Stdout, o.clusterID, pipelineRun)
+}
+
 func (o *cadRunOptions) validate() error {
 	if o.clusterID == "" {
 		return fmt.Errorf("cluster-id is required")
 	}
 
-	if !slices.Contains(validInvestigations, o.investigation) {
-		return fmt.Errorf("invalid investigation %q, must be one of: %v", o.investigation, validInvestigations)
+	if o.investigation == "" {
+		return fmt.Errorf("investigation is required")
+	}
+
+	o.investigationParams = map[string]string{}
+	for _, p := range o.params {
+		name, value, err := templates.ParseAssignment(p)
+		if err != nil {
+			return err
+		}
+		o.investigationParams[name] = value
 	}
 
 	if !slices.Contains(validEnvironments, o.environment) {
@@ -202,13 +282,31 @@ func (o *cadRunOptions) validate() error {
 }
 
 func (o *cadRunOptions) getCADClusterConfig() (clusterID, namespace string) {
-	if o.environment == "stage" {
-		return cadClusterIDStage, cadNamespaceStage
-	}
-	return cadClusterIDProd, cadNamespaceProd
+	return getCADClusterConfig(o.environment)
 }
 
 func (o *cadRunOptions) pipelineRunTemplate(cadNamespace string) *unstructured.Unstructured {
+	params := []map[string]interface{}{
+		{
+			"name":  "cluster-id",
+			"value": o.clusterID,
+		},
+		{
+			"name":  "investigation",
+			"value": o.investigation,
+		},
+		{
+			"name":  "dry-run",
+			"value": o.isDryRun,
+		},
+	}
+	if len(o.investigationParams) > 0 {
+		params = append(params, map[string]interface{}{
+			"name":  investigationParametersParam,
+			"value": encodeInvestigationParameters(o.investigationParams),
+		})
+	}
+
 	u := unstructured.Unstructured{}
 	u.Object = map[string]interface{}{
 		"apiVersion": "tekton.dev/v1beta1",
@@ -218,20 +316,7 @@ func (o *cadRunOptions) pipelineRunTemplate(cadNamespace string) *unstructured.U
 			"namespace":    cadNamespace,
 		},
 		"spec": map[string]interface{}{
-			"params": []map[string]interface{}{
-				{
-					"name":  "cluster-id",
-					"value": o.clusterID,
-				},
-				{
-					"name":  "investigation",
-					"value": o.investigation,
-				},
-				{
-					"name":  "dry-run",
-					"value": o.isDryRun,
-				},
-			},
+			"params": params,
 			"pipelineRef": map[string]interface{}{
 				"name": "cad-manual-investigation-pipeline",
 			},
@@ -240,11 +325,7 @@ func (o *cadRunOptions) pipelineRunTemplate(cadNamespace string) *unstructured.U
 		},
 	}
 
-	u.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "tekton.dev",
-		Version: "v1beta1",
-		Kind:    "PipelineRun",
-	})
+	u.SetGroupVersionKind(pipelineRunGVK)
 
 	return &u
 }
@@ -0,0 +1,91 @@
+package cad
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type cadStatusOptions struct {
+	pipelineRunName string
+	environment     string
+	elevationReason string
+	wait            bool
+}
+
+func newCmdStatus() *cobra.Command {
+	opts := &cadStatusOptions{}
+
+	statusCmd := &cobra.Command{
+		Use:   "status <pipelinerun>",
+		Short: "Show the status of a manual investigation on the CAD cluster",
+		Long: `Show the status of a manual investigation scheduled with 'osdctl cluster cad run'.
+
+The PipelineRun name is printed by 'osdctl cluster cad run' and listed by 'osdctl cluster cad list'.
+
+Examples:
+` + "```bash" + `
+# Show the status of an investigation
+osdctl cluster cad status cad-manual-abc12 --environment production --reason "OHSS-12345"
+
+# Wait for an investigation to finish and stream its logs
+osdctl cluster cad status cad-manual-abc12 --environment production --reason "OHSS-12345" --wait
+` + "```",
+		Args:              cobra.ExactArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.pipelineRunName = args[0]
+			return opts.run(cmd.Context())
+		},
+	}
+
+	statusCmd.Flags().StringVarP(&opts.environment, "environment", "e", "", "Environment of the CAD cluster the investigation was scheduled on. Allowed values: \"stage\" or \"production\"")
+	statusCmd.Flags().StringVar(&opts.elevationReason, "reason", "", "Provide a reason for accessing the CAD cluster, used for backplane. Eg: 'OHSS-XXXX', or '#ITN-2024-XXXXX.")
+	statusCmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "Wait for the investigation to finish and stream its logs")
+
+	_ = statusCmd.MarkFlagRequired("environment")
+	_ = statusCmd.MarkFlagRequired("reason")
+
+	_ = statusCmd.RegisterFlagCompletionFunc("environment", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validEnvironments, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return statusCmd
+}
+
+func (o *cadStatusOptions) run(ctx context.Context) error {
+	if !slices.Contains(validEnvironments, o.environment) {
+		return fmt.Errorf("invalid environment %q, must be one of: %v", o.environment, validEnvironments)
+	}
+
+	// CAD clusters are always in production OCM, so explicitly create a production connection
+	ocmConn, err := utils.CreateConnectionWithUrl("production")
+	if err != nil {
+		return fmt.Errorf("failed to create production OCM connection: %w", err)
+	}
+	defer ocmConn.Close()
+
+	cad, err := newCADCluster(ocmConn, o.environment, o.elevationReason, "Need elevation for cad cluster in order to read Tekton pipeline runs")
+	if err != nil {
+		return err
+	}
+
+	if o.wait {
+		pipelineRun, err := cad.waitForPipelineRun(ctx, o.pipelineRunName, os.Stdout)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		return printPipelineRunStatus(os.Stdout, pipelineRun)
+	}
+
+	pipelineRun, err := cad.getPipelineRun(ctx, o.pipelineRunName)
+	if err != nil {
+		return err
+	}
+	return printPipelineRunStatus(os.Stdout, pipelineRun)
+}
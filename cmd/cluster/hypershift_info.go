@@ -40,7 +40,17 @@ func NewCmdHypershiftInfo(streams genericclioptions.IOStreams) *cobra.Command {
 		Use:   "hypershift-info",
 		Short: "Pull information about AWS objects from the cluster, the management cluster and the privatelink cluster",
 		Long: `This command aggregates AWS objects from the cluster, management cluster and privatelink for hypershift cluster.
-It attempts to render the relationships as graphviz if that output format is chosen or will simply print the output as tables.`,
+It attempts to render the relationships as graphviz if that output format is chosen or will simply print the output as tables.
+
+The json output has a stable schema, identified by its schemaVersion, and lists the issues found in the AWS objects,
+such as VPC endpoints and endpoint connections which are not available, load balancers which are not active and
+blackhole routes. The html output renders an interactive topology of the VPC endpoints, endpoint services, load
+balancers and Route53 records with the resources with issues highlighted. Selecting a resource shows its details.`,
+		Example: `  # Render the topology of a cluster as an HTML page
+  osdctl cluster hypershift-info -C <cluster-id> -p <profile> -l <privatelink-account-id> -o html > topology.html
+
+  # List the issues found in the AWS objects of a cluster
+  osdctl cluster hypershift-info -C <cluster-id> -p <profile> -l <privatelink-account-id> -o json | jq .issues`,
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(ops.complete(cmd))
@@ -51,7 +61,7 @@ It attempts to render the relationships as graphviz if that output format is cho
 	infoCmd.Flags().StringVarP(&ops.awsProfile, "profile", "p", "", "AWS Profile")
 	infoCmd.Flags().StringVarP(&ops.awsRegion, "region", "r", "", "AWS Region")
 	infoCmd.Flags().StringVarP(&ops.privatelinkAccountId, "privatelinkaccount", "l", "", "Privatelink account ID")
-	infoCmd.Flags().StringVarP(&ops.output, "output", "o", "graphviz", "output format ['table', 'graphviz']")
+	infoCmd.Flags().StringVarP(&ops.output, "output", "o", "graphviz", "output format ['table', 'graphviz', 'json', 'html']")
 	infoCmd.Flags().BoolVarP(&ops.verbose, "verbose", "", false, "Verbose output")
 
 	// Mark cluster-id as required
@@ -141,8 +151,8 @@ func (i *infoOptions) complete(cmd *cobra.Command) error {
 		errMsg += "missing argument -l."
 	}
 	if i.output != "" {
-		if i.output != "graphviz" && i.output != "table" {
-			errMsg += "output must be 'graphviz' or 'table'"
+		if i.output != "graphviz" && i.output != "table" && i.output != "json" && i.output != "html" {
+			errMsg += "output must be 'graphviz', 'table', 'json' or 'html'"
 		}
 	}
 	if errMsg != "" {
@@ -187,7 +197,7 @@ func (i *infoOptions) run() error {
 	go gatherManagementClusterInfo(awsSessions.managementClient, clusters.customerCluster.ID(), mcC)
 	go gatherCustomerClusterInfo(awsSessions.customerClient, clusters.customerCluster, cC)
 	go gatherPrivatelinkClusterInfo(awsSessions.privatelinkClient, clusters.customerCluster, plC)
-	for {
+	for ai.clusterInfo == nil || ai.managementClusterInfo == nil || ai.privatelinkInfo == nil {
 		select {
 		case r := <-plC:
 			verboseLog("Received privatelink information")
@@ -208,9 +218,6 @@ func (i *infoOptions) run() error {
 			}
 			ai.clusterInfo = &r.Value
 		}
-		if ai.clusterInfo != nil && ai.managementClusterInfo != nil && ai.privatelinkInfo != nil {
-			break
-		}
 	}
 	switch i.output {
 	case "table":
@@ -219,6 +226,13 @@ func (i *infoOptions) run() error {
 		connections := createGraphViz(&ai)
 		verboseLog("Generating GraphViz Input - please run this: 'echo <output> | dot -Tpng -o/tmp/example.png'")
 		graphviz.RenderGraphViz(connections)
+	case "json":
+		encoder := json.NewEncoder(i.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newHypershiftTopology(&ai, clusters))
+	case "html":
+		topology := newHypershiftTopology(&ai, clusters)
+		return topology.renderHTML(i.Out)
 	default:
 		fmt.Println("No valid output format selected")
 	}
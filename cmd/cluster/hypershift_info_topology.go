@@ -0,0 +1,506 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/openshift/osdctl/pkg/graphviz"
+)
+
+// hypershiftTopologySchemaVersion is the version of the json output schema, it changes if fields are removed or
+// change meaning, not when fields are added
+const hypershiftTopologySchemaVersion = "v1"
+
+const (
+	topologyAccountCustomer    = "customer"
+	topologyAccountManagement  = "management"
+	topologyAccountPrivatelink = "privatelink"
+)
+
+// hypershiftTopology is the json output of hypershift-info. Lists are never null so consumers don't have to
+// distinguish missing and empty resources.
+type hypershiftTopology struct {
+	SchemaVersion       string              `json:"schemaVersion"`
+	ClusterID           string              `json:"clusterId"`
+	ClusterName         string              `json:"clusterName"`
+	ManagementClusterID string              `json:"managementClusterId"`
+	Customer            customerTopology    `json:"customer"`
+	Management          managementTopology  `json:"management"`
+	Privatelink         privatelinkTopology `json:"privatelink"`
+	Issues              []topologyIssue     `json:"issues"`
+}
+
+type customerTopology struct {
+	HostedZones  []topologyHostedZone  `json:"hostedZones"`
+	Records      []topologyRecord      `json:"records"`
+	VpcEndpoints []topologyVpcEndpoint `json:"vpcEndpoints"`
+	Subnets      []topologySubnet      `json:"subnets"`
+	RouteTables  []topologyRouteTable  `json:"routeTables"`
+}
+
+type managementTopology struct {
+	EndpointServices    []topologyEndpointService    `json:"endpointServices"`
+	EndpointConnections []topologyEndpointConnection `json:"endpointConnections"`
+	LoadBalancers       []topologyLoadBalancer       `json:"loadBalancers"`
+}
+
+type privatelinkTopology struct {
+	HostedZones  []topologyHostedZone  `json:"hostedZones"`
+	Records      []topologyRecord      `json:"records"`
+	VpcEndpoints []topologyVpcEndpoint `json:"vpcEndpoints"`
+}
+
+type topologyHostedZone struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+}
+
+type topologyRecord struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+type topologyVpcEndpoint struct {
+	ID          string   `json:"id"`
+	VpcID       string   `json:"vpcId"`
+	ServiceName string   `json:"serviceName"`
+	Type        string   `json:"type"`
+	State       string   `json:"state"`
+	DNSNames    []string `json:"dnsNames"`
+}
+
+type topologyEndpointService struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Owner    string   `json:"owner"`
+	DNSNames []string `json:"dnsNames"`
+}
+
+type topologyEndpointConnection struct {
+	ID               string   `json:"id"`
+	ServiceID        string   `json:"serviceId"`
+	VpcEndpointID    string   `json:"vpcEndpointId"`
+	VpcEndpointOwner string   `json:"vpcEndpointOwner"`
+	State            string   `json:"state"`
+	LoadBalancerARNs []string `json:"loadBalancerArns"`
+}
+
+type topologyLoadBalancer struct {
+	ARN     string `json:"arn"`
+	Name    string `json:"name"`
+	DNSName string `json:"dnsName"`
+	Type    string `json:"type"`
+	Scheme  string `json:"scheme"`
+	State   string `json:"state"`
+}
+
+type topologySubnet struct {
+	ID               string `json:"id"`
+	VpcID            string `json:"vpcId"`
+	AvailabilityZone string `json:"availabilityZone"`
+	CIDR             string `json:"cidr"`
+	State            string `json:"state"`
+}
+
+type topologyRouteTable struct {
+	ID     string          `json:"id"`
+	VpcID  string          `json:"vpcId"`
+	Routes []topologyRoute `json:"routes"`
+}
+
+type topologyRoute struct {
+	Destination string `json:"destination"`
+	Target      string `json:"target"`
+	State       string `json:"state"`
+}
+
+// topologyIssue is a resource in a state which likely breaks connectivity to the hosted control plane
+type topologyIssue struct {
+	Account    string `json:"account"`
+	ResourceID string `json:"resourceId"`
+	Message    string `json:"message"`
+}
+
+func (i topologyIssue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Account, i.ResourceID, i.Message)
+}
+
+// newHypershiftTopology converts the AWS objects gathered from the three accounts and checks their health
+func newHypershiftTopology(ai *aggregateClusterInfo, clusters *infoClusters) hypershiftTopology {
+	t := hypershiftTopology{
+		SchemaVersion: hypershiftTopologySchemaVersion,
+		ClusterID:     clusters.customerCluster.ID(),
+		ClusterName:   clusters.customerCluster.Name(),
+		Customer: customerTopology{
+			HostedZones:  toTopologyHostedZones(ai.clusterInfo.HostedZones),
+			Records:      toTopologyRecords(ai.clusterInfo.ResourceRecords),
+			VpcEndpoints: toTopologyVpcEndpoints(ai.clusterInfo.Endpoints),
+			Subnets:      make([]topologySubnet, 0, len(ai.clusterInfo.Subnets)),
+			RouteTables:  make([]topologyRouteTable, 0, len(ai.clusterInfo.SubnetRouteTables)),
+		},
+		Management: managementTopology{
+			EndpointServices:    make([]topologyEndpointService, 0, len(ai.managementClusterInfo.EndpointServices)),
+			EndpointConnections: make([]topologyEndpointConnection, 0, len(ai.managementClusterInfo.EndpointConnections)),
+			LoadBalancers:       make([]topologyLoadBalancer, 0, len(ai.managementClusterInfo.LoadBalancers)),
+		},
+		Privatelink: privatelinkTopology{
+			HostedZones:  toTopologyHostedZones(ai.privatelinkInfo.HostedZones),
+			Records:      toTopologyRecords(ai.privatelinkInfo.ResourceRecords),
+			VpcEndpoints: toTopologyVpcEndpoints(ai.privatelinkInfo.Endpoints),
+		},
+	}
+	if clusters.managementCluster != nil {
+		t.ManagementClusterID = clusters.managementCluster.ID()
+	}
+
+	for _, subnet := range ai.clusterInfo.Subnets {
+		t.Customer.Subnets = append(t.Customer.Subnets, topologySubnet{
+			ID:               safeDeref(subnet.SubnetId),
+			VpcID:            safeDeref(subnet.VpcId),
+			AvailabilityZone: safeDeref(subnet.AvailabilityZone),
+			CIDR:             safeDeref(subnet.CidrBlock),
+			State:            string(subnet.State),
+		})
+	}
+	for _, rtb := range ai.clusterInfo.SubnetRouteTables {
+		t.Customer.RouteTables = append(t.Customer.RouteTables, toTopologyRouteTable(rtb))
+	}
+
+	for _, svc := range ai.managementClusterInfo.EndpointServices {
+		serviceType := ""
+		if len(svc.ServiceType) > 0 {
+			serviceType = string(svc.ServiceType[0].ServiceType)
+		}
+		t.Management.EndpointServices = append(t.Management.EndpointServices, topologyEndpointService{
+			ID:       safeDeref(svc.ServiceId),
+			Name:     safeDeref(svc.ServiceName),
+			Type:     serviceType,
+			Owner:    safeDeref(svc.Owner),
+			DNSNames: nonNil(svc.BaseEndpointDnsNames),
+		})
+	}
+	for _, conn := range ai.managementClusterInfo.EndpointConnections {
+		t.Management.EndpointConnections = append(t.Management.EndpointConnections, topologyEndpointConnection{
+			ID:               safeDeref(conn.VpcEndpointConnectionId),
+			ServiceID:        safeDeref(conn.ServiceId),
+			VpcEndpointID:    safeDeref(conn.VpcEndpointId),
+			VpcEndpointOwner: safeDeref(conn.VpcEndpointOwner),
+			State:            string(conn.VpcEndpointState),
+			LoadBalancerARNs: nonNil(conn.NetworkLoadBalancerArns),
+		})
+	}
+	for _, lb := range ai.managementClusterInfo.LoadBalancers {
+		state := ""
+		if lb.State != nil {
+			state = string(lb.State.Code)
+		}
+		t.Management.LoadBalancers = append(t.Management.LoadBalancers, topologyLoadBalancer{
+			ARN:     safeDeref(lb.LoadBalancerArn),
+			Name:    safeDeref(lb.LoadBalancerName),
+			DNSName: safeDeref(lb.DNSName),
+			Type:    string(lb.Type),
+			Scheme:  string(lb.Scheme),
+			State:   state,
+		})
+	}
+
+	t.Issues = t.checkHealth()
+	return t
+}
+
+func toTopologyHostedZones(zones []route53types.HostedZone) []topologyHostedZone {
+	result := make([]topologyHostedZone, 0, len(zones))
+	for _, hz := range zones {
+		result = append(result, topologyHostedZone{
+			ID:      safeDeref(hz.Id),
+			Name:    safeDeref(hz.Name),
+			Private: hz.Config != nil && hz.Config.PrivateZone,
+		})
+	}
+	return result
+}
+
+// toTopologyRecords converts record sets, the value of alias records is their alias target
+func toTopologyRecords(recordSets []route53types.ResourceRecordSet) []topologyRecord {
+	result := make([]topologyRecord, 0, len(recordSets))
+	for _, rrs := range recordSets {
+		values := make([]string, 0, len(rrs.ResourceRecords))
+		for _, rr := range rrs.ResourceRecords {
+			values = append(values, safeDeref(rr.Value))
+		}
+		if rrs.AliasTarget != nil {
+			values = append(values, safeDeref(rrs.AliasTarget.DNSName))
+		}
+		result = append(result, topologyRecord{
+			Name:   safeDeref(rrs.Name),
+			Type:   string(rrs.Type),
+			Values: values,
+		})
+	}
+	return result
+}
+
+func toTopologyVpcEndpoints(endpoints []ec2types.VpcEndpoint) []topologyVpcEndpoint {
+	result := make([]topologyVpcEndpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		dnsNames := make([]string, 0, len(ep.DnsEntries))
+		for _, entry := range ep.DnsEntries {
+			dnsNames = append(dnsNames, safeDeref(entry.DnsName))
+		}
+		result = append(result, topologyVpcEndpoint{
+			ID:          safeDeref(ep.VpcEndpointId),
+			VpcID:       safeDeref(ep.VpcId),
+			ServiceName: safeDeref(ep.ServiceName),
+			Type:        string(ep.VpcEndpointType),
+			State:       string(ep.State),
+			DNSNames:    dnsNames,
+		})
+	}
+	return result
+}
+
+func toTopologyRouteTable(rtb ec2types.RouteTable) topologyRouteTable {
+	routes := make([]topologyRoute, 0, len(rtb.Routes))
+	for _, route := range rtb.Routes {
+		destination := safeDeref(route.DestinationCidrBlock)
+		if destination == "" {
+			destination = safeDeref(route.DestinationPrefixListId)
+		}
+		target := "Unknown"
+		for _, id := range []*string{route.GatewayId, route.NatGatewayId, route.TransitGatewayId, route.LocalGatewayId, route.VpcPeeringConnectionId, route.NetworkInterfaceId} {
+			if id != nil {
+				target = *id
+				break
+			}
+		}
+		routes = append(routes, topologyRoute{
+			Destination: destination,
+			Target:      target,
+			State:       string(route.State),
+		})
+	}
+	return topologyRouteTable{
+		ID:     safeDeref(rtb.RouteTableId),
+		VpcID:  safeDeref(rtb.VpcId),
+		Routes: routes,
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// checkHealth returns the resources in a state which likely breaks the connectivity between the customer VPC and
+// the hosted control plane
+func (t *hypershiftTopology) checkHealth() []topologyIssue {
+	issues := []topologyIssue{}
+
+	for _, ep := range t.Customer.VpcEndpoints {
+		if !isAvailable(ep.State) {
+			issues = append(issues, topologyIssue{topologyAccountCustomer, ep.ID, fmt.Sprintf("VPC endpoint is %s", ep.State)})
+		}
+		if !slices.ContainsFunc(t.Management.EndpointConnections, func(c topologyEndpointConnection) bool { return c.VpcEndpointID == ep.ID }) {
+			issues = append(issues, topologyIssue{topologyAccountCustomer, ep.ID, "VPC endpoint has no connection to an endpoint service of the management cluster"})
+		}
+	}
+	for _, subnet := range t.Customer.Subnets {
+		if !isAvailable(subnet.State) {
+			issues = append(issues, topologyIssue{topologyAccountCustomer, subnet.ID, fmt.Sprintf("subnet is %s", subnet.State)})
+		}
+	}
+	for _, rtb := range t.Customer.RouteTables {
+		for _, route := range rtb.Routes {
+			if route.State == string(ec2types.RouteStateBlackhole) {
+				issues = append(issues, topologyIssue{topologyAccountCustomer, rtb.ID, fmt.Sprintf("route to %s via %s is a blackhole", route.Destination, route.Target)})
+			}
+		}
+	}
+
+	if len(t.Management.EndpointServices) == 0 {
+		issues = append(issues, topologyIssue{topologyAccountManagement, t.ClusterID, "no VPC endpoint service found for the cluster"})
+	}
+	for _, conn := range t.Management.EndpointConnections {
+		if !isAvailable(conn.State) {
+			issues = append(issues, topologyIssue{topologyAccountManagement, conn.ID, fmt.Sprintf("endpoint connection of %s is %s", conn.VpcEndpointID, conn.State)})
+		}
+	}
+	for _, lb := range t.Management.LoadBalancers {
+		if lb.State != "" && lb.State != string(elbv2types.LoadBalancerStateEnumActive) {
+			issues = append(issues, topologyIssue{topologyAccountManagement, lb.ARN, fmt.Sprintf("load balancer %s is %s", lb.Name, lb.State)})
+		}
+	}
+
+	for _, ep := range t.Privatelink.VpcEndpoints {
+		if !isAvailable(ep.State) {
+			issues = append(issues, topologyIssue{topologyAccountPrivatelink, ep.ID, fmt.Sprintf("VPC endpoint is %s", ep.State)})
+		}
+	}
+
+	return issues
+}
+
+// isAvailable compares case-insensitively, as EC2 reports the state of VPC endpoints in lowercase while the SDK
+// constant is capitalized
+func isAvailable(state string) bool {
+	return strings.EqualFold(state, string(ec2types.StateAvailable))
+}
+
+// graph builds the topology graph of the VPC endpoints, endpoint services, load balancers and Route53 records,
+// highlighting resources with issues
+func (t *hypershiftTopology) graph() *graphviz.Graph {
+	g := graphviz.NewGraph()
+	unhealthy := map[string][]string{}
+	for _, issue := range t.Issues {
+		unhealthy[issue.ResourceID] = append(unhealthy[issue.ResourceID], issue.Message)
+	}
+	addNode := func(id, label, group string, details ...string) {
+		details = append(details, unhealthy[id]...)
+		g.AddNode(graphviz.GraphNode{
+			Id:        id,
+			Label:     label,
+			Group:     group + " account",
+			Highlight: len(unhealthy[id]) > 0,
+			Details:   strings.Join(details, "\n"),
+		})
+	}
+
+	// dnsTargets maps the DNS names of endpoints, hosted zones and load balancers to their node ids, so records can
+	// be linked to what they resolve to. The DNS names of endpoints are subdomains of their endpoint service.
+	dnsTargets := map[string]string{}
+	serviceDNSNames := map[string]string{}
+
+	for _, ep := range t.Customer.VpcEndpoints {
+		addNode(ep.ID, fmt.Sprintf("VPC Endpoint\n%s\n%s", ep.ID, ep.State), topologyAccountCustomer, endpointDetails(ep)...)
+		for _, name := range ep.DNSNames {
+			dnsTargets[normalizeDNSName(name)] = ep.ID
+		}
+	}
+	for _, ep := range t.Privatelink.VpcEndpoints {
+		addNode(ep.ID, fmt.Sprintf("VPC Endpoint\n%s\n%s", ep.ID, ep.State), topologyAccountPrivatelink, endpointDetails(ep)...)
+		for _, name := range ep.DNSNames {
+			dnsTargets[normalizeDNSName(name)] = ep.ID
+		}
+	}
+
+	for _, svc := range t.Management.EndpointServices {
+		addNode(svc.ID, fmt.Sprintf("Endpoint Service\n%s", svc.ID), topologyAccountManagement,
+			"name: "+svc.Name, "type: "+svc.Type, "owner: "+svc.Owner, "dns: "+strings.Join(svc.DNSNames, ", "))
+		for _, name := range svc.DNSNames {
+			serviceDNSNames[normalizeDNSName(name)] = svc.ID
+		}
+		for _, ep := range slices.Concat(t.Customer.VpcEndpoints, t.Privatelink.VpcEndpoints) {
+			if ep.ServiceName == svc.Name {
+				g.AddEdge(graphviz.Edge{From: ep.ID, To: svc.ID})
+			}
+		}
+	}
+	for _, lb := range t.Management.LoadBalancers {
+		addNode(lb.ARN, fmt.Sprintf("Load Balancer\n%s\n%s", lb.Name, lb.State), topologyAccountManagement,
+			"arn: "+lb.ARN, "dns: "+lb.DNSName, "type: "+lb.Type, "scheme: "+lb.Scheme, "state: "+lb.State)
+		dnsTargets[normalizeDNSName(lb.DNSName)] = lb.ARN
+	}
+	for _, conn := range t.Management.EndpointConnections {
+		addNode(conn.ID, fmt.Sprintf("Endpoint Connection\n%s\n%s", conn.ID, conn.State), topologyAccountManagement,
+			"vpc endpoint: "+conn.VpcEndpointID, "vpc endpoint owner: "+conn.VpcEndpointOwner, "state: "+conn.State)
+		if g.HasNode(conn.VpcEndpointID) {
+			g.AddEdge(graphviz.Edge{From: conn.VpcEndpointID, To: conn.ID})
+		}
+		if g.HasNode(conn.ServiceID) {
+			g.AddEdge(graphviz.Edge{From: conn.ID, To: conn.ServiceID})
+		}
+		for _, arn := range conn.LoadBalancerARNs {
+			if !g.HasNode(arn) {
+				addNode(arn, fmt.Sprintf("Load Balancer\n%s", arn), topologyAccountManagement, "arn: "+arn)
+			}
+			g.AddEdge(graphviz.Edge{From: conn.ID, To: arn})
+		}
+	}
+
+	zoneNodes := func(account string, zones []topologyHostedZone) {
+		for _, hz := range zones {
+			addNode(hz.ID, fmt.Sprintf("Hosted Zone\n%s", hz.Name), account, "id: "+hz.ID, fmt.Sprintf("private: %t", hz.Private))
+			dnsTargets[normalizeDNSName(hz.Name)] = hz.ID
+		}
+	}
+	zoneNodes(topologyAccountCustomer, t.Customer.HostedZones)
+	zoneNodes(topologyAccountPrivatelink, t.Privatelink.HostedZones)
+
+	recordNodes := func(account string, zones []topologyHostedZone, records []topologyRecord) {
+		for _, rr := range records {
+			if rr.Type != string(route53types.RRTypeA) && rr.Type != string(route53types.RRTypeCname) {
+				continue
+			}
+			id := fmt.Sprintf("%s/%s/%s", account, rr.Type, rr.Name)
+			addNode(id, fmt.Sprintf("%s Record\n%s", rr.Type, rr.Name), account, "values: "+strings.Join(rr.Values, ", "))
+			if zoneID := recordZone(rr.Name, zones); zoneID != "" {
+				g.AddEdge(graphviz.Edge{From: zoneID, To: id})
+			}
+			for _, value := range rr.Values {
+				if target := resolveDNSTarget(value, dnsTargets, serviceDNSNames); target != "" {
+					g.AddEdge(graphviz.Edge{From: id, To: target})
+				}
+			}
+		}
+	}
+	recordNodes(topologyAccountCustomer, t.Customer.HostedZones, t.Customer.Records)
+	recordNodes(topologyAccountPrivatelink, t.Privatelink.HostedZones, t.Privatelink.Records)
+
+	return g
+}
+
+func endpointDetails(ep topologyVpcEndpoint) []string {
+	return []string{"vpc: " + ep.VpcID, "service: " + ep.ServiceName, "type: " + ep.Type, "state: " + ep.State, "dns: " + strings.Join(ep.DNSNames, ", ")}
+}
+
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// recordZone returns the id of the most specific hosted zone the record belongs to
+func recordZone(recordName string, zones []topologyHostedZone) string {
+	name := normalizeDNSName(recordName)
+	var zoneID, zoneName string
+	for _, hz := range zones {
+		hzName := normalizeDNSName(hz.Name)
+		if (name == hzName || strings.HasSuffix(name, "."+hzName)) && len(hzName) > len(zoneName) {
+			zoneID, zoneName = hz.ID, hzName
+		}
+	}
+	return zoneID
+}
+
+// resolveDNSTarget returns the node id a record value resolves to, falling back to the endpoint service with the
+// longest DNS name the value is a subdomain of
+func resolveDNSTarget(value string, dnsTargets, serviceDNSNames map[string]string) string {
+	name := normalizeDNSName(value)
+	if id, ok := dnsTargets[name]; ok {
+		return id
+	}
+	var target, targetDNSName string
+	for dnsName, id := range serviceDNSNames {
+		if strings.HasSuffix(name, "."+dnsName) && len(dnsName) > len(targetDNSName) {
+			target, targetDNSName = id, dnsName
+		}
+	}
+	return target
+}
+
+// renderHTML writes the interactive topology with the issues listed above it
+func (t *hypershiftTopology) renderHTML(w io.Writer) error {
+	issues := make([]string, 0, len(t.Issues))
+	for _, issue := range t.Issues {
+		issues = append(issues, issue.String())
+	}
+	return t.graph().RenderHTML(w, fmt.Sprintf("Hypershift topology of %s (%s)", t.ClusterName, t.ClusterID), issues)
+}
@@ -0,0 +1,147 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTopologyInfo(t *testing.T) (*aggregateClusterInfo, *infoClusters) {
+	customerCluster, err := v1.NewCluster().ID("abc123").Name("hcp-cluster").Build()
+	require.NoError(t, err)
+	managementCluster, err := v1.NewCluster().ID("mc123").Build()
+	require.NoError(t, err)
+
+	ai := &aggregateClusterInfo{
+		clusterInfo: &clusterInfo{
+			HostedZones: []route53types.HostedZone{
+				{Id: awsv2.String("Z1"), Name: awsv2.String("hcp-cluster.hypershift.local."), Config: &route53types.HostedZoneConfig{PrivateZone: true}},
+			},
+			ResourceRecords: []route53types.ResourceRecordSet{
+				{
+					Name:            awsv2.String("api.hcp-cluster.hypershift.local."),
+					Type:            route53types.RRTypeCname,
+					ResourceRecords: []route53types.ResourceRecord{{Value: awsv2.String("vpce-1-abcd.vpce-svc-1.us-east-1.vpce.amazonaws.com")}},
+				},
+				{Name: awsv2.String("hcp-cluster.hypershift.local."), Type: route53types.RRTypeSoa},
+			},
+			Endpoints: []ec2types.VpcEndpoint{
+				{
+					VpcEndpointId: awsv2.String("vpce-1"),
+					ServiceName:   awsv2.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+					State:         ec2types.State("available"),
+					DnsEntries:    []ec2types.DnsEntry{{DnsName: awsv2.String("vpce-1-abcd.vpce-svc-1.us-east-1.vpce.amazonaws.com")}},
+				},
+				{
+					VpcEndpointId: awsv2.String("vpce-2"),
+					ServiceName:   awsv2.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+					State:         ec2types.State("pendingAcceptance"),
+				},
+			},
+			SubnetRouteTables: []ec2types.RouteTable{{
+				RouteTableId: awsv2.String("rtb-1"),
+				Routes: []ec2types.Route{
+					{DestinationCidrBlock: awsv2.String("10.0.0.0/16"), GatewayId: awsv2.String("local"), State: ec2types.RouteStateActive},
+					{DestinationCidrBlock: awsv2.String("0.0.0.0/0"), NatGatewayId: awsv2.String("nat-1"), State: ec2types.RouteStateBlackhole},
+				},
+			}},
+		},
+		managementClusterInfo: &managementClusterInfo{
+			EndpointServices: []ec2types.ServiceDetail{{
+				ServiceId:            awsv2.String("vpce-svc-1"),
+				ServiceName:          awsv2.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+				BaseEndpointDnsNames: []string{"vpce-svc-1.us-east-1.vpce.amazonaws.com"},
+			}},
+			EndpointConnections: []ec2types.VpcEndpointConnection{{
+				VpcEndpointConnectionId: awsv2.String("vpce-con-1"),
+				ServiceId:               awsv2.String("vpce-svc-1"),
+				VpcEndpointId:           awsv2.String("vpce-1"),
+				VpcEndpointState:        ec2types.State("available"),
+				NetworkLoadBalancerArns: []string{"arn:lb-1"},
+			}},
+			LoadBalancers: []elbv2types.LoadBalancer{{
+				LoadBalancerArn:  awsv2.String("arn:lb-1"),
+				LoadBalancerName: awsv2.String("lb-1"),
+				State:            &elbv2types.LoadBalancerState{Code: elbv2types.LoadBalancerStateEnumProvisioning},
+			}},
+		},
+		privatelinkInfo: &privatelinkInfo{},
+	}
+
+	return ai, &infoClusters{customerCluster: customerCluster, managementCluster: managementCluster}
+}
+
+func TestNewHypershiftTopologyIssues(t *testing.T) {
+	ai, clusters := newTestTopologyInfo(t)
+
+	topology := newHypershiftTopology(ai, clusters)
+
+	assert.Equal(t, "v1", topology.SchemaVersion)
+	assert.Equal(t, "abc123", topology.ClusterID)
+	assert.Equal(t, "mc123", topology.ManagementClusterID)
+	assert.Equal(t, []topologyIssue{
+		{Account: "customer", ResourceID: "vpce-2", Message: "VPC endpoint is pendingAcceptance"},
+		{Account: "customer", ResourceID: "vpce-2", Message: "VPC endpoint has no connection to an endpoint service of the management cluster"},
+		{Account: "customer", ResourceID: "rtb-1", Message: "route to 0.0.0.0/0 via nat-1 is a blackhole"},
+		{Account: "management", ResourceID: "arn:lb-1", Message: "load balancer lb-1 is provisioning"},
+	}, topology.Issues)
+}
+
+func TestHypershiftTopologyJSONHasNoNullLists(t *testing.T) {
+	customerCluster, err := v1.NewCluster().ID("abc123").Build()
+	require.NoError(t, err)
+	ai := &aggregateClusterInfo{
+		clusterInfo:           &clusterInfo{},
+		managementClusterInfo: &managementClusterInfo{},
+		privatelinkInfo:       &privatelinkInfo{},
+	}
+
+	out, err := json.Marshal(newHypershiftTopology(ai, &infoClusters{customerCluster: customerCluster}))
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(out), "null")
+	assert.Contains(t, string(out), `"message":"no VPC endpoint service found for the cluster"`)
+}
+
+func TestHypershiftTopologyGraph(t *testing.T) {
+	ai, clusters := newTestTopologyInfo(t)
+	topology := newHypershiftTopology(ai, clusters)
+
+	var out bytes.Buffer
+	require.NoError(t, topology.graph().RenderDot(&out))
+	dot := out.String()
+
+	assert.Contains(t, dot, `"vpce-1" -> "vpce-svc-1"`)
+	assert.Contains(t, dot, `"vpce-1" -> "vpce-con-1"`)
+	assert.Contains(t, dot, `"vpce-con-1" -> "vpce-svc-1"`)
+	assert.Contains(t, dot, `"vpce-con-1" -> "arn:lb-1"`)
+	assert.Contains(t, dot, `"Z1" -> "customer/CNAME/api.hcp-cluster.hypershift.local."`)
+	assert.Contains(t, dot, `"customer/CNAME/api.hcp-cluster.hypershift.local." -> "vpce-1"`)
+	assert.Contains(t, dot, `"vpce-2" [label="VPC Endpoint\nvpce-2\npendingAcceptance" color=red penwidth=2]`)
+	assert.Contains(t, dot, `"arn:lb-1" [label="Load Balancer\nlb-1\nprovisioning" color=red penwidth=2]`)
+	assert.NotContains(t, dot, "SOA")
+
+	out.Reset()
+	require.NoError(t, topology.renderHTML(&out))
+	assert.Contains(t, out.String(), "<li>customer rtb-1: route to 0.0.0.0/0 via nat-1 is a blackhole</li>")
+}
+
+func TestResolveDNSTarget(t *testing.T) {
+	dnsTargets := map[string]string{"lb-1.elb.amazonaws.com": "arn:lb-1"}
+	serviceDNSNames := map[string]string{
+		"vpce-svc-1.us-east-1.vpce.amazonaws.com": "vpce-svc-1",
+		"us-east-1.vpce.amazonaws.com":            "too-broad",
+	}
+
+	assert.Equal(t, "arn:lb-1", resolveDNSTarget("LB-1.elb.amazonaws.com.", dnsTargets, serviceDNSNames))
+	assert.Equal(t, "vpce-svc-1", resolveDNSTarget("vpce-9-xyz.vpce-svc-1.us-east-1.vpce.amazonaws.com", dnsTargets, serviceDNSNames))
+	assert.Equal(t, "", resolveDNSTarget("example.com", dnsTargets, serviceDNSNames))
+}
@@ -1,8 +1,9 @@
 package dns
 
 import (
-	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
 type WithCluster struct {
@@ -19,6 +20,18 @@ func (w WithExpectedTarget) ConfigureVerifyCNAMERecord(cfg *VerifyCNAMERecordCon
 	cfg.ExpectedTarget = string(w)
 }
 
+type WithExpectedIPs []string
+
+func (w WithExpectedIPs) ConfigureVerifyARecord(cfg *VerifyARecordConfig) {
+	cfg.ExpectedIPs = w
+}
+
+type WithExpectedNameServers []string
+
+func (w WithExpectedNameServers) ConfigureVerifyNSRecord(cfg *VerifyNSRecordConfig) {
+	cfg.ExpectedNameServers = w
+}
+
 type WithRecommender struct {
 	Recommender Recommender
 }
@@ -32,3 +45,11 @@ type WithTimeout time.Duration
 func (w WithTimeout) ConfigureDefaultVerifier(cfg *DefaultVerifierConfig) {
 	cfg.Timeout = time.Duration(w)
 }
+
+// WithResolverAddress queries the DNS server at the given address, formatted as [tcp://|udp://]host[:port], instead
+// of the system resolver
+type WithResolverAddress string
+
+func (w WithResolverAddress) ConfigureDefaultVerifier(cfg *DefaultVerifierConfig) {
+	cfg.ResolverAddress = string(w)
+}
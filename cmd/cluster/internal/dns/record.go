@@ -5,4 +5,7 @@ type RecordType string
 const (
 	RecordTypeA     RecordType = "A"
 	RecordTypeCNAME RecordType = "CNAME"
+	RecordTypeNS    RecordType = "NS"
+	// RecordTypeZone marks the verification of a Route 53 private hosted zone's VPC associations
+	RecordTypeZone RecordType = "ZONE"
 )
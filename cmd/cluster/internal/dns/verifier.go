@@ -5,13 +5,15 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 )
 
 type Verifier interface {
-	VerifyARecord(context.Context, string) VerifyResult
+	VerifyARecord(context.Context, string, ...VerifyARecordOption) VerifyResult
 	VerifyCNAMERecord(context.Context, string, ...VerifyCNAMERecordOption) VerifyResult
+	VerifyNSRecord(context.Context, string, ...VerifyNSRecordOption) VerifyResult
 }
 
 type VerifyResult struct {
@@ -33,10 +35,18 @@ const (
 	VerifyResultStatusSkip VerifyResultStatus = "SKIP"
 )
 
+type VerifyARecordOption interface {
+	ConfigureVerifyARecord(*VerifyARecordConfig)
+}
+
 type VerifyCNAMERecordOption interface {
 	ConfigureVerifyCNAMERecord(*VerifyCNAMERecordConfig)
 }
 
+type VerifyNSRecordOption interface {
+	ConfigureVerifyNSRecord(*VerifyNSRecordConfig)
+}
+
 func NewDefaultVerifier(opts ...DefaultVerifierOption) *DefaultVerifier {
 	var cfg DefaultVerifierConfig
 
@@ -52,8 +62,11 @@ type DefaultVerifier struct {
 	cfg DefaultVerifierConfig
 }
 
-// VerifyARecord tests an A record and returns the result
-func (v *DefaultVerifier) VerifyARecord(ctx context.Context, path string) VerifyResult {
+// VerifyARecord tests an A record and, if expected IPs are given, validates it only resolves to those
+func (v *DefaultVerifier) VerifyARecord(ctx context.Context, path string, opts ...VerifyARecordOption) VerifyResult {
+	var cfg VerifyARecordConfig
+	cfg.Option(opts...)
+
 	url, err := url.Parse(path)
 	if err != nil {
 		return VerifyResult{
@@ -79,14 +92,42 @@ func (v *DefaultVerifier) VerifyARecord(ctx context.Context, path string) Verify
 	if err != nil {
 		rec.Status = VerifyResultStatusFail
 		rec.ErrorMessage = err.Error()
-	} else {
-		rec.ResolvedIPs = ips
+		return rec
+	}
+	rec.ResolvedIPs = ips
+
+	if cfg.ExpectedIPs == nil {
 		rec.Status = VerifyResultStatusPass
+		return rec
+	}
+
+	var unexpected []string
+	for _, ip := range ips {
+		if !slices.Contains(cfg.ExpectedIPs, ip) {
+			unexpected = append(unexpected, ip)
+		}
+	}
+	if len(unexpected) == 0 {
+		rec.Status = VerifyResultStatusPass
+	} else {
+		rec.Status = VerifyResultStatusFail
+		rec.ErrorMessage = fmt.Sprintf("resolved IPs %s are not among the expected IPs", strings.Join(unexpected, ", "))
 	}
 
 	return rec
 }
 
+type VerifyARecordConfig struct {
+	// ExpectedIPs are the only IPs the record may resolve to, nil to accept any
+	ExpectedIPs []string
+}
+
+func (c *VerifyARecordConfig) Option(opts ...VerifyARecordOption) {
+	for _, opt := range opts {
+		opt.ConfigureVerifyARecord(c)
+	}
+}
+
 // VerifyCNAMERecord tests a CNAME record and validates it points to the expected target
 func (v *DefaultVerifier) VerifyCNAMERecord(ctx context.Context, dnsName string, opts ...VerifyCNAMERecordOption) VerifyResult {
 	var cfg VerifyCNAMERecordConfig
@@ -138,14 +179,87 @@ func (c *VerifyCNAMERecordConfig) Option(opts ...VerifyCNAMERecordOption) {
 	}
 }
 
+// VerifyNSRecord tests the NS records of a zone and, if expected name servers are given, validates they match them,
+// e.g. to verify the delegation of a hosted zone
+func (v *DefaultVerifier) VerifyNSRecord(ctx context.Context, zone string, opts ...VerifyNSRecordOption) VerifyResult {
+	var cfg VerifyNSRecordConfig
+	cfg.Option(opts...)
+
+	expected := normalizeNames(cfg.ExpectedNameServers)
+	rec := VerifyResult{
+		Name:           zone,
+		Type:           RecordTypeNS,
+		ExpectedTarget: strings.Join(expected, ", "),
+	}
+
+	records, err := v.cfg.Resolver.LookupNS(ctx, zone)
+	if err != nil {
+		rec.Status = VerifyResultStatusFail
+		rec.ErrorMessage = err.Error()
+		return rec
+	}
+
+	var hosts []string
+	for _, ns := range records {
+		hosts = append(hosts, ns.Host)
+	}
+	actual := normalizeNames(hosts)
+	rec.ActualTarget = strings.Join(actual, ", ")
+
+	if len(expected) == 0 || slices.Equal(actual, expected) {
+		rec.Status = VerifyResultStatusPass
+		return rec
+	}
+
+	var missing, unexpected []string
+	for _, ns := range expected {
+		if !slices.Contains(actual, ns) {
+			missing = append(missing, ns)
+		}
+	}
+	for _, ns := range actual {
+		if !slices.Contains(expected, ns) {
+			unexpected = append(unexpected, ns)
+		}
+	}
+	rec.Status = VerifyResultStatusFail
+	rec.ErrorMessage = fmt.Sprintf("name servers don't match the delegation: missing [%s], unexpected [%s]",
+		strings.Join(missing, ", "), strings.Join(unexpected, ", "))
+
+	return rec
+}
+
+type VerifyNSRecordConfig struct {
+	ExpectedNameServers []string
+}
+
+func (c *VerifyNSRecordConfig) Option(opts ...VerifyNSRecordOption) {
+	for _, opt := range opts {
+		opt.ConfigureVerifyNSRecord(c)
+	}
+}
+
+// normalizeNames returns the sorted DNS names in lower case and without trailing dots
+func normalizeNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized = append(normalized, strings.ToLower(strings.TrimSuffix(name, ".")))
+	}
+	slices.Sort(normalized)
+	return normalized
+}
+
 type Resolver interface {
 	LookupHost(ctx context.Context, host string) ([]string, error)
 	LookupCNAME(ctx context.Context, name string) (string, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
 }
 
 type DefaultVerifierConfig struct {
 	Timeout  time.Duration
 	Resolver Resolver
+	// ResolverAddress is the DNS server queried by the default resolver, empty for the system resolver
+	ResolverAddress string
 }
 
 func (c *DefaultVerifierConfig) Option(opts ...DefaultVerifierOption) {
@@ -160,18 +274,52 @@ func (c *DefaultVerifierConfig) Default() {
 	}
 
 	if c.Resolver == nil {
+		// The address has been validated by callers, fall back to the system resolver if it's invalid anyway
+		serverNetwork, serverAddress, _ := ParseResolverAddress(c.ResolverAddress)
 		c.Resolver = &net.Resolver{
 			PreferGo: true,
 			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
 				d := net.Dialer{
 					Timeout: c.Timeout,
 				}
+				// The Go resolver handles stream connections for any query, so TCP-only servers work too
+				if serverAddress != "" {
+					return d.DialContext(ctx, serverNetwork, serverAddress)
+				}
 				return d.DialContext(ctx, network, address)
 			},
 		}
 	}
 }
 
+// ParseResolverAddress parses a DNS server address formatted as [tcp://|udp://]host[:port] into the network and
+// address to dial. The network defaults to udp and the port to 53. An empty address returns empty values.
+func ParseResolverAddress(resolver string) (string, string, error) {
+	if resolver == "" {
+		return "", "", nil
+	}
+
+	network := "udp"
+	if scheme, rest, found := strings.Cut(resolver, "://"); found {
+		if scheme != "udp" && scheme != "tcp" {
+			return "", "", fmt.Errorf("unsupported resolver protocol %q, must be udp or tcp", scheme)
+		}
+		network = scheme
+		resolver = rest
+	}
+
+	host, port, err := net.SplitHostPort(resolver)
+	if err != nil {
+		// No port given, also handles IPv6 addresses without brackets
+		host, port = strings.Trim(resolver, "[]"), "53"
+	}
+	if host == "" {
+		return "", "", fmt.Errorf("invalid resolver address %q", resolver)
+	}
+
+	return network, net.JoinHostPort(host, port), nil
+}
+
 type DefaultVerifierOption interface {
 	ConfigureDefaultVerifier(*DefaultVerifierConfig)
 }
@@ -3,6 +3,7 @@ package dns
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -28,6 +29,14 @@ func (m *MockResolver) LookupCNAME(ctx context.Context, name string) (string, er
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*net.NS), args.Error(1)
+}
+
 func TestVerifyARecord(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -116,6 +125,159 @@ func TestVerifyARecord(t *testing.T) {
 	}
 }
 
+func TestVerifyARecordWithExpectedIPs(t *testing.T) {
+	tests := []struct {
+		name           string
+		resolverIPs    []string
+		expectedIPs    []string
+		expectedStatus VerifyResultStatus
+		expectedError  string
+	}{
+		{
+			name:           "all IPs expected",
+			resolverIPs:    []string{"10.0.0.1", "10.0.0.2"},
+			expectedIPs:    []string{"10.0.0.2", "10.0.0.1", "10.0.0.3"},
+			expectedStatus: VerifyResultStatusPass,
+		},
+		{
+			name:           "unexpected IP",
+			resolverIPs:    []string{"10.0.0.1", "192.168.1.1"},
+			expectedIPs:    []string{"10.0.0.1"},
+			expectedStatus: VerifyResultStatusFail,
+			expectedError:  "resolved IPs 192.168.1.1 are not among the expected IPs",
+		},
+		{
+			name:           "no expected IPs",
+			resolverIPs:    []string{"10.0.0.1"},
+			expectedIPs:    []string{},
+			expectedStatus: VerifyResultStatusFail,
+			expectedError:  "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockResolver := new(MockResolver)
+			mockResolver.On("LookupHost", mock.Anything, "api.example.com").Return(tt.resolverIPs, nil)
+
+			verifier := &DefaultVerifier{
+				cfg: DefaultVerifierConfig{
+					Timeout:  10 * time.Second,
+					Resolver: mockResolver,
+				},
+			}
+
+			result := verifier.VerifyARecord(context.Background(), "api.example.com", WithExpectedIPs(tt.expectedIPs))
+
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.resolverIPs, result.ResolvedIPs)
+			if tt.expectedError != "" {
+				assert.Contains(t, result.ErrorMessage, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestVerifyNSRecord(t *testing.T) {
+	tests := []struct {
+		name           string
+		expected       []string
+		resolverNS     []*net.NS
+		resolverErr    error
+		expectedStatus VerifyResultStatus
+		expectedActual string
+		expectedError  string
+	}{
+		{
+			name:           "matching delegation",
+			expected:       []string{"ns-2.awsdns-02.net", "NS-1.awsdns-01.org"},
+			resolverNS:     []*net.NS{{Host: "ns-1.awsdns-01.org."}, {Host: "ns-2.awsdns-02.net."}},
+			expectedStatus: VerifyResultStatusPass,
+			expectedActual: "ns-1.awsdns-01.org, ns-2.awsdns-02.net",
+		},
+		{
+			name:           "no expected name servers",
+			resolverNS:     []*net.NS{{Host: "ns-1.awsdns-01.org."}},
+			expectedStatus: VerifyResultStatusPass,
+			expectedActual: "ns-1.awsdns-01.org",
+		},
+		{
+			name:           "stale delegation",
+			expected:       []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.net"},
+			resolverNS:     []*net.NS{{Host: "ns-1.awsdns-01.org."}, {Host: "ns-9.awsdns-09.com."}},
+			expectedStatus: VerifyResultStatusFail,
+			expectedActual: "ns-1.awsdns-01.org, ns-9.awsdns-09.com",
+			expectedError:  "missing [ns-2.awsdns-02.net], unexpected [ns-9.awsdns-09.com]",
+		},
+		{
+			name:           "lookup failure",
+			expected:       []string{"ns-1.awsdns-01.org"},
+			resolverErr:    errors.New("no such host"),
+			expectedStatus: VerifyResultStatusFail,
+			expectedError:  "no such host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mockResolver := new(MockResolver)
+			mockResolver.On("LookupNS", mock.Anything, "rosa.example.com").Return(tt.resolverNS, tt.resolverErr)
+
+			verifier := &DefaultVerifier{
+				cfg: DefaultVerifierConfig{
+					Timeout:  10 * time.Second,
+					Resolver: mockResolver,
+				},
+			}
+
+			result := verifier.VerifyNSRecord(context.Background(), "rosa.example.com", WithExpectedNameServers(tt.expected))
+
+			assert.Equal(t, "rosa.example.com", result.Name)
+			assert.Equal(t, RecordTypeNS, result.Type)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedActual, result.ActualTarget)
+			if tt.expectedError != "" {
+				assert.Contains(t, result.ErrorMessage, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestParseResolverAddress(t *testing.T) {
+	tests := []struct {
+		resolver        string
+		expectedNetwork string
+		expectedAddress string
+		expectedError   bool
+	}{
+		{resolver: "", expectedNetwork: "", expectedAddress: ""},
+		{resolver: "10.0.0.2", expectedNetwork: "udp", expectedAddress: "10.0.0.2:53"},
+		{resolver: "10.0.0.2:5353", expectedNetwork: "udp", expectedAddress: "10.0.0.2:5353"},
+		{resolver: "tcp://127.0.0.1:5353", expectedNetwork: "tcp", expectedAddress: "127.0.0.1:5353"},
+		{resolver: "udp://dns.example.com", expectedNetwork: "udp", expectedAddress: "dns.example.com:53"},
+		{resolver: "fd00::2", expectedNetwork: "udp", expectedAddress: "[fd00::2]:53"},
+		{resolver: "https://dns.example.com", expectedError: true},
+		{resolver: "tcp://", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resolver, func(t *testing.T) {
+			t.Parallel()
+			network, address, err := ParseResolverAddress(tt.resolver)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedNetwork, network)
+			assert.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
+
 func TestVerifyCNAMERecord(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -282,6 +444,14 @@ func TestWithTimeout(t *testing.T) {
 	assert.Equal(t, 15*time.Second, cfg.Timeout)
 }
 
+func TestWithResolverAddress(t *testing.T) {
+	t.Parallel()
+	cfg := &DefaultVerifierConfig{}
+	WithResolverAddress("tcp://127.0.0.1:5353").ConfigureDefaultVerifier(cfg)
+
+	assert.Equal(t, "tcp://127.0.0.1:5353", cfg.ResolverAddress)
+}
+
 func TestWithExpectedTarget(t *testing.T) {
 	t.Parallel()
 	target := WithExpectedTarget("example.com")
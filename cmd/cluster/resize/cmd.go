@@ -62,6 +62,7 @@ func NewCmdResize() *cobra.Command {
 		newCmdResizeInfra(),
 		newCmdResizeControlPlane(),
 		newCmdResizeRequestServingNodes(),
+		newCmdResizeStatus(),
 	)
 
 	return resize
@@ -6,20 +6,31 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	configv1 "github.com/openshift/api/config/v1"
 	machinev1 "github.com/openshift/api/machine/v1"
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	bpelevate "github.com/openshift/backplane-cli/pkg/elevate"
-	"github.com/openshift/osdctl/cmd/servicelog"
+	"github.com/openshift/osdctl/internal/servicelog"
+	"github.com/openshift/osdctl/internal/templates"
 	"github.com/openshift/osdctl/pkg/k8s"
 	"github.com/openshift/osdctl/pkg/printer"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,6 +38,12 @@ const (
 	resizeControlPlaneServiceLogTemplate = "https://raw.githubusercontent.com/openshift/managed-notifications/master/osd/controlplane_resized.json"
 	cpmsNamespace                        = "openshift-machine-api"
 	cpmsName                             = "cluster"
+	masterMachineRoleLabel               = "machine.openshift.io/cluster-api-machine-role"
+
+	defaultPatchTimeout      = 2 * time.Minute
+	defaultServiceLogTimeout = 2 * time.Minute
+	defaultRolloutTimeout    = 90 * time.Minute
+	rolloutPollInterval      = 30 * time.Second
 )
 
 // controlPlane defines the struct for running resizeControlPlaneNode command
@@ -43,6 +60,34 @@ type controlPlane struct {
 
 	// reason to provide for elevation (eg: OHSS/PG ticket)
 	reason string
+
+	// state is the persisted progress of the resize
+	state *controlPlaneResizeState
+
+	// resume continues a previously interrupted resize from its last recorded step
+	resume bool
+
+	// nonInteractive disables all prompts, service log details must then be passed as flags
+	nonInteractive bool
+
+	// wait for the control plane machines to be replaced before exiting
+	wait bool
+
+	// JIRA ID and justification referenced in the service log
+	jiraID         string
+	justification  string
+	skipServiceLog bool
+
+	// dryRun prints the preflight checks and the control plane machine set patch without applying it
+	dryRun bool
+
+	// skipPreflight doesn't run the preflight checks before patching
+	skipPreflight bool
+
+	// timeouts of the individual resize steps
+	patchTimeout      time.Duration
+	serviceLogTimeout time.Duration
+	rolloutTimeout    time.Duration
 }
 
 // This command requires to previously be logged in via `ocm login`
@@ -55,32 +100,70 @@ func newCmdResizeControlPlane() *cobra.Command {
 
   Requires previous login to the api server via "ocm backplane login".
   The user will be prompted to send a service log after initiating the resize. The resize process runs asynchronously,
-  and this command exits immediately after sending the service log. Any issues with the resize will be reported via PagerDuty.`,
+  and this command exits immediately after sending the service log unless --wait is passed. Any issues with the resize
+  will be reported via PagerDuty.
+
+  The progress of the resize is saved to a local state file after every step (patching the control plane machine set,
+  sending the service log and waiting for the rollout). If the command is interrupted, continue it with --resume and
+  inspect it with "osdctl cluster resize status". Once the rollout started, waiting for it is optional and a new resize
+  can be started without resuming.
+
+  Before patching, preflight checks verify the EC2 quota and availability zone offerings of the new machine type,
+  PodDisruptionBudgets which block draining, etcd health and control plane node readiness. Use --dry-run to only run
+  the checks and print the control plane machine set patch.
+
+  With --non-interactive no prompts are shown, the service log details must then be passed with --jira-id and
+  --justification, or the service log skipped with --skip-service-log.`,
 		Example: `
   # Resize all control plane instances to m5.4xlarge using control plane machine sets
-  osdctl cluster resize control-plane -c "${CLUSTER_ID}" --machine-type m5.4xlarge --reason "${OHSS}"`,
+  osdctl cluster resize control-plane -C "${CLUSTER_ID}" --machine-type m5.4xlarge --reason "${OHSS}"
+
+  # Resize without prompts and wait for all control plane machines to be replaced
+  osdctl cluster resize control-plane -C "${CLUSTER_ID}" --machine-type m5.4xlarge --reason "${OHSS}" \
+    --non-interactive --jira-id "${OHSS}" --justification "Control plane is CPU starved" --wait
+
+  # Run the preflight checks and show the control plane machine set patch without applying it
+  osdctl cluster resize control-plane -C "${CLUSTER_ID}" --machine-type m5.4xlarge --reason "${OHSS}" --dry-run
+
+  # Continue an interrupted resize
+  osdctl cluster resize control-plane -C "${CLUSTER_ID}" --reason "${OHSS}" --resume`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := ops.New(); err != nil {
 				return err
 			}
-			return ops.run(context.Background())
+			return ops.run(cmd.Context())
 		},
 	}
 	resizeControlPlaneNodeCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "The internal ID of the cluster to perform actions on")
-	resizeControlPlaneNodeCmd.Flags().StringVar(&ops.newMachineType, "machine-type", "", "The target AWS machine type to resize to (e.g. m5.2xlarge)")
+	resizeControlPlaneNodeCmd.Flags().StringVar(&ops.newMachineType, "machine-type", "", "The target AWS machine type to resize to (e.g. m5.2xlarge). Defaults to the machine type of the resumed resize with --resume")
 	resizeControlPlaneNodeCmd.Flags().StringVar(&ops.reason, "reason", "", "The reason for this command, which requires elevation, to be run (usually an OHSS or PD ticket)")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.resume, "resume", false, "Continue a previously interrupted resize from its last recorded step")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.nonInteractive, "non-interactive", false, "Don't prompt for confirmation or service log details")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.wait, "wait", false, "Wait for all control plane machines to be replaced with the new machine type")
+	resizeControlPlaneNodeCmd.Flags().StringVar(&ops.jiraID, "jira-id", "", "The JIRA ID referenced in the service log")
+	resizeControlPlaneNodeCmd.Flags().StringVar(&ops.justification, "justification", "", "The justification for the resize referenced in the service log")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.skipServiceLog, "skip-service-log", false, "Don't send a service log for the resize")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.dryRun, "dry-run", false, "Run the preflight checks and print the control plane machine set patch without applying it")
+	resizeControlPlaneNodeCmd.Flags().BoolVar(&ops.skipPreflight, "skip-preflight", false, "Don't run the preflight checks before patching the control plane machine set")
+	resizeControlPlaneNodeCmd.Flags().DurationVar(&ops.patchTimeout, "patch-timeout", defaultPatchTimeout, "Timeout for patching the control plane machine set")
+	resizeControlPlaneNodeCmd.Flags().DurationVar(&ops.serviceLogTimeout, "service-log-timeout", defaultServiceLogTimeout, "Timeout for sending the service log")
+	resizeControlPlaneNodeCmd.Flags().DurationVar(&ops.rolloutTimeout, "rollout-timeout", defaultRolloutTimeout, "Timeout for replacing all control plane machines with --wait")
 	_ = resizeControlPlaneNodeCmd.MarkFlagRequired("cluster-id")
-	_ = resizeControlPlaneNodeCmd.MarkFlagRequired("machine-type")
 	_ = resizeControlPlaneNodeCmd.MarkFlagRequired("reason")
+	resizeControlPlaneNodeCmd.MarkFlagsMutuallyExclusive("dry-run", "resume")
 
 	return resizeControlPlaneNodeCmd
 }
 
 func (o *controlPlane) New() error {
-	if err := validateInstanceSize(o.newMachineType, "controlplane"); err != nil {
-		return err
+	if o.newMachineType == "" && !o.resume {
+		return errors.New("--machine-type is required unless resuming a resize with --resume")
+	}
+
+	if o.nonInteractive && !o.skipServiceLog && (o.jiraID == "" || o.justification == "") {
+		return errors.New("--jira-id and --justification are required with --non-interactive, or pass --skip-service-log")
 	}
 
 	if o.cluster != nil && o.cluster.Hypershift().Enabled() {
@@ -108,11 +191,36 @@ func (o *controlPlane) New() error {
 	// Ensure we store the internal OCM cluster id
 	o.clusterID = cluster.ID()
 
+	// A dry run doesn't record any progress
+	if !o.dryRun {
+		if err := o.initState(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateInstanceSize(o.newMachineType, "controlplane"); err != nil {
+		return err
+	}
+
 	scheme := runtime.NewScheme()
 	// Register machinev1 for ControlPlaneMachineSets
 	if err := machinev1.Install(scheme); err != nil {
 		return err
 	}
+	// Register machinev1beta1 for Machines
+	if err := machinev1beta1.Install(scheme); err != nil {
+		return err
+	}
+	// Register the types inspected by the preflight checks
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := policyv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := configv1.Install(scheme); err != nil {
+		return err
+	}
 
 	c, err := k8s.New(o.clusterID, client.Options{Scheme: scheme})
 	if err != nil {
@@ -132,6 +240,49 @@ func (o *controlPlane) New() error {
 	return nil
 }
 
+// initState loads the state of the resize to resume, or starts a new one. A new resize is refused
+// while another one of the cluster is still in progress, unless it only waits for the rollout.
+func (o *controlPlane) initState() error {
+	path, err := controlPlaneStatePath(o.clusterID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := loadControlPlaneState(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if o.resume {
+		if existing == nil || !existing.inProgress() {
+			return fmt.Errorf("%w for cluster %s, nothing to resume", errNoResizeState, o.clusterID)
+		}
+		if o.newMachineType != "" && o.newMachineType != existing.NewMachineType {
+			return fmt.Errorf("the resize to resume is to machine type %s, not %s", existing.NewMachineType, o.newMachineType)
+		}
+		o.newMachineType = existing.NewMachineType
+		o.state = existing
+		log.Printf("Resuming the resize of cluster %s to %s at step %s", o.clusterID, o.newMachineType, existing.Step)
+		return nil
+	}
+
+	if existing != nil && existing.blocksNewResize() {
+		return fmt.Errorf("a resize of cluster %s to %s is at step %s, continue it with --resume or remove %s to start over", o.clusterID, existing.NewMachineType, existing.Step, path)
+	}
+
+	o.state = &controlPlaneResizeState{
+		ClusterID:        o.clusterID,
+		ClusterName:      o.cluster.Name(),
+		NewMachineType:   o.newMachineType,
+		Step:             stepPatchCPMS,
+		MachinesReplaced: []string{},
+		Reason:           o.reason,
+		StartedAt:        time.Now().UTC(),
+		path:             path,
+	}
+	return nil
+}
+
 func (o *controlPlane) embiggenMachineType() {}
 
 // extractInstanceClass extracts the instance class from an instance type string.
@@ -293,17 +444,72 @@ func (o *controlPlane) patchMachineType(machine string, machineType string, reas
 // run performs a control plane resize leveraging control plane machine sets
 // https://docs.openshift.com/container-platform/latest/machine_management/control_plane_machine_management/cpmso-about.html
 func (o *controlPlane) run(ctx context.Context) error {
+	if o.dryRun {
+		return o.planResize(ctx)
+	}
+
+	for o.state.inProgress() {
+		var err error
+		switch o.state.Step {
+		case stepPatchCPMS:
+			err = o.patchControlPlaneMachineSet(ctx)
+			if err == nil {
+				err = o.state.advance(stepServiceLog)
+			}
+		case stepServiceLog:
+			err = o.sendResizeServiceLog(ctx)
+			if err == nil {
+				err = o.state.advance(stepRollout)
+			}
+		case stepRollout:
+			if !o.wait {
+				fmt.Println("The resize is in progress and will complete asynchronously. Use the following command to track its progress:")
+				fmt.Println()
+				fmt.Printf("osdctl cluster resize status -C %s\n", o.clusterID)
+				return nil
+			}
+			err = o.waitForRollout(ctx)
+			if err == nil {
+				err = o.state.advance(stepCompleted)
+			}
+		default:
+			err = fmt.Errorf("unknown resize step %q in %s", o.state.Step, o.state.path)
+		}
+
+		if err != nil {
+			o.printResumeHint(os.Stderr)
+			return err
+		}
+	}
+
+	printer.PrintlnGreen("Control plane resize to", o.newMachineType, "complete")
+	return nil
+}
+
+// printResumeHint explains how to continue an interrupted resize. Before the first step completed, e.g. when the
+// prompt was declined or the patch failed, no state was written and there is nothing to resume.
+func (o *controlPlane) printResumeHint(w io.Writer) {
+	if !o.state.persisted {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "The resize stopped at step %s, continue it with: osdctl cluster resize control-plane -C %s --reason <reason> --resume\n", o.state.Step, o.clusterID)
+}
+
+// desiredControlPlaneMachineSet returns the control plane machine set, a copy of it with the new machine type
+// and its current machine type
+func (o *controlPlane) desiredControlPlaneMachineSet(ctx context.Context) (*machinev1.ControlPlaneMachineSet, *machinev1.ControlPlaneMachineSet, string, error) {
+	getCtx, cancel := context.WithTimeout(ctx, o.patchTimeout)
+	defer cancel()
+
 	cpms := &machinev1.ControlPlaneMachineSet{}
-	if err := o.client.Get(ctx, client.ObjectKey{Namespace: cpmsNamespace, Name: cpmsName}, cpms); err != nil {
-		return fmt.Errorf("error retrieving control plane machine set: %v", err)
+	if err := o.client.Get(getCtx, client.ObjectKey{Namespace: cpmsNamespace, Name: cpmsName}, cpms); err != nil {
+		return nil, nil, "", fmt.Errorf("error retrieving control plane machine set: %v", err)
 	}
 
 	if cpms.Spec.State != machinev1.ControlPlaneMachineSetStateActive {
-		return fmt.Errorf("control plane machine set is unexpectedly in %s state, must be %s - check for service logs, support exceptions, ask for a second opinion", cpms.Spec.State, machinev1.ControlPlaneMachineSetStateActive)
+		return nil, nil, "", fmt.Errorf("control plane machine set is unexpectedly in %s state, must be %s - check for service logs, support exceptions, ask for a second opinion", cpms.Spec.State, machinev1.ControlPlaneMachineSetStateActive)
 	}
 
-	patch := client.MergeFrom(cpms.DeepCopy())
-
 	var (
 		rawBytes            []byte
 		currentInstanceType string
@@ -313,103 +519,337 @@ func (o *controlPlane) run(ctx context.Context) error {
 	case "aws":
 		awsSpec := &machinev1beta1.AWSMachineProviderConfig{}
 		if err := json.Unmarshal(cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value.Raw, &awsSpec); err != nil {
-			return fmt.Errorf("error unmarshalling providerSpec: %v", err)
+			return nil, nil, "", fmt.Errorf("error unmarshalling providerSpec: %v", err)
 		}
 		currentInstanceType = awsSpec.InstanceType
 
 		// Validate that instance class is not being changed
 		currentClass, err := extractInstanceClass(currentInstanceType)
 		if err != nil {
-			return fmt.Errorf("error extracting current instance class: %v", err)
+			return nil, nil, "", fmt.Errorf("error extracting current instance class: %v", err)
 		}
 		newClass, err := extractInstanceClass(o.newMachineType)
 		if err != nil {
-			return fmt.Errorf("error extracting new instance class: %v", err)
+			return nil, nil, "", fmt.Errorf("error extracting new instance class: %v", err)
 		}
 		if currentClass != newClass {
-			return fmt.Errorf("cannot change instance class from %s to %s (current: %s, requested: %s). You can only resize within the same instance class", currentClass, newClass, currentInstanceType, o.newMachineType)
+			return nil, nil, "", fmt.Errorf("cannot change instance class from %s to %s (current: %s, requested: %s). You can only resize within the same instance class", currentClass, newClass, currentInstanceType, o.newMachineType)
 		}
 
 		awsSpec.InstanceType = o.newMachineType
 
 		rawBytes, err = json.Marshal(awsSpec)
 		if err != nil {
-			return fmt.Errorf("error marshalling AWS spec: %v", err)
+			return nil, nil, "", fmt.Errorf("error marshalling AWS spec: %v", err)
 		}
 	case "gcp":
 		gcpSpec := &machinev1beta1.GCPMachineProviderSpec{}
 		if err := json.Unmarshal(cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value.Raw, gcpSpec); err != nil {
-			return fmt.Errorf("error unmarshalling providerSpec: %v", err)
+			return nil, nil, "", fmt.Errorf("error unmarshalling providerSpec: %v", err)
 		}
+		currentInstanceType = gcpSpec.MachineType
 
 		gcpSpec.MachineType = o.newMachineType
 		rawBytes, err = json.Marshal(gcpSpec)
 		if err != nil {
-			return fmt.Errorf("error marshalling GCP spec: %v", err)
+			return nil, nil, "", fmt.Errorf("error marshalling GCP spec: %v", err)
 		}
 	default:
-		return fmt.Errorf("cloud provider not supported: %s, only AWS and GCP are supported", o.cluster.CloudProvider().ID())
+		return nil, nil, "", fmt.Errorf("cloud provider not supported: %s, only AWS and GCP are supported", o.cluster.CloudProvider().ID())
+	}
+
+	desired := cpms.DeepCopy()
+	desired.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: rawBytes}
+	return cpms, desired, currentInstanceType, nil
+}
+
+// preflightChecks returns the checks run before patching the control plane machine set. The machine set
+// replaces one machine at a time, so quota for a single additional machine is required.
+func (o *controlPlane) preflightChecks() []preflightCheck {
+	checks := newInstanceTypeChecks(o.cluster, o.newMachineType, 1)
+	return append(checks,
+		newPDBCheck(o.client, ""),
+		newEtcdOperatorCheck(o.client),
+		newNodeReadinessCheck(o.client, client.HasLabels{"node-role.kubernetes.io/master"}),
+	)
+}
+
+// planResize prints the preflight checks and the control plane machine set patch without applying it
+func (o *controlPlane) planResize(ctx context.Context) error {
+	original, desired, currentInstanceType, err := o.desiredControlPlaneMachineSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	var preflightErr error
+	if !o.skipPreflight {
+		preflightErr = runPreflightChecks(ctx, os.Stdout, o.preflightChecks())
+	}
+
+	if currentInstanceType == o.newMachineType {
+		fmt.Printf("Control plane machine set already has machine type %s, nothing would be patched\n", o.newMachineType)
+		return preflightErr
+	}
+	if err := printDryRunPatch(os.Stdout, "ControlPlaneMachineSet", original, desired); err != nil {
+		return err
+	}
+	fmt.Printf("The control plane machines of cluster %s would be replaced one at a time, changing them from %s to %s\n", o.clusterID, currentInstanceType, o.newMachineType)
+	if !o.skipServiceLog {
+		fmt.Printf("Service log %s would be sent with INSTANCE_TYPE=%s\n", resizeControlPlaneServiceLogTemplate, o.newMachineType)
+	}
+	return preflightErr
+}
+
+// patchControlPlaneMachineSet sets the new machine type in the control plane machine set, which starts the rollout.
+// The patch is skipped when the machine set already has the new machine type, e.g. when resuming.
+func (o *controlPlane) patchControlPlaneMachineSet(ctx context.Context) error {
+	original, desired, currentInstanceType, err := o.desiredControlPlaneMachineSet(ctx)
+	if err != nil {
+		return err
+	}
+
+	if currentInstanceType == o.newMachineType {
+		log.Printf("Control plane machine set already has machine type %s, skipping the patch", o.newMachineType)
+		return nil
+	}
+
+	if !o.skipPreflight {
+		if err := runPreflightChecks(ctx, os.Stdout, o.preflightChecks()); err != nil {
+			return err
+		}
 	}
 
-	log.Printf("Initiating control plane node resize for cluster %s/%s to %s using control plane machine sets. This process runs asynchronously.", o.cluster.Name(), o.cluster.ID(), o.newMachineType)
-	if !utils.ConfirmPrompt() {
+	log.Printf("Initiating control plane node resize for cluster %s/%s from %s to %s using control plane machine sets. This process runs asynchronously.", o.cluster.Name(), o.cluster.ID(), currentInstanceType, o.newMachineType)
+	if !o.nonInteractive && !utils.ConfirmPrompt() {
 		return errors.New("aborting control plane resize")
 	}
 
 	// Patch the ControlPlaneMachineSet
-	cpms.Spec.Template.OpenShiftMachineV1Beta1Machine.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: rawBytes}
-	if err := o.clientAdmin.Patch(ctx, cpms, patch); err != nil {
+	patchCtx, cancel := context.WithTimeout(ctx, o.patchTimeout)
+	defer cancel()
+	if err := o.clientAdmin.Patch(patchCtx, desired, client.MergeFrom(original)); err != nil {
 		return fmt.Errorf("failed patching control plane machine set: %v", err)
 	}
+	// Persisted together with the next step, so declining or a failed patch doesn't leave a resize in progress
+	o.state.OldMachineType = currentInstanceType
 
-	log.Println("Control plane machine set patched successfully. The resize is now in progress and will complete asynchronously. This command will exit after sending a service log, and any issues will be reported via PagerDuty.")
-
-	return promptGenerateResizeSL(o.clusterID, o.newMachineType)
+	log.Println("Control plane machine set patched successfully. The resize is now in progress and will complete asynchronously. Any issues will be reported via PagerDuty.")
+	return nil
 }
 
-func promptGenerateResizeSL(clusterID string, newMachineType string) error {
-	fmt.Println("The resize operation is in progress and will complete asynchronously. A service log will now be sent to document this action. Any issues with the resize will be reported via PagerDuty.")
-	fmt.Println("Would you like to proceed with sending the service log?")
-	if !utils.ConfirmPrompt() {
-		fmt.Println("Service log not sent. The resize is still in progress, and this command will now exit. Monitor PagerDuty for any issues.")
+// sendResizeServiceLog sends the service log documenting the resize. In interactive mode, missing
+// details are prompted for and the user may decline sending it.
+func (o *controlPlane) sendResizeServiceLog(ctx context.Context) error {
+	if o.skipServiceLog {
+		fmt.Println("Skipping the service log for the resize")
 		return nil
 	}
 
-	var jiraID string
-	fmt.Print("Please enter the JIRA ID that corresponds to this resize: ")
-	_, err := fmt.Scanln(&jiraID)
+	if !o.nonInteractive {
+		fmt.Println("The resize operation is in progress and will complete asynchronously. A service log will now be sent to document this action. Any issues with the resize will be reported via PagerDuty.")
+		fmt.Println("Would you like to proceed with sending the service log?")
+		if !utils.ConfirmPrompt() {
+			fmt.Println("Service log not sent. The resize is still in progress. Monitor PagerDuty for any issues.")
+			return nil
+		}
+
+		if o.jiraID == "" {
+			fmt.Print("Please enter the JIRA ID that corresponds to this resize: ")
+			_, err := fmt.Scanln(&o.jiraID)
+			if err != nil {
+				log.Printf("Error reading JIRA ID: %v, proceeding with empty value", err)
+			}
+		}
+
+		if o.justification == "" {
+			fmt.Print("Please enter a justification for the resize: ")
+			scanner := bufio.NewScanner(os.Stdin)
+			if scanner.Scan() {
+				o.justification = scanner.Text()
+			} else if err := scanner.Err(); err != nil {
+				errText := "failed to read justification text, send service log manually"
+				_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", errText, err)
+				return errors.New(errText)
+			}
+		}
+	}
+
+	postCtx, cancel := context.WithTimeout(ctx, o.serviceLogTimeout)
+	defer cancel()
+
+	template, err := fetchServiceLogTemplate(postCtx, resizeControlPlaneServiceLogTemplate)
 	if err != nil {
-		log.Printf("Error reading JIRA ID: %v, proceeding with empty value", err)
-	}
-
-	var justification string
-	fmt.Print("Please enter a justification for the resize: ")
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() {
-		justification = scanner.Text()
-	} else if err := scanner.Err(); err != nil {
-		errText := "failed to read justification text, send service log manually"
-		_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", errText, err)
-		return errors.New(errText)
-	}
-
-	postCmd := servicelog.PostCmdOptions{
-		Template: resizeControlPlaneServiceLogTemplate,
-		TemplateParams: []string{
-			fmt.Sprintf("INSTANCE_TYPE=%s", newMachineType),
-			fmt.Sprintf("JIRA_ID=%s", jiraID),
-			fmt.Sprintf("JUSTIFICATION=%s", justification),
-		},
-		ClusterId: clusterID,
+		return fmt.Errorf("failed to send service log: %w", err)
+	}
+	logEntry, err := buildServiceLog(template, o.cluster, map[string]string{
+		"INSTANCE_TYPE": o.newMachineType,
+		"JIRA_ID":       o.jiraID,
+		"JUSTIFICATION": o.justification,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send service log: %w", err)
+	}
+
+	connection, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	defer connection.Close()
+
+	if _, err := connection.ServiceLogs().V1().ClusterLogs().Add().Body(logEntry).SendContext(postCtx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("sending the service log didn't complete within %s, check whether it was sent before resuming: %w", o.serviceLogTimeout, err)
+		}
+		return fmt.Errorf("failed to send service log: %w", err)
+	}
+
+	o.state.ServiceLogSent = true
+	fmt.Println("Service log sent successfully.")
+	return nil
+}
+
+// fetchServiceLogTemplate downloads a service log template from managed-notifications
+func fetchServiceLogTemplate(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download service log template %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download service log template %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// buildServiceLog fills in the parameters of a service log template and returns the log entry to post to the cluster
+func buildServiceLog(template []byte, cluster *cmv1.Cluster, params map[string]string) (*slv1.LogEntry, error) {
+	message := servicelog.Message{}
+	if err := json.Unmarshal(template, &message); err != nil {
+		return nil, fmt.Errorf("failed to parse service log template: %w", err)
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := templates.Replace(templates.Placeholder(name), params[name], message.TextFields()...); err != nil {
+			return nil, err
+		}
+	}
+	if err := templates.CheckLeftovers(nil, message.Texts()...); err != nil {
+		return nil, err
 	}
 
-	if err := postCmd.Run(); err != nil {
-		return fmt.Errorf("failed to send service log: %v", err)
+	logEntryBuilder := slv1.NewLogEntry().
+		ClusterUUID(cluster.ExternalID()).
+		ClusterID(cluster.ID()).
+		Severity(slv1.Severity(message.Severity)).
+		ServiceName(message.ServiceName).
+		Summary(message.Summary).
+		Description(message.Description).
+		InternalOnly(message.InternalOnly).
+		EventStreamID(message.EventStreamID).
+		DocReferences(message.DocReferences...)
+	if subscription := cluster.Subscription(); subscription != nil {
+		logEntryBuilder.SubscriptionID(subscription.ID())
 	}
+	return logEntryBuilder.Build()
+}
 
-	fmt.Println("Service log sent successfully. Use the following command to track progress of the resize:")
-	fmt.Println()
-	fmt.Println(`watch -d 'oc get machines -n openshift-machine-api -l machine.openshift.io/cluster-api-machine-role=master && oc get nodes -l node-role.kubernetes.io/master'`)
+// waitForRollout polls the control plane machines until all of them were replaced with the new machine type,
+// recording the replaced machines in the resize state
+func (o *controlPlane) waitForRollout(ctx context.Context) error {
+	log.Printf("Waiting up to %s for the control plane machines to be replaced with %s", o.rolloutTimeout, o.newMachineType)
+	err := wait.PollUntilContextTimeout(ctx, rolloutPollInterval, o.rolloutTimeout, true, func(ctx context.Context) (bool, error) {
+		progress, err := getRolloutProgress(ctx, o.client, o.newMachineType)
+		if err != nil {
+			log.Printf("Error checking control plane machines: %v", err)
+			return false, nil
+		}
 
+		if !slices.Equal(o.state.MachinesReplaced, progress.replaced) {
+			o.state.MachinesReplaced = progress.replaced
+			if err := o.state.save(); err != nil {
+				return false, err
+			}
+		}
+
+		log.Printf("[%s] %d/%d control plane machines replaced", time.Now().Format("15:04:05"), len(progress.replaced), progress.desired)
+		return progress.done(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("control plane machines were not replaced within %s: %w", o.rolloutTimeout, err)
+	}
 	return nil
 }
+
+// rolloutProgress is the progress of replacing the control plane machines with a new machine type
+type rolloutProgress struct {
+	// desired is the number of control plane replicas
+	desired int
+	// replaced are the running machines with the new machine type
+	replaced []string
+	// pending are the machines still to be replaced, or being provisioned or deleted
+	pending []string
+}
+
+func (p rolloutProgress) done() bool {
+	return len(p.pending) == 0 && len(p.replaced) >= p.desired
+}
+
+func getRolloutProgress(ctx context.Context, c client.Client, newMachineType string) (rolloutProgress, error) {
+	progress := rolloutProgress{replaced: []string{}, pending: []string{}}
+
+	cpms := &machinev1.ControlPlaneMachineSet{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cpmsNamespace, Name: cpmsName}, cpms); err != nil {
+		return progress, fmt.Errorf("error retrieving control plane machine set: %v", err)
+	}
+	if cpms.Spec.Replicas != nil {
+		progress.desired = int(*cpms.Spec.Replicas)
+	}
+
+	machines := &machinev1beta1.MachineList{}
+	if err := c.List(ctx, machines, client.InNamespace(cpmsNamespace), client.MatchingLabels{masterMachineRoleLabel: "master"}); err != nil {
+		return progress, fmt.Errorf("error listing control plane machines: %v", err)
+	}
+
+	for _, machine := range machines.Items {
+		machineType, err := machineInstanceType(&machine)
+		if err != nil {
+			return progress, err
+		}
+		running := machine.Status.Phase != nil && *machine.Status.Phase == "Running"
+		if machine.DeletionTimestamp == nil && running && machineType == newMachineType {
+			progress.replaced = append(progress.replaced, machine.Name)
+		} else {
+			progress.pending = append(progress.pending, machine.Name)
+		}
+	}
+	sort.Strings(progress.replaced)
+	sort.Strings(progress.pending)
+	return progress, nil
+}
+
+// machineInstanceType returns the AWS instance type or GCP machine type of a machine
+func machineInstanceType(machine *machinev1beta1.Machine) (string, error) {
+	if machine.Spec.ProviderSpec.Value == nil {
+		return "", nil
+	}
+	spec := struct {
+		InstanceType string `json:"instanceType"`
+		MachineType  string `json:"machineType"`
+	}{}
+	if err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, &spec); err != nil {
+		return "", fmt.Errorf("error unmarshalling providerSpec of machine %s: %v", machine.Name, err)
+	}
+	if spec.InstanceType != "" {
+		return spec.InstanceType, nil
+	}
+	return spec.MachineType, nil
+}
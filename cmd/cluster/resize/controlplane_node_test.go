@@ -2,6 +2,8 @@ package resize
 
 import (
 	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
 func TestExtractInstanceClass_AWS(t *testing.T) {
@@ -92,3 +94,40 @@ func TestInstanceClassValidation_AWS(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildServiceLog(t *testing.T) {
+	cluster := newTestCluster(t, cmv1.NewCluster().ID("cluster").ExternalID("uuid").Subscription(cmv1.NewSubscription().ID("sub")))
+	template := []byte(`{
+		"severity": "Info",
+		"service_name": "SREManualAction",
+		"summary": "Control plane resized",
+		"description": "Resized to ${INSTANCE_TYPE} for ${JIRA_ID}: ${JUSTIFICATION}",
+		"internal_only": false,
+		"doc_references": ["https://docs.openshift.com/dedicated/welcome/index.html"]
+	}`)
+
+	logEntry, err := buildServiceLog(template, cluster, map[string]string{
+		"INSTANCE_TYPE": "m5.4xlarge",
+		"JIRA_ID":       "OHSS-1",
+		"JUSTIFICATION": "CPU starved",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logEntry.Description() != "Resized to m5.4xlarge for OHSS-1: CPU starved" {
+		t.Errorf("unexpected description %q", logEntry.Description())
+	}
+	if logEntry.ClusterID() != "cluster" || logEntry.ClusterUUID() != "uuid" || logEntry.SubscriptionID() != "sub" {
+		t.Errorf("log entry doesn't target the cluster: %s/%s/%s", logEntry.ClusterID(), logEntry.ClusterUUID(), logEntry.SubscriptionID())
+	}
+	if len(logEntry.DocReferences()) != 1 {
+		t.Errorf("expected the doc references of the template, got %v", logEntry.DocReferences())
+	}
+
+	if _, err := buildServiceLog(template, cluster, map[string]string{"INSTANCE_TYPE": "m5.4xlarge"}); err == nil {
+		t.Error("expected an error for the parameters left in the template")
+	}
+	if _, err := buildServiceLog(template, cluster, map[string]string{"INSTANCE_TYPE": "m5.4xlarge", "JIRA_ID": "", "JUSTIFICATION": "x"}); err == nil {
+		t.Error("expected an error for an empty parameter")
+	}
+}
@@ -0,0 +1,119 @@
+package resize
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+)
+
+// controlPlaneResizeStep is a step of a control plane resize, in the order they are run
+type controlPlaneResizeStep string
+
+const (
+	stepPatchCPMS  controlPlaneResizeStep = "patch-cpms"
+	stepServiceLog controlPlaneResizeStep = "service-log"
+	stepRollout    controlPlaneResizeStep = "rollout"
+	stepCompleted  controlPlaneResizeStep = "completed"
+)
+
+// controlPlaneResizeState is the progress of a control plane resize, persisted after every step
+// so an interrupted resize can be continued with --resume
+type controlPlaneResizeState struct {
+	ClusterID        string                 `json:"clusterID"`
+	ClusterName      string                 `json:"clusterName"`
+	OldMachineType   string                 `json:"oldMachineType"`
+	NewMachineType   string                 `json:"newMachineType"`
+	Step             controlPlaneResizeStep `json:"step"`
+	MachinesReplaced []string               `json:"machinesReplaced"`
+	ServiceLogSent   bool                   `json:"serviceLogSent"`
+	Reason           string                 `json:"reason"`
+	StartedAt        time.Time              `json:"startedAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+
+	path string
+	// persisted is set once the state was read from or written to path, i.e. there is a resize to resume
+	persisted bool
+}
+
+// controlPlaneStatePath returns the location of the resize state file of a cluster
+func controlPlaneStatePath(clusterID string) (string, error) {
+	cacheDir, err := utils.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "resize", clusterID+"-control-plane.json"), nil
+}
+
+// loadControlPlaneState reads a resize state file. The returned error wraps os.ErrNotExist
+// if no resize was started for the cluster.
+func loadControlPlaneState(path string) (*controlPlaneResizeState, error) {
+	state := &controlPlaneResizeState{}
+	found, err := utils.LoadJSON(path, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resize state: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("failed to load resize state %s: %w", path, os.ErrNotExist)
+	}
+	state.path = path
+	state.persisted = true
+	return state, nil
+}
+
+// save writes the state file
+func (s *controlPlaneResizeState) save() error {
+	s.UpdatedAt = time.Now().UTC()
+	if err := utils.SaveJSONAtomic(s.path, s); err != nil {
+		return fmt.Errorf("failed to save resize state: %w", err)
+	}
+	s.persisted = true
+	return nil
+}
+
+// advance moves the resize to the next step and persists it
+func (s *controlPlaneResizeState) advance(step controlPlaneResizeStep) error {
+	s.Step = step
+	return s.save()
+}
+
+func (s *controlPlaneResizeState) inProgress() bool {
+	return s.Step != stepCompleted
+}
+
+// blocksNewResize reports whether a new resize of the cluster must wait for this one. Once the control plane
+// machine set is patched and the service log sent the rollout completes on its own, so waiting for it is optional.
+func (s *controlPlaneResizeState) blocksNewResize() bool {
+	return s.inProgress() && s.Step != stepRollout
+}
+
+func printControlPlaneState(w io.Writer, state *controlPlaneResizeState) error {
+	replaced := "-"
+	if len(state.MachinesReplaced) > 0 {
+		replaced = fmt.Sprintf("%d %v", len(state.MachinesReplaced), state.MachinesReplaced)
+	}
+	serviceLog := "not sent"
+	if state.ServiceLogSent {
+		serviceLog = "sent"
+	}
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Cluster", fmt.Sprintf("%s (%s)", state.ClusterName, state.ClusterID)})
+	table.AddRow([]string{"Machine Type", fmt.Sprintf("%s -> %s", state.OldMachineType, state.NewMachineType)})
+	table.AddRow([]string{"Step", string(state.Step)})
+	table.AddRow([]string{"Machines Replaced", replaced})
+	table.AddRow([]string{"Service Log", serviceLog})
+	table.AddRow([]string{"Reason", state.Reason})
+	table.AddRow([]string{"Started", state.StartedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"Updated", state.UpdatedAt.Format(time.RFC3339)})
+	table.AddRow([]string{"State File", state.path})
+	return table.Flush()
+}
+
+// errNoResizeState is returned when --resume or status is used for a cluster without a resize in progress
+var errNoResizeState = errors.New("no control plane resize state found")
@@ -0,0 +1,246 @@
+package resize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// setCacheDir points os.UserCacheDir at a temporary directory
+func setCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+}
+
+func TestControlPlaneState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resize", "cluster-control-plane.json")
+	state := &controlPlaneResizeState{
+		ClusterID:        "cluster",
+		OldMachineType:   "m5.2xlarge",
+		NewMachineType:   "m5.4xlarge",
+		Step:             stepPatchCPMS,
+		MachinesReplaced: []string{},
+		path:             path,
+	}
+	assert.False(t, state.persisted)
+	require.NoError(t, state.advance(stepServiceLog))
+	assert.True(t, state.persisted)
+
+	loaded, err := loadControlPlaneState(path)
+	require.NoError(t, err)
+	assert.Equal(t, stepServiceLog, loaded.Step)
+	assert.Equal(t, "m5.2xlarge", loaded.OldMachineType)
+	assert.Equal(t, "m5.4xlarge", loaded.NewMachineType)
+	assert.Equal(t, path, loaded.path)
+	assert.False(t, loaded.UpdatedAt.IsZero())
+	assert.True(t, loaded.persisted)
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	_, err = loadControlPlaneState(filepath.Join(t.TempDir(), "missing.json"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestControlPlane_initState(t *testing.T) {
+	cluster := newTestCluster(t, cmv1.NewCluster().ID("cluster").Name("my-cluster"))
+
+	tests := []struct {
+		name           string
+		existing       *controlPlaneResizeState
+		resume         bool
+		newMachineType string
+		expectedType   string
+		expectedStep   controlPlaneResizeStep
+		expectErr      bool
+	}{
+		{
+			name:           "new resize",
+			newMachineType: "m5.4xlarge",
+			expectedType:   "m5.4xlarge",
+			expectedStep:   stepPatchCPMS,
+		},
+		{
+			name:           "new resize after a completed one",
+			existing:       &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepCompleted},
+			newMachineType: "m5.8xlarge",
+			expectedType:   "m5.8xlarge",
+			expectedStep:   stepPatchCPMS,
+		},
+		{
+			name:           "new resize while one is in progress",
+			existing:       &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepServiceLog},
+			newMachineType: "m5.8xlarge",
+			expectErr:      true,
+		},
+		{
+			name:           "new resize while one is rolling out",
+			existing:       &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepRollout},
+			newMachineType: "m5.8xlarge",
+			expectedType:   "m5.8xlarge",
+			expectedStep:   stepPatchCPMS,
+		},
+		{
+			name:         "resume defaults to the recorded machine type",
+			existing:     &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepRollout},
+			resume:       true,
+			expectedType: "m5.4xlarge",
+			expectedStep: stepRollout,
+		},
+		{
+			name:           "resume with a different machine type",
+			existing:       &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepRollout},
+			resume:         true,
+			newMachineType: "m5.8xlarge",
+			expectErr:      true,
+		},
+		{
+			name:      "resume without state",
+			resume:    true,
+			expectErr: true,
+		},
+		{
+			name:      "resume a completed resize",
+			existing:  &controlPlaneResizeState{NewMachineType: "m5.4xlarge", Step: stepCompleted},
+			resume:    true,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setCacheDir(t)
+			if tt.existing != nil {
+				path, err := controlPlaneStatePath("cluster")
+				require.NoError(t, err)
+				tt.existing.path = path
+				require.NoError(t, tt.existing.save())
+			}
+
+			o := &controlPlane{clusterID: "cluster", cluster: cluster, newMachineType: tt.newMachineType, resume: tt.resume}
+			err := o.initState()
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, o.newMachineType)
+			assert.Equal(t, tt.expectedStep, o.state.Step)
+			assert.Equal(t, tt.expectedType, o.state.NewMachineType)
+		})
+	}
+}
+
+func TestControlPlane_printResumeHint(t *testing.T) {
+	o := &controlPlane{clusterID: "cluster", state: &controlPlaneResizeState{Step: stepPatchCPMS, path: filepath.Join(t.TempDir(), "state.json")}}
+
+	// The prompt was declined or the patch failed, no state was written
+	buf := &bytes.Buffer{}
+	o.printResumeHint(buf)
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, o.state.advance(stepServiceLog))
+	o.printResumeHint(buf)
+	assert.Contains(t, buf.String(), "stopped at step service-log")
+	assert.Contains(t, buf.String(), "-C cluster --reason <reason> --resume")
+}
+
+func newTestMachine(name, instanceType, phase string) *machinev1beta1.Machine {
+	return &machinev1beta1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cpmsNamespace,
+			Labels:    map[string]string{masterMachineRoleLabel: "master"},
+		},
+		Spec: machinev1beta1.MachineSpec{
+			ProviderSpec: machinev1beta1.ProviderSpec{
+				Value: &runtime.RawExtension{Raw: []byte(`{"instanceType":"` + instanceType + `"}`)},
+			},
+		},
+		Status: machinev1beta1.MachineStatus{Phase: ptr.To(phase)},
+	}
+}
+
+func TestGetRolloutProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, machinev1.Install(scheme))
+	require.NoError(t, machinev1beta1.Install(scheme))
+
+	cpms := &machinev1.ControlPlaneMachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: cpmsName, Namespace: cpmsNamespace},
+		Spec:       machinev1.ControlPlaneMachineSetSpec{Replicas: ptr.To(int32(3))},
+	}
+
+	tests := []struct {
+		name             string
+		machines         []runtime.Object
+		expectedReplaced []string
+		expectedPending  []string
+		expectedDone     bool
+	}{
+		{
+			name: "rollout in progress",
+			machines: []runtime.Object{
+				newTestMachine("master-a", "m5.2xlarge", "Running"),
+				newTestMachine("master-b", "m5.4xlarge", "Running"),
+				newTestMachine("master-c", "m5.2xlarge", "Running"),
+				newTestMachine("master-d", "m5.4xlarge", "Provisioning"),
+			},
+			expectedReplaced: []string{"master-b"},
+			expectedPending:  []string{"master-a", "master-c", "master-d"},
+		},
+		{
+			name: "rollout complete",
+			machines: []runtime.Object{
+				newTestMachine("master-d", "m5.4xlarge", "Running"),
+				newTestMachine("master-e", "m5.4xlarge", "Running"),
+				newTestMachine("master-f", "m5.4xlarge", "Running"),
+			},
+			expectedReplaced: []string{"master-d", "master-e", "master-f"},
+			expectedPending:  []string{},
+			expectedDone:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := append([]runtime.Object{cpms.DeepCopy()}, tt.machines...)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+			progress, err := getRolloutProgress(context.Background(), c, "m5.4xlarge")
+			require.NoError(t, err)
+			assert.Equal(t, 3, progress.desired)
+			assert.Equal(t, tt.expectedReplaced, progress.replaced)
+			assert.Equal(t, tt.expectedPending, progress.pending)
+			assert.Equal(t, tt.expectedDone, progress.done())
+		})
+	}
+}
+
+func TestMachineInstanceType(t *testing.T) {
+	aws := newTestMachine("aws", "m5.4xlarge", "Running")
+	machineType, err := machineInstanceType(aws)
+	require.NoError(t, err)
+	assert.Equal(t, "m5.4xlarge", machineType)
+
+	gcp := &machinev1beta1.Machine{Spec: machinev1beta1.MachineSpec{ProviderSpec: machinev1beta1.ProviderSpec{
+		Value: &runtime.RawExtension{Raw: []byte(`{"machineType":"n2-standard-16"}`)},
+	}}}
+	machineType, err = machineInstanceType(gcp)
+	require.NoError(t, err)
+	assert.Equal(t, "n2-standard-16", machineType)
+}
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
 	"slices"
 	"strings"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/aws/smithy-go"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	configv1 "github.com/openshift/api/config/v1"
 	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/osdctl/cmd/servicelog"
@@ -23,6 +26,7 @@ import (
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -54,6 +58,12 @@ type Infra struct {
 
 	// hiveOcmUrl is the OCM environment URL for Hive operations
 	hiveOcmUrl string
+
+	// dryRun prints the preflight checks and the MachinePool changes without applying them
+	dryRun bool
+
+	// skipPreflight doesn't run the preflight checks before resizing
+	skipPreflight bool
 }
 
 func newCmdResizeInfra() *cobra.Command {
@@ -70,6 +80,10 @@ func newCmdResizeInfra() *cobra.Command {
   Remember to follow the SOP for preparation and follow up steps:
 
     https://github.com/openshift/ops-sop/blob/master/v4/howto/resize-infras-workers.md
+
+  Before resizing, preflight checks verify the EC2 quota and availability zone offerings of the new instance type,
+  PodDisruptionBudgets which block draining, etcd health and infra node readiness. Use --dry-run to only run the
+  checks and print the MachinePool changes.
 `,
 		Example: `
   # Automatically vertically scale infra nodes to the next size
@@ -77,6 +91,9 @@ func newCmdResizeInfra() *cobra.Command {
 
   # Resize infra nodes to a specific instance type
   osdctl cluster resize infra --cluster-id ${CLUSTER_ID} --instance-type "r5.xlarge"
+
+  # Run the preflight checks and show the MachinePool changes without applying them
+  osdctl cluster resize infra --cluster-id ${CLUSTER_ID} --instance-type "r5.xlarge" --dry-run
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return r.RunInfra(context.Background())
@@ -88,6 +105,8 @@ func newCmdResizeInfra() *cobra.Command {
 	infraResizeCmd.Flags().StringVar(&r.reason, "reason", "", "The reason for this command, which requires elevation, to be run (usually an OHSS or PD ticket)")
 	infraResizeCmd.Flags().StringVar(&r.justification, "justification", "", "The justification behind resize")
 	infraResizeCmd.Flags().StringVar(&r.ohss, "ohss", "", "OHSS ticket tracking this infra node resize")
+	infraResizeCmd.Flags().BoolVar(&r.dryRun, "dry-run", false, "Run the preflight checks and print the MachinePool changes without applying them")
+	infraResizeCmd.Flags().BoolVar(&r.skipPreflight, "skip-preflight", false, "Don't run the preflight checks before resizing")
 	infraResizeCmd.Flags().StringVar(&r.hiveOcmUrl, "hive-ocm-url", "", "(optional) OCM environment URL for hive operations. Aliases: 'production', 'staging', 'integration'. If not specified, uses the same OCM environment as the target cluster.")
 
 	_ = infraResizeCmd.MarkFlagRequired("cluster-id")
@@ -125,6 +144,13 @@ func (r *Infra) New() error {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return err
 	}
+	// Register the types inspected by the preflight checks
+	if err := policyv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := configv1.Install(scheme); err != nil {
+		return err
+	}
 
 	var hive *cmv1.Cluster
 	var c, hc, hac client.Client
@@ -243,6 +269,21 @@ func (r *Infra) RunInfra(ctx context.Context) error {
 		return fmt.Errorf("failed to parse instance type from machinepool: %v", err)
 	}
 
+	var preflightErr error
+	if !r.skipPreflight {
+		preflightErr = runPreflightChecks(ctx, os.Stdout, r.preflightChecks(originalMp, instanceType))
+		if preflightErr != nil && !r.dryRun {
+			return preflightErr
+		}
+	}
+
+	if r.dryRun {
+		if err := printMachinePoolPlan(os.Stdout, originalMp, newMp); err != nil {
+			return err
+		}
+		return preflightErr
+	}
+
 	log.Printf("planning to resize to instance type from %s to %s", originalInstanceType, instanceType)
 	if !utils.ConfirmPrompt() {
 		log.Printf("exiting")
@@ -269,6 +310,46 @@ func (r *Infra) RunInfra(ctx context.Context) error {
 	return nil
 }
 
+// preflightChecks returns the checks run before the machinepool dance. The dance runs a temporary MachinePool
+// alongside the original one, so quota for a second set of infra nodes is required.
+func (r *Infra) preflightChecks(mp *hivev1.MachinePool, instanceType string) []preflightCheck {
+	replicas := 0
+	if mp.Spec.Replicas != nil {
+		replicas = int(*mp.Spec.Replicas)
+	}
+
+	checks := newInstanceTypeChecks(r.cluster, instanceType, replicas)
+	return append(checks,
+		newPDBCheck(r.client, ""),
+		newEtcdOperatorCheck(r.client),
+		newNodeReadinessCheck(r.client, client.HasLabels{infraPkg.InfraNodeLabel}),
+	)
+}
+
+// printMachinePoolPlan prints the MachinePool changes the machinepool dance would apply
+func printMachinePoolPlan(w io.Writer, originalMp, newMp *hivev1.MachinePool) error {
+	// Compare against a clone so only the spec changes are shown, not the reset metadata
+	original, err := infraPkg.CloneMachinePool(originalMp, nil)
+	if err != nil {
+		return err
+	}
+	if err := printDryRunPatch(w, "MachinePool", original, newMp); err != nil {
+		return err
+	}
+
+	replicas := int64(0)
+	if originalMp.Spec.Replicas != nil {
+		replicas = *originalMp.Spec.Replicas
+	}
+	_, err = fmt.Fprintf(w, `The machinepool dance would:
+  1. create the temporary MachinePool %[1]s2 with %[2]d replicas and the patch above
+  2. wait for its nodes to be Ready, then delete the MachinePool %[1]s
+  3. recreate the MachinePool %[1]s with the patch above
+  4. delete the temporary MachinePool %[1]s2 once the new nodes are Ready
+`, originalMp.Name, replicas)
+	return err
+}
+
 func (r *Infra) embiggenMachinePool(mp *hivev1.MachinePool) (*hivev1.MachinePool, error) {
 	embiggen := map[string]string{
 		"m5.xlarge":  "r5.xlarge",
@@ -0,0 +1,374 @@
+package resize
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/osdctl/pkg/osdCloud"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/provider/aws"
+	"github.com/openshift/osdctl/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// preflightStatus is the outcome of a preflight check
+type preflightStatus string
+
+const (
+	preflightPass preflightStatus = "PASS"
+	preflightWarn preflightStatus = "WARN"
+	preflightFail preflightStatus = "FAIL"
+	preflightSkip preflightStatus = "SKIP"
+
+	// standardInstancesQuotaCode is the EC2 quota of running On-Demand standard (A, C, D, H, I, M, R, T, Z) instance vCPUs
+	standardInstancesQuotaCode = "L-1216C47A"
+)
+
+// preflightCheck is a check run before a resize mutates anything. Checks returning preflightFail block the resize.
+type preflightCheck struct {
+	name string
+	run  func(ctx context.Context) (preflightStatus, string)
+}
+
+type preflightResult struct {
+	name    string
+	status  preflightStatus
+	message string
+}
+
+// runPreflightChecks runs all checks, prints their results and returns an error if any of them failed
+func runPreflightChecks(ctx context.Context, w io.Writer, checks []preflightCheck) error {
+	results := make([]preflightResult, 0, len(checks))
+	for _, check := range checks {
+		status, message := check.run(ctx)
+		results = append(results, preflightResult{name: check.name, status: status, message: message})
+	}
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Check", "Status", "Details"})
+	failed := []string{}
+	for _, result := range results {
+		table.AddRow([]string{result.name, string(result.status), result.message})
+		if result.status == preflightFail {
+			failed = append(failed, result.name)
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("preflight checks failed: %s - resolve them or rerun with --skip-preflight", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func failedCheck(name string, err error) preflightCheck {
+	return preflightCheck{name: name, run: func(context.Context) (preflightStatus, string) {
+		return preflightFail, err.Error()
+	}}
+}
+
+func skippedCheck(name, reason string) preflightCheck {
+	return preflightCheck{name: name, run: func(context.Context) (preflightStatus, string) {
+		return preflightSkip, reason
+	}}
+}
+
+// newInstanceTypeChecks returns the service quota and availability zone checks for launching
+// additionalInstances instances of the new instance type. They are only supported on AWS.
+func newInstanceTypeChecks(cluster *cmv1.Cluster, instanceType string, additionalInstances int) []preflightCheck {
+	const quotaName, zonesName = "Service quota", "Availability zones"
+
+	if cluster.CloudProvider().ID() != "aws" {
+		reason := fmt.Sprintf("not supported for %s clusters", cluster.CloudProvider().ID())
+		return []preflightCheck{skippedCheck(quotaName, reason), skippedCheck(zonesName, reason)}
+	}
+
+	awsClient, err := newClusterAWSClient(cluster)
+	if err != nil {
+		err = fmt.Errorf("failed to get AWS credentials: %w", err)
+		return []preflightCheck{failedCheck(quotaName, err), failedCheck(zonesName, err)}
+	}
+
+	return []preflightCheck{
+		{name: quotaName, run: func(ctx context.Context) (preflightStatus, string) {
+			return checkInstanceQuota(ctx, awsClient, instanceType, additionalInstances)
+		}},
+		{name: zonesName, run: func(ctx context.Context) (preflightStatus, string) {
+			return checkAvailabilityZones(ctx, awsClient, instanceType, cluster.Nodes().AvailabilityZones())
+		}},
+	}
+}
+
+func newClusterAWSClient(cluster *cmv1.Cluster) (aws.ContextClient, error) {
+	connection, err := utils.CreateConnection()
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	cfg, err := osdCloud.CreateAWSV2Config(connection, cluster)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Region = cluster.Region().ID()
+	return aws.NewAwsContextClientFromConfig(cfg), nil
+}
+
+// checkInstanceQuota verifies the running instances plus the additional instances fit into the account's vCPU quota
+func checkInstanceQuota(ctx context.Context, awsClient aws.ContextClient, instanceType string, additionalInstances int) (preflightStatus, string) {
+	if !isStandardInstanceType(instanceType) {
+		return preflightSkip, fmt.Sprintf("%s is not a standard instance type", instanceType)
+	}
+
+	types, err := awsClient.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{InstanceTypes: []ec2types.InstanceType{ec2types.InstanceType(instanceType)}})
+	if err != nil {
+		return preflightFail, fmt.Sprintf("failed to describe instance type %s: %v", instanceType, err)
+	}
+	if len(types.InstanceTypes) == 0 || types.InstanceTypes[0].VCpuInfo == nil {
+		return preflightFail, fmt.Sprintf("instance type %s not found", instanceType)
+	}
+	required := int(awsSdk.ToInt32(types.InstanceTypes[0].VCpuInfo.DefaultVCpus)) * additionalInstances
+
+	quota, err := awsClient.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{ServiceCode: awsSdk.String("ec2"), QuotaCode: awsSdk.String(standardInstancesQuotaCode)})
+	if err != nil {
+		return preflightFail, fmt.Sprintf("failed to get the standard instances quota: %v", err)
+	}
+	if quota.Quota == nil || quota.Quota.Value == nil {
+		return preflightFail, "the standard instances quota has no value"
+	}
+	limit := int(math.Floor(*quota.Quota.Value))
+
+	used, err := standardInstanceVCPUs(ctx, awsClient)
+	if err != nil {
+		return preflightFail, err.Error()
+	}
+
+	message := fmt.Sprintf("%d vCPUs in use + %d for %d %s instance(s), quota is %d vCPUs", used, required, additionalInstances, instanceType, limit)
+	if used+required > limit {
+		return preflightFail, message
+	}
+	return preflightPass, message
+}
+
+// standardInstanceVCPUs returns the vCPUs of all pending and running standard instances in the region
+func standardInstanceVCPUs(ctx context.Context, awsClient aws.ContextClient) (int, error) {
+	vcpus := 0
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{{Name: awsSdk.String("instance-state-name"), Values: []string{"pending", "running"}}},
+	}
+	for {
+		out, err := awsClient.DescribeInstances(ctx, input)
+		if err != nil {
+			return 0, fmt.Errorf("failed to describe instances: %w", err)
+		}
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				if !isStandardInstanceType(string(instance.InstanceType)) || instance.CpuOptions == nil {
+					continue
+				}
+				vcpus += int(awsSdk.ToInt32(instance.CpuOptions.CoreCount) * awsSdk.ToInt32(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+		if out.NextToken == nil {
+			return vcpus, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// isStandardInstanceType returns true if the instance type counts against the standard instances quota
+func isStandardInstanceType(instanceType string) bool {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	if family == "" {
+		return false
+	}
+	for _, prefix := range []string{"inf", "dl", "trn", "u-"} {
+		if strings.HasPrefix(family, prefix) {
+			return false
+		}
+	}
+	return strings.ContainsRune("acdhimrtz", rune(family[0]))
+}
+
+// checkAvailabilityZones verifies the instance type is offered in all of the cluster's availability zones
+func checkAvailabilityZones(ctx context.Context, awsClient aws.ContextClient, instanceType string, zones []string) (preflightStatus, string) {
+	if len(zones) == 0 {
+		return preflightSkip, "the cluster has no availability zones in OCM"
+	}
+
+	offered := []string{}
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: ec2types.LocationTypeAvailabilityZone,
+		Filters: []ec2types.Filter{
+			{Name: awsSdk.String("instance-type"), Values: []string{instanceType}},
+			{Name: awsSdk.String("location"), Values: zones},
+		},
+	}
+	for {
+		out, err := awsClient.DescribeInstanceTypeOfferings(ctx, input)
+		if err != nil {
+			return preflightFail, fmt.Sprintf("failed to describe instance type offerings: %v", err)
+		}
+		for _, offering := range out.InstanceTypeOfferings {
+			offered = append(offered, awsSdk.ToString(offering.Location))
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	missing := []string{}
+	for _, zone := range zones {
+		if !slices.Contains(offered, zone) {
+			missing = append(missing, zone)
+		}
+	}
+	if len(missing) > 0 {
+		return preflightFail, fmt.Sprintf("%s is not offered in %s", instanceType, strings.Join(missing, ", "))
+	}
+	return preflightPass, fmt.Sprintf("%s is offered in %s", instanceType, strings.Join(zones, ", "))
+}
+
+// newPDBCheck warns about PodDisruptionBudgets which currently allow no disruptions, as they block draining nodes.
+// An empty namespace checks all namespaces.
+func newPDBCheck(c client.Client, namespace string) preflightCheck {
+	return preflightCheck{name: "PodDisruptionBudgets", run: func(ctx context.Context) (preflightStatus, string) {
+		pdbs := &policyv1.PodDisruptionBudgetList{}
+		if err := c.List(ctx, pdbs, client.InNamespace(namespace)); err != nil {
+			return preflightFail, fmt.Sprintf("failed to list PodDisruptionBudgets: %v", err)
+		}
+
+		blockers := []string{}
+		for _, pdb := range pdbs.Items {
+			if pdb.Status.ExpectedPods > 0 && pdb.Status.DisruptionsAllowed == 0 {
+				blockers = append(blockers, pdb.Namespace+"/"+pdb.Name)
+			}
+		}
+		if len(blockers) > 0 {
+			return preflightWarn, fmt.Sprintf("%d allow no disruptions and may block draining: %s", len(blockers), strings.Join(blockers, ", "))
+		}
+		return preflightPass, fmt.Sprintf("%d PodDisruptionBudgets allow disruptions", len(pdbs.Items))
+	}}
+}
+
+// newEtcdOperatorCheck verifies the etcd ClusterOperator is available and not degraded
+func newEtcdOperatorCheck(c client.Client) preflightCheck {
+	return preflightCheck{name: "etcd health", run: func(ctx context.Context) (preflightStatus, string) {
+		co := &configv1.ClusterOperator{}
+		if err := c.Get(ctx, client.ObjectKey{Name: "etcd"}, co); err != nil {
+			return preflightFail, fmt.Sprintf("failed to get the etcd ClusterOperator: %v", err)
+		}
+
+		for _, condition := range co.Status.Conditions {
+			switch {
+			case condition.Type == configv1.OperatorAvailable && condition.Status != configv1.ConditionTrue:
+				return preflightFail, fmt.Sprintf("etcd is not available: %s", condition.Message)
+			case condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue:
+				return preflightFail, fmt.Sprintf("etcd is degraded: %s", condition.Message)
+			}
+		}
+		return preflightPass, "etcd ClusterOperator is available and not degraded"
+	}}
+}
+
+// newEtcdPodsCheck verifies all etcd pods of a hosted control plane namespace are ready
+func newEtcdPodsCheck(c client.Client, namespace string) preflightCheck {
+	return preflightCheck{name: "etcd health", run: func(ctx context.Context) (preflightStatus, string) {
+		pods := &corev1.PodList{}
+		if err := c.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels{"app": "etcd"}); err != nil {
+			return preflightFail, fmt.Sprintf("failed to list etcd pods: %v", err)
+		}
+		if len(pods.Items) == 0 {
+			return preflightFail, fmt.Sprintf("no etcd pods found in %s", namespace)
+		}
+
+		notReady := []string{}
+		for _, pod := range pods.Items {
+			if !podReady(&pod) {
+				notReady = append(notReady, pod.Name)
+			}
+		}
+		if len(notReady) > 0 {
+			return preflightFail, fmt.Sprintf("etcd pods not ready: %s", strings.Join(notReady, ", "))
+		}
+		return preflightPass, fmt.Sprintf("%d etcd pods ready", len(pods.Items))
+	}}
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newNodeReadinessCheck verifies all nodes matching the label selector are Ready
+func newNodeReadinessCheck(c client.Client, selector client.ListOption) preflightCheck {
+	return preflightCheck{name: "Node readiness", run: func(ctx context.Context) (preflightStatus, string) {
+		nodes := &corev1.NodeList{}
+		if err := c.List(ctx, nodes, selector); err != nil {
+			return preflightFail, fmt.Sprintf("failed to list nodes: %v", err)
+		}
+		if len(nodes.Items) == 0 {
+			return preflightFail, fmt.Sprintf("no nodes found matching %v", selector)
+		}
+
+		notReady := []string{}
+		for _, node := range nodes.Items {
+			if !nodeReady(&node) {
+				notReady = append(notReady, node.Name)
+			}
+		}
+		if len(notReady) > 0 {
+			return preflightFail, fmt.Sprintf("nodes not ready: %s", strings.Join(notReady, ", "))
+		}
+		return preflightPass, fmt.Sprintf("%d nodes ready", len(nodes.Items))
+	}}
+}
+
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// printDryRunPatch prints the merge patch which turns original into modified
+func printDryRunPatch(w io.Writer, kind string, original, modified client.Object) error {
+	data, err := client.MergeFrom(original).Data(modified)
+	if err != nil {
+		return fmt.Errorf("failed to compute the %s patch: %v", kind, err)
+	}
+	out, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert the %s patch: %v", kind, err)
+	}
+
+	name := original.GetName()
+	if original.GetNamespace() != "" {
+		name = original.GetNamespace() + "/" + name
+	}
+	_, err = fmt.Fprintf(w, "%s %s would be patched with:\n\n%s\n", kind, name, out)
+	return err
+}
@@ -0,0 +1,240 @@
+package resize
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	sqtypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	configv1 "github.com/openshift/api/config/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/osdctl/pkg/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPreflightTestClient(t *testing.T, objs ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
+	require.NoError(t, configv1.Install(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+}
+
+func TestRunPreflightChecks(t *testing.T) {
+	check := func(name string, status preflightStatus) preflightCheck {
+		return preflightCheck{name: name, run: func(context.Context) (preflightStatus, string) { return status, "details" }}
+	}
+
+	var out bytes.Buffer
+	err := runPreflightChecks(context.Background(), &out, []preflightCheck{check("a", preflightPass), check("b", preflightWarn), check("c", preflightSkip)})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "WARN")
+
+	out.Reset()
+	err = runPreflightChecks(context.Background(), &out, []preflightCheck{check("a", preflightFail), check("b", preflightPass), check("c", preflightFail)})
+	assert.ErrorContains(t, err, "preflight checks failed: a, c")
+}
+
+func TestIsStandardInstanceType(t *testing.T) {
+	for instanceType, expected := range map[string]bool{
+		"m5.4xlarge":  true,
+		"r6i.2xlarge": true,
+		"c5.large":    true,
+		"inf1.xlarge": false,
+		"p3.2xlarge":  false,
+		"x2idn.large": false,
+		"":            false,
+	} {
+		assert.Equal(t, expected, isStandardInstanceType(instanceType), instanceType)
+	}
+}
+
+func TestCheckInstanceQuota(t *testing.T) {
+	tests := []struct {
+		name           string
+		quota          float64
+		expectedStatus preflightStatus
+	}{
+		{name: "within quota", quota: 96, expectedStatus: preflightPass},
+		{name: "exceeds quota", quota: 48, expectedStatus: preflightFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			awsClient := mock.NewMockContextClient(ctrl)
+
+			awsClient.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).Return(&ec2.DescribeInstanceTypesOutput{
+				InstanceTypes: []ec2types.InstanceTypeInfo{{VCpuInfo: &ec2types.VCpuInfo{DefaultVCpus: awsSdk.Int32(16)}}},
+			}, nil)
+			awsClient.EXPECT().GetServiceQuota(gomock.Any(), gomock.Any()).Return(&servicequotas.GetServiceQuotaOutput{
+				Quota: &sqtypes.ServiceQuota{Value: awsSdk.Float64(tt.quota)},
+			}, nil)
+			awsClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+					{InstanceType: "m5.2xlarge", CpuOptions: &ec2types.CpuOptions{CoreCount: awsSdk.Int32(4), ThreadsPerCore: awsSdk.Int32(2)}},
+					{InstanceType: "m5.2xlarge", CpuOptions: &ec2types.CpuOptions{CoreCount: awsSdk.Int32(4), ThreadsPerCore: awsSdk.Int32(2)}},
+					{InstanceType: "p3.2xlarge", CpuOptions: &ec2types.CpuOptions{CoreCount: awsSdk.Int32(4), ThreadsPerCore: awsSdk.Int32(2)}},
+				}}},
+			}, nil)
+
+			status, message := checkInstanceQuota(context.Background(), awsClient, "m5.4xlarge", 3)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Contains(t, message, "16 vCPUs in use + 48")
+		})
+	}
+}
+
+func TestCheckAvailabilityZones(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl := gomock.NewController(t)
+	awsClient := mock.NewMockContextClient(ctrl)
+	// The check's context is passed to AWS, so cancelling the resize cancels the call
+	awsClient.EXPECT().DescribeInstanceTypeOfferings(ctx, gomock.Any()).Return(&ec2.DescribeInstanceTypeOfferingsOutput{
+		InstanceTypeOfferings: []ec2types.InstanceTypeOffering{{Location: awsSdk.String("us-east-1a")}, {Location: awsSdk.String("us-east-1b")}},
+	}, nil).Times(2)
+
+	status, _ := checkAvailabilityZones(ctx, awsClient, "m5.4xlarge", []string{"us-east-1a", "us-east-1b"})
+	assert.Equal(t, preflightPass, status)
+
+	status, message := checkAvailabilityZones(ctx, awsClient, "m5.4xlarge", []string{"us-east-1a", "us-east-1f"})
+	assert.Equal(t, preflightFail, status)
+	assert.Equal(t, "m5.4xlarge is not offered in us-east-1f", message)
+
+	status, _ = checkAvailabilityZones(ctx, awsClient, "m5.4xlarge", nil)
+	assert.Equal(t, preflightSkip, status)
+}
+
+func TestPDBCheck(t *testing.T) {
+	c := newPreflightTestClient(t,
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "blocking", Namespace: "customer"},
+			Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: 1, DisruptionsAllowed: 0},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "fine", Namespace: "openshift-ingress"},
+			Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: 2, DisruptionsAllowed: 1},
+		},
+	)
+
+	status, message := newPDBCheck(c, "").run(context.Background())
+	assert.Equal(t, preflightWarn, status)
+	assert.Contains(t, message, "customer/blocking")
+
+	status, _ = newPDBCheck(c, "openshift-ingress").run(context.Background())
+	assert.Equal(t, preflightPass, status)
+}
+
+func TestEtcdOperatorCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		conditions     []configv1.ClusterOperatorStatusCondition
+		expectedStatus preflightStatus
+	}{
+		{
+			name: "healthy",
+			conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+			},
+			expectedStatus: preflightPass,
+		},
+		{
+			name: "degraded",
+			conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.OperatorDegraded, Status: configv1.ConditionTrue, Message: "member unhealthy"},
+			},
+			expectedStatus: preflightFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newPreflightTestClient(t, &configv1.ClusterOperator{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Status:     configv1.ClusterOperatorStatus{Conditions: tt.conditions},
+			})
+			status, _ := newEtcdOperatorCheck(c).run(context.Background())
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+
+	status, _ := newEtcdOperatorCheck(newPreflightTestClient(t)).run(context.Background())
+	assert.Equal(t, preflightFail, status)
+}
+
+func TestEtcdPodsCheck(t *testing.T) {
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ocm-production-123-hcp", Labels: map[string]string{"app": "etcd"}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+
+	c := newPreflightTestClient(t, pod("etcd-0", corev1.ConditionTrue), pod("etcd-1", corev1.ConditionFalse))
+	status, message := newEtcdPodsCheck(c, "ocm-production-123-hcp").run(context.Background())
+	assert.Equal(t, preflightFail, status)
+	assert.Equal(t, "etcd pods not ready: etcd-1", message)
+
+	status, _ = newEtcdPodsCheck(c, "other").run(context.Background())
+	assert.Equal(t, preflightFail, status)
+}
+
+func TestNodeReadinessCheck(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/master": ""}},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+
+	c := newPreflightTestClient(t, node("master-0", corev1.ConditionTrue), node("master-1", corev1.ConditionTrue))
+	status, message := newNodeReadinessCheck(c, client.HasLabels{"node-role.kubernetes.io/master"}).run(context.Background())
+	assert.Equal(t, preflightPass, status)
+	assert.Equal(t, "2 nodes ready", message)
+
+	c = newPreflightTestClient(t, node("master-0", corev1.ConditionTrue), node("master-1", corev1.ConditionUnknown))
+	status, message = newNodeReadinessCheck(c, client.HasLabels{"node-role.kubernetes.io/master"}).run(context.Background())
+	assert.Equal(t, preflightFail, status)
+	assert.Equal(t, "nodes not ready: master-1", message)
+
+	status, _ = newNodeReadinessCheck(c, client.HasLabels{"node-role.kubernetes.io/infra"}).run(context.Background())
+	assert.Equal(t, preflightFail, status)
+}
+
+func TestPrintMachinePoolPlan(t *testing.T) {
+	original := &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-infra", Namespace: "uhc-production-123", ResourceVersion: "42"},
+		Spec: hivev1.MachinePoolSpec{
+			Name:     "infra",
+			Replicas: ptr.To(int64(3)),
+			Platform: hivev1.MachinePoolPlatform{AWS: &hivev1aws.MachinePoolPlatform{InstanceType: "r5.xlarge"}},
+		},
+	}
+	newMp := original.DeepCopy()
+	newMp.ResourceVersion = ""
+	newMp.Spec.Platform.AWS.InstanceType = "r5.2xlarge"
+
+	var out bytes.Buffer
+	require.NoError(t, printMachinePoolPlan(&out, original, newMp))
+	assert.Contains(t, out.String(), "MachinePool uhc-production-123/cluster-infra would be patched with:")
+	assert.Contains(t, out.String(), "type: r5.2xlarge")
+	assert.NotContains(t, out.String(), "resourceVersion")
+	assert.Contains(t, out.String(), "create the temporary MachinePool cluster-infra2 with 3 replicas")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -13,6 +14,8 @@ import (
 	"github.com/openshift/osdctl/pkg/printer"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -30,6 +33,12 @@ type requestServingNodesOpts struct {
 	reason         string
 	removeOverride bool
 
+	// dryRun prints the preflight checks and the HostedCluster patch without applying it
+	dryRun bool
+
+	// skipPreflight doesn't run the preflight checks before resizing
+	skipPreflight bool
+
 	// mgmtClient is a K8s client to management cluster
 	mgmtClient client.Client
 
@@ -52,7 +61,11 @@ func newCmdResizeRequestServingNodes() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "request-serving-nodes",
 		Short: "Resize a ROSA HCP cluster's request-serving nodes",
-		Long:  `Resize a ROSA HCP cluster's request-serving nodes by applying a cluster-size-override annotation`,
+		Long: `Resize a ROSA HCP cluster's request-serving nodes by applying a cluster-size-override annotation
+
+  Before resizing, preflight checks verify the health of the hosted control plane's etcd, the readiness of the
+  cluster's request-serving nodes and PodDisruptionBudgets which block draining. Use --dry-run to only run the checks
+  and print the HostedCluster patch.`,
 		Example: `
   # Resize a ROSA HCP cluster's request-serving nodes to the next size
   osdctl cluster resize request-serving-nodes --cluster-id "${CLUSTER_ID}" --reason "${OHSS}"
@@ -62,6 +75,9 @@ func newCmdResizeRequestServingNodes() *cobra.Command {
 
   # Remove the cluster-size-override annotation to revert to default sizing behavior
   osdctl cluster resize request-serving-nodes --cluster-id "${CLUSTER_ID}" --remove-override --reason "${OHSS}"
+
+  # Run the preflight checks and show the HostedCluster patch without applying it
+  osdctl cluster resize request-serving-nodes --cluster-id "${CLUSTER_ID}" --size m54xl --reason "${OHSS}" --dry-run
 `,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
@@ -74,6 +90,8 @@ func newCmdResizeRequestServingNodes() *cobra.Command {
 	cmd.Flags().StringVar(&opts.size, "size", "", "The target request-serving node size (e.g. m54xl). If not specified, will auto-select the next size up")
 	cmd.Flags().StringVar(&opts.reason, "reason", "", "The reason for this command, which requires elevation, to be run (usually an OHSS or PD ticket)")
 	cmd.Flags().BoolVar(&opts.removeOverride, "remove-override", false, "Remove the cluster-size-override annotation to revert to default sizing behavior")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Run the preflight checks and print the HostedCluster patch without applying it")
+	cmd.Flags().BoolVar(&opts.skipPreflight, "skip-preflight", false, "Don't run the preflight checks before resizing")
 	_ = cmd.MarkFlagRequired("cluster-id")
 	_ = cmd.MarkFlagRequired("reason")
 	cmd.MarkFlagsMutuallyExclusive("size", "remove-override")
@@ -124,6 +142,13 @@ func (r *requestServingNodesOpts) run(ctx context.Context) error {
 	if err := hypershiftv1beta1.AddToScheme(scheme); err != nil {
 		return fmt.Errorf("failed to add hypershift scheme: %v", err)
 	}
+	// Register the types inspected by the preflight checks
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add core scheme: %v", err)
+	}
+	if err := policyv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to add policy scheme: %v", err)
+	}
 
 	// Create client to management cluster using backplane SDK
 	printer.PrintlnGreen("Creating management cluster client...")
@@ -223,6 +248,28 @@ func (r *requestServingNodesOpts) run(ctx context.Context) error {
 		return fmt.Errorf("target size '%s' is the same as current size '%s'. No resize needed", targetSize, currentSize)
 	}
 
+	var preflightErr error
+	if !r.skipPreflight {
+		fmt.Println()
+		preflightErr = runPreflightChecks(ctx, os.Stdout, r.preflightChecks(hcpNamespace, hcNamespace))
+		if preflightErr != nil && !r.dryRun {
+			return preflightErr
+		}
+	}
+
+	if r.dryRun {
+		desired := hostedCluster.DeepCopy()
+		if desired.Annotations == nil {
+			desired.Annotations = map[string]string{}
+		}
+		desired.Annotations["hypershift.openshift.io/cluster-size-override"] = targetSize
+		if err := printDryRunPatch(os.Stdout, "HostedCluster", hostedCluster, desired); err != nil {
+			return err
+		}
+		fmt.Printf("Service log %s would be sent\n", resizeRequestServingServiceLogTemplate)
+		return preflightErr
+	}
+
 	// Prompt user to confirm
 	fmt.Printf("\nThis will resize cluster %s from %s to %s\n", cluster.Name(), currentSize, targetSize)
 	if !utils.ConfirmPrompt() {
@@ -272,6 +319,15 @@ func (r *requestServingNodesOpts) run(ctx context.Context) error {
 	return nil
 }
 
+// preflightChecks returns the checks run before applying the cluster-size-override annotation
+func (r *requestServingNodesOpts) preflightChecks(hcpNamespace, hcNamespace string) []preflightCheck {
+	return []preflightCheck{
+		newEtcdPodsCheck(r.mgmtClient, hcpNamespace),
+		newNodeReadinessCheck(r.mgmtClient, client.MatchingLabels{"hypershift.openshift.io/cluster-namespace": hcNamespace}),
+		newPDBCheck(r.mgmtClient, hcpNamespace),
+	}
+}
+
 func (r *requestServingNodesOpts) findHostedCluster(ctx context.Context, clusterID string) (*hypershiftv1beta1.HostedCluster, error) {
 	// Search for the HostedCluster across all namespaces using the label selector
 	hostedClusterList := &hypershiftv1beta1.HostedClusterList{}
@@ -436,6 +492,12 @@ func (r *requestServingNodesOpts) handleRemoveOverride(ctx context.Context, host
 		printer.PrintlnGreen(fmt.Sprintf("Recommended cluster size: %s", currentRecommendatation))
 	}
 
+	if r.dryRun {
+		desired := hostedCluster.DeepCopy()
+		delete(desired.Annotations, overrideAnnotation)
+		return printDryRunPatch(os.Stdout, "HostedCluster", hostedCluster, desired)
+	}
+
 	fmt.Printf("\nThis will remove the cluster-size-override annotation from cluster %s\n", clusterName)
 	if hasRecommendatation && currentRecommendatation != "" {
 		fmt.Printf("The cluster will revert to the recommended size: %s\n", currentRecommendatation)
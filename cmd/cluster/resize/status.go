@@ -0,0 +1,121 @@
+package resize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/openshift/osdctl/pkg/k8s"
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type resizeStatusOptions struct {
+	clusterID string
+
+	// offline only prints the local state file without querying the cluster
+	offline bool
+
+	client client.Client
+	output io.Writer
+}
+
+func newCmdResizeStatus() *cobra.Command {
+	ops := &resizeStatusOptions{output: os.Stdout}
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the progress of a cluster's control plane resize",
+		Long: `Show the progress of a cluster's control plane resize
+
+  Prints the progress recorded by "osdctl cluster resize control-plane" in its local state file. Unless --offline is
+  passed, the control plane machines are inspected to refresh the machines replaced so far, which requires previous
+  login to the api server via "ocm backplane login".`,
+		Example: `
+  # Show the progress of the control plane resize of a cluster
+  osdctl cluster resize status -C "${CLUSTER_ID}"`,
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ops.run(cmd.Context())
+		},
+	}
+	statusCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "The internal ID of the cluster to show the resize of")
+	statusCmd.Flags().BoolVar(&ops.offline, "offline", false, "Only print the local state file without inspecting the cluster")
+	_ = statusCmd.MarkFlagRequired("cluster-id")
+
+	return statusCmd
+}
+
+func (o *resizeStatusOptions) run(ctx context.Context) error {
+	if err := utils.IsValidClusterKey(o.clusterID); err != nil {
+		return err
+	}
+
+	if !o.offline {
+		connection, err := utils.CreateConnection()
+		if err != nil {
+			return err
+		}
+		defer connection.Close()
+
+		cluster, err := utils.GetCluster(connection, o.clusterID)
+		if err != nil {
+			return err
+		}
+		// The state file is keyed by the internal OCM cluster id
+		o.clusterID = cluster.ID()
+	}
+
+	path, err := controlPlaneStatePath(o.clusterID)
+	if err != nil {
+		return err
+	}
+	state, err := loadControlPlaneState(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w for cluster %s", errNoResizeState, o.clusterID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !o.offline && state.Step == stepRollout {
+		if o.client == nil {
+			scheme := runtime.NewScheme()
+			if err := machinev1.Install(scheme); err != nil {
+				return err
+			}
+			if err := machinev1beta1.Install(scheme); err != nil {
+				return err
+			}
+			o.client, err = k8s.New(o.clusterID, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+		}
+		if err := refreshControlPlaneState(ctx, o.client, state); err != nil {
+			return err
+		}
+	}
+
+	return printControlPlaneState(o.output, state)
+}
+
+// refreshControlPlaneState records the machines replaced so far, completing the resize once all were replaced
+func refreshControlPlaneState(ctx context.Context, c client.Client, state *controlPlaneResizeState) error {
+	progress, err := getRolloutProgress(ctx, c, state.NewMachineType)
+	if err != nil {
+		return err
+	}
+
+	state.MachinesReplaced = progress.replaced
+	if progress.done() {
+		state.Step = stepCompleted
+	}
+	return state.save()
+}
@@ -0,0 +1,163 @@
+package support
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/internal/io"
+	"github.com/openshift/osdctl/pkg/printer"
+	ctlutil "github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// clusterTargets selects the clusters a limited support command acts on: a single cluster,
+// the clusters matching OCM search queries, or the clusters listed in a clusters file
+type clusterTargets struct {
+	clusterID    string
+	queries      []string
+	clustersFile string
+}
+
+func (t *clusterTargets) addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&t.queries, "query", "q", []string{}, "Specify a search query (eg. -q \"name like foo\") to act on all matching clusters")
+	cmd.Flags().StringVar(&t.clustersFile, "clusters-file", "", `Read a list of clusters to act on. The format of the file is: {"clusters":["$CLUSTERID"]}`)
+}
+
+// isBulk returns true when the targets were selected with a query or a clusters file
+func (t *clusterTargets) isBulk() bool {
+	return len(t.queries) > 0 || t.clustersFile != ""
+}
+
+func (t *clusterTargets) validate() error {
+	if t.clusterID == "" && !t.isBulk() {
+		return fmt.Errorf("no cluster identifier has been found, please specify --cluster-id, --query or --clusters-file")
+	}
+	if t.clusterID != "" && t.isBulk() {
+		return fmt.Errorf("--cluster-id cannot be combined with --query or --clusters-file")
+	}
+	return nil
+}
+
+// filters returns the OCM search filters matching the targets
+func (t *clusterTargets) filters() ([]string, error) {
+	filters := append([]string{}, t.queries...)
+
+	var clusterQueries []string
+	if t.clustersFile != "" {
+		clusterIDs, err := io.ParseAndValidateClustersFile(t.clustersFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse clusters file %s: %w", t.clustersFile, err)
+		}
+		for _, clusterID := range clusterIDs {
+			clusterQueries = append(clusterQueries, ctlutil.GenerateQuery(clusterID))
+		}
+	}
+	if t.clusterID != "" {
+		clusterQueries = append(clusterQueries, ctlutil.GenerateQuery(t.clusterID))
+	}
+	if len(clusterQueries) > 0 {
+		filters = append(filters, strings.Join(clusterQueries, " or "))
+	}
+
+	return filters, nil
+}
+
+// resolve returns the clusters matching the targets
+func (t *clusterTargets) resolve(connection *sdk.Connection) ([]*cmv1.Cluster, error) {
+	if !t.isBulk() {
+		if err := ctlutil.IsValidClusterKey(t.clusterID); err != nil {
+			return nil, err
+		}
+		cluster, err := ctlutil.GetCluster(connection, t.clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("can't retrieve cluster: %w", err)
+		}
+		return []*cmv1.Cluster{cluster}, nil
+	}
+
+	filters, err := t.filters()
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := ctlutil.ApplyFilters(connection, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for clusters with provided filters (%v): %w", filters, err)
+	}
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("no clusters match the given filters (%v)", filters)
+	}
+
+	return clusters, nil
+}
+
+// bulkResult records the outcome of a limited support operation per cluster
+type bulkResult struct {
+	succeeded map[string]string
+	failed    map[string]string
+}
+
+func newBulkResult() *bulkResult {
+	return &bulkResult{
+		succeeded: map[string]string{},
+		failed:    map[string]string{},
+	}
+}
+
+func (r *bulkResult) success(clusterID, message string) {
+	r.succeeded[clusterID] = message
+}
+
+func (r *bulkResult) failure(clusterID string, err error) {
+	r.failed[clusterID] = err.Error()
+}
+
+func (r *bulkResult) err() error {
+	if len(r.failed) > 0 {
+		return fmt.Errorf("failed on %d cluster(s)", len(r.failed))
+	}
+	return nil
+}
+
+func (r *bulkResult) print() {
+	table := printer.NewTablePrinter(os.Stdout, 20, 1, 3, ' ')
+	table.AddRow([]string{"Cluster ID", "Result", "Details"})
+	for _, row := range sortedResultRows(r.succeeded, "success") {
+		table.AddRow(row)
+	}
+	for _, row := range sortedResultRows(r.failed, "failed") {
+		table.AddRow(row)
+	}
+	table.AddRow([]string{})
+	if err := table.Flush(); err != nil {
+		fmt.Println("error while flushing table: ", err.Error())
+	}
+}
+
+func sortedResultRows(results map[string]string, result string) [][]string {
+	clusterIDs := make([]string, 0, len(results))
+	for clusterID := range results {
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+	sort.Strings(clusterIDs)
+
+	rows := make([][]string, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		rows = append(rows, []string{clusterID, result, results[clusterID]})
+	}
+	return rows
+}
+
+func printTargetClusters(clusters []*cmv1.Cluster) error {
+	table := printer.NewTablePrinter(os.Stdout, 20, 1, 3, ' ')
+	table.AddRow([]string{"Cluster ID", "External ID", "Name"})
+	for _, cluster := range clusters {
+		table.AddRow([]string{cluster.ID(), cluster.ExternalID(), cluster.Name()})
+	}
+	table.AddRow([]string{})
+	return table.Flush()
+}
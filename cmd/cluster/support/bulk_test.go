@@ -0,0 +1,64 @@
+package support
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterTargetsValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		targets   clusterTargets
+		expectErr bool
+	}{
+		{
+			name:      "no target",
+			targets:   clusterTargets{},
+			expectErr: true,
+		},
+		{
+			name:    "single cluster",
+			targets: clusterTargets{clusterID: "cluster-1"},
+		},
+		{
+			name:    "query",
+			targets: clusterTargets{queries: []string{"name like 'foo%'"}},
+		},
+		{
+			name:      "cluster ID combined with a clusters file",
+			targets:   clusterTargets{clusterID: "cluster-1", clustersFile: "clusters.json"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.targets.validate()
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClusterTargetsFilters(t *testing.T) {
+	clustersFile := filepath.Join(t.TempDir(), "clusters.json")
+	assert.NoError(t, os.WriteFile(clustersFile, []byte(`{"clusters":["1a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p","my-cluster"]}`), 0600))
+
+	targets := clusterTargets{
+		queries:      []string{"product.id = 'rosa'"},
+		clustersFile: clustersFile,
+	}
+
+	filters, err := targets.filters()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"product.id = 'rosa'",
+		"(id = '1a2b3c4d5e6f7g8h9i0j1k2l3m4n5o6p') or (display_name like 'my-cluster')",
+	}, filters)
+}
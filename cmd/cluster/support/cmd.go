@@ -12,6 +12,7 @@ import (
 // osdctl cluster support status
 // osdctl cluster support create --summary="" --reason=""
 // osdctl cluster support delete --reason=""
+// osdctl cluster support history --cluster-id=""
 func NewCmdSupport(streams genericclioptions.IOStreams, client client.Client, globalOpts *globalflags.GlobalOptions) *cobra.Command {
 	supportCmd := &cobra.Command{
 		Use:               "support",
@@ -24,6 +25,7 @@ func NewCmdSupport(streams genericclioptions.IOStreams, client client.Client, gl
 	supportCmd.AddCommand(newCmdstatus(streams, globalOpts))
 	supportCmd.AddCommand(newCmdPost())
 	supportCmd.AddCommand(newCmddelete(streams, globalOpts))
+	supportCmd.AddCommand(newCmdHistory())
 
 	return supportCmd
 }
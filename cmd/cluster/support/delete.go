@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osdctl/internal/support"
 	"github.com/openshift/osdctl/internal/utils/globalflags"
+	"github.com/openshift/osdctl/pkg/printer"
 	"github.com/openshift/osdctl/pkg/utils"
 	ctlutil "github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
@@ -24,7 +27,12 @@ type deleteOptions struct {
 	clusterID              string
 	limitedSupportReasonID string
 	removeAll              bool
+	allMatching            string
 	isDryRun               bool
+	skipPrompts            bool
+	targets                clusterTargets
+
+	allMatchingRegexp *regexp.Regexp
 
 	genericclioptions.IOStreams
 	GlobalOptions *globalflags.GlobalOptions
@@ -34,8 +42,10 @@ func newCmddelete(streams genericclioptions.IOStreams, globalOpts *globalflags.G
 
 	ops := newDeleteOptions(streams, globalOpts)
 	deleteCmd := &cobra.Command{
-		Use:               "delete --cluster-id <cluster-identifier>",
-		Short:             "Delete specified limited support reason for a given cluster",
+		Use:   "delete --cluster-id <cluster-identifier>",
+		Short: "Delete specified limited support reason for a given cluster",
+		Example: `# Remove every limited support reason whose summary mentions ingress from the clusters matching a query, previewing it first
+osdctl cluster support delete -q "name like 'my-clusters%'" --all-matching "(?i)ingress" --dry-run`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -45,14 +55,14 @@ func newCmddelete(streams genericclioptions.IOStreams, globalOpts *globalflags.G
 	}
 
 	// Defined required flags
-	deleteCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "Internal cluster ID (required)")
+	deleteCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "Internal cluster ID")
 	deleteCmd.Flags().BoolVar(&ops.removeAll, "all", false, "Remove all limited support reasons")
+	deleteCmd.Flags().StringVar(&ops.allMatching, "all-matching", "", "Remove all limited support reasons whose summary matches the given regular expression")
 	deleteCmd.Flags().StringVarP(&ops.limitedSupportReasonID, "limited-support-reason-id", "i", "", "Limited support reason ID")
-	deleteCmd.Flags().BoolVarP(&ops.isDryRun, "dry-run", "d", false, "Dry-run - print the limited support reason about to be sent but don't send it.")
+	deleteCmd.Flags().BoolVarP(&ops.isDryRun, "dry-run", "d", false, "Dry-run - print the limited support reasons about to be deleted but don't delete them.")
+	deleteCmd.Flags().BoolVarP(&ops.skipPrompts, "yes", "y", false, "Skip the confirmation prompt")
 	deleteCmd.Flags().BoolVarP(&ops.verbose, "verbose", "", false, "Verbose output")
-
-	// Mark cluster-id as required
-	_ = deleteCmd.MarkFlagRequired("cluster-id")
+	ops.targets.addBulkFlags(deleteCmd)
 
 	return deleteCmd
 }
@@ -70,20 +80,41 @@ func (o *deleteOptions) complete(cmd *cobra.Command, args []string) error {
 	if o.limitedSupportReasonID != "" && o.removeAll {
 		return cmdutil.UsageErrorf(cmd, "Cannot provide a reason ID with the `all` flag. Please provide one or the other.")
 	}
+	if o.allMatching != "" && (o.limitedSupportReasonID != "" || o.removeAll) {
+		return cmdutil.UsageErrorf(cmd, "Cannot combine `all-matching` with a reason ID or the `all` flag.")
+	}
+
+	o.targets.clusterID = o.clusterID
+	if err := o.targets.validate(); err != nil {
+		return cmdutil.UsageErrorf(cmd, "%s", err.Error())
+	}
+	if o.targets.isBulk() && o.limitedSupportReasonID != "" {
+		return cmdutil.UsageErrorf(cmd, "Reason IDs are specific to a cluster, use `all` or `all-matching` when deleting from multiple clusters.")
+	}
+	if o.targets.isBulk() && !o.removeAll && o.allMatching == "" {
+		return cmdutil.UsageErrorf(cmd, "Specify the limited support reasons to delete with `all` or `all-matching` when deleting from multiple clusters.")
+	}
+
+	if o.allMatching != "" {
+		allMatchingRegexp, err := regexp.Compile(o.allMatching)
+		if err != nil {
+			return cmdutil.UsageErrorf(cmd, "Invalid `all-matching` regular expression: %v", err)
+		}
+		o.allMatchingRegexp = allMatchingRegexp
+	}
 
 	o.output = o.GlobalOptions.Output
 
 	return nil
 }
 
-func (o *deleteOptions) run() error {
+// reasonsToDelete is the set of limited support reasons to remove from a single cluster
+type reasonsToDelete struct {
+	cluster *v1.Cluster
+	reasons []*v1.LimitedSupportReason
+}
 
-	// Check that the cluster key (name, identifier or external identifier) given by the user
-	// is reasonably safe so that there is no risk of SQL injection
-	err := ctlutil.IsValidClusterKey(o.clusterID)
-	if err != nil {
-		return err
-	}
+func (o *deleteOptions) run() error {
 
 	// Create an OCM client to talk to the cluster API
 	connection, err := ctlutil.CreateConnection()
@@ -97,48 +128,114 @@ func (o *deleteOptions) run() error {
 		}
 	}()
 
+	clusters, err := o.targets.resolve(connection)
+	if err != nil {
+		return err
+	}
+
+	var plan []reasonsToDelete
+	for _, cluster := range clusters {
+		limitedSupportReasons, err := ctlutil.GetClusterLimitedSupportReasons(connection, cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Can't retrieve cluster limited support reasons: %v\n", err)
+		}
+
+		reasons, err := o.selectReasons(limitedSupportReasons)
+		if err != nil {
+			if o.targets.isBulk() {
+				fmt.Printf("Skipping cluster %s: %v", cluster.ID(), err)
+				continue
+			}
+			return err
+		}
+		if len(reasons) > 0 {
+			plan = append(plan, reasonsToDelete{cluster: cluster, reasons: reasons})
+		}
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("No limited support reasons to delete")
+		return nil
+	}
+
+	if err := printDeletePlan(plan); err != nil {
+		return err
+	}
+
 	// Stop here if dry-run
 	if o.isDryRun {
 		return nil
 	}
 
 	// confirmSend prompt to confirm
-	if !utils.ConfirmPrompt() {
+	if !o.skipPrompts && !utils.ConfirmPrompt() {
 		return nil
 	}
 
-	//getting the cluster
-	cluster, err := ctlutil.GetCluster(connection, o.clusterID)
-	if err != nil {
-		return fmt.Errorf("Can't retrieve cluster: %v\n", err)
+	result := newBulkResult()
+	for _, entry := range plan {
+		var deleted []string
+		for _, reason := range entry.reasons {
+			if err := deleteLimitedSupportReason(connection, entry.cluster, reason.ID()); err != nil {
+				result.failure(entry.cluster.ID(), err)
+				break
+			}
+			deleted = append(deleted, reason.ID())
+		}
+		if _, failed := result.failed[entry.cluster.ID()]; !failed {
+			result.success(entry.cluster.ID(), fmt.Sprintf("deleted %s", strings.Join(deleted, ",")))
+		}
 	}
 
-	/*conditions to check the presence of --all & -i flags;
-	also, checking if there is one or more limited support resonID before deleting the same */
-	var limitedSupportReasonIds []string
-	limitedSupportReasons, err := getLimitedSupportReasons(o.clusterID)
+	if o.targets.isBulk() {
+		result.print()
+	}
+	return result.err()
+}
 
+// selectReasons returns the limited support reasons of a cluster which should be deleted according to the flags
+func (o *deleteOptions) selectReasons(limitedSupportReasons []*v1.LimitedSupportReason) ([]*v1.LimitedSupportReason, error) {
 	if len(limitedSupportReasons) == 0 {
-		return fmt.Errorf("Cluster is not in limited support. \n")
+		return nil, fmt.Errorf("Cluster is not in limited support. \n")
 	}
 
-	if o.removeAll || (len(limitedSupportReasons) > 1 && o.limitedSupportReasonID == "") {
-		if !o.removeAll && o.limitedSupportReasonID == "" {
-			return fmt.Errorf("This cluster has multiple limited support reason IDs.\nPlease specify the exact reason ID or the `all` flag \n")
-		}
-		for _, limitedSupportReason := range limitedSupportReasons {
-			err = deleteLimitedSupportReason(connection, cluster, limitedSupportReason.ID())
+	switch {
+	case o.removeAll:
+		return limitedSupportReasons, nil
+	case o.allMatchingRegexp != nil:
+		var matching []*v1.LimitedSupportReason
+		for _, reason := range limitedSupportReasons {
+			if o.allMatchingRegexp.MatchString(reason.Summary()) {
+				matching = append(matching, reason)
+			}
 		}
-	} else {
-		if len(limitedSupportReasons) == 1 {
-			o.limitedSupportReasonID = limitedSupportReasons[0].ID()
+		return matching, nil
+	case o.limitedSupportReasonID != "":
+		for _, reason := range limitedSupportReasons {
+			if reason.ID() == o.limitedSupportReasonID {
+				return []*v1.LimitedSupportReason{reason}, nil
+			}
 		}
-		limitedSupportReasonIds = append(limitedSupportReasonIds, o.limitedSupportReasonID)
-		for _, limitedSupportReasonId := range limitedSupportReasonIds {
-			err = deleteLimitedSupportReason(connection, cluster, limitedSupportReasonId)
+		return nil, fmt.Errorf("Limited support reason %s not found on the cluster\n", o.limitedSupportReasonID)
+	case len(limitedSupportReasons) == 1 && !o.targets.isBulk():
+		// Only a single cluster's only reason is deleted without selecting it, never every cluster's of a fleet
+		return limitedSupportReasons, nil
+	default:
+		return nil, fmt.Errorf("This cluster has multiple limited support reason IDs.\nPlease specify the exact reason ID or the `all` flag \n")
+	}
+}
+
+func printDeletePlan(plan []reasonsToDelete) error {
+	fmt.Println("The following limited support reasons will be deleted:")
+	table := printer.NewTablePrinter(os.Stdout, 20, 1, 3, ' ')
+	table.AddRow([]string{"Cluster ID", "Reason ID", "Summary"})
+	for _, entry := range plan {
+		for _, reason := range entry.reasons {
+			table.AddRow([]string{entry.cluster.ID(), reason.ID(), reason.Summary()})
 		}
 	}
-	return err
+	table.AddRow([]string{})
+	return table.Flush()
 }
 
 func deleteLimitedSupportReason(connection SDKConnection, cluster *v1.Cluster, reasonID string) (err error) {
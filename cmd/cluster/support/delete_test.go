@@ -3,12 +3,15 @@ package support
 import (
 	"net/http"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"unsafe"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/internal/utils/globalflags"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -119,3 +122,126 @@ func createMockResponse(status int, body []byte, header http.Header) *sdk.Respon
 
 	return resp
 }
+
+func TestSelectReasons(t *testing.T) {
+	ingress, _ := cmv1.NewLimitedSupportReason().ID("ls-1").Summary("Unsupported ingress controller").Build()
+	network, _ := cmv1.NewLimitedSupportReason().ID("ls-2").Summary("Unsupported network configuration").Build()
+
+	tests := []struct {
+		name        string
+		opts        *deleteOptions
+		reasons     []*cmv1.LimitedSupportReason
+		expectedIDs []string
+		expectErr   bool
+	}{
+		{
+			name:      "cluster not in limited support",
+			opts:      &deleteOptions{},
+			reasons:   nil,
+			expectErr: true,
+		},
+		{
+			name:        "single reason is selected by default",
+			opts:        &deleteOptions{},
+			reasons:     []*cmv1.LimitedSupportReason{ingress},
+			expectedIDs: []string{"ls-1"},
+		},
+		{
+			name:      "single reason requires a selection in bulk mode",
+			opts:      &deleteOptions{targets: clusterTargets{queries: []string{"name like 'foo%'"}}},
+			reasons:   []*cmv1.LimitedSupportReason{ingress},
+			expectErr: true,
+		},
+		{
+			name:      "multiple reasons require a selection",
+			opts:      &deleteOptions{},
+			reasons:   []*cmv1.LimitedSupportReason{ingress, network},
+			expectErr: true,
+		},
+		{
+			name:        "all reasons",
+			opts:        &deleteOptions{removeAll: true},
+			reasons:     []*cmv1.LimitedSupportReason{ingress, network},
+			expectedIDs: []string{"ls-1", "ls-2"},
+		},
+		{
+			name:        "reason by ID",
+			opts:        &deleteOptions{limitedSupportReasonID: "ls-2"},
+			reasons:     []*cmv1.LimitedSupportReason{ingress, network},
+			expectedIDs: []string{"ls-2"},
+		},
+		{
+			name:      "unknown reason ID",
+			opts:      &deleteOptions{limitedSupportReasonID: "ls-3"},
+			reasons:   []*cmv1.LimitedSupportReason{ingress, network},
+			expectErr: true,
+		},
+		{
+			name:        "reasons matching summary",
+			opts:        &deleteOptions{allMatchingRegexp: regexp.MustCompile("(?i)INGRESS")},
+			reasons:     []*cmv1.LimitedSupportReason{ingress, network},
+			expectedIDs: []string{"ls-1"},
+		},
+		{
+			name:        "no reason matching summary",
+			opts:        &deleteOptions{allMatchingRegexp: regexp.MustCompile("etcd")},
+			reasons:     []*cmv1.LimitedSupportReason{ingress, network},
+			expectedIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons, err := tt.opts.selectReasons(tt.reasons)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, reason := range reasons {
+				ids = append(ids, reason.ID())
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestDeleteOptionsCompleteBulkRequiresSelection(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *deleteOptions
+		expectErr bool
+	}{
+		{
+			name:      "bulk without a selection",
+			opts:      &deleteOptions{targets: clusterTargets{queries: []string{"name like 'foo%'"}}},
+			expectErr: true,
+		},
+		{
+			name: "bulk with all",
+			opts: &deleteOptions{removeAll: true, targets: clusterTargets{queries: []string{"name like 'foo%'"}}},
+		},
+		{
+			name: "bulk with all-matching",
+			opts: &deleteOptions{allMatching: "ingress", targets: clusterTargets{clustersFile: "clusters.json"}},
+		},
+		{
+			name: "single cluster without a selection",
+			opts: &deleteOptions{clusterID: "cluster-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.GlobalOptions = &globalflags.GlobalOptions{}
+			err := tt.opts.complete(&cobra.Command{Use: "delete"}, nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
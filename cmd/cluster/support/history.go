@@ -0,0 +1,180 @@
+package support
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/openshift/osdctl/cmd/servicelog"
+	"github.com/openshift/osdctl/pkg/printer"
+	ctlutil "github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	historyStatusActive  = "active"
+	historyStatusRemoved = "removed"
+	historyUnknown       = "unknown"
+)
+
+type historyOptions struct {
+	clusterID string
+}
+
+// limitedSupportHistoryEntry is a limited support reason which is, or was, set on a cluster
+type limitedSupportHistoryEntry struct {
+	ReasonID      string
+	Summary       string
+	Status        string
+	PostedAt      time.Time
+	PostedBy      string
+	Evidence      string
+	ServiceLogIDs []string
+}
+
+func newCmdHistory() *cobra.Command {
+	ops := &historyOptions{}
+	historyCmd := &cobra.Command{
+		Use:   "history --cluster-id <cluster-identifier>",
+		Short: "Shows every limited support reason ever posted to a cluster",
+		Long: `Shows every limited support reason ever posted to a cluster, including reasons which have since been removed.
+
+Reasons are correlated with the internal service logs created by 'osdctl cluster support post --evidence', which record who posted the reason and why.
+Reasons removed from the cluster can only be shown if such an internal service log exists.`,
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(ops.run())
+		},
+	}
+
+	historyCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "Cluster ID for which to show the limited support history")
+	_ = historyCmd.MarkFlagRequired("cluster-id")
+
+	return historyCmd
+}
+
+func (o *historyOptions) run() error {
+	if err := ctlutil.IsValidClusterKey(o.clusterID); err != nil {
+		return err
+	}
+
+	reasons, err := getLimitedSupportReasons(o.clusterID)
+	if err != nil {
+		return err
+	}
+
+	serviceLogs, err := servicelog.FetchServiceLogs(o.clusterID, false, true)
+	if err != nil {
+		return err
+	}
+
+	return printLimitedSupportHistory(os.Stdout, buildLimitedSupportHistory(reasons, serviceLogs.Items().Slice()))
+}
+
+// buildLimitedSupportHistory merges the currently active limited support reasons with the
+// internal evidence service logs, which reference the limited support reason they were posted for
+func buildLimitedSupportHistory(reasons []*cmv1.LimitedSupportReason, serviceLogs []*slv1.LogEntry) []*limitedSupportHistoryEntry {
+	entries := map[string]*limitedSupportHistoryEntry{}
+
+	for _, reason := range reasons {
+		entries[reason.ID()] = &limitedSupportHistoryEntry{
+			ReasonID: reason.ID(),
+			Summary:  reason.Summary(),
+			Status:   historyStatusActive,
+			PostedAt: reason.CreationTimestamp(),
+			PostedBy: historyUnknown,
+		}
+	}
+
+	for _, serviceLog := range serviceLogs {
+		reasonID, evidence, ok := parseEvidenceServiceLog(serviceLog)
+		if !ok {
+			continue
+		}
+
+		entry, found := entries[reasonID]
+		if !found {
+			entry = &limitedSupportHistoryEntry{
+				ReasonID: reasonID,
+				Summary:  historyUnknown,
+				Status:   historyStatusRemoved,
+				PostedAt: serviceLog.Timestamp(),
+			}
+			entries[reasonID] = entry
+		}
+
+		entry.PostedBy = serviceLogAuthor(serviceLog)
+		entry.Evidence = evidence
+		entry.ServiceLogIDs = append(entry.ServiceLogIDs, serviceLog.ID())
+	}
+
+	history := make([]*limitedSupportHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, entry)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].PostedAt.Equal(history[j].PostedAt) {
+			return history[i].ReasonID < history[j].ReasonID
+		}
+		return history[i].PostedAt.After(history[j].PostedAt)
+	})
+
+	return history
+}
+
+// parseEvidenceServiceLog returns the limited support reason ID and evidence from an internal
+// service log built by buildInternalServiceLog
+func parseEvidenceServiceLog(serviceLog *slv1.LogEntry) (reasonID string, evidence string, ok bool) {
+	if serviceLog.ServiceName() != InternalServiceLogServiceName || serviceLog.Summary() != InternalServiceLogSummary {
+		return "", "", false
+	}
+
+	reasonID, evidence, found := strings.Cut(serviceLog.Description(), " - ")
+	if !found || reasonID == "" {
+		return "", "", false
+	}
+
+	return reasonID, evidence, true
+}
+
+func serviceLogAuthor(serviceLog *slv1.LogEntry) string {
+	if serviceLog.Username() != "" {
+		return serviceLog.Username()
+	}
+	if serviceLog.CreatedBy() != "" {
+		return serviceLog.CreatedBy()
+	}
+	return historyUnknown
+}
+
+func printLimitedSupportHistory(w io.Writer, history []*limitedSupportHistoryEntry) error {
+	if len(history) == 0 {
+		_, err := fmt.Fprintln(w, "No limited support reasons have been posted to this cluster")
+		return err
+	}
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Reason ID", "Status", "Posted At", "Posted By", "Summary", "Evidence", "Service Logs"})
+	for _, entry := range history {
+		table.AddRow([]string{
+			entry.ReasonID,
+			entry.Status,
+			entry.PostedAt.UTC().Format(time.RFC3339),
+			entry.PostedBy,
+			entry.Summary,
+			entry.Evidence,
+			strings.Join(entry.ServiceLogIDs, ","),
+		})
+	}
+
+	// Add empty row for readability
+	table.AddRow([]string{})
+	return table.Flush()
+}
@@ -0,0 +1,99 @@
+package support
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildEvidenceLog(t *testing.T, id, description, username string, timestamp time.Time) *slv1.LogEntry {
+	logEntry, err := slv1.NewLogEntry().
+		ID(id).
+		ServiceName(InternalServiceLogServiceName).
+		Summary(InternalServiceLogSummary).
+		Description(description).
+		Username(username).
+		Timestamp(timestamp).
+		Build()
+	assert.NoError(t, err)
+	return logEntry
+}
+
+func TestParseEvidenceServiceLog(t *testing.T) {
+	otherLog, _ := slv1.NewLogEntry().ServiceName("OtherService").Summary(InternalServiceLogSummary).Description("abc - evidence").Build()
+	noSeparatorLog, _ := slv1.NewLogEntry().ServiceName(InternalServiceLogServiceName).Summary(InternalServiceLogSummary).Description("no separator").Build()
+
+	tests := []struct {
+		name             string
+		logEntry         *slv1.LogEntry
+		expectedOk       bool
+		expectedReasonID string
+		expectedEvidence string
+	}{
+		{
+			name:             "evidence service log",
+			logEntry:         buildEvidenceLog(t, "sl-1", "ls-123 - See OHSS-1234 - more context", "jdoe", time.Now()),
+			expectedOk:       true,
+			expectedReasonID: "ls-123",
+			expectedEvidence: "See OHSS-1234 - more context",
+		},
+		{
+			name:       "unrelated service log",
+			logEntry:   otherLog,
+			expectedOk: false,
+		},
+		{
+			name:       "malformed description",
+			logEntry:   noSeparatorLog,
+			expectedOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasonID, evidence, ok := parseEvidenceServiceLog(tt.logEntry)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedReasonID, reasonID)
+			assert.Equal(t, tt.expectedEvidence, evidence)
+		})
+	}
+}
+
+func TestBuildLimitedSupportHistory(t *testing.T) {
+	now := time.Now()
+	active, err := cmv1.NewLimitedSupportReason().ID("ls-active").Summary("Active reason").CreationTimestamp(now).Build()
+	assert.NoError(t, err)
+	activeWithoutEvidence, err := cmv1.NewLimitedSupportReason().ID("ls-no-evidence").Summary("No evidence").CreationTimestamp(now.Add(-time.Hour)).Build()
+	assert.NoError(t, err)
+
+	serviceLogs := []*slv1.LogEntry{
+		buildEvidenceLog(t, "sl-1", "ls-active - OHSS-1", "jdoe", now),
+		buildEvidenceLog(t, "sl-2", "ls-removed - OHSS-2", "asmith", now.Add(-48*time.Hour)),
+	}
+
+	history := buildLimitedSupportHistory([]*cmv1.LimitedSupportReason{active, activeWithoutEvidence}, serviceLogs)
+
+	assert.Len(t, history, 3)
+
+	assert.Equal(t, "ls-active", history[0].ReasonID)
+	assert.Equal(t, historyStatusActive, history[0].Status)
+	assert.Equal(t, "jdoe", history[0].PostedBy)
+	assert.Equal(t, "OHSS-1", history[0].Evidence)
+	assert.Equal(t, []string{"sl-1"}, history[0].ServiceLogIDs)
+
+	assert.Equal(t, "ls-no-evidence", history[1].ReasonID)
+	assert.Equal(t, historyStatusActive, history[1].Status)
+	assert.Equal(t, historyUnknown, history[1].PostedBy)
+
+	assert.Equal(t, "ls-removed", history[2].ReasonID)
+	assert.Equal(t, historyStatusRemoved, history[2].Status)
+	assert.Equal(t, "asmith", history[2].PostedBy)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, printLimitedSupportHistory(buf, history))
+	assert.Contains(t, buf.String(), "ls-removed")
+}
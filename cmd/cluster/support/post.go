@@ -10,13 +10,12 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 
 	"github.com/openshift-online/ocm-cli/pkg/dump"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/openshift/osdctl/internal/templates"
 	"github.com/openshift/osdctl/internal/utils"
 	ctlutil "github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
@@ -46,6 +45,11 @@ type Post struct {
 	Evidence         string
 	cluster          *cmv1.Cluster
 	ClusterID        string
+	isDryRun         bool
+	skipPrompts      bool
+	listParams       bool
+	lint             bool
+	targets          clusterTargets
 }
 
 type TemplateFile struct {
@@ -54,6 +58,7 @@ type TemplateFile struct {
 	LogType       string             `json:"log_type"`
 	Details       string             `json:"details"`
 	DetectionType cmv1.DetectionType `json:"detection_type"`
+	Parameters    templates.Params   `json:"parameters,omitempty"`
 }
 
 var (
@@ -75,24 +80,37 @@ osdctl cluster support post --cluster-id=1a2B3c4DefghIjkLMNOpQrSTUV5 --misconfig
 
 Will result in the following limited-support text sent to the customer:
 The cluster has a second failing ingress controller, which is not supported and can cause issues with SLA. Remove the additional ingress controller 'my-custom-ingresscontroller'. 'oc get ingresscontroller -n openshift-ingress-operator' should yield only 'default'.
+
+# Show the parameters a template needs, or check that it declares all of them
+osdctl cluster support post -t template.json --list-params
+osdctl cluster support post -t template.json --lint
+
+# Post the same limited support reason to every cluster listed in a clusters file, previewing it first
+osdctl cluster support post --clusters-file clusters.json -t template.json --dry-run
 `,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if p.listParams || p.lint {
+				return p.inspectTemplate()
+			}
 			return p.Run(p.ClusterID)
 		},
 	}
 
 	// Define required flags
-	postCmd.Flags().StringVarP(&p.ClusterID, "cluster-id", "C", "", "Internal Cluster ID (required)")
+	postCmd.Flags().StringVarP(&p.ClusterID, "cluster-id", "C", "", "Internal Cluster ID")
 	postCmd.Flags().StringVarP(&p.Template, "template", "t", "", "Message template file or URL")
 	postCmd.Flags().StringArrayVarP(&p.TemplateParams, "param", "p", p.TemplateParams, "Specify a key-value pair (eg. -p FOO=BAR) to set/override a parameter value in the template.")
 	postCmd.Flags().Var(&p.Misconfiguration, MisconfigurationFlag, "The type of misconfiguration responsible for the cluster being placed into limited support. Valid values are `cloud` or `cluster`.")
 	postCmd.Flags().StringVar(&p.Problem, ProblemFlag, "", "Complete sentence(s) describing the problem responsible for the cluster being placed into limited support. Will form the limited support message with the contents of --resolution appended")
 	postCmd.Flags().StringVar(&p.Resolution, ResolutionFlag, "", "Complete sentence(s) describing the steps for the customer to take to resolve the issue and move out of limited support. Will form the limited support message with the contents of --problem prepended")
 	postCmd.Flags().StringVar(&p.Evidence, EvidenceFlag, "", "(optional) The reasoning that led to the decision to place the cluster in limited support. Can also be a link to a Jira case. Used for internal service log only.")
-
-	_ = postCmd.MarkFlagRequired("cluster-id")
+	postCmd.Flags().BoolVarP(&p.isDryRun, "dry-run", "d", false, "Dry-run - print the limited support reason and the clusters it would be sent to, but don't send it.")
+	postCmd.Flags().BoolVarP(&p.skipPrompts, "yes", "y", false, "Skip the confirmation prompt when posting to multiple clusters")
+	postCmd.Flags().BoolVar(&p.listParams, "list-params", false, "List the parameters used by the template, with the types, defaults and descriptions it declares, then exit")
+	postCmd.Flags().BoolVar(&p.lint, "lint", false, "Check that every placeholder used by the template is declared in its \"parameters\", then exit")
+	p.targets.addBulkFlags(postCmd)
 
 	return postCmd
 }
@@ -139,9 +157,8 @@ func (p *Post) Run(clusterID string) error {
 		return err
 	}
 
-	// Check that the cluster key (name, identifier or external identifier) given by the user
-	// is reasonably safe so that there is no risk of SQL injection
-	if err := ctlutil.IsValidClusterKey(clusterID); err != nil {
+	p.targets.clusterID = clusterID
+	if err := p.targets.validate(); err != nil {
 		return err
 	}
 
@@ -156,35 +173,22 @@ func (p *Post) Run(clusterID string) error {
 		}
 	}()
 
-	p.cluster, err = ctlutil.GetCluster(connection, clusterID)
+	clusters, err := p.targets.resolve(connection)
 	if err != nil {
-		return fmt.Errorf("can't retrieve cluster: %w", err)
+		return err
 	}
 
-	subscriptionResponse, err := connection.
-		AccountsMgmt().
-		V1().
-		Subscriptions().
-		Subscription(p.cluster.Subscription().ID()).
-		Get().Send()
-	if err != nil {
-		return fmt.Errorf("failed to retrieve cluster subscription: %w", err)
+	if p.targets.isBulk() {
+		return p.runBulk(connection, clusters)
 	}
 
-	labelResponse, err := connection.
-		AccountsMgmt().
-		V1().
-		Organizations().
-		Organization(subscriptionResponse.Body().OrganizationID()).
-		Labels().
-		Label(managedCriticalCustomerLabel).
-		Get().Send()
+	p.cluster = clusters[0]
+
+	critical, err := isCriticalCustomer(connection, p.cluster)
 	if err != nil {
-		// if the label is missing, there's no need to show an error to the user
-		if labelResponse.Error().Status() != http.StatusNotFound {
-			return fmt.Errorf("failed to retrieve cluster labels: %w", err)
-		}
-	} else if labelResponse.Body().Value() == "true" {
+		return err
+	}
+	if critical {
 		fmt.Println(`WARNING: This cluster is owned by a critical customer. Make sure that an SL has been sent and proactive case opened with the customer. Only continue if there has been no customer response for 24 hours.
 
 See: https://source.redhat.com/groups/public/sre/wiki/defining_limited_support_process_for_osdrosa_for_critical_customers`)
@@ -193,17 +197,9 @@ See: https://source.redhat.com/groups/public/sre/wiki/defining_limited_support_p
 		}
 	}
 
-	var limitedSupport *cmv1.LimitedSupportReason
-	if p.Template != "" {
-		limitedSupport, err = p.buildLimitedSupportTemplate()
-		if err != nil {
-			return err
-		}
-	} else {
-		limitedSupport, err = p.buildLimitedSupport()
-		if err != nil {
-			return err
-		}
+	limitedSupport, err := p.buildLimitedSupportReason()
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("The following limited support reason will be sent to %s:\n", clusterID)
@@ -211,39 +207,138 @@ See: https://source.redhat.com/groups/public/sre/wiki/defining_limited_support_p
 		return fmt.Errorf("failed to print limited support reason template: %w", err)
 	}
 
+	if p.isDryRun {
+		return nil
+	}
+
 	if !ctlutil.ConfirmPrompt() {
 		return nil
 	}
 
+	_, err = p.postToCluster(connection, limitedSupport)
+	return err
+}
+
+// runBulk posts the same limited support reason to every cluster. Clusters owned by critical
+// customers are skipped, as they need the individual process enforced by the single cluster flow.
+func (p *Post) runBulk(connection *sdk.Connection, clusters []*cmv1.Cluster) error {
+	limitedSupport, err := p.buildLimitedSupportReason()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The following limited support reason will be sent to %d cluster(s):\n", len(clusters))
+	if err = printLimitedSupportReason(limitedSupport); err != nil {
+		return fmt.Errorf("failed to print limited support reason template: %w", err)
+	}
+	if err = printTargetClusters(clusters); err != nil {
+		return fmt.Errorf("could not print matching clusters: %w", err)
+	}
+
+	if p.isDryRun {
+		return nil
+	}
+
+	if !p.skipPrompts && !ctlutil.ConfirmPrompt() {
+		return nil
+	}
+
+	result := newBulkResult()
+	for _, cluster := range clusters {
+		p.cluster = cluster
+
+		critical, err := isCriticalCustomer(connection, cluster)
+		if err != nil {
+			result.failure(cluster.ID(), err)
+			continue
+		}
+		if critical {
+			result.failure(cluster.ID(), errors.New("cluster is owned by a critical customer, post to it individually with --cluster-id"))
+			continue
+		}
+
+		reasonID, err := p.postToCluster(connection, limitedSupport)
+		if err != nil {
+			result.failure(cluster.ID(), err)
+			continue
+		}
+		result.success(cluster.ID(), fmt.Sprintf("limited support reason %s", reasonID))
+	}
+
+	result.print()
+	return result.err()
+}
+
+func (p *Post) buildLimitedSupportReason() (*cmv1.LimitedSupportReason, error) {
+	if p.Template != "" {
+		return p.buildLimitedSupportTemplate()
+	}
+	return p.buildLimitedSupport()
+}
+
+// postToCluster sends the limited support reason to p.cluster, along with the internal evidence service log if evidence was given
+func (p *Post) postToCluster(connection *sdk.Connection, limitedSupport *cmv1.LimitedSupportReason) (string, error) {
 	postLimitedSupportResponse, err := sendLimitedSupportPostRequest(connection, p.cluster.ID(), limitedSupport)
 	if err != nil {
-		return fmt.Errorf("failed to post limited support reason: %w", err)
+		return "", fmt.Errorf("failed to post limited support reason: %w", err)
 	}
-	fmt.Printf("Successfully added new limited support reason with ID %v\n", postLimitedSupportResponse.Body().ID())
+	reasonID := postLimitedSupportResponse.Body().ID()
+	fmt.Printf("Successfully added new limited support reason with ID %v\n", reasonID)
 
 	if p.Evidence != "" {
 		var subscriptionId string
 		if subscription, ok := p.cluster.GetSubscription(); ok {
 			subscriptionId = subscription.ID()
 		}
-		internalServiceLog, err := p.buildInternalServiceLog(postLimitedSupportResponse.Body().ID(), subscriptionId)
+		internalServiceLog, err := p.buildInternalServiceLog(reasonID, subscriptionId)
 		if err != nil {
-			return err
+			return reasonID, err
 		}
 
-		fmt.Printf("Sending the following internal service log to %s:\n", clusterID)
+		fmt.Printf("Sending the following internal service log to %s:\n", p.cluster.ID())
 		if err = printInternalServiceLog(internalServiceLog); err != nil {
-			return fmt.Errorf("failed to print internal service log template: %w", err)
+			return reasonID, fmt.Errorf("failed to print internal service log template: %w", err)
 		}
 
 		postServiceLogResponse, err := sendInternalServiceLogPostRequest(connection, internalServiceLog)
 		if err != nil {
-			return fmt.Errorf("failed to post internal service log: %w", err)
+			return reasonID, fmt.Errorf("failed to post internal service log: %w", err)
 		}
 		fmt.Printf("Successfully sent internal service log with ID %v\n", postServiceLogResponse.Body().ID())
 	}
 
-	return nil
+	return reasonID, nil
+}
+
+// isCriticalCustomer returns true if the organization owning the cluster is labelled as a managed critical customer
+func isCriticalCustomer(connection *sdk.Connection, cluster *cmv1.Cluster) (bool, error) {
+	subscriptionResponse, err := connection.
+		AccountsMgmt().
+		V1().
+		Subscriptions().
+		Subscription(cluster.Subscription().ID()).
+		Get().Send()
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve cluster subscription: %w", err)
+	}
+
+	labelResponse, err := connection.
+		AccountsMgmt().
+		V1().
+		Organizations().
+		Organization(subscriptionResponse.Body().OrganizationID()).
+		Labels().
+		Label(managedCriticalCustomerLabel).
+		Get().Send()
+	if err != nil {
+		// if the label is missing, there's no need to show an error to the user
+		if labelResponse.Error().Status() != http.StatusNotFound {
+			return false, fmt.Errorf("failed to retrieve cluster labels: %w", err)
+		}
+		return false, nil
+	}
+
+	return labelResponse.Body().Value() == "true", nil
 }
 
 func (p *Post) buildLimitedSupport() (*cmv1.LimitedSupportReason, error) {
@@ -271,11 +366,17 @@ func (p *Post) buildLimitedSupportTemplate() (*cmv1.LimitedSupportReason, error)
 	}
 
 	p.parseUserParameters() // parse all the '-p' user flags
+	// Fill in the declared defaults of parameters which weren't set with '-p', and check the values against their declared types
+	if err := p.applyParamDefaults(t); err != nil {
+		return nil, err
+	}
 	// For every '-p' flag, replace its related placeholder in the template
 	for k := range userParameterNames {
 		p.replaceFlags(t, userParameterNames[k], userParameterValues[k])
 	}
-	p.checkLeftovers(t)
+	if err := templates.CheckLeftovers(nil, t.Details); err != nil {
+		return nil, err
+	}
 
 	limitedSupportBuilder := cmv1.NewLimitedSupportReason().Summary(t.Summary).Details(t.Details).DetectionType(t.DetectionType)
 	limitedSupport, err := limitedSupportBuilder.Build()
@@ -288,19 +389,46 @@ func (p *Post) buildLimitedSupportTemplate() (*cmv1.LimitedSupportReason, error)
 
 // parseUserParameters parse all the '-p FOO=BAR' parameters and checks for syntax errors
 func (p *Post) parseUserParameters() {
-	for _, v := range p.TemplateParams {
-		if !strings.Contains(v, "=") {
-			log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
-		}
+	names, values, err := templates.ParseAssignments(p.TemplateParams)
+	if err != nil {
+		log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
+	}
+	userParameterNames = append(userParameterNames, names...)
+	userParameterValues = append(userParameterValues, values...)
+}
 
-		param := strings.SplitN(v, "=", 2)
-		if param[0] == "" || param[1] == "" {
-			log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
-		}
+// applyParamDefaults adds the default value of every declared parameter not set with '-p', then validates all values
+func (p *Post) applyParamDefaults(template *TemplateFile) error {
+	var err error
+	userParameterNames, userParameterValues, err = template.Parameters.WithDefaults(templates.UsedParams(template.Details), userParameterNames, userParameterValues)
+	return err
+}
+
+// inspectTemplate lists the parameters of the template, or lints it, without contacting OCM
+func (p *Post) inspectTemplate() error {
+	if p.Template == "" {
+		return errors.New("--list-params and --lint require --template")
+	}
+
+	t, err := p.readTemplate()
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+	used := templates.UsedParams(t.Details)
+
+	if p.listParams {
+		return t.Parameters.Print(os.Stdout, used, nil)
+	}
 
-		userParameterNames = append(userParameterNames, fmt.Sprintf("${%v}", param[0]))
-		userParameterValues = append(userParameterValues, param[1])
+	problems := t.Parameters.Lint(used, nil)
+	for _, problem := range problems {
+		log.Println(problem)
 	}
+	if len(problems) > 0 {
+		return fmt.Errorf("template %s has %d problem(s)", p.Template, len(problems))
+	}
+	fmt.Printf("Template %s declares all of its parameters\n", p.Template)
+	return nil
 }
 
 func (p *Post) readTemplate() (*TemplateFile, error) {
@@ -314,6 +442,10 @@ func (p *Post) readTemplate() (*TemplateFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The declared parameters are defaulted and validated the same way as service log templates'
+	if template.Parameters, err = templates.ParseParams(templateObj); err != nil {
+		return nil, err
+	}
 
 	return &template, nil
 }
@@ -345,43 +477,8 @@ func (p *Post) accessFile(filePath string) ([]byte, error) {
 }
 
 func (p *Post) replaceFlags(template *TemplateFile, flagName string, flagValue string) {
-	if flagValue == "" {
-		log.Fatalf("The selected template is using '%[1]s' parameter, but '%[1]s' flag was not set. Use '-p %[1]s=\"FOOBAR\"' to fix this.", flagName)
-	}
-
-	found := false
-
-	if strings.Contains(template.Details, flagName) {
-		found = true
-		template.Details = strings.ReplaceAll(template.Details, flagName, flagValue)
-	}
-
-	if !found {
-		log.Fatalf("The selected template is not using '%s' parameter, but '--param' flag was set. Do not use '-p %s=%s' to fix this.", flagName, flagName, flagValue)
-	}
-}
-
-func (p *Post) findLeftovers(s string) (matches []string) {
-	r := regexp.MustCompile(`\${[^{}]*}`)
-	matches = r.FindAllString(s, -1)
-	return matches
-}
-
-func (p *Post) checkLeftovers(template *TemplateFile) {
-	unusedParameters := p.findLeftovers(template.Details)
-	var numberOfMissingParameters int
-	for _, v := range unusedParameters {
-		// Ignore parameters in the exclude list, ie ${CLUSTER_UUID}, which will be replaced later for each cluster a servicelog is sent to
-		if strings.Contains(template.Details, v) {
-			numberOfMissingParameters++
-			regex := strings.NewReplacer("${", "", "}", "")
-			log.Printf("The one of the template files is using '%s' parameter, but '--param' flag is not set for this one. Use '-p %v=\"FOOBAR\"' to fix this.", v, regex.Replace(v))
-		}
-	}
-	if numberOfMissingParameters == 1 {
-		log.Fatal("Please define this missing parameter properly.")
-	} else if numberOfMissingParameters > 1 {
-		log.Fatalf("Please define all %v missing parameters properly.", numberOfMissingParameters)
+	if err := templates.Replace(flagName, flagValue, &template.Details); err != nil {
+		log.Fatal(err)
 	}
 }
 
@@ -4,14 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/openshift/osdctl/internal/templates"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -363,96 +364,6 @@ func TestReplaceFlags(t *testing.T) {
 	}
 }
 
-func TestPostFindLeftovers(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected []string
-	}{
-		{
-			name:     "No_leftovers",
-			input:    "This is a template with no parameters.",
-			expected: []string{},
-		},
-		{
-			name:     "Single_leftover",
-			input:    "This is a template with ${PARAM1}.",
-			expected: []string{"${PARAM1}"},
-		},
-		{
-			name:     "Multiple_leftovers",
-			input:    "This is a template with ${PARAM1} and ${PARAM2}.",
-			expected: []string{"${PARAM1}", "${PARAM2}"},
-		},
-		{
-			name:     "Nested_leftovers",
-			input:    "This is a template with ${PARAM1} and ${NESTED_PARAM}.",
-			expected: []string{"${PARAM1}", "${NESTED_PARAM}"},
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			p := &Post{}
-			actual := p.findLeftovers(tt.input)
-			assert.ElementsMatch(t, tt.expected, actual)
-		})
-	}
-}
-
-func TestPostCheckLeftovers(t *testing.T) {
-	tests := []struct {
-		name               string
-		templateDetails    string
-		expectedFatalError bool
-		expectedLog        string
-	}{
-		{
-			name:               "No_leftovers",
-			templateDetails:    "This is a template with no parameters.",
-			expectedFatalError: false,
-			expectedLog:        "",
-		},
-		{
-			name:               "Single_leftover_missing_parameter",
-			templateDetails:    "This is a template with ${PARAM1}.",
-			expectedFatalError: true,
-			expectedLog:        "The one of the template files is using '${PARAM1}' parameter, but '--param' flag is not set for this one. Use '-p PARAM1=\"FOOBAR\"' to fix this.",
-		},
-		{
-			name:               "Multiple_leftover__missing_parameters",
-			templateDetails:    "This is a template with ${PARAM1} and ${PARAM2}.",
-			expectedFatalError: true,
-			expectedLog:        "The one of the template files is using '${PARAM1}' parameter, but '--param' flag is not set for this one. Use '-p PARAM1=\"FOOBAR\"' to fix this.",
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.expectedFatalError {
-
-				ok := runsAndFailsWithFatal([]string{tt.templateDetails})
-				assert.True(t, ok)
-				return
-			}
-
-			var capturedLogs strings.Builder
-			log.SetOutput(&capturedLogs)
-			defer log.SetOutput(nil)
-
-			template := &TemplateFile{
-				Details: tt.templateDetails,
-			}
-			p := &Post{}
-			p.checkLeftovers(template)
-
-			assert.Contains(t, capturedLogs.String(), tt.expectedLog)
-		})
-	}
-}
-
 func TestPrintLimitedSupportReason(t *testing.T) {
 	limitedSupportReason1, _ := cmv1.NewLimitedSupportReason().
 		ID("ls-123").
@@ -565,3 +476,59 @@ func TestPrintInternalServiceLog(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyParamDefaults(t *testing.T) {
+	defaultDays := "7"
+	template := &TemplateFile{
+		Details: "Fix ${ACTION} within ${DAYS} days",
+		Parameters: templates.Params{
+			"DAYS": {Type: templates.TypeInt, Default: &defaultDays},
+		},
+	}
+
+	userParameterNames = []string{"${ACTION}"}
+	userParameterValues = []string{"the ingress controller"}
+
+	p := &Post{}
+	assert.NoError(t, p.applyParamDefaults(template))
+	assert.Equal(t, []string{"${ACTION}", "${DAYS}"}, userParameterNames)
+	assert.Equal(t, []string{"the ingress controller", "7"}, userParameterValues)
+
+	userParameterNames = []string{"${DAYS}"}
+	userParameterValues = []string{"soon"}
+	assert.Error(t, p.applyParamDefaults(template))
+}
+
+func TestReadTemplateParams(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, os.WriteFile(valid, []byte(`{"details": "Fix ${ACTION}", "parameters": {"ACTION": {}}}`), 0o600))
+	assert.NoError(t, os.WriteFile(invalid, []byte(`{"details": "Fix ${ACTION}", "parameters": {"ACTION": {"type": "float"}}}`), 0o600))
+
+	p := &Post{Template: valid}
+	template, err := p.readTemplate()
+	assert.NoError(t, err)
+	assert.Equal(t, templates.TypeString, template.Parameters["ACTION"].Type)
+
+	p = &Post{Template: invalid}
+	_, err = p.readTemplate()
+	assert.Error(t, err)
+}
+
+func TestBuildLimitedSupportTemplateLeftovers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"summary": "Action required", "details": "Fix ${ACTION}"}`), 0o600))
+	userParameterNames, userParameterValues = nil, nil
+
+	p := &Post{Template: path}
+	_, err := p.buildLimitedSupportTemplate()
+	assert.ErrorContains(t, err, "'-p ACTION=\"FOOBAR\"'")
+
+	userParameterNames, userParameterValues = nil, nil
+	p = &Post{Template: path, TemplateParams: []string{"ACTION=the network"}}
+	reason, err := p.buildLimitedSupportTemplate()
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix the network", reason.Details())
+	userParameterNames, userParameterValues = nil, nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/olekukonko/tablewriter"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osdctl/cmd/cluster/internal/dns"
+	"github.com/openshift/osdctl/pkg/osdCloud"
+	"github.com/openshift/osdctl/pkg/provider/aws"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -26,6 +28,11 @@ type verifyDNSOptions struct {
 	cluster   *cmv1.Cluster
 	verbose   bool
 	output    string
+	// resolver is the DNS server to query instead of the system resolver
+	resolver   string
+	awsChecks  bool
+	awsProfile string
+	awsClient  aws.ContextClient
 	genericclioptions.IOStreams
 	analyzer dns.Analyzer
 	verifier dns.Verifier
@@ -43,7 +50,7 @@ func NewCmdVerifyDNS(streams genericclioptions.IOStreams) *cobra.Command {
 
 	verifyDNSCmd := &cobra.Command{
 		Use:               "verify-dns --cluster-id <cluster-id>",
-		Short:             "Verify DNS resolution for HCP cluster public endpoints",
+		Short:             "Verify DNS resolution for cluster endpoints",
 		Long:              verifyDNSLongDescription,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
@@ -56,6 +63,9 @@ func NewCmdVerifyDNS(streams genericclioptions.IOStreams) *cobra.Command {
 	verifyDNSCmd.Flags().StringVarP(&ops.clusterID, "cluster-id", "C", "", "Cluster ID (internal or external)")
 	verifyDNSCmd.Flags().BoolVarP(&ops.verbose, "verbose", "v", false, "Verbose output")
 	verifyDNSCmd.Flags().StringVarP(&ops.output, "output", "o", "table", "Output format: 'table' or 'json'")
+	verifyDNSCmd.Flags().StringVar(&ops.resolver, "resolver", "", "DNS server to query instead of the system resolver, as [tcp://|udp://]host[:port]")
+	verifyDNSCmd.Flags().BoolVar(&ops.awsChecks, "aws", false, "Verify load balancer ownership of the resolved IPs and Route 53 hosted zones in the cluster's AWS account")
+	verifyDNSCmd.Flags().StringVarP(&ops.awsProfile, "profile", "p", "", "AWS profile used with --aws")
 
 	if err := verifyDNSCmd.MarkFlagRequired("cluster-id"); err != nil {
 		panic(fmt.Sprintf("failed to mark cluster-id flag as required: %v", err))
@@ -71,6 +81,13 @@ func (v *verifyDNSOptions) complete(cmd *cobra.Command) error {
 	if v.clusterID == "" {
 		return fmt.Errorf("cluster-id is required")
 	}
+
+	if v.resolver != "" {
+		if _, _, err := dns.ParseResolverAddress(v.resolver); err != nil {
+			return err
+		}
+		v.verifier = dns.NewDefaultVerifier(dns.WithResolverAddress(v.resolver))
+	}
 	return nil
 }
 
@@ -84,24 +101,47 @@ func (v *verifyDNSOptions) run(ctx context.Context) error {
 		return fmt.Errorf("failed to get cluster: %w", err)
 	}
 
-	if !cluster.Hypershift().Enabled() {
-		return fmt.Errorf("cluster %s is not an HCP cluster. This command only supports HCP clusters", v.clusterID)
-	}
-
 	if v.verbose {
-		fmt.Fprintf(v.Out, "Cluster %s is an HCP cluster\n", cluster.Name())
+		if cluster.Hypershift().Enabled() {
+			fmt.Fprintf(v.Out, "Cluster %s is an HCP cluster\n", cluster.Name())
+		} else {
+			fmt.Fprintf(v.Out, "Cluster %s is a classic cluster\n", cluster.Name())
+		}
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		return fmt.Errorf("cluster %s is not in Ready state. Current state: %s", cluster.Name(), cluster.State())
 	}
 
+	testcases := v.buildTestCases(cluster)
+
+	var awsClient aws.ContextClient
+	if v.awsChecks {
+		awsClient, err = v.getAWSClient(cluster)
+		if err != nil {
+			return fmt.Errorf("failed to create AWS client: %w", err)
+		}
+
+		if v.verbose {
+			fmt.Fprintf(v.Out, "Resolving the cluster's load balancers\n")
+		}
+		lbNames, err := getClusterLoadBalancerDNSNames(ctx, awsClient, cluster)
+		if err != nil {
+			return err
+		}
+		lbIPs := v.resolveLoadBalancerIPs(ctx, lbNames)
+		for key, tc := range testcases {
+			if tc.checkLoadBalancer {
+				tc.expectedIPs = lbIPs
+				testcases[key] = tc
+			}
+		}
+	}
+
 	if v.verbose {
 		fmt.Fprintf(v.Out, "Performing DNS resolution test\n")
 	}
 
-	testcases := v.buildTestCases(cluster)
-
 	var wg sync.WaitGroup
 	resultCh := make(chan dns.VerifyResult, len(testcases))
 	for _, tc := range testcases {
@@ -113,7 +153,7 @@ func (v *verifyDNSOptions) run(ctx context.Context) error {
 					Name:       t.name,
 					Type:       t.recordType,
 					Status:     "SKIP",
-					SkipReason: "Skipped due to cluster creation date before March 10, 2025",
+					SkipReason: t.skipReason,
 				}
 				return
 			}
@@ -121,7 +161,11 @@ func (v *verifyDNSOptions) run(ctx context.Context) error {
 				c <- v.verifier.VerifyCNAMERecord(ctx, t.name, dns.WithExpectedTarget(t.expectedTarget))
 			}
 			if t.recordType == dns.RecordTypeA {
-				c <- v.verifier.VerifyARecord(ctx, t.name)
+				var opts []dns.VerifyARecordOption
+				if t.expectedIPs != nil {
+					opts = append(opts, dns.WithExpectedIPs(t.expectedIPs))
+				}
+				c <- v.verifier.VerifyARecord(ctx, t.name, opts...)
 			}
 		}(resultCh, tc)
 	}
@@ -136,6 +180,17 @@ func (v *verifyDNSOptions) run(ctx context.Context) error {
 		results = append(results, res)
 	}
 
+	if awsClient != nil {
+		if v.verbose {
+			fmt.Fprintf(v.Out, "Verifying Route 53 hosted zones\n")
+		}
+		zoneResults, err := v.verifyHostedZones(ctx, awsClient, cluster)
+		if err != nil {
+			return err
+		}
+		results = append(results, zoneResults...)
+	}
+
 	report := v.analyzer.Analyze(cluster, results)
 
 	// Output based on format
@@ -170,12 +225,100 @@ func (v *verifyDNSOptions) getCluster() (*cmv1.Cluster, error) {
 	return v.cluster, err
 }
 
+func (v *verifyDNSOptions) getAWSClient(cluster *cmv1.Cluster) (aws.ContextClient, error) {
+	if v.awsClient != nil {
+		return v.awsClient, nil
+	}
+
+	if cluster.CloudProvider().ID() != "aws" {
+		return nil, fmt.Errorf("--aws is only supported for AWS clusters")
+	}
+
+	awsClient, err := osdCloud.GenerateAWSClientForCluster(v.awsProfile, cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	v.awsClient, err = aws.NewContextClientFromClient(awsClient)
+	return v.awsClient, err
+}
+
 func (v *verifyDNSOptions) buildTestCases(cluster *cmv1.Cluster) map[string]dnstestCase {
+	if !cluster.Hypershift().Enabled() {
+		return v.buildClassicTestCases(cluster)
+	}
+	return v.buildHCPTestCases(cluster)
+}
+
+// buildClassicTestCases builds the test cases for classic OSD and ROSA clusters, whose records all point to load
+// balancers in the cluster's AWS account
+func (v *verifyDNSOptions) buildClassicTestCases(cluster *cmv1.Cluster) map[string]dnstestCase {
 	tests := make(map[string]dnstestCase)
 
+	name := cluster.Name()
+	domain := cluster.DNS().BaseDomain()
+
+	apiTest := dnstestCase{
+		name:              fmt.Sprintf("api.%s.%s", name, domain),
+		recordType:        dns.RecordTypeA,
+		description:       "Test A record: api.<cluster-name>.<base-domain>",
+		checkLoadBalancer: true,
+	}
+	// Private clusters only publish their API in the private hosted zone of the VPC
+	if cluster.API().Listening() == cmv1.ListeningMethodInternal && v.resolver == "" {
+		apiTest.skip = true
+		apiTest.skipReason = "Private cluster API is only published in the private hosted zone, use --resolver"
+	}
+	tests["api"] = apiTest
+
+	apiIntTest := dnstestCase{
+		name:              fmt.Sprintf("api-int.%s.%s", name, domain),
+		recordType:        dns.RecordTypeA,
+		description:       "Test A record: api-int.<cluster-name>.<base-domain>",
+		checkLoadBalancer: true,
+	}
+	if v.resolver == "" {
+		apiIntTest.skip = true
+		apiIntTest.skipReason = "Internal API is only published in the private hosted zone, use --resolver"
+	}
+	tests["api_int"] = apiIntTest
+
 	tests["console"] = dnstestCase{
-		name:       cluster.Console().URL(),
-		recordType: "A",
+		name:              cluster.Console().URL(),
+		recordType:        dns.RecordTypeA,
+		description:       "Test Console A record: console-openshift-console.apps.<cluster-name>.<base-domain>",
+		checkLoadBalancer: true,
+	}
+
+	tests["oauth"] = dnstestCase{
+		name:              fmt.Sprintf("oauth-openshift.apps.%s.%s", name, domain),
+		recordType:        dns.RecordTypeA,
+		description:       "Test OAuth A record: oauth-openshift.apps.<cluster-name>.<base-domain>",
+		checkLoadBalancer: true,
+	}
+
+	// Any name below apps resolves through the wildcard record of the default ingress controller
+	tests["apps_wildcard"] = dnstestCase{
+		name:              fmt.Sprintf("%s.apps.%s.%s", wildcardTestLabel, name, domain),
+		recordType:        dns.RecordTypeA,
+		description:       "Test wildcard A record: *.apps.<cluster-name>.<base-domain>",
+		checkLoadBalancer: true,
+	}
+
+	return tests
+}
+
+// wildcardTestLabel is resolved below wildcard domains, it isn't expected to have a record of its own
+const wildcardTestLabel = "osdctl-verify-dns"
+
+func (v *verifyDNSOptions) buildHCPTestCases(cluster *cmv1.Cluster) map[string]dnstestCase {
+	tests := make(map[string]dnstestCase)
+
+	// The API and OAuth load balancers of HCP clusters live in the management cluster's account, only the ingress
+	// load balancer is in the cluster's account
+	tests["console"] = dnstestCase{
+		name:              cluster.Console().URL(),
+		recordType:        "A",
+		checkLoadBalancer: true,
 		description: "Test Console A record: console-openshift-console.apps.rosa.<cluster-name>.<base-domain>." +
 			"This verifies the presence of the A record for the wildcard domain " +
 			"*.apps.rosa.<cluster-name>.<base-domain>",
@@ -216,6 +359,7 @@ func (v *verifyDNSOptions) buildTestCases(cluster *cmv1.Cluster) map[string]dnst
 	shouldSkipUnique := v.shouldSkipUniqueFQDN(cluster)
 	if shouldSkipUnique {
 		uniqueTest.skip = true
+		uniqueTest.skipReason = uniqueFQDNSkipReason
 	}
 	tests["unique"] = uniqueTest
 
@@ -228,6 +372,7 @@ func (v *verifyDNSOptions) buildTestCases(cluster *cmv1.Cluster) map[string]dnst
 	}
 	if shouldSkipUnique {
 		uniqueChallengeTest.skip = true
+		uniqueChallengeTest.skipReason = uniqueFQDNSkipReason
 	}
 	tests["unique_challenge"] = uniqueChallengeTest
 
@@ -258,6 +403,8 @@ func (v *verifyDNSOptions) buildTestCases(cluster *cmv1.Cluster) map[string]dnst
 	return tests
 }
 
+const uniqueFQDNSkipReason = "Skipped due to cluster creation date before March 10, 2025"
+
 func (v *verifyDNSOptions) shouldSkipUniqueFQDN(cluster *cmv1.Cluster) bool {
 	cutoffDate := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
 	creationTime := cluster.CreationTimestamp()
@@ -272,6 +419,10 @@ type dnstestCase struct {
 	description    string
 	expectedTarget string // For CNAME records
 	skip           bool
+	skipReason     string
+	// checkLoadBalancer verifies A records resolve to the cluster's load balancers when AWS checks are enabled
+	checkLoadBalancer bool
+	expectedIPs       []string
 }
 
 type recommender struct{}
@@ -286,7 +437,23 @@ func (r *recommender) MakeRecommendations(results []dns.VerifyResult, opts ...dn
 			continue
 		}
 
-		if strings.HasPrefix(res.Name, "console") {
+		if res.Type == dns.RecordTypeNS {
+			recommendations = append(recommendations, strings.Join([]string{
+				"If the name servers of a public hosted zone don't match its Route 53 delegation set then the",
+				"NS records in the parent zone are stale or missing, e.g. because the hosted zone was deleted and",
+				"recreated. Compare the NS records in the parent zone with the name servers of the hosted zone",
+				"in the cluster's AWS account.",
+			}, " "))
+		} else if res.Type == dns.RecordTypeZone {
+			recommendations = append(recommendations, strings.Join([]string{
+				"If a private hosted zone is missing or not associated with the cluster VPC then records are",
+				"not resolvable from within the cluster. Check the private hosted zone in the cluster's AWS",
+				"account and associate it with the cluster VPC. For shared VPCs the association is made in",
+				"the account owning the hosted zone.",
+			}, " "))
+		} else if !cfg.Cluster.Hypershift().Enabled() {
+			recommendations = append(recommendations, classicRecommendation(res))
+		} else if strings.HasPrefix(res.Name, "console") {
 			recommendations = append(recommendations, strings.Join([]string{
 				"If the console FQDN is not resolving then there is likely an issue with",
 				"CIO on the HCP cluster. Check if the A record <*.apps.rosa.<cluster-name>.<base-domain>",
@@ -322,6 +489,23 @@ func (r *recommender) MakeRecommendations(results []dns.VerifyResult, opts ...dn
 	return recommendations
 }
 
+// classicRecommendation returns the recommendation for a failed record of a classic cluster
+func classicRecommendation(res dns.VerifyResult) string {
+	if strings.HasPrefix(res.Name, "api") {
+		return strings.Join([]string{
+			"If the API FQDNs are not resolving or point elsewhere then check the api and api-int records",
+			"in the cluster's Route 53 hosted zones. They must alias the <infra-id>-ext and <infra-id>-int",
+			"load balancers, which are created during installation and are not reconciled afterwards.",
+		}, " ")
+	}
+	return strings.Join([]string{
+		"If the *.apps FQDNs are not resolving or point elsewhere then there is likely an issue with the",
+		"default ingress controller. Check the router-default service in the openshift-ingress namespace",
+		"for its load balancer, and the CIO which maintains the *.apps record in the cluster's Route 53",
+		"hosted zones.",
+	}, " ")
+}
+
 func (v *verifyDNSOptions) renderTable(report dns.DNSVerificationReport) {
 	// Print cluster info
 	fmt.Fprintf(v.Out, "Cluster: %s (ID: %s, Region: %s)\n\n",
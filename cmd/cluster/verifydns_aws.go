@@ -0,0 +1,259 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/cmd/cluster/internal/dns"
+	"github.com/openshift/osdctl/pkg/provider/aws"
+)
+
+// loadBalancerTagsBatchSize is the maximum number of load balancers DescribeTags accepts per call
+const loadBalancerTagsBatchSize = 20
+
+// clusterOwnedTagKey returns the tag key marking AWS resources owned by the cluster
+func clusterOwnedTagKey(cluster *cmv1.Cluster) string {
+	return "kubernetes.io/cluster/" + cluster.InfraID()
+}
+
+// getClusterLoadBalancerDNSNames returns the DNS names of the classic and v2 load balancers owned by the cluster
+func getClusterLoadBalancerDNSNames(ctx context.Context, client aws.ContextClient, cluster *cmv1.Cluster) ([]string, error) {
+	tagKey := clusterOwnedTagKey(cluster)
+	var dnsNames []string
+
+	v2DNSNames := map[string]string{}
+	var v2Marker *string
+	for {
+		loadBalancers, err := client.DescribeV2LoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{Marker: v2Marker})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancers: %w", err)
+		}
+		for _, lb := range loadBalancers.LoadBalancers {
+			v2DNSNames[awsv2.ToString(lb.LoadBalancerArn)] = awsv2.ToString(lb.DNSName)
+		}
+		if loadBalancers.NextMarker == nil {
+			break
+		}
+		v2Marker = loadBalancers.NextMarker
+	}
+	for arns := range slices.Chunk(slices.Sorted(maps.Keys(v2DNSNames)), loadBalancerTagsBatchSize) {
+		tags, err := client.DescribeV2Tags(ctx, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: arns})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancer tags: %w", err)
+		}
+		for _, description := range tags.TagDescriptions {
+			for _, tag := range description.Tags {
+				if awsv2.ToString(tag.Key) == tagKey {
+					dnsNames = append(dnsNames, v2DNSNames[awsv2.ToString(description.ResourceArn)])
+				}
+			}
+		}
+	}
+
+	classicDNSNames := map[string]string{}
+	var classicMarker *string
+	for {
+		loadBalancers, err := client.DescribeLoadBalancers(ctx, &elasticloadbalancing.DescribeLoadBalancersInput{Marker: classicMarker})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe classic load balancers: %w", err)
+		}
+		for _, lb := range loadBalancers.LoadBalancerDescriptions {
+			classicDNSNames[awsv2.ToString(lb.LoadBalancerName)] = awsv2.ToString(lb.DNSName)
+		}
+		if loadBalancers.NextMarker == nil {
+			break
+		}
+		classicMarker = loadBalancers.NextMarker
+	}
+	for names := range slices.Chunk(slices.Sorted(maps.Keys(classicDNSNames)), loadBalancerTagsBatchSize) {
+		tags, err := client.DescribeTags(ctx, &elasticloadbalancing.DescribeTagsInput{LoadBalancerNames: names})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe classic load balancer tags: %w", err)
+		}
+		for _, description := range tags.TagDescriptions {
+			for _, tag := range description.Tags {
+				if awsv2.ToString(tag.Key) == tagKey {
+					dnsNames = append(dnsNames, classicDNSNames[awsv2.ToString(description.LoadBalancerName)])
+				}
+			}
+		}
+	}
+
+	return dnsNames, nil
+}
+
+// resolveLoadBalancerIPs resolves the DNS names of the cluster's load balancers with the configured resolver, so
+// that they resolve the same way as the cluster's records
+func (v *verifyDNSOptions) resolveLoadBalancerIPs(ctx context.Context, dnsNames []string) []string {
+	ips := []string{}
+	for _, name := range dnsNames {
+		res := v.verifier.VerifyARecord(ctx, name)
+		if res.Status != dns.VerifyResultStatusPass {
+			if v.verbose {
+				fmt.Fprintf(v.Out, "Failed to resolve load balancer %s: %s\n", name, res.ErrorMessage)
+			}
+			continue
+		}
+		ips = append(ips, res.ResolvedIPs...)
+	}
+	return ips
+}
+
+// isClusterHostedZone returns whether the hosted zone serves records of the cluster
+func isClusterHostedZone(zoneName string, cluster *cmv1.Cluster) bool {
+	zoneName = strings.TrimSuffix(zoneName, ".")
+	clusterDomain := fmt.Sprintf("%s.%s", cluster.Name(), cluster.DNS().BaseDomain())
+
+	return zoneName == clusterDomain ||
+		strings.HasSuffix(zoneName, "."+clusterDomain) ||
+		(cluster.Hypershift().Enabled() && zoneName == cluster.Name()+".hypershift.local")
+}
+
+// getClusterHostedZones returns the Route 53 hosted zones of the cluster
+func getClusterHostedZones(ctx context.Context, client aws.ContextClient, cluster *cmv1.Cluster) ([]route53types.HostedZone, error) {
+	var zones []route53types.HostedZone
+	var marker *string
+	for {
+		hostedZones, err := client.ListHostedZones(ctx, &route53.ListHostedZonesInput{Marker: marker})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hosted zones: %w", err)
+		}
+		for _, zone := range hostedZones.HostedZones {
+			if isClusterHostedZone(awsv2.ToString(zone.Name), cluster) {
+				zones = append(zones, zone)
+			}
+		}
+		if hostedZones.NextMarker == nil {
+			break
+		}
+		marker = hostedZones.NextMarker
+	}
+	return zones, nil
+}
+
+// getClusterVPCIDs returns the VPCs of the cluster's subnets, or the VPCs owned by the cluster if it was installed
+// without existing subnets
+func getClusterVPCIDs(ctx context.Context, client aws.ContextClient, cluster *cmv1.Cluster) ([]string, error) {
+	var vpcIDs []string
+
+	if subnetIDs := cluster.AWS().SubnetIDs(); len(subnetIDs) > 0 {
+		subnets, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: subnetIDs})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe cluster subnets: %w", err)
+		}
+		for _, subnet := range subnets.Subnets {
+			if id := awsv2.ToString(subnet.VpcId); !slices.Contains(vpcIDs, id) {
+				vpcIDs = append(vpcIDs, id)
+			}
+		}
+	} else {
+		vpcs, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+			Filters: []ec2types.Filter{{
+				Name:   awsv2.String("tag-key"),
+				Values: []string{clusterOwnedTagKey(cluster)},
+			}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe cluster VPCs: %w", err)
+		}
+		for _, vpc := range vpcs.Vpcs {
+			vpcIDs = append(vpcIDs, awsv2.ToString(vpc.VpcId))
+		}
+	}
+
+	if len(vpcIDs) == 0 {
+		return nil, fmt.Errorf("no VPC found for cluster %s", cluster.ID())
+	}
+	return vpcIDs, nil
+}
+
+// verifyHostedZones verifies the delegation of the cluster's public hosted zones and the VPC associations of its
+// private hosted zones
+func (v *verifyDNSOptions) verifyHostedZones(ctx context.Context, client aws.ContextClient, cluster *cmv1.Cluster) ([]dns.VerifyResult, error) {
+	zones, err := getClusterHostedZones(ctx, client, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(zones) == 0 {
+		return []dns.VerifyResult{{
+			Name:         fmt.Sprintf("%s.%s", cluster.Name(), cluster.DNS().BaseDomain()),
+			Type:         dns.RecordTypeZone,
+			Status:       dns.VerifyResultStatusFail,
+			ErrorMessage: "no Route 53 hosted zone found for the cluster domain",
+		}}, nil
+	}
+
+	var vpcIDs []string
+	results := make([]dns.VerifyResult, 0, len(zones))
+	for _, zone := range zones {
+		zoneName := strings.TrimSuffix(awsv2.ToString(zone.Name), ".")
+		if v.verbose {
+			fmt.Fprintf(v.Out, "Verifying hosted zone %s (%s)\n", zoneName, awsv2.ToString(zone.Id))
+		}
+
+		hostedZone, err := client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: zone.Id})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hosted zone %s: %w", zoneName, err)
+		}
+
+		if zone.Config == nil || !zone.Config.PrivateZone {
+			var nameServers []string
+			if hostedZone.DelegationSet != nil {
+				nameServers = hostedZone.DelegationSet.NameServers
+			}
+			results = append(results, v.verifier.VerifyNSRecord(ctx, zoneName, dns.WithExpectedNameServers(nameServers)))
+			continue
+		}
+
+		if vpcIDs == nil {
+			vpcIDs, err = getClusterVPCIDs(ctx, client, cluster)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, verifyZoneAssociation(zoneName, hostedZone.VPCs, vpcIDs))
+	}
+
+	return results, nil
+}
+
+// verifyZoneAssociation verifies a private hosted zone is associated with all VPCs of the cluster
+func verifyZoneAssociation(zoneName string, zoneVPCs []route53types.VPC, clusterVPCIDs []string) dns.VerifyResult {
+	associated := make([]string, 0, len(zoneVPCs))
+	for _, vpc := range zoneVPCs {
+		associated = append(associated, awsv2.ToString(vpc.VPCId))
+	}
+
+	res := dns.VerifyResult{
+		Name:           zoneName,
+		Type:           dns.RecordTypeZone,
+		ActualTarget:   strings.Join(associated, ", "),
+		ExpectedTarget: strings.Join(clusterVPCIDs, ", "),
+		Status:         dns.VerifyResultStatusPass,
+	}
+
+	var missing []string
+	for _, id := range clusterVPCIDs {
+		if !slices.Contains(associated, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		res.Status = dns.VerifyResultStatusFail
+		res.ErrorMessage = fmt.Sprintf("private hosted zone is not associated with the cluster VPC %s", strings.Join(missing, ", "))
+	}
+
+	return res
+}
@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/cmd/cluster/internal/dns"
+	awsmock "github.com/openshift/osdctl/pkg/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/mock/gomock"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func createTestClassicCluster(t *testing.T, subnetIDs ...string) *cmv1.Cluster {
+	cluster, err := cmv1.NewCluster().
+		ID("abc123").
+		Name("test-cluster").
+		InfraID("test-cluster-x7k2p").
+		DNS(cmv1.NewDNS().BaseDomain("abcd.p1.openshiftapps.com")).
+		AWS(cmv1.NewAWS().SubnetIDs(subnetIDs...)).
+		Build()
+	assert.NoError(t, err)
+	return cluster
+}
+
+func TestGetClusterLoadBalancerDNSNames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := awsmock.NewMockContextClient(ctrl)
+	cluster := createTestClassicCluster(t)
+	ownedTag := elbv2types.Tag{Key: awsv2.String("kubernetes.io/cluster/test-cluster-x7k2p"), Value: awsv2.String("owned")}
+
+	client.EXPECT().DescribeV2LoadBalancers(gomock.Any(), gomock.Any()).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []elbv2types.LoadBalancer{
+			{LoadBalancerArn: awsv2.String("arn-ext"), DNSName: awsv2.String("test-cluster-x7k2p-ext.elb.amazonaws.com")},
+			{LoadBalancerArn: awsv2.String("arn-other"), DNSName: awsv2.String("other.elb.amazonaws.com")},
+		},
+		NextMarker: awsv2.String("page-2"),
+	}, nil)
+	client.EXPECT().DescribeV2LoadBalancers(gomock.Any(), &elasticloadbalancingv2.DescribeLoadBalancersInput{Marker: awsv2.String("page-2")}).
+		Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []elbv2types.LoadBalancer{
+				{LoadBalancerArn: awsv2.String("arn-int"), DNSName: awsv2.String("test-cluster-x7k2p-int.elb.amazonaws.com")},
+			},
+		}, nil)
+	client.EXPECT().DescribeV2Tags(gomock.Any(), &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: []string{"arn-ext", "arn-int", "arn-other"}}).
+		Return(&elasticloadbalancingv2.DescribeTagsOutput{
+			TagDescriptions: []elbv2types.TagDescription{
+				{ResourceArn: awsv2.String("arn-ext"), Tags: []elbv2types.Tag{ownedTag}},
+				{ResourceArn: awsv2.String("arn-int"), Tags: []elbv2types.Tag{ownedTag}},
+				{ResourceArn: awsv2.String("arn-other"), Tags: []elbv2types.Tag{{Key: awsv2.String("kubernetes.io/cluster/other"), Value: awsv2.String("owned")}}},
+			},
+		}, nil)
+
+	client.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).Return(&elasticloadbalancing.DescribeLoadBalancersOutput{
+		LoadBalancerDescriptions: []elbtypes.LoadBalancerDescription{
+			{LoadBalancerName: awsv2.String("a1b2c3"), DNSName: awsv2.String("a1b2c3.elb.amazonaws.com")},
+		},
+	}, nil)
+	client.EXPECT().DescribeTags(gomock.Any(), &elasticloadbalancing.DescribeTagsInput{LoadBalancerNames: []string{"a1b2c3"}}).
+		Return(&elasticloadbalancing.DescribeTagsOutput{
+			TagDescriptions: []elbtypes.TagDescription{
+				{LoadBalancerName: awsv2.String("a1b2c3"), Tags: []elbtypes.Tag{{Key: awsv2.String("kubernetes.io/cluster/test-cluster-x7k2p"), Value: awsv2.String("owned")}}},
+			},
+		}, nil)
+
+	names, err := getClusterLoadBalancerDNSNames(context.Background(), client, cluster)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"test-cluster-x7k2p-ext.elb.amazonaws.com",
+		"test-cluster-x7k2p-int.elb.amazonaws.com",
+		"a1b2c3.elb.amazonaws.com",
+	}, names)
+}
+
+func TestIsClusterHostedZone(t *testing.T) {
+	classic := createTestClassicCluster(t)
+	hcp, _ := cmv1.NewCluster().
+		Name("hcp-cluster").
+		DNS(cmv1.NewDNS().BaseDomain("abcd.openshiftapps.com")).
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		Build()
+
+	tests := []struct {
+		name     string
+		zone     string
+		cluster  *cmv1.Cluster
+		expected bool
+	}{
+		{name: "classic cluster domain", zone: "test-cluster.abcd.p1.openshiftapps.com.", cluster: classic, expected: true},
+		{name: "base domain", zone: "abcd.p1.openshiftapps.com.", cluster: classic, expected: false},
+		{name: "other cluster with same suffix", zone: "other-test-cluster.abcd.p1.openshiftapps.com.", cluster: classic, expected: false},
+		{name: "HCP rosa subdomain", zone: "rosa.hcp-cluster.abcd.openshiftapps.com.", cluster: hcp, expected: true},
+		{name: "HCP local zone", zone: "hcp-cluster.hypershift.local.", cluster: hcp, expected: true},
+		{name: "local zone of classic cluster", zone: "test-cluster.hypershift.local.", cluster: classic, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, isClusterHostedZone(tt.zone, tt.cluster))
+		})
+	}
+}
+
+func TestVerifyZoneAssociation(t *testing.T) {
+	tests := []struct {
+		name           string
+		zoneVPCs       []string
+		clusterVPCs    []string
+		expectedStatus dns.VerifyResultStatus
+		expectedError  string
+	}{
+		{
+			name:           "associated",
+			zoneVPCs:       []string{"vpc-1", "vpc-2"},
+			clusterVPCs:    []string{"vpc-2"},
+			expectedStatus: dns.VerifyResultStatusPass,
+		},
+		{
+			name:           "not associated",
+			zoneVPCs:       []string{"vpc-1"},
+			clusterVPCs:    []string{"vpc-2"},
+			expectedStatus: dns.VerifyResultStatusFail,
+			expectedError:  "not associated with the cluster VPC vpc-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var vpcs []route53types.VPC
+			for _, id := range tt.zoneVPCs {
+				vpcs = append(vpcs, route53types.VPC{VPCId: awsv2.String(id)})
+			}
+
+			res := verifyZoneAssociation("test.example.com", vpcs, tt.clusterVPCs)
+
+			assert.Equal(t, dns.RecordTypeZone, res.Type)
+			assert.Equal(t, tt.expectedStatus, res.Status)
+			assert.Contains(t, res.ErrorMessage, tt.expectedError)
+		})
+	}
+}
+
+func TestVerifyHostedZones(t *testing.T) {
+	t.Run("verifies delegation and VPC association", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := awsmock.NewMockContextClient(ctrl)
+		cluster := createTestClassicCluster(t, "subnet-1", "subnet-2")
+
+		client.EXPECT().ListHostedZones(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesOutput{
+			HostedZones: []route53types.HostedZone{
+				{Id: awsv2.String("Z1"), Name: awsv2.String("test-cluster.abcd.p1.openshiftapps.com."), Config: &route53types.HostedZoneConfig{PrivateZone: false}},
+				{Id: awsv2.String("Z2"), Name: awsv2.String("test-cluster.abcd.p1.openshiftapps.com."), Config: &route53types.HostedZoneConfig{PrivateZone: true}},
+				{Id: awsv2.String("Z3"), Name: awsv2.String("unrelated.example.com.")},
+			},
+		}, nil)
+		client.EXPECT().GetHostedZone(gomock.Any(), &route53.GetHostedZoneInput{Id: awsv2.String("Z1")}).Return(&route53.GetHostedZoneOutput{
+			DelegationSet: &route53types.DelegationSet{NameServers: []string{"ns-1.awsdns-01.org"}},
+		}, nil)
+		client.EXPECT().GetHostedZone(gomock.Any(), &route53.GetHostedZoneInput{Id: awsv2.String("Z2")}).Return(&route53.GetHostedZoneOutput{
+			VPCs: []route53types.VPC{{VPCId: awsv2.String("vpc-1")}},
+		}, nil)
+		client.EXPECT().DescribeSubnets(gomock.Any(), &ec2.DescribeSubnetsInput{SubnetIds: []string{"subnet-1", "subnet-2"}}).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{{VpcId: awsv2.String("vpc-1")}, {VpcId: awsv2.String("vpc-1")}},
+		}, nil)
+
+		verifier := new(MockVerifier)
+		nsResult := dns.VerifyResult{Name: "test-cluster.abcd.p1.openshiftapps.com", Type: dns.RecordTypeNS, Status: dns.VerifyResultStatusPass}
+		verifier.On("VerifyNSRecord", mock.Anything, "test-cluster.abcd.p1.openshiftapps.com",
+			[]dns.VerifyNSRecordOption{dns.WithExpectedNameServers{"ns-1.awsdns-01.org"}}).Return(nsResult)
+
+		opts := &verifyDNSOptions{verifier: verifier, IOStreams: genericclioptions.NewTestIOStreamsDiscard()}
+		results, err := opts.verifyHostedZones(context.Background(), client, cluster)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, nsResult, results[0])
+		assert.Equal(t, dns.RecordTypeZone, results[1].Type)
+		assert.Equal(t, dns.VerifyResultStatusPass, results[1].Status)
+		assert.Equal(t, "vpc-1", results[1].ExpectedTarget)
+		verifier.AssertExpectations(t)
+	})
+
+	t.Run("fails without hosted zones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		client := awsmock.NewMockContextClient(ctrl)
+		cluster := createTestClassicCluster(t)
+
+		client.EXPECT().ListHostedZones(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesOutput{}, nil)
+
+		opts := &verifyDNSOptions{IOStreams: genericclioptions.NewTestIOStreamsDiscard()}
+		results, err := opts.verifyHostedZones(context.Background(), client, cluster)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, dns.VerifyResultStatusFail, results[0].Status)
+		assert.Equal(t, "test-cluster.abcd.p1.openshiftapps.com", results[0].Name)
+	})
+}
@@ -189,11 +189,74 @@ func TestVerifyDNSOptions_BuildTestCases(t *testing.T) {
 	}
 }
 
+func TestVerifyDNSOptions_BuildClassicTestCases(t *testing.T) {
+	tests := []struct {
+		name            string
+		private         bool
+		resolver        string
+		expectedSkipped []string
+	}{
+		{
+			name:            "public cluster with system resolver",
+			expectedSkipped: []string{"api_int"},
+		},
+		{
+			name:            "private cluster with system resolver",
+			private:         true,
+			expectedSkipped: []string{"api", "api_int"},
+		},
+		{
+			name:     "private cluster with VPC resolver",
+			private:  true,
+			resolver: "tcp://127.0.0.1:5353",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			listening := cmv1.ListeningMethodExternal
+			if tt.private {
+				listening = cmv1.ListeningMethodInternal
+			}
+			cluster, err := cmv1.NewCluster().
+				Name("test-cluster").
+				DNS(cmv1.NewDNS().BaseDomain("abcd.p1.openshiftapps.com")).
+				Console(cmv1.NewClusterConsole().URL("https://console-openshift-console.apps.test-cluster.abcd.p1.openshiftapps.com")).
+				API(cmv1.NewClusterAPI().Listening(listening)).
+				Build()
+			assert.NoError(t, err)
+
+			opts := &verifyDNSOptions{resolver: tt.resolver}
+			testCases := opts.buildTestCases(cluster)
+
+			assert.Len(t, testCases, 5)
+			assert.Equal(t, "api.test-cluster.abcd.p1.openshiftapps.com", testCases["api"].name)
+			assert.Equal(t, "api-int.test-cluster.abcd.p1.openshiftapps.com", testCases["api_int"].name)
+			assert.Equal(t, "oauth-openshift.apps.test-cluster.abcd.p1.openshiftapps.com", testCases["oauth"].name)
+			assert.Equal(t, "osdctl-verify-dns.apps.test-cluster.abcd.p1.openshiftapps.com", testCases["apps_wildcard"].name)
+
+			var skipped []string
+			for key, tc := range testCases {
+				assert.Equal(t, dns.RecordTypeA, tc.recordType, key)
+				assert.True(t, tc.checkLoadBalancer, key)
+				if tc.skip {
+					assert.NotEmpty(t, tc.skipReason, key)
+					skipped = append(skipped, key)
+				}
+			}
+			assert.ElementsMatch(t, tt.expectedSkipped, skipped)
+		})
+	}
+}
+
 func TestVerifyDNSOptions_Complete(t *testing.T) {
 	tests := []struct {
 		name        string
 		clusterID   string
+		resolver    string
 		expectError bool
+		errContent  string
 	}{
 		{
 			name:        "valid cluster ID",
@@ -204,6 +267,20 @@ func TestVerifyDNSOptions_Complete(t *testing.T) {
 			name:        "empty cluster ID",
 			clusterID:   "",
 			expectError: true,
+			errContent:  "cluster-id is required",
+		},
+		{
+			name:        "valid resolver",
+			clusterID:   "test-cluster-123",
+			resolver:    "tcp://127.0.0.1:5353",
+			expectError: false,
+		},
+		{
+			name:        "invalid resolver protocol",
+			clusterID:   "test-cluster-123",
+			resolver:    "https://dns.example.com",
+			expectError: true,
+			errContent:  "unsupported resolver protocol",
 		},
 	}
 
@@ -212,13 +289,14 @@ func TestVerifyDNSOptions_Complete(t *testing.T) {
 			t.Parallel()
 			opts := &verifyDNSOptions{
 				clusterID: tt.clusterID,
+				resolver:  tt.resolver,
 			}
 
 			err := opts.complete(nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.Contains(t, err.Error(), "cluster-id is required")
+				assert.Contains(t, err.Error(), tt.errContent)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -231,6 +309,7 @@ func TestRecommender_MakeRecommendations(t *testing.T) {
 		name                    string
 		results                 []dns.VerifyResult
 		clusterID               string
+		classic                 bool
 		expectedRecommendations int
 		shouldContain           []string
 	}{
@@ -282,6 +361,59 @@ func TestRecommender_MakeRecommendations(t *testing.T) {
 			expectedRecommendations: 1,
 			shouldContain:           []string{"API", "external-dns"},
 		},
+		{
+			name: "classic API failure",
+			results: []dns.VerifyResult{
+				{
+					Name:   "api.test.example.com",
+					Status: dns.VerifyResultStatusFail,
+				},
+			},
+			clusterID:               "test-cluster",
+			classic:                 true,
+			expectedRecommendations: 1,
+			shouldContain:           []string{"API", "<infra-id>-ext"},
+		},
+		{
+			name: "classic ingress failure",
+			results: []dns.VerifyResult{
+				{
+					Name:   "osdctl-verify-dns.apps.test.example.com",
+					Status: dns.VerifyResultStatusFail,
+				},
+			},
+			clusterID:               "test-cluster",
+			classic:                 true,
+			expectedRecommendations: 1,
+			shouldContain:           []string{"router-default"},
+		},
+		{
+			name: "hosted zone delegation failure",
+			results: []dns.VerifyResult{
+				{
+					Name:   "rosa.test.example.com",
+					Type:   dns.RecordTypeNS,
+					Status: dns.VerifyResultStatusFail,
+				},
+			},
+			clusterID:               "test-cluster",
+			expectedRecommendations: 1,
+			shouldContain:           []string{"delegation set"},
+		},
+		{
+			name: "private hosted zone association failure",
+			results: []dns.VerifyResult{
+				{
+					Name:   "test.example.com",
+					Type:   dns.RecordTypeZone,
+					Status: dns.VerifyResultStatusFail,
+				},
+			},
+			clusterID:               "test-cluster",
+			classic:                 true,
+			expectedRecommendations: 1,
+			shouldContain:           []string{"cluster VPC"},
+		},
 		{
 			name: "no failures",
 			results: []dns.VerifyResult{
@@ -312,7 +444,7 @@ func TestRecommender_MakeRecommendations(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			r := &recommender{}
-			cluster, _ := cmv1.NewCluster().ID(tt.clusterID).Build()
+			cluster, _ := cmv1.NewCluster().ID(tt.clusterID).Hypershift(cmv1.NewHypershift().Enabled(!tt.classic)).Build()
 			cfg := dns.MakeRecommendationsConfig{
 				Cluster: cluster,
 			}
@@ -337,8 +469,8 @@ type MockVerifier struct {
 	mock.Mock
 }
 
-func (m *MockVerifier) VerifyARecord(ctx context.Context, path string) dns.VerifyResult {
-	args := m.Called(ctx, path)
+func (m *MockVerifier) VerifyARecord(ctx context.Context, path string, opts ...dns.VerifyARecordOption) dns.VerifyResult {
+	args := m.Called(ctx, path, opts)
 	return args.Get(0).(dns.VerifyResult)
 }
 
@@ -347,6 +479,11 @@ func (m *MockVerifier) VerifyCNAMERecord(ctx context.Context, name string, opts
 	return args.Get(0).(dns.VerifyResult)
 }
 
+func (m *MockVerifier) VerifyNSRecord(ctx context.Context, zone string, opts ...dns.VerifyNSRecordOption) dns.VerifyResult {
+	args := m.Called(ctx, zone, opts)
+	return args.Get(0).(dns.VerifyResult)
+}
+
 // MockAnalyzer is a mock implementation of the Analyzer interface
 type MockAnalyzer struct {
 	mock.Mock
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -22,6 +23,7 @@ import (
 	"github.com/openshift/osdctl/cmd/alerts"
 	"github.com/openshift/osdctl/cmd/cloudtrail"
 	"github.com/openshift/osdctl/cmd/cluster"
+	"github.com/openshift/osdctl/cmd/cluster/access"
 	"github.com/openshift/osdctl/cmd/cost"
 	"github.com/openshift/osdctl/cmd/dynatrace"
 	"github.com/openshift/osdctl/cmd/env"
@@ -79,6 +81,10 @@ func NewCmdRoot(streams genericclioptions.IOStreams) *cobra.Command {
 			if shouldRunVersionCheck(skipVersionCheck, cmd.Use) {
 				versionCheck()
 			}
+
+			if shouldWarnExpiredSessions(cmd) {
+				access.WarnExpiredSessions(os.Stderr, time.Now())
+			}
 		},
 	}
 
@@ -129,6 +135,18 @@ func shouldRunVersionCheck(skipVersionCheckFlag bool, commandName string) bool {
 	return !(skipVersionCheckFlag || canCommandSkipVersionCheck(commandName))
 }
 
+// shouldWarnExpiredSessions returns whether the expired break-glass sessions should be reported before running cmd.
+// The break-glass subcommands show them themselves, and shell completion output must not be polluted.
+func shouldWarnExpiredSessions(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+			return false
+		}
+	}
+	return cmd.Parent() == nil || cmd.Parent().Name() != "break-glass"
+}
+
 func canCommandSkipVersionCheck(commandName string) bool {
 	// Checks if the specific command is in the allowlist
 	return slice.ContainsString(getSkipVersionCommands(), commandName, nil)
@@ -1,6 +1,7 @@
 package cost
 
 import (
+	"context"
 	"log"
 
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
@@ -9,6 +10,7 @@ import (
 	"github.com/openshift/osdctl/internal/utils/globalflags"
 	awsprovider "github.com/openshift/osdctl/pkg/provider/aws"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -20,6 +22,9 @@ func NewCmdCost(streams genericclioptions.IOStreams, globalOpts *globalflags.Glo
 		Short: "Cost Management related utilities",
 		Long: `The cost command allows for cost management on the AWS platform (other 
 platforms may be added in the future)`,
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			awsprovider.PrintRequestMetrics(streams.ErrOut)
+		},
 	}
 
 	//Set flags
@@ -28,6 +33,7 @@ platforms may be added in the future)`,
 	costCmd.PersistentFlags().StringVarP(&opsCost.profile, "aws-profile", "p", "", "specify AWS profile")
 	costCmd.PersistentFlags().StringVarP(&opsCost.configFile, "aws-config", "c", "", "specify AWS config file path")
 	costCmd.PersistentFlags().StringVarP(&opsCost.region, "aws-region", "g", common.DefaultRegion, "specify AWS region")
+	costCmd.PersistentFlags().BoolVar(&opsCost.verbose, "verbose", false, "Print timing of every AWS API call and a summary at the end")
 
 	//Add commands
 	costCmd.AddCommand(newCmdGet(streams, globalOpts))
@@ -49,6 +55,7 @@ type costOptions struct {
 	configFile      string
 	profile         string
 	region          string
+	verbose         bool
 
 	genericclioptions.IOStreams
 }
@@ -61,15 +68,22 @@ func newCostOptions(streams genericclioptions.IOStreams) *costOptions {
 
 // Initiate AWS clients for Organizations and Cost Explorer services using, if given, credentials in flags, else, credentials in the environment
 func (opsCost *costOptions) initAWSClients() (awsprovider.Client, error) {
+	return opsCost.initAWSClientsWithContext(context.Background())
+}
+
+// initAWSClientsWithContext behaves like initAWSClients, but every AWS call is issued with ctx so the command can be cancelled
+func (opsCost *costOptions) initAWSClientsWithContext(ctx context.Context) (awsprovider.Client, error) {
+	viper.Set(awsprovider.RequestMetricsConfigKey, opsCost.verbose)
+
 	//Initialize AWS clients
 	var (
-		awsClient awsprovider.Client
+		awsClient awsprovider.ContextClient
 		err       error
 	)
 	if opsCost.accessKeyID == "" && opsCost.secretAccessKey == "" {
-		awsClient, err = awsprovider.NewAwsClient(opsCost.profile, opsCost.region, opsCost.configFile)
+		awsClient, err = awsprovider.NewAwsContextClient(ctx, opsCost.profile, opsCost.region, opsCost.configFile)
 	} else {
-		awsClient, err = awsprovider.NewAwsClientWithInput(&awsprovider.ClientInput{
+		awsClient, err = awsprovider.NewAwsContextClientWithInput(ctx, &awsprovider.ClientInput{
 			AccessKeyID:     opsCost.accessKeyID,
 			SecretAccessKey: opsCost.secretAccessKey,
 			Region:          opsCost.region,
@@ -80,7 +94,7 @@ func (opsCost *costOptions) initAWSClients() (awsprovider.Client, error) {
 		return nil, err
 	}
 
-	return awsClient, err
+	return awsprovider.BindContext(ctx, awsClient), nil
 }
 
 // Gets information regarding Organizational Unit
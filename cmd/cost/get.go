@@ -1,6 +1,7 @@
 package cost
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -27,7 +28,7 @@ func newCmdGet(streams genericclioptions.IOStreams, globalOpts *globalflags.Glob
 		Short: "Get total cost of a given OU",
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(ops.checkArgs(cmd, args))
-			cmdutil.CheckErr(ops.run())
+			cmdutil.CheckErr(ops.run(cmd.Context()))
 		},
 	}
 	getCmd.Flags().StringVar(&ops.ou, "ou", "", "set OU ID")
@@ -101,9 +102,9 @@ func newGetOptions(streams genericclioptions.IOStreams, globalOpts *globalflags.
 	}
 }
 
-func (o *getOptions) run() error {
+func (o *getOptions) run(ctx context.Context) error {
 
-	awsClient, err := opsCost.initAWSClients()
+	awsClient, err := opsCost.initAWSClientsWithContext(ctx)
 	if err != nil {
 		return err
 	}
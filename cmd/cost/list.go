@@ -1,6 +1,7 @@
 package cost
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sort"
@@ -23,7 +24,7 @@ func newCmdList(streams genericclioptions.IOStreams, globalOpts *globalflags.Glo
 		Short: "List the cost of each Account/OU under given OU",
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(ops.checkArgs(cmd, args))
-			cmdutil.CheckErr(ops.runList())
+			cmdutil.CheckErr(ops.runList(cmd.Context()))
 		},
 	}
 	listCmd.Flags().StringArrayVar(&ops.ou, "ou", []string{}, "get OU ID")
@@ -111,8 +112,8 @@ func newListOptions(streams genericclioptions.IOStreams, globalOpts *globalflags
 	}
 }
 
-func (o *listOptions) runList() error {
-	awsClient, err := opsCost.initAWSClients()
+func (o *listOptions) runList(ctx context.Context) error {
+	awsClient, err := opsCost.initAWSClientsWithContext(ctx)
 	cmdutil.CheckErr(err)
 
 	printHeader(o)
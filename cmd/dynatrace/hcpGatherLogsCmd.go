@@ -24,6 +24,8 @@ type GatherLogsOpts struct {
 	SortOrder string
 	DestDir   string
 	ClusterID string
+	// Namespaces restricts the gathered namespaces to those matching one of the shell patterns, all if empty
+	Namespaces []string
 }
 
 func NewCmdHCPMustGather() *cobra.Command {
@@ -80,7 +82,7 @@ func (g *GatherLogsOpts) GatherLogs(clusterID string, elevationReasons ...string
 
 	fmt.Printf("Using HCP Namespace %v\n", hcpCluster.hcpNamespace)
 
-	gatherNamespaces := []string{hcpCluster.hcpNamespace, hcpCluster.klusterletNS, hcpCluster.hostedNS, "hypershift", "cert-manager", "redhat-cert-manager-operator", "open-cluster-management-agent", "open-cluster-management-agent-addon"}
+	gatherNamespaces := FilterNamespaces([]string{hcpCluster.hcpNamespace, hcpCluster.klusterletNS, hcpCluster.hostedNS, "hypershift", "cert-manager", "redhat-cert-manager-operator", "open-cluster-management-agent", "open-cluster-management-agent-addon"}, g.Namespaces)
 
 	gatherDir, err := setupGatherDir(g.DestDir, hcpCluster.hcpNamespace)
 	if err != nil {
@@ -407,3 +409,21 @@ func getDeploymentsForNamespace(clientset *kubernetes.Clientset, namespace strin
 
 	return deploys, nil
 }
+
+// FilterNamespaces returns the namespaces matching one of the shell patterns, or all namespaces if there are none
+func FilterNamespaces(namespaces []string, patterns []string) []string {
+	if len(patterns) == 0 {
+		return namespaces
+	}
+
+	var filtered []string
+	for _, ns := range namespaces {
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, ns); ok {
+				filtered = append(filtered, ns)
+				break
+			}
+		}
+	}
+	return filtered
+}
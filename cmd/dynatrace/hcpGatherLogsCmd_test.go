@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -277,3 +278,43 @@ func TestGetEventQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterNamespaces(t *testing.T) {
+	namespaces := []string{"ocm-production-abc-test", "hypershift", "cert-manager", "open-cluster-management-agent"}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+	}{
+		{
+			name:     "no_patterns_keeps_all",
+			patterns: nil,
+			expected: namespaces,
+		},
+		{
+			name:     "exact_name",
+			patterns: []string{"hypershift"},
+			expected: []string{"hypershift"},
+		},
+		{
+			name:     "glob_pattern",
+			patterns: []string{"ocm-*", "open-cluster-management-*"},
+			expected: []string{"ocm-production-abc-test", "open-cluster-management-agent"},
+		},
+		{
+			name:     "no_match",
+			patterns: []string{"kube-system"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterNamespaces(namespaces, tt.patterns)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -23,7 +23,8 @@ func NewCmdBackup() *cobra.Command {
 		Long:  longDescription,
 		Example: "  osdctl hcp backup --cluster-id 1abc2def3ghi --reason OHSS-12345\n" +
 			"  osdctl hcp backup --cluster-id 1abc2def3ghi --reason OHSS-12345 --label env=prod --label incident=OHSS-12345\n" +
-			"  osdctl hcp backup --cluster-id 1abc2def3ghi --reason OHSS-12345 --annotation owner=sre-team",
+			"  osdctl hcp backup --cluster-id 1abc2def3ghi --reason OHSS-12345 --annotation owner=sre-team\n" +
+			"  osdctl hcp backup --cluster-id 1abc2def3ghi --reason OHSS-12345 --wait --timeout 1h",
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -49,17 +50,113 @@ func NewCmdBackup() *cobra.Command {
 	_ = cmd.MarkFlagRequired("cluster-id")
 	_ = cmd.MarkFlagRequired("reason")
 
+	cmd.AddCommand(newCmdList())
+	cmd.AddCommand(newCmdDescribe())
+	cmd.AddCommand(newCmdRestore())
+
+	return cmd
+}
+
+// runLifecycle creates the OCM connection and runner shared by the list,
+// describe and restore subcommands, and passes the runner to fn.
+func runLifecycle(cmd *cobra.Command, fn func(r *defaultBackupRunner) error) error {
+	logger := logrus.New()
+	logger.SetOutput(cmd.ErrOrStderr())
+
+	ocmConn, err := utils.CreateConnection()
+	if err != nil {
+		return fmt.Errorf("creating OCM connection: %w", err)
+	}
+	defer ocmConn.Close()
+
+	return fn(NewDefaultBackupRunner(
+		ocmConn,
+		WithLogger{Logger: logger},
+		WithPrinter{Printer: &defaultPrinter{w: cmd.OutOrStdout()}},
+	))
+}
+
+func newCmdList() *cobra.Command {
+	flags := &lifecycleFlags{}
+
+	cmd := &cobra.Command{
+		Use:               "list --cluster-id <cluster-id>",
+		Short:             "List the Velero backups of an HCP cluster",
+		Long:              "List the Velero backups created from the HCP cluster's daily schedule, newest first.",
+		Example:           "  osdctl hcp backup list --cluster-id 1abc2def3ghi",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLifecycle(cmd, func(r *defaultBackupRunner) error {
+				return r.List(cmd.Context(), flags.clusterID)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.clusterID, "cluster-id", "C", "", "Internal ID, name, or external ID of the HCP cluster")
+	_ = cmd.MarkFlagRequired("cluster-id")
+
+	return cmd
+}
+
+func newCmdDescribe() *cobra.Command {
+	flags := &lifecycleFlags{}
+
+	cmd := &cobra.Command{
+		Use:               "describe <backup-name> --cluster-id <cluster-id>",
+		Short:             "Describe a Velero backup of an HCP cluster",
+		Long:              "Show the phase, timestamps, item counts, warnings and errors of a Velero backup of the HCP cluster.",
+		Example:           "  osdctl hcp backup describe 1abc2def3ghi-daily-20260319184212 --cluster-id 1abc2def3ghi",
+		Args:              cobra.ExactArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLifecycle(cmd, func(r *defaultBackupRunner) error {
+				return r.Describe(cmd.Context(), flags.clusterID, args[0])
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.clusterID, "cluster-id", "C", "", "Internal ID, name, or external ID of the HCP cluster")
+	_ = cmd.MarkFlagRequired("cluster-id")
+
+	return cmd
+}
+
+func newCmdRestore() *cobra.Command {
+	flags := &lifecycleFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "restore --cluster-id <cluster-id> --backup <backup-name> --dry-run",
+		Short: "Generate the Velero Restore CR for a backup of an HCP cluster",
+		Long: "Generate the Velero Restore CR which restores the HCP cluster from one of its backups, to rehearse the\n" +
+			"restore procedure. The Restore CR is printed but never applied, so --dry-run is required.",
+		Example:           "  osdctl hcp backup restore --cluster-id 1abc2def3ghi --backup 1abc2def3ghi-daily-20260319184212 --dry-run",
+		Args:              cobra.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLifecycle(cmd, func(r *defaultBackupRunner) error {
+				return r.Restore(cmd.Context(), flags.clusterID, flags.backupName, flags.dryRun)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.clusterID, "cluster-id", "C", "", "Internal ID, name, or external ID of the HCP cluster")
+	cmd.Flags().StringVar(&flags.backupName, "backup", "", "Name of the Velero Backup CR to restore from")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Print the Restore CR without applying it")
+	_ = cmd.MarkFlagRequired("cluster-id")
+	_ = cmd.MarkFlagRequired("backup")
+
 	return cmd
 }
 
 // newKubeClientForCluster logs into the given cluster via backplane, reusing the
 // caller's OCM connection to avoid opening a second connection. elevationReasons,
-// if provided, elevates the session to backplane-cluster-admin (required for pod
-// listing and exec).
+// if provided, elevates the session to backplane-cluster-admin (required for
+// creating the Velero Backup CR).
 func newKubeClientForCluster(ocmConn *ocmsdk.Connection, clusterID string, elevationReasons ...string) (KubeClient, error) {
-	kubeCli, restCfg, clientset, err := common.GetKubeConfigAndClientWithConn(clusterID, ocmConn, elevationReasons...)
+	kubeCli, _, _, err := common.GetKubeConfigAndClientWithConn(clusterID, ocmConn, elevationReasons...)
 	if err != nil {
 		return nil, err
 	}
-	return newKubeClient(kubeCli, restCfg, clientset), nil
+	return newKubeClient(kubeCli), nil
 }
@@ -1,17 +1,27 @@
 package backup
 
-import "github.com/spf13/pflag"
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// defaultWaitTimeout is how long --wait waits for the backup to complete.
+const defaultWaitTimeout = 30 * time.Minute
 
 // backupFlags holds the parsed command-line flag values for the backup command.
 type backupFlags struct {
 	clusterID string
 	reason    string
-	// labels holds optional key=value pairs that are forwarded to the Velero
-	// backup CR via --labels. Populated by repeated --label flags.
+	// labels holds optional key=value pairs that are set on the Velero Backup
+	// CR on top of the schedule's labels. Populated by repeated --label flags.
 	labels map[string]string
-	// annotations holds optional key=value pairs that are forwarded to the
-	// Velero backup CR via --annotations. Populated by repeated --annotation flags.
+	// annotations holds optional key=value pairs that are set on the Velero
+	// Backup CR. Populated by repeated --annotation flags.
 	annotations map[string]string
+	// wait blocks until the Velero Backup CR reaches a terminal phase.
+	wait    bool
+	timeout time.Duration
 }
 
 // AddFlags binds the command-line flags for this command to the given FlagSet.
@@ -20,4 +30,15 @@ func (f *backupFlags) AddFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&f.reason, "reason", "", "Reason for privilege elevation (e.g., OHSS-1234 or PD incident ID)")
 	flags.StringToStringVar(&f.labels, "label", nil, "Label to add to the Velero Backup CR (key=value); may be repeated")
 	flags.StringToStringVar(&f.annotations, "annotation", nil, "Annotation to add to the Velero Backup CR (key=value); may be repeated")
+	flags.BoolVar(&f.wait, "wait", false, "Wait for the backup to complete and report its item counts, warnings and errors")
+	flags.DurationVar(&f.timeout, "timeout", defaultWaitTimeout, "Maximum time to wait for the backup to complete with --wait")
+}
+
+// lifecycleFlags holds the parsed command-line flag values for the list,
+// describe and restore subcommands.
+type lifecycleFlags struct {
+	clusterID string
+	// backupName is the Velero Backup CR to restore from.
+	backupName string
+	dryRun     bool
 }
@@ -0,0 +1,321 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/printer"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// scheduleNameLabel is set by Velero on backups created from a schedule, including
+// those created with velero backup create --from-schedule.
+const scheduleNameLabel = "velero.io/schedule-name"
+
+var (
+	scheduleGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Schedule"}
+	backupGVK   = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Backup"}
+	restoreGVK  = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Restore"}
+)
+
+// terminalBackupPhases are the Velero Backup phases after which the backup no longer changes.
+var terminalBackupPhases = map[string]bool{
+	"Completed":        true,
+	"PartiallyFailed":  true,
+	"Failed":           true,
+	"FailedValidation": true,
+}
+
+// backupSummary holds the fields of a Velero Backup CR shown by the lifecycle commands.
+type backupSummary struct {
+	Name                string
+	Schedule            string
+	Phase               string
+	StartTimestamp      string
+	CompletionTimestamp string
+	Expiration          string
+	ItemsBackedUp       int64
+	TotalItems          int64
+	Warnings            int64
+	Errors              int64
+	FailureReason       string
+	ValidationErrors    []string
+	StorageLocation     string
+	IncludedNamespaces  []string
+}
+
+// parseBackup reads a backupSummary from an unstructured Velero Backup CR. Missing
+// fields are left empty, e.g. while the backup is still New.
+func parseBackup(u *unstructured.Unstructured) backupSummary {
+	b := backupSummary{
+		Name:     u.GetName(),
+		Schedule: u.GetLabels()[scheduleNameLabel],
+	}
+	b.Phase, _, _ = unstructured.NestedString(u.Object, "status", "phase")
+	b.StartTimestamp, _, _ = unstructured.NestedString(u.Object, "status", "startTimestamp")
+	b.CompletionTimestamp, _, _ = unstructured.NestedString(u.Object, "status", "completionTimestamp")
+	b.Expiration, _, _ = unstructured.NestedString(u.Object, "status", "expiration")
+	b.ItemsBackedUp, _, _ = unstructured.NestedInt64(u.Object, "status", "progress", "itemsBackedUp")
+	b.TotalItems, _, _ = unstructured.NestedInt64(u.Object, "status", "progress", "totalItems")
+	b.Warnings, _, _ = unstructured.NestedInt64(u.Object, "status", "warnings")
+	b.Errors, _, _ = unstructured.NestedInt64(u.Object, "status", "errors")
+	b.FailureReason, _, _ = unstructured.NestedString(u.Object, "status", "failureReason")
+	b.ValidationErrors, _, _ = unstructured.NestedStringSlice(u.Object, "status", "validationErrors")
+	b.StorageLocation, _, _ = unstructured.NestedString(u.Object, "spec", "storageLocation")
+	b.IncludedNamespaces, _, _ = unstructured.NestedStringSlice(u.Object, "spec", "includedNamespaces")
+	if b.Phase == "" {
+		b.Phase = "New"
+	}
+	return b
+}
+
+// belongsToCluster returns whether a backup was created from the cluster's schedule.
+func belongsToCluster(b backupSummary, scheduleName string) bool {
+	return b.Schedule == scheduleName || strings.HasPrefix(b.Name, scheduleName+"-")
+}
+
+// getBackup retrieves a Velero Backup CR from the ADP namespace.
+func (r *defaultBackupRunner) getBackup(ctx context.Context, readClient KubeClient, name string) (*unstructured.Unstructured, error) {
+	backup := &unstructured.Unstructured{}
+	backup.SetGroupVersionKind(backupGVK)
+	if err := readClient.Get(ctx, client.ObjectKey{Namespace: r.cfg.ADPNamespace, Name: name}, backup); err != nil {
+		return nil, fmt.Errorf("getting Velero backup %q in namespace %q: %w", name, r.cfg.ADPNamespace, err)
+	}
+	return backup, nil
+}
+
+// waitForBackup polls the Backup CR until it reaches a terminal phase or timeout
+// elapses, logging progress whenever it changes.
+func (r *defaultBackupRunner) waitForBackup(ctx context.Context, readClient KubeClient, name string, timeout time.Duration) (backupSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(r.cfg.WaitPollInterval)
+	defer ticker.Stop()
+
+	var last backupSummary
+	for {
+		backup, err := r.getBackup(ctx, readClient, name)
+		if err != nil {
+			// The Backup CR may not be visible yet right after submission
+			r.logger.Debugf("Backup %q not available yet: %v", name, err)
+		} else {
+			b := parseBackup(backup)
+			if b.Phase != last.Phase || b.ItemsBackedUp != last.ItemsBackedUp {
+				r.logger.Infof("Backup %q is %s (%d/%d items)", name, b.Phase, b.ItemsBackedUp, b.TotalItems)
+			}
+			last = b
+			if terminalBackupPhases[b.Phase] {
+				return b, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("timed out after %s waiting for backup %q to complete (last phase: %s)", timeout, name, last.Phase)
+		case <-ticker.C:
+		}
+	}
+}
+
+// printBackupResult prints the outcome of a backup which was waited for. It returns
+// an error unless the backup completed without failures.
+func (r *defaultBackupRunner) printBackupResult(b backupSummary) error {
+	r.printer.Printf("Backup %q finished with phase %s.\n", b.Name, b.Phase)
+	r.printer.Printf("Items backed up: %d/%d, warnings: %d, errors: %d\n", b.ItemsBackedUp, b.TotalItems, b.Warnings, b.Errors)
+	if b.FailureReason != "" {
+		r.printer.Printf("Failure reason: %s\n", b.FailureReason)
+	}
+	for _, e := range b.ValidationErrors {
+		r.printer.Printf("Validation error: %s\n", e)
+	}
+
+	if b.Phase != "Completed" {
+		return fmt.Errorf("backup %q did not complete successfully: %s", b.Name, b.Phase)
+	}
+	return nil
+}
+
+// resolveReadClient resolves the HCP cluster and returns its schedule name and an
+// unprivileged client for its management cluster.
+func (r *defaultBackupRunner) resolveReadClient(ctx context.Context, clusterID string) (string, KubeClient, error) {
+	clusterInfo, err := r.resolver.Resolve(ctx, clusterID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	readClient, err := r.builder.Build(ctx, WithClusterID{ClusterID: clusterInfo.MgmtClusterID})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return clusterInfo.HCPClusterID + r.cfg.ScheduleNameSuffix, readClient, nil
+}
+
+// getClusterBackup retrieves a backup and verifies that it belongs to the cluster,
+// so a mistyped name never targets another cluster's backup.
+func (r *defaultBackupRunner) getClusterBackup(ctx context.Context, clusterID, name string) (*unstructured.Unstructured, backupSummary, error) {
+	scheduleName, readClient, err := r.resolveReadClient(ctx, clusterID)
+	if err != nil {
+		return nil, backupSummary{}, err
+	}
+
+	backup, err := r.getBackup(ctx, readClient, name)
+	if err != nil {
+		return nil, backupSummary{}, err
+	}
+
+	b := parseBackup(backup)
+	if !belongsToCluster(b, scheduleName) {
+		return nil, backupSummary{}, fmt.Errorf("backup %q was not created from the cluster's schedule %q", name, scheduleName)
+	}
+
+	return backup, b, nil
+}
+
+// List prints the cluster's backups, newest first.
+func (r *defaultBackupRunner) List(ctx context.Context, clusterID string) error {
+	scheduleName, readClient, err := r.resolveReadClient(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	backups := &unstructured.UnstructuredList{}
+	backups.SetGroupVersionKind(backupGVK.GroupVersion().WithKind("BackupList"))
+	if err := readClient.List(ctx, backups, client.InNamespace(r.cfg.ADPNamespace)); err != nil {
+		return fmt.Errorf("listing Velero backups in namespace %q: %w", r.cfg.ADPNamespace, err)
+	}
+
+	var summaries []backupSummary
+	for i := range backups.Items {
+		b := parseBackup(&backups.Items[i])
+		if belongsToCluster(b, scheduleName) {
+			summaries = append(summaries, b)
+		}
+	}
+	if len(summaries) == 0 {
+		r.printer.Printf("No backups found for schedule %q in namespace %q.\n", scheduleName, r.cfg.ADPNamespace)
+		return nil
+	}
+
+	// Velero timestamps are RFC3339, so they sort lexically; backups which haven't started yet go first
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if (summaries[i].StartTimestamp == "") != (summaries[j].StartTimestamp == "") {
+			return summaries[i].StartTimestamp == ""
+		}
+		return summaries[i].StartTimestamp > summaries[j].StartTimestamp
+	})
+
+	sb := &strings.Builder{}
+	p := printer.NewTablePrinter(sb, 20, 1, 3, ' ')
+	p.AddRow([]string{"NAME", "PHASE", "STARTED", "COMPLETED", "EXPIRES", "ITEMS", "WARNINGS", "ERRORS"})
+	for _, b := range summaries {
+		p.AddRow([]string{
+			b.Name,
+			b.Phase,
+			b.StartTimestamp,
+			b.CompletionTimestamp,
+			b.Expiration,
+			fmt.Sprintf("%d/%d", b.ItemsBackedUp, b.TotalItems),
+			fmt.Sprint(b.Warnings),
+			fmt.Sprint(b.Errors),
+		})
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+	r.printer.Print(sb.String())
+
+	return nil
+}
+
+// Describe prints the details of one of the cluster's backups.
+func (r *defaultBackupRunner) Describe(ctx context.Context, clusterID, name string) error {
+	_, b, err := r.getClusterBackup(ctx, clusterID, name)
+	if err != nil {
+		return err
+	}
+
+	r.printer.Printf("Name:                %s\n", b.Name)
+	r.printer.Printf("Namespace:           %s\n", r.cfg.ADPNamespace)
+	r.printer.Printf("Schedule:            %s\n", b.Schedule)
+	r.printer.Printf("Phase:               %s\n", b.Phase)
+	r.printer.Printf("Storage Location:    %s\n", b.StorageLocation)
+	r.printer.Printf("Included Namespaces: %s\n", strings.Join(b.IncludedNamespaces, ", "))
+	r.printer.Printf("Started:             %s\n", b.StartTimestamp)
+	r.printer.Printf("Completed:           %s\n", b.CompletionTimestamp)
+	r.printer.Printf("Expires:             %s\n", b.Expiration)
+	r.printer.Printf("Items Backed Up:     %d/%d\n", b.ItemsBackedUp, b.TotalItems)
+	r.printer.Printf("Warnings:            %d\n", b.Warnings)
+	r.printer.Printf("Errors:              %d\n", b.Errors)
+	if b.FailureReason != "" {
+		r.printer.Printf("Failure Reason:      %s\n", b.FailureReason)
+	}
+	for _, e := range b.ValidationErrors {
+		r.printer.Printf("Validation Error:    %s\n", e)
+	}
+
+	return nil
+}
+
+// newRestore builds the Velero Restore CR which restores the given backup.
+func (r *defaultBackupRunner) newRestore(b backupSummary, now time.Time) *unstructured.Unstructured {
+	restore := &unstructured.Unstructured{}
+	restore.SetGroupVersionKind(restoreGVK)
+	restore.SetName(fmt.Sprintf("%s-restore-%s", b.Name, now.UTC().Format("20060102150405")))
+	restore.SetNamespace(r.cfg.ADPNamespace)
+
+	spec := map[string]interface{}{
+		"backupName": b.Name,
+		"restorePVs": true,
+	}
+	if len(b.IncludedNamespaces) > 0 {
+		namespaces := make([]interface{}, 0, len(b.IncludedNamespaces))
+		for _, ns := range b.IncludedNamespaces {
+			namespaces = append(namespaces, ns)
+		}
+		spec["includedNamespaces"] = namespaces
+	}
+	restore.Object["spec"] = spec
+
+	return restore
+}
+
+// Restore prints the Velero Restore CR for one of the cluster's backups. Only dry
+// runs are supported: the CR is never applied, so restores remain a deliberate,
+// reviewed step of the restore procedure.
+func (r *defaultBackupRunner) Restore(ctx context.Context, clusterID, name string, dryRun bool) error {
+	if !dryRun {
+		return fmt.Errorf("only --dry-run is supported: review the generated Restore CR and apply it following the restore procedure")
+	}
+
+	_, b, err := r.getClusterBackup(ctx, clusterID, name)
+	if err != nil {
+		return err
+	}
+
+	switch b.Phase {
+	case "Completed":
+	case "PartiallyFailed":
+		r.logger.Warnf("Backup %q partially failed with %d errors, the restore may be incomplete.", b.Name, b.Errors)
+	default:
+		return fmt.Errorf("backup %q can not be restored: phase is %s, expected Completed", b.Name, b.Phase)
+	}
+
+	data, err := yaml.Marshal(r.newRestore(b, time.Now()).Object)
+	if err != nil {
+		return fmt.Errorf("marshalling Restore CR: %w", err)
+	}
+
+	r.printer.Printf("# Dry run: this Restore CR was generated but not applied.\n")
+	r.printer.Printf("# To restore, log into the management cluster with elevated permissions and run: oc create -f <file>\n")
+	r.printer.Print(string(data))
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package backup
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+)
+
+// newBackup builds an unstructured Velero Backup CR created from the given
+// schedule, with fixed progress counts.
+func newBackup(name, schedule, phase string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(backupGVK)
+	obj.SetName(name)
+	obj.SetNamespace("openshift-adp")
+	if schedule != "" {
+		obj.SetLabels(map[string]string{scheduleNameLabel: schedule})
+	}
+	obj.Object["spec"] = map[string]interface{}{
+		"includedNamespaces": []interface{}{"ocm-production-" + schedule, "ocm-production-" + schedule + "-hcp"},
+		"storageLocation":    "default",
+	}
+	obj.Object["status"] = map[string]interface{}{
+		"phase":          phase,
+		"startTimestamp": "2026-03-19T18:42:12Z",
+		"progress":       map[string]interface{}{"itemsBackedUp": int64(10), "totalItems": int64(10)},
+		"warnings":       int64(1),
+	}
+	return obj
+}
+
+// newLifecycleRunner returns a runner whose unprivileged client serves objs,
+// writing its output to out.
+func newLifecycleRunner(out *strings.Builder, objs ...client.Object) *defaultBackupRunner {
+	return NewDefaultBackupRunner(nil,
+		WithPrinter{Printer: &defaultPrinter{w: out}},
+		WithWaitPollInterval(10*time.Millisecond),
+		WithResolver{Resolver: &staticClusterResolver{clusterInfo: ClusterInfo{HCPClusterID: "abc123", MgmtClusterID: "mgmt-cluster-id"}}},
+		WithBuilder{Builder: &staticKubeClientBuilder{
+			unprivilegedClient: newTestClient(fake.NewClientBuilder().WithObjects(objs...).Build()),
+		}},
+	)
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	older := newBackup("abc123-daily-20260318000000", "abc123-daily", "Completed")
+	require.NoError(t, unstructured.SetNestedField(older.Object, "2026-03-18T00:00:00Z", "status", "startTimestamp"))
+
+	var out strings.Builder
+	runner := newLifecycleRunner(&out,
+		older,
+		newBackup("abc123-daily-20260319000000", "abc123-daily", "PartiallyFailed"),
+		newBackup("other-daily-20260319000000", "other-daily", "Completed"),
+	)
+
+	require.NoError(t, runner.List(context.Background(), "abc123"))
+
+	got := out.String()
+	assert.Contains(t, got, "NAME")
+	assert.Contains(t, got, "PartiallyFailed")
+	assert.NotContains(t, got, "other-daily")
+	assert.Less(t, strings.Index(got, "abc123-daily-20260319000000"), strings.Index(got, "abc123-daily-20260318000000"),
+		"newest backup should be listed first")
+}
+
+func TestList_NoBackups(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	runner := newLifecycleRunner(&out)
+
+	require.NoError(t, runner.List(context.Background(), "abc123"))
+	assert.Contains(t, out.String(), "No backups found")
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		backup      string
+		wantOutput  []string
+		errContains string
+	}{
+		{
+			name:   "backup of the cluster",
+			backup: "abc123-daily-20260319000000",
+			wantOutput: []string{
+				"Phase:               Completed",
+				"Items Backed Up:     10/10",
+				"Warnings:            1",
+				"ocm-production-abc123-daily, ocm-production-abc123-daily-hcp",
+			},
+		},
+		{
+			name:        "backup of another cluster is rejected",
+			backup:      "other-daily-20260319000000",
+			errContains: "not created from the cluster's schedule",
+		},
+		{
+			name:        "missing backup",
+			backup:      "abc123-daily-missing",
+			errContains: "getting Velero backup",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out strings.Builder
+			runner := newLifecycleRunner(&out,
+				newBackup("abc123-daily-20260319000000", "abc123-daily", "Completed"),
+				newBackup("other-daily-20260319000000", "other-daily", "Completed"),
+			)
+
+			err := runner.Describe(context.Background(), "abc123", tt.backup)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			for _, substr := range tt.wantOutput {
+				assert.Contains(t, out.String(), substr)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		phase       string
+		dryRun      bool
+		errContains string
+	}{
+		{name: "dry run of a completed backup", phase: "Completed", dryRun: true},
+		{name: "dry run of a partially failed backup", phase: "PartiallyFailed", dryRun: true},
+		{name: "failed backup is rejected", phase: "Failed", dryRun: true, errContains: "can not be restored"},
+		{name: "applying is not supported", phase: "Completed", dryRun: false, errContains: "only --dry-run is supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out strings.Builder
+			runner := newLifecycleRunner(&out, newBackup("abc123-daily-20260319000000", "abc123-daily", tt.phase))
+
+			err := runner.Restore(context.Background(), "abc123", "abc123-daily-20260319000000", tt.dryRun)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "not applied")
+
+			restore := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal([]byte(out.String()), &restore))
+			assert.Equal(t, "velero.io/v1", restore["apiVersion"])
+			assert.Equal(t, "Restore", restore["kind"])
+			backupName, _, _ := unstructured.NestedString(restore, "spec", "backupName")
+			assert.Equal(t, "abc123-daily-20260319000000", backupName)
+			namespaces, _, _ := unstructured.NestedStringSlice(restore, "spec", "includedNamespaces")
+			assert.Equal(t, []string{"ocm-production-abc123-daily", "ocm-production-abc123-daily-hcp"}, namespaces)
+			name, _, _ := unstructured.NestedString(restore, "metadata", "name")
+			assert.True(t, strings.HasPrefix(name, "abc123-daily-20260319000000-restore-"))
+		})
+	}
+}
+
+func TestWaitForBackup_Timeout(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	runner := newLifecycleRunner(&out, newBackup("abc123-daily-20260319000000", "abc123-daily", "InProgress"))
+	readClient, err := runner.builder.Build(context.Background())
+	require.NoError(t, err)
+
+	b, err := runner.waitForBackup(context.Background(), readClient, "abc123-daily-20260319000000", 50*time.Millisecond)
+
+	assert.ErrorContains(t, err, "timed out")
+	assert.Equal(t, "InProgress", b.Phase)
+}
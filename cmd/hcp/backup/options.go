@@ -1,6 +1,10 @@
 package backup
 
-import logrus "github.com/sirupsen/logrus"
+import (
+	"time"
+
+	logrus "github.com/sirupsen/logrus"
+)
 
 // The With* types below are concrete implementations of DefaultBackupRunnerOption,
 // used to override defaultBackupRunnerConfig defaults at construction time.
@@ -12,27 +16,6 @@ func (v WithADPNamespace) ConfigureDefaultBackupRunner(c *defaultBackupRunnerCon
 	c.ADPNamespace = string(v)
 }
 
-// WithVeleroContainer overrides the name of the container inside the Velero pod to exec into.
-type WithVeleroContainer string
-
-func (v WithVeleroContainer) ConfigureDefaultBackupRunner(c *defaultBackupRunnerConfig) {
-	c.VeleroContainer = string(v)
-}
-
-// WithVeleroLabelKey overrides the pod label key used to locate the Velero pod.
-type WithVeleroLabelKey string
-
-func (v WithVeleroLabelKey) ConfigureDefaultBackupRunner(c *defaultBackupRunnerConfig) {
-	c.VeleroLabelKey = string(v)
-}
-
-// WithVeleroLabelValue overrides the pod label value used to locate the Velero pod.
-type WithVeleroLabelValue string
-
-func (v WithVeleroLabelValue) ConfigureDefaultBackupRunner(c *defaultBackupRunnerConfig) {
-	c.VeleroLabelValue = string(v)
-}
-
 // WithScheduleNameSuffix overrides the suffix appended to the cluster ID to form the schedule name.
 type WithScheduleNameSuffix string
 
@@ -40,6 +23,13 @@ func (v WithScheduleNameSuffix) ConfigureDefaultBackupRunner(c *defaultBackupRun
 	c.ScheduleNameSuffix = string(v)
 }
 
+// WithWaitPollInterval overrides how often the Backup CR is polled with --wait.
+type WithWaitPollInterval time.Duration
+
+func (v WithWaitPollInterval) ConfigureDefaultBackupRunner(c *defaultBackupRunnerConfig) {
+	c.WaitPollInterval = time.Duration(v)
+}
+
 // WithLogger overrides the logrus.Logger used for diagnostic output.
 // By default a new logger writing to os.Stderr is created; callers may redirect
 // it (e.g. to cmd.ErrOrStderr()) before passing it here.
@@ -2,53 +2,24 @@ package backup
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"regexp"
-	"sort"
-	"strings"
+	"time"
 
 	ocmsdk "github.com/openshift-online/ocm-sdk-go"
-	"github.com/openshift/osdctl/cmd/cluster"
 	logrus "github.com/sirupsen/logrus"
-	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/remotecommand"
-	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// backupIDPattern matches the backup name from velero's submission output, e.g.:
-//
-//	Backup request "2p49ok746l9e7o06v76t3ptp95k72heb-daily-20260319184212" submitted successfully.
-var backupIDPattern = regexp.MustCompile(`Backup request "([^"]+)" submitted`)
-
-// joinSortedMap returns a comma-separated "key=value,..." string built from m
-// with keys in ascending alphabetical order, suitable for Velero's --labels /
-// --annotations flags.
-func joinSortedMap(m map[string]string) string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	pairs := make([]string, 0, len(keys))
-	for _, k := range keys {
-		pairs = append(pairs, k+"="+m[k])
-	}
-	return strings.Join(pairs, ",")
-}
-
 // KubeClient is a generic interface for interacting with Kubernetes resources.
-// It mirrors controller-runtime's Reader for Get and List, and adds Exec for
-// in-pod command execution. Both privileged and unprivileged clients satisfy
-// this interface, making them interchangeable in the runner and in tests.
+// It mirrors the subset of controller-runtime's Client used by the runner:
+// Get and List for reads, and Create to submit the Velero Backup CR. Both
+// privileged and unprivileged clients satisfy this interface, making them
+// interchangeable in the runner and in tests.
 type KubeClient interface {
 	// Get retrieves the resource identified by key and populates obj with the result.
 	// obj must be a pointer to an initialized struct (e.g. &corev1.Pod{}).
@@ -58,28 +29,19 @@ type KubeClient interface {
 	// Namespace and label filters are passed as ListOptions (e.g. client.InNamespace, client.MatchingLabels).
 	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
 
-	// Exec runs cmd inside the named container of the given pod and returns its stdout.
-	// It is only supported by clients constructed with a REST config and Clientset.
-	Exec(ctx context.Context, namespace, pod, container string, cmd []string) (string, error)
+	// Create saves obj in the cluster. obj is updated with the server's response.
+	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
 }
 
-// kubeClient is the production implementation of KubeClient. runtimeCli handles
-// Get and List via the controller-runtime API. restCfg and clientset are used
-// exclusively by Exec to build a SPDY executor.
+// kubeClient is the production implementation of KubeClient, delegating to a
+// controller-runtime client.
 type kubeClient struct {
 	runtimeCli client.Client
-	restCfg    *rest.Config
-	clientset  kubernetes.Interface
 }
 
-// newKubeClient constructs a kubeClient. restCfg and clientset may be nil for
-// read-only clients that will never call Exec.
-func newKubeClient(runtimeCli client.Client, restCfg *rest.Config, clientset kubernetes.Interface) *kubeClient {
-	return &kubeClient{
-		runtimeCli: runtimeCli,
-		restCfg:    restCfg,
-		clientset:  clientset,
-	}
+// newKubeClient constructs a kubeClient.
+func newKubeClient(runtimeCli client.Client) *kubeClient {
+	return &kubeClient{runtimeCli: runtimeCli}
 }
 
 func (k *kubeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
@@ -90,49 +52,8 @@ func (k *kubeClient) List(ctx context.Context, list client.ObjectList, opts ...c
 	return k.runtimeCli.List(ctx, list, opts...)
 }
 
-// Exec runs cmd inside the named container of the given pod using a SPDY executor.
-// It returns an error if the client was constructed without a REST config or Clientset.
-func (k *kubeClient) Exec(ctx context.Context, namespace, pod, container string, cmd []string) (string, error) {
-	if k.restCfg == nil || k.clientset == nil {
-		return "", errors.New("Exec is not supported on this client: no REST config or Clientset provided")
-	}
-
-	req := k.clientset.CoreV1().RESTClient().Post().
-		Resource("pods").
-		Name(pod).
-		Namespace(namespace).
-		SubResource("exec")
-
-	option := &corev1.PodExecOptions{
-		Container: container,
-		Command:   cmd,
-		Stdin:     false,
-		Stdout:    true,
-		Stderr:    true,
-		TTY:       false,
-	}
-
-	req.VersionedParams(option, scheme.ParameterCodec)
-
-	executor, err := remotecommand.NewSPDYExecutor(k.restCfg, "POST", req.URL())
-	if err != nil {
-		return "", fmt.Errorf("creating SPDY executor for pod exec: %w", err)
-	}
-
-	stdoutCapture := &cluster.LogCapture{}
-	stderrCapture := &cluster.LogCapture{}
-
-	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  nil,
-		Stdout: stdoutCapture,
-		Stderr: stderrCapture,
-		Tty:    false,
-	})
-	if err != nil {
-		return "", fmt.Errorf("executing command in pod %q: %w\nstderr: %s", pod, err, stderrCapture.GetStdOut())
-	}
-
-	return stdoutCapture.GetStdOut(), nil
+func (k *kubeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return k.runtimeCli.Create(ctx, obj, opts...)
 }
 
 // Printer writes user-facing output (command results, hints) to an output stream.
@@ -161,12 +82,11 @@ func (p *defaultPrinter) Printf(format string, args ...any) {
 // instance with defaults applied.
 type defaultBackupRunnerConfig struct {
 	ADPNamespace       string
-	VeleroContainer    string
-	VeleroLabelKey     string
-	VeleroLabelValue   string
 	ScheduleNameSuffix string
-	Logger             *logrus.Logger
-	Printer            Printer
+	// WaitPollInterval is how often the Backup CR is polled with --wait.
+	WaitPollInterval time.Duration
+	Logger           *logrus.Logger
+	Printer          Printer
 	// Resolver resolves a raw cluster identifier to canonical OCM IDs.
 	// Defaults to an ocmClusterResolver constructed from the OCM connection
 	// passed to NewDefaultBackupRunner. Override via WithResolver in tests.
@@ -184,10 +104,8 @@ type defaultBackupRunnerConfig struct {
 func newDefaultBackupRunnerConfig(opts ...DefaultBackupRunnerOption) defaultBackupRunnerConfig {
 	cfg := defaultBackupRunnerConfig{
 		ADPNamespace:       "openshift-adp",
-		VeleroContainer:    "velero",
-		VeleroLabelKey:     "app.kubernetes.io/name",
-		VeleroLabelValue:   "velero",
 		ScheduleNameSuffix: "-daily",
+		WaitPollInterval:   10 * time.Second,
 		Logger:             logrus.New(),
 		Printer:            &defaultPrinter{w: os.Stdout},
 	}
@@ -248,9 +166,9 @@ func NewDefaultBackupRunner(
 	}
 }
 
-// Run executes the backup workflow: resolve cluster, validate schedule, find
-// pod, trigger backup. All network I/O happens here — nothing is pre-fetched
-// at construction time.
+// Run executes the backup workflow: resolve cluster, read schedule, create the
+// Backup CR from the schedule's template. All network I/O happens here — nothing
+// is pre-fetched at construction time.
 func (r *defaultBackupRunner) Run(ctx context.Context, flags *backupFlags) error {
 	// Resolve the HCP cluster and its management cluster using the shared OCM
 	// connection. This is the first network call in the workflow.
@@ -261,89 +179,61 @@ func (r *defaultBackupRunner) Run(ctx context.Context, flags *backupFlags) error
 
 	scheduleName := clusterInfo.HCPClusterID + r.cfg.ScheduleNameSuffix
 
-	// Build an unprivileged client for read-only schedule validation.
+	// Build an unprivileged client for reading the schedule.
 	// The elevated login is intentionally deferred until after this check.
 	readClient, err := r.builder.Build(ctx, WithClusterID{ClusterID: clusterInfo.MgmtClusterID})
 	if err != nil {
 		return err
 	}
 
-	// Validate the Velero Schedule resource exists before attempting the backup.
-	// This is done with the unprivileged client so that a missing schedule never
-	// triggers an elevated login.
-	r.logger.Infof("Validating Velero schedule %q in namespace %q...", scheduleName, r.cfg.ADPNamespace)
-	if err := r.validateSchedule(ctx, readClient, scheduleName); err != nil {
+	// Read the Velero Schedule before attempting the backup. This is done with
+	// the unprivileged client so that a missing schedule never triggers an
+	// elevated login.
+	r.logger.Infof("Reading Velero schedule %q in namespace %q...", scheduleName, r.cfg.ADPNamespace)
+	schedule, err := r.getSchedule(ctx, readClient, scheduleName)
+	if err != nil {
 		return err
 	}
 	r.logger.Infof("Schedule %q found.", scheduleName)
 
-	// Schedule validation passed — now build the elevated client for pod
-	// listing and exec.
-	execClient, err := r.builder.Build(ctx, WithClusterID{ClusterID: clusterInfo.MgmtClusterID}, WithElevation{Reason: flags.reason})
+	backup, err := newBackupFromSchedule(schedule, flags.labels, flags.annotations, time.Now())
 	if err != nil {
 		return err
 	}
 
-	// Find a running Velero pod to exec into
-	podName, err := r.findVeleroPod(ctx, execClient)
+	// Schedule read — now build the elevated client for creating the Backup CR.
+	writeClient, err := r.builder.Build(ctx, WithClusterID{ClusterID: clusterInfo.MgmtClusterID}, WithElevation{Reason: flags.reason})
 	if err != nil {
 		return err
 	}
-	r.logger.Infof("Found Velero pod: %s", podName)
-
-	// Trigger the backup
-	backupCmd := []string{"./velero", "backup", "create", "--from-schedule", scheduleName}
-
-	// Append user-supplied labels as a single --labels key=value,... argument.
-	// Keys are sorted for determinism.
-	if len(flags.labels) > 0 {
-		backupCmd = append(backupCmd, "--labels", joinSortedMap(flags.labels))
-	}
-
-	// Append user-supplied annotations as a single --annotations key=value,... argument.
-	// Keys are sorted for determinism.
-	if len(flags.annotations) > 0 {
-		backupCmd = append(backupCmd, "--annotations", joinSortedMap(flags.annotations))
-	}
 
 	r.logger.Infof("Triggering backup from schedule %q...", scheduleName)
-
-	output, err := execClient.Exec(ctx, r.cfg.ADPNamespace, podName, r.cfg.VeleroContainer, backupCmd)
-	if err != nil {
-		return fmt.Errorf("triggering Velero backup from schedule %q: %w", scheduleName, err)
+	if err := writeClient.Create(ctx, backup); err != nil {
+		return fmt.Errorf("creating Velero backup from schedule %q: %w", scheduleName, err)
 	}
+	backupID := backup.GetName()
 
-	// Parse the backup ID from the velero submission output.
-	// Example output:
-	//   Creating backup from schedule, all other filters are ignored.
-	//   Backup request "2p49ok746l9e7o06v76t3ptp95k72heb-daily-20260319184212" submitted successfully.
-	//   Run `velero backup describe ...` or `velero backup logs ...` for more details.
-	matches := backupIDPattern.FindStringSubmatch(output)
-	if len(matches) < 2 {
-		// Unexpected output format — print raw output so the operator can inspect it.
-		r.printer.Print(output)
-		r.printer.Printf("Backup triggered successfully, but could not parse backup ID from velero output.\n")
+	r.printer.Printf("Backup %q triggered successfully.\n", backupID)
+	if !flags.wait {
+		r.printer.Printf("To check status, run:\n")
+		r.printer.Printf("oc get backup %s -n %s\n", backupID, r.cfg.ADPNamespace)
 		return nil
 	}
-	backupID := matches[1]
-
-	r.printer.Printf("Backup %q triggered successfully.\n", backupID)
-	r.printer.Printf("To check status, run:\n")
-	r.printer.Printf("oc get backup %s -n %s\n", backupID, r.cfg.ADPNamespace)
 
-	return nil
+	r.logger.Infof("Waiting up to %s for backup %q to complete...", flags.timeout, backupID)
+	result, err := r.waitForBackup(ctx, readClient, backupID, flags.timeout)
+	if err != nil {
+		return err
+	}
+	return r.printBackupResult(result)
 }
 
-// validateSchedule checks that a Velero Schedule CR with the given name exists
-// in the ADP namespace. It uses an unstructured lookup to avoid importing the
-// Velero SDK. readClient must be an unprivileged KubeClient with Get access.
-func (r *defaultBackupRunner) validateSchedule(ctx context.Context, readClient KubeClient, scheduleName string) error {
+// getSchedule retrieves the Velero Schedule CR with the given name from the ADP
+// namespace. It uses an unstructured lookup to avoid importing the Velero SDK.
+// readClient must be an unprivileged KubeClient with Get access.
+func (r *defaultBackupRunner) getSchedule(ctx context.Context, readClient KubeClient, scheduleName string) (*unstructured.Unstructured, error) {
 	schedule := &unstructured.Unstructured{}
-	schedule.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "velero.io",
-		Version: "v1",
-		Kind:    "Schedule",
-	})
+	schedule.SetGroupVersionKind(scheduleGVK)
 
 	key := client.ObjectKey{
 		Namespace: r.cfg.ADPNamespace,
@@ -351,45 +241,55 @@ func (r *defaultBackupRunner) validateSchedule(ctx context.Context, readClient K
 	}
 
 	if err := readClient.Get(ctx, key, schedule); err != nil {
-		return fmt.Errorf("getting Velero schedule %q in namespace %q (expected name: <cluster-id>%s): %w",
+		return nil, fmt.Errorf("getting Velero schedule %q in namespace %q (expected name: <cluster-id>%s): %w",
 			scheduleName, r.cfg.ADPNamespace, r.cfg.ScheduleNameSuffix, err)
 	}
 
-	return nil
+	return schedule, nil
 }
 
-// findVeleroPod returns the name of a Running, Ready, non-terminating Velero
-// pod. execClient must be a privileged KubeClient with List access to pods in
-// the ADP namespace.
-func (r *defaultBackupRunner) findVeleroPod(ctx context.Context, execClient KubeClient) (string, error) {
-	podList := &corev1.PodList{}
-	if err := execClient.List(ctx, podList,
-		client.InNamespace(r.cfg.ADPNamespace),
-		client.MatchingLabels{r.cfg.VeleroLabelKey: r.cfg.VeleroLabelValue},
-	); err != nil {
-		return "", fmt.Errorf("listing Velero pods in namespace %s: %w", r.cfg.ADPNamespace, err)
+// newBackupFromSchedule builds the Backup CR which Velero itself would create for
+// the schedule, mirroring velero backup create --from-schedule: the spec is the
+// schedule's template, the name is the schedule name suffixed with the UTC
+// timestamp, and the schedule's labels are copied along with the schedule name
+// label. labels and annotations are added on top.
+func newBackupFromSchedule(schedule *unstructured.Unstructured, labels, annotations map[string]string, now time.Time) (*unstructured.Unstructured, error) {
+	template, found, err := unstructured.NestedMap(schedule.Object, "spec", "template")
+	if err != nil {
+		return nil, fmt.Errorf("reading template of Velero schedule %q: %w", schedule.GetName(), err)
+	}
+	if !found {
+		return nil, fmt.Errorf("velero schedule %q has no backup template", schedule.GetName())
+	}
+
+	backup := &unstructured.Unstructured{}
+	backup.SetGroupVersionKind(backupGVK)
+	backup.SetName(fmt.Sprintf("%s-%s", schedule.GetName(), now.UTC().Format("20060102150405")))
+	backup.SetNamespace(schedule.GetNamespace())
+	backup.Object["spec"] = template
+
+	backupLabels := map[string]string{}
+	for k, v := range schedule.GetLabels() {
+		backupLabels[k] = v
+	}
+	backupLabels[scheduleNameLabel] = schedule.GetName()
+	for k, v := range labels {
+		backupLabels[k] = v
+	}
+	backup.SetLabels(backupLabels)
+	if len(annotations) > 0 {
+		backup.SetAnnotations(annotations)
 	}
 
-	for _, pod := range podList.Items {
-		if pod.DeletionTimestamp != nil {
-			continue // terminating — skip
-		}
-		if pod.Status.Phase != corev1.PodRunning {
-			continue
-		}
-		ready := false
-		for _, c := range pod.Status.Conditions {
-			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
-				ready = true
-				break
-			}
-		}
-		if !ready {
-			continue
-		}
-		return pod.Name, nil
+	if useOwnerRef, _, _ := unstructured.NestedBool(schedule.Object, "spec", "useOwnerReferencesInBackup"); useOwnerRef {
+		backup.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: schedule.GetAPIVersion(),
+			Kind:       schedule.GetKind(),
+			Name:       schedule.GetName(),
+			UID:        schedule.GetUID(),
+			Controller: ptr.To(true),
+		}})
 	}
 
-	labelSelector := fmt.Sprintf("%s=%s", r.cfg.VeleroLabelKey, r.cfg.VeleroLabelValue)
-	return "", fmt.Errorf("no running Velero pod found in namespace %s with label %s", r.cfg.ADPNamespace, labelSelector)
+	return backup, nil
 }
@@ -5,12 +5,11 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	logrus "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,18 +17,21 @@ import (
 )
 
 // testKubeClient is a test double for KubeClient. It embeds a controller-runtime
-// fake client to satisfy Get and List, and exposes an optional execFn hook for
-// Exec so individual tests can control its behaviour.
+// fake client to satisfy Get, List and Create, and exposes an optional createFn
+// hook which runs before the object is stored so individual tests can fail the
+// call or simulate the server mutating the object.
 type testKubeClient struct {
 	client.Client
-	execFn func(ctx context.Context, namespace, pod, container string, cmd []string) (string, error)
+	createFn func(ctx context.Context, obj client.Object) error
 }
 
-func (t *testKubeClient) Exec(ctx context.Context, namespace, pod, container string, cmd []string) (string, error) {
-	if t.execFn != nil {
-		return t.execFn(ctx, namespace, pod, container, cmd)
+func (t *testKubeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if t.createFn != nil {
+		if err := t.createFn(ctx, obj); err != nil {
+			return err
+		}
 	}
-	return "", errors.New("Exec not configured in this test")
+	return t.Client.Create(ctx, obj, opts...)
 }
 
 // newTestClient wraps a controller-runtime fake client in a testKubeClient.
@@ -83,10 +85,8 @@ func TestDefaultBackupRunnerConfig_Default(t *testing.T) {
 	cfg := newDefaultBackupRunnerConfig()
 
 	assert.Equal(t, "openshift-adp", cfg.ADPNamespace)
-	assert.Equal(t, "velero", cfg.VeleroContainer)
-	assert.Equal(t, "app.kubernetes.io/name", cfg.VeleroLabelKey)
-	assert.Equal(t, "velero", cfg.VeleroLabelValue)
 	assert.Equal(t, "-daily", cfg.ScheduleNameSuffix)
+	assert.Equal(t, 10*time.Second, cfg.WaitPollInterval)
 	assert.NotNil(t, cfg.Logger, "default Logger should be non-nil")
 	assert.NotNil(t, cfg.Printer, "default Printer should be non-nil")
 }
@@ -105,30 +105,14 @@ func TestDefaultBackupRunnerConfig_Options(t *testing.T) {
 			assertFn: func(t *testing.T, cfg defaultBackupRunnerConfig) {
 				assert.Equal(t, "custom-ns", cfg.ADPNamespace)
 				// other fields stay at defaults
-				assert.Equal(t, "velero", cfg.VeleroContainer)
+				assert.Equal(t, "-daily", cfg.ScheduleNameSuffix)
 			},
 		},
 		{
-			name: "WithVeleroContainer overrides VeleroContainer",
-			opts: []DefaultBackupRunnerOption{WithVeleroContainer("sidecar")},
+			name: "WithWaitPollInterval overrides WaitPollInterval",
+			opts: []DefaultBackupRunnerOption{WithWaitPollInterval(time.Second)},
 			assertFn: func(t *testing.T, cfg defaultBackupRunnerConfig) {
-				assert.Equal(t, "sidecar", cfg.VeleroContainer)
-				assert.Equal(t, "openshift-adp", cfg.ADPNamespace)
-			},
-		},
-		{
-			name: "WithVeleroLabelKey overrides VeleroLabelKey",
-			opts: []DefaultBackupRunnerOption{WithVeleroLabelKey("app")},
-			assertFn: func(t *testing.T, cfg defaultBackupRunnerConfig) {
-				assert.Equal(t, "app", cfg.VeleroLabelKey)
-				assert.Equal(t, "openshift-adp", cfg.ADPNamespace)
-			},
-		},
-		{
-			name: "WithVeleroLabelValue overrides VeleroLabelValue",
-			opts: []DefaultBackupRunnerOption{WithVeleroLabelValue("backup-agent")},
-			assertFn: func(t *testing.T, cfg defaultBackupRunnerConfig) {
-				assert.Equal(t, "backup-agent", cfg.VeleroLabelValue)
+				assert.Equal(t, time.Second, cfg.WaitPollInterval)
 				assert.Equal(t, "openshift-adp", cfg.ADPNamespace)
 			},
 		},
@@ -204,19 +188,6 @@ func TestNewDefaultBackupRunner_WithLoggerAndPrinter(t *testing.T) {
 	assert.Equal(t, customPrinter, runner.printer, "WithPrinter should override the default printer")
 }
 
-// ── kubeClient.Exec nil-guard ──────────────────────────────────────────────
-
-func TestKubeClientExec_NilGuard(t *testing.T) {
-	t.Parallel()
-
-	// Client constructed without restCfg or clientset (read-only).
-	k := newKubeClient(fake.NewClientBuilder().Build(), nil, nil)
-	_, err := k.Exec(context.TODO(), "ns", "pod", "container", []string{"./velero", "backup", "get"})
-
-	assert.Error(t, err)
-	assert.ErrorContains(t, err, "no REST config or Clientset provided")
-}
-
 // ── buildConfig / BuildOption ──────────────────────────────────────────────
 
 func TestBuildConfig(t *testing.T) {
@@ -251,9 +222,10 @@ func TestBuildConfig(t *testing.T) {
 	})
 }
 
-// ── validateSchedule ───────────────────────────────────────────────────────
+// ── getSchedule ────────────────────────────────────────────────────────────
 
-// newSchedule returns an *unstructured.Unstructured representing a Velero Schedule CR.
+// newSchedule returns an *unstructured.Unstructured representing a Velero Schedule CR
+// with a backup template.
 func newSchedule(name, namespace string) *unstructured.Unstructured {
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(schema.GroupVersionKind{
@@ -263,10 +235,20 @@ func newSchedule(name, namespace string) *unstructured.Unstructured {
 	})
 	obj.SetName(name)
 	obj.SetNamespace(namespace)
+	obj.SetUID("schedule-uid")
+	obj.SetLabels(map[string]string{"hypershift.openshift.io/hosted-cluster": "abc123"})
+	obj.Object["spec"] = map[string]interface{}{
+		"schedule": "0 0 * * *",
+		"template": map[string]interface{}{
+			"includedNamespaces": []interface{}{"ocm-production-abc123", "ocm-production-abc123-hcp"},
+			"storageLocation":    "default",
+			"ttl":                "720h0m0s",
+		},
+	}
 	return obj
 }
 
-func TestValidateSchedule(t *testing.T) {
+func TestGetSchedule(t *testing.T) {
 	t.Parallel()
 
 	const (
@@ -326,7 +308,7 @@ func TestValidateSchedule(t *testing.T) {
 				cfg: newDefaultBackupRunnerConfig(),
 			}
 
-			err := runner.validateSchedule(context.Background(), readClient, tt.scheduleName)
+			schedule, err := runner.getSchedule(context.Background(), readClient, tt.scheduleName)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -335,144 +317,70 @@ func TestValidateSchedule(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.scheduleName, schedule.GetName())
 			}
 		})
 	}
 }
 
-// ── findVeleroPod ──────────────────────────────────────────────────────────
-
-// newVeleroPod builds a corev1.Pod with the standard Velero label selector.
-// The pod has no PodReady condition; use newReadyVeleroPod for a fully ready pod.
-func newVeleroPod(name, namespace string, phase corev1.PodPhase, labelValue string) *corev1.Pod {
-	return &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				"app.kubernetes.io/name": labelValue,
-			},
-		},
-		Status: corev1.PodStatus{
-			Phase: phase,
-		},
-	}
-}
+// ── newBackupFromSchedule ──────────────────────────────────────────────────
 
-// newReadyVeleroPod builds a Running, Ready, non-terminating Velero pod.
-func newReadyVeleroPod(name, namespace string) *corev1.Pod {
-	pod := newVeleroPod(name, namespace, corev1.PodRunning, "velero")
-	pod.Status.Conditions = []corev1.PodCondition{
-		{Type: corev1.PodReady, Status: corev1.ConditionTrue},
-	}
-	return pod
-}
-
-func TestFindVeleroPod(t *testing.T) {
+func TestNewBackupFromSchedule(t *testing.T) {
 	t.Parallel()
 
-	const (
-		defaultNS   = "openshift-adp"
-		otherNS     = "other-ns"
-		veleroLabel = "velero"
-		wrongLabel  = "not-velero"
-		pod1Name    = "velero-abc-111"
-		pod2Name    = "velero-abc-222"
-	)
+	now := time.Date(2026, 3, 19, 18, 42, 12, 0, time.FixedZone("EST", -5*60*60))
 
 	tests := []struct {
-		name        string
-		pods        []*corev1.Pod
-		wantPodName string
-		wantErr     bool
-		errContains []string
+		name            string
+		schedule        func() *unstructured.Unstructured
+		labels          map[string]string
+		annotations     map[string]string
+		wantErr         string
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+		wantOwnerRef    bool
 	}{
 		{
-			name:        "one running, ready pod with correct label",
-			pods:        []*corev1.Pod{newReadyVeleroPod(pod1Name, defaultNS)},
-			wantPodName: pod1Name,
-			wantErr:     false,
-		},
-		{
-			name: "multiple running ready pods — first is returned",
-			pods: []*corev1.Pod{
-				newReadyVeleroPod(pod1Name, defaultNS),
-				newReadyVeleroPod(pod2Name, defaultNS),
+			name:     "spec copied from template, name uses UTC timestamp, schedule labels copied",
+			schedule: func() *unstructured.Unstructured { return newSchedule("abc123-daily", "openshift-adp") },
+			wantLabels: map[string]string{
+				"hypershift.openshift.io/hosted-cluster": "abc123",
+				scheduleNameLabel:                        "abc123-daily",
 			},
-			wantPodName: pod1Name,
-			wantErr:     false,
-		},
-		{
-			name:        "no pods at all",
-			pods:        nil,
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
-		},
-		{
-			name:        "pod exists but phase is Pending",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, defaultNS, corev1.PodPending, veleroLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
 		},
 		{
-			name:        "pod exists but phase is Succeeded",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, defaultNS, corev1.PodSucceeded, veleroLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
-		},
-		{
-			name:        "pod exists but phase is Failed",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, defaultNS, corev1.PodFailed, veleroLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
-		},
-		{
-			name:        "pod is running but has wrong label value",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, defaultNS, corev1.PodRunning, wrongLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
-		},
-		{
-			name:        "pod is running with correct label but in wrong namespace",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, otherNS, corev1.PodRunning, veleroLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
-		},
-		{
-			// A Running pod without a PodReady=True condition should be skipped.
-			name:        "pod is running but PodReady condition is False",
-			pods:        []*corev1.Pod{newVeleroPod(pod1Name, defaultNS, corev1.PodRunning, veleroLabel)},
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
+			name:        "labels and annotations added on top, user labels win",
+			schedule:    func() *unstructured.Unstructured { return newSchedule("abc123-daily", "openshift-adp") },
+			labels:      map[string]string{"incident": "OHSS-1234", "hypershift.openshift.io/hosted-cluster": "override"},
+			annotations: map[string]string{"owner": "sre-team"},
+			wantLabels: map[string]string{
+				"hypershift.openshift.io/hosted-cluster": "override",
+				scheduleNameLabel:                        "abc123-daily",
+				"incident":                               "OHSS-1234",
+			},
+			wantAnnotations: map[string]string{"owner": "sre-team"},
 		},
 		{
-			// A terminating pod (DeletionTimestamp set) should be skipped even
-			// if it is Running and Ready. A finalizer is required so the fake
-			// client accepts the object with a non-nil DeletionTimestamp.
-			name: "pod is running and ready but terminating",
-			pods: func() []*corev1.Pod {
-				pod := newReadyVeleroPod(pod1Name, defaultNS)
-				pod.Finalizers = []string{"test/keep"}
-				now := metav1.Now()
-				pod.DeletionTimestamp = &now
-				return []*corev1.Pod{pod}
-			}(),
-			wantErr:     true,
-			errContains: []string{defaultNS, "app.kubernetes.io/name=velero"},
+			name: "owner reference set when the schedule requests it",
+			schedule: func() *unstructured.Unstructured {
+				s := newSchedule("abc123-daily", "openshift-adp")
+				_ = unstructured.SetNestedField(s.Object, true, "spec", "useOwnerReferencesInBackup")
+				return s
+			},
+			wantLabels: map[string]string{
+				"hypershift.openshift.io/hosted-cluster": "abc123",
+				scheduleNameLabel:                        "abc123-daily",
+			},
+			wantOwnerRef: true,
 		},
 		{
-			// A terminating pod is skipped; the next ready pod is returned.
-			// The terminating pod has a finalizer so the fake client accepts it.
-			name: "terminating pod skipped, second ready pod returned",
-			pods: func() []*corev1.Pod {
-				terminating := newReadyVeleroPod(pod1Name, defaultNS)
-				terminating.Finalizers = []string{"test/keep"}
-				now := metav1.Now()
-				terminating.DeletionTimestamp = &now
-				return []*corev1.Pod{terminating, newReadyVeleroPod(pod2Name, defaultNS)}
-			}(),
-			wantPodName: pod2Name,
-			wantErr:     false,
+			name: "schedule without template",
+			schedule: func() *unstructured.Unstructured {
+				s := newSchedule("abc123-daily", "openshift-adp")
+				unstructured.RemoveNestedField(s.Object, "spec", "template")
+				return s
+			},
+			wantErr: "has no backup template",
 		},
 	}
 
@@ -480,27 +388,30 @@ func TestFindVeleroPod(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			fakeBuilder := fake.NewClientBuilder()
-			for _, p := range tt.pods {
-				fakeBuilder = fakeBuilder.WithObjects(p)
-			}
-			execClient := newTestClient(fakeBuilder.Build())
-
-			runner := &defaultBackupRunner{
-				cfg: newDefaultBackupRunnerConfig(),
+			backup, err := newBackupFromSchedule(tt.schedule(), tt.labels, tt.annotations, now)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
 			}
-
-			podName, err := runner.findVeleroPod(context.Background(), execClient)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				for _, substr := range tt.errContains {
-					assert.Contains(t, err.Error(), substr)
-				}
-				assert.Empty(t, podName)
+			assert.NoError(t, err)
+
+			assert.Equal(t, backupGVK, backup.GroupVersionKind())
+			assert.Equal(t, "abc123-daily-20260319234212", backup.GetName())
+			assert.Equal(t, "openshift-adp", backup.GetNamespace())
+			assert.Equal(t, tt.wantLabels, backup.GetLabels())
+			assert.Equal(t, tt.wantAnnotations, backup.GetAnnotations())
+
+			namespaces, _, _ := unstructured.NestedStringSlice(backup.Object, "spec", "includedNamespaces")
+			assert.Equal(t, []string{"ocm-production-abc123", "ocm-production-abc123-hcp"}, namespaces)
+			ttl, _, _ := unstructured.NestedString(backup.Object, "spec", "ttl")
+			assert.Equal(t, "720h0m0s", ttl)
+
+			if tt.wantOwnerRef {
+				assert.Len(t, backup.GetOwnerReferences(), 1)
+				assert.Equal(t, "Schedule", backup.GetOwnerReferences()[0].Kind)
+				assert.Equal(t, "abc123-daily", backup.GetOwnerReferences()[0].Name)
 			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.wantPodName, podName)
+				assert.Empty(t, backup.GetOwnerReferences())
 			}
 		})
 	}
@@ -514,328 +425,180 @@ func TestRun(t *testing.T) {
 	const (
 		clusterID    = "abc123"
 		scheduleName = clusterID + "-daily"
-		podName      = "velero-pod-1"
 		reason       = "OHSS-9999"
 	)
 
-	// newRunningPod and newDailySchedule construct fresh objects each call to
-	// avoid concurrent-map-write races when parallel subtests pass the same
-	// pointer to fake.ClientBuilder.WithObjects (which mutates the object's
+	// newDailySchedule constructs a fresh object each call to avoid
+	// concurrent-map-write races when parallel subtests pass the same pointer
+	// to fake.ClientBuilder.WithObjects (which mutates the object's
 	// ResourceVersion during Build).
-	newRunningPod := func() *corev1.Pod {
-		return newReadyVeleroPod(podName, "openshift-adp")
-	}
 	newDailySchedule := func() *unstructured.Unstructured {
 		return newSchedule(scheduleName, "openshift-adp")
 	}
 
+	// completeBackup simulates Velero processing the backup as soon as it is
+	// created, so that --wait observes a terminal phase on its first poll.
+	completeBackup := func(phase string) func(t *testing.T, ctx context.Context, obj client.Object) error {
+		return func(_ *testing.T, _ context.Context, obj client.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			u.Object["status"] = map[string]interface{}{
+				"phase":    phase,
+				"progress": map[string]interface{}{"itemsBackedUp": int64(10), "totalItems": int64(10)},
+				"warnings": int64(1),
+			}
+			return nil
+		}
+	}
+
 	tests := []struct {
 		name     string
 		readObjs func() []client.Object // built fresh inside each subtest
-		execObjs func() []client.Object // built fresh inside each subtest
-		// execFn receives the subtest *testing.T so assertions inside the
+		// createFn receives the subtest *testing.T so assertions inside the
 		// closure are reported on the correct subtest (not the parent TestRun).
-		execFn          func(t *testing.T, ctx context.Context, namespace, pod, container string, cmd []string) (string, error)
+		createFn        func(t *testing.T, ctx context.Context, obj client.Object) error
 		resolverErr     error // if set, staticClusterResolver returns this error
 		unprivilegedErr error // if set, staticKubeClientBuilder.Build() returns this for unprivileged calls
 		privilegedErr   error // if set, staticKubeClientBuilder.Build() returns this for elevated calls
 		flags           *backupFlags
 		wantErr         bool
 		errContains     string
-		schedSuffix     string        // non-empty activates WithScheduleNameSuffix
-		wantOutput      []string      // substrings expected in printer output
-		wantNoOutput    []string      // substrings that must NOT appear
-		wantBuildCalls  []buildConfig // if non-nil, asserts on the Build calls received by the builder
+		schedSuffix     string            // non-empty activates WithScheduleNameSuffix
+		wantOutput      []string          // substrings expected in printer output
+		wantNoOutput    []string          // substrings that must NOT appear
+		wantBuildCalls  []buildConfig     // if non-nil, asserts on the Build calls received by the builder
+		wantLabels      map[string]string // if non-nil, asserts on the labels of the created Backup CR
+		wantAnnotations map[string]string // if non-nil, asserts on the annotations of the created Backup CR
+		wantNoBackup    bool              // asserts that no Backup CR was created
 	}{
 		{
-			name:     "happy path — backup ID parsed, hint uses oc get backup",
+			name:     "happy path — backup created, hint uses oc get backup",
 			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(_ *testing.T, _ context.Context, _ string, _ string, _ string, _ []string) (string, error) {
-				return `Creating backup from schedule, all other filters are ignored.
-Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-Run ` + "`velero backup describe " + clusterID + `-daily-20260319184212` + "`" + ` for more details.
-`, nil
-			},
-			flags:   &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr: false,
+			flags:    &backupFlags{clusterID: clusterID, reason: reason},
+			wantErr:  false,
 			wantOutput: []string{
-				clusterID + "-daily-20260319184212",
+				scheduleName + "-",
 				"triggered successfully",
 				"oc get backup",
 				"openshift-adp",
 			},
-			wantNoOutput: []string{
-				// raw velero output must not be forwarded to the printer
-				"Creating backup from schedule",
-				"Run `velero backup describe",
-				// old grep-based hint must not appear
-				"grep",
+			wantLabels: map[string]string{
+				"hypershift.openshift.io/hosted-cluster": clusterID,
+				scheduleNameLabel:                        scheduleName,
 			},
 		},
 		{
-			name:     "unparseable velero output — raw output printed, no error",
+			name:     "wait — backup completed, item counts reported",
 			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(_ *testing.T, _ context.Context, _ string, _ string, _ string, _ []string) (string, error) {
-				return "some unexpected velero output\n", nil
-			},
-			flags:   &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr: false,
+			createFn: completeBackup("Completed"),
+			flags:    &backupFlags{clusterID: clusterID, reason: reason, wait: true, timeout: time.Minute},
 			wantOutput: []string{
-				"some unexpected velero output",
-				"could not parse backup ID",
+				"finished with phase Completed",
+				"Items backed up: 10/10, warnings: 1, errors: 0",
 			},
+			wantNoOutput: []string{"oc get backup"},
 		},
 		{
-			name:        "validateSchedule fails — schedule not found",
-			readObjs:    func() []client.Object { return nil }, // no schedule seeded
-			execObjs:    func() []client.Object { return []client.Object{newRunningPod()} },
-			flags:       &backupFlags{clusterID: clusterID, reason: reason},
+			name:        "wait — backup failed returns error",
+			readObjs:    func() []client.Object { return []client.Object{newDailySchedule()} },
+			createFn:    completeBackup("Failed"),
+			flags:       &backupFlags{clusterID: clusterID, reason: reason, wait: true, timeout: time.Minute},
 			wantErr:     true,
-			errContains: scheduleName,
+			errContains: "did not complete successfully: Failed",
 		},
 		{
-			name:        "findVeleroPod fails — no running pods",
-			readObjs:    func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs:    func() []client.Object { return nil }, // no pods seeded
-			flags:       &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr:     true,
-			errContains: "no running Velero pod found",
+			name:         "getSchedule fails — schedule not found",
+			readObjs:     func() []client.Object { return nil }, // no schedule seeded
+			flags:        &backupFlags{clusterID: clusterID, reason: reason},
+			wantErr:      true,
+			errContains:  scheduleName,
+			wantNoBackup: true,
 		},
 		{
-			name:     "Exec fails — error wraps schedule name",
+			name:     "Create fails — error wraps schedule name",
 			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(_ *testing.T, _ context.Context, _ string, _ string, _ string, _ []string) (string, error) {
-				return "", errors.New("connection refused")
+			createFn: func(_ *testing.T, _ context.Context, _ client.Object) error {
+				return errors.New("connection refused")
 			},
-			flags:       &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr:     true,
-			errContains: scheduleName,
+			flags:        &backupFlags{clusterID: clusterID, reason: reason},
+			wantErr:      true,
+			errContains:  scheduleName,
+			wantNoBackup: true,
 		},
 		{
 			name:     "custom schedule suffix via config option",
 			readObjs: func() []client.Object { return []client.Object{newSchedule(clusterID+"-weekly", "openshift-adp")} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				// Verify the correct schedule name is passed to the velero command
-				assert.Contains(t, cmd[len(cmd)-1], "-weekly")
-				return `Backup request "` + clusterID + `-weekly-20260319184212" submitted successfully.
-`, nil
+			createFn: func(t *testing.T, _ context.Context, obj client.Object) error {
+				// Verify the backup is created from the weekly schedule
+				assert.Equal(t, clusterID+"-weekly", obj.GetLabels()[scheduleNameLabel])
+				return nil
 			},
 			flags:       &backupFlags{clusterID: clusterID, reason: reason},
 			schedSuffix: "-weekly",
 			wantErr:     false,
 			wantOutput: []string{
-				clusterID + "-weekly-20260319184212",
+				clusterID + "-weekly-",
 				"triggered successfully",
 			},
 		},
 		{
-			name:     "single label — forwarded to velero as --labels key=value",
+			name:     "labels and annotations — set on the Backup CR",
 			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				// --labels and its value must appear somewhere in the command slice
-				labelsIdx := -1
-				for i, arg := range cmd {
-					if arg == "--labels" {
-						labelsIdx = i
-						break
-					}
-				}
-				assert.NotEqual(t, -1, labelsIdx, "--labels flag must be present")
-				if labelsIdx != -1 && labelsIdx+1 < len(cmd) {
-					assert.Equal(t, "incident=OHSS-1234", cmd[labelsIdx+1])
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason, labels: map[string]string{"incident": "OHSS-1234"}},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "multiple labels — sorted and joined, forwarded to velero",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				labelsIdx := -1
-				for i, arg := range cmd {
-					if arg == "--labels" {
-						labelsIdx = i
-						break
-					}
-				}
-				assert.NotEqual(t, -1, labelsIdx, "--labels flag must be present")
-				if labelsIdx != -1 && labelsIdx+1 < len(cmd) {
-					// Keys are sorted alphabetically: env before incident
-					assert.Equal(t, "env=prod,incident=OHSS-5678", cmd[labelsIdx+1])
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason, labels: map[string]string{"incident": "OHSS-5678", "env": "prod"}},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "no labels — --labels flag not passed to velero",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				for _, arg := range cmd {
-					assert.NotEqual(t, "--labels", arg, "--labels must not appear when no labels are provided")
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "single annotation — forwarded to velero as --annotations key=value",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				annIdx := -1
-				for i, arg := range cmd {
-					if arg == "--annotations" {
-						annIdx = i
-						break
-					}
-				}
-				assert.NotEqual(t, -1, annIdx, "--annotations flag must be present")
-				if annIdx != -1 && annIdx+1 < len(cmd) {
-					assert.Equal(t, "ticket=OHSS-9999", cmd[annIdx+1])
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason, annotations: map[string]string{"ticket": "OHSS-9999"}},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "multiple annotations — sorted and joined, forwarded to velero",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				annIdx := -1
-				for i, arg := range cmd {
-					if arg == "--annotations" {
-						annIdx = i
-						break
-					}
-				}
-				assert.NotEqual(t, -1, annIdx, "--annotations flag must be present")
-				if annIdx != -1 && annIdx+1 < len(cmd) {
-					// Keys sorted: owner before ticket
-					assert.Equal(t, "owner=sre-team,ticket=OHSS-9999", cmd[annIdx+1])
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason, annotations: map[string]string{"ticket": "OHSS-9999", "owner": "sre-team"}},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "no annotations — --annotations flag not passed to velero",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				for _, arg := range cmd {
-					assert.NotEqual(t, "--annotations", arg, "--annotations must not appear when no annotations are provided")
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:      &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr:    false,
-			wantOutput: []string{"triggered successfully"},
-		},
-		{
-			name:     "labels and annotations together — both forwarded",
-			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(t *testing.T, _ context.Context, _ string, _ string, _ string, cmd []string) (string, error) {
-				labelsIdx, annIdx := -1, -1
-				for i, arg := range cmd {
-					switch arg {
-					case "--labels":
-						labelsIdx = i
-					case "--annotations":
-						annIdx = i
-					}
-				}
-				assert.NotEqual(t, -1, labelsIdx, "--labels must be present")
-				assert.NotEqual(t, -1, annIdx, "--annotations must be present")
-				if labelsIdx != -1 && labelsIdx+1 < len(cmd) {
-					assert.Equal(t, "env=prod", cmd[labelsIdx+1])
-				}
-				if annIdx != -1 && annIdx+1 < len(cmd) {
-					assert.Equal(t, "ticket=OHSS-0001", cmd[annIdx+1])
-				}
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
 			flags: &backupFlags{
 				clusterID:   clusterID,
 				reason:      reason,
-				labels:      map[string]string{"env": "prod"},
-				annotations: map[string]string{"ticket": "OHSS-0001"},
+				labels:      map[string]string{"incident": "OHSS-1234", "env": "prod"},
+				annotations: map[string]string{"owner": "sre-team"},
 			},
 			wantErr:    false,
 			wantOutput: []string{"triggered successfully"},
+			wantLabels: map[string]string{
+				"hypershift.openshift.io/hosted-cluster": clusterID,
+				scheduleNameLabel:                        scheduleName,
+				"incident":                               "OHSS-1234",
+				"env":                                    "prod",
+			},
+			wantAnnotations: map[string]string{"owner": "sre-team"},
 		},
 		{
-			// Proves that ClusterResolver failures surface before any kube login.
-			name:        "ClusterResolver fails — error returned before any login",
-			readObjs:    func() []client.Object { return nil },
-			execObjs:    func() []client.Object { return nil },
-			resolverErr: errors.New("OCM unreachable"),
-			flags:       &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr:     true,
-			errContains: "OCM unreachable",
+			// Proves that a resolver failure is surfaced before any login.
+			name:         "ClusterResolver fails — error returned before any login",
+			readObjs:     func() []client.Object { return nil },
+			resolverErr:  errors.New("OCM unreachable"),
+			flags:        &backupFlags{clusterID: clusterID, reason: reason},
+			wantErr:      true,
+			errContains:  "OCM unreachable",
+			wantNoBackup: true,
 		},
 		{
 			// Proves that an unprivileged login failure is surfaced immediately,
-			// before any schedule validation or elevated login attempt.
-			name:            "unprivileged client build fails — error returned before validation",
+			// before any schedule lookup or elevated login attempt.
+			name:            "unprivileged client build fails — error returned before schedule lookup",
 			readObjs:        func() []client.Object { return nil },
-			execObjs:        func() []client.Object { return nil },
 			unprivilegedErr: errors.New("backplane unavailable"),
 			flags:           &backupFlags{clusterID: clusterID, reason: reason},
 			wantErr:         true,
 			errContains:     "backplane unavailable",
+			wantNoBackup:    true,
 		},
 		{
-			// Proves that the elevated login only occurs after schedule validation
-			// passes: the schedule is present so validation succeeds, but the
-			// elevated Build call then returns an error that Run surfaces.
-			name:          "elevated client build fails — only triggered after schedule validation",
+			// Proves that the elevated login only occurs after the schedule
+			// lookup succeeds: the schedule is present, but the elevated Build
+			// call then returns an error that Run surfaces.
+			name:          "elevated client build fails — only triggered after schedule lookup",
 			readObjs:      func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs:      func() []client.Object { return nil },
 			privilegedErr: errors.New("elevation denied"),
 			flags:         &backupFlags{clusterID: clusterID, reason: reason},
 			wantErr:       true,
 			errContains:   "elevation denied",
+			wantNoBackup:  true,
 		},
 		{
 			// Proves that Run passes MgmtClusterID (not HCPClusterID) and the
 			// correct elevation reason to both Build calls.
 			name:     "Build receives correct clusterID and elevation reason",
 			readObjs: func() []client.Object { return []client.Object{newDailySchedule()} },
-			execObjs: func() []client.Object { return []client.Object{newRunningPod()} },
-			execFn: func(_ *testing.T, _ context.Context, _, _, _ string, _ []string) (string, error) {
-				return `Backup request "` + clusterID + `-daily-20260319184212" submitted successfully.
-`, nil
-			},
-			flags:   &backupFlags{clusterID: clusterID, reason: reason},
-			wantErr: false,
+			flags:    &backupFlags{clusterID: clusterID, reason: reason},
+			wantErr:  false,
 			wantBuildCalls: []buildConfig{
 				// First call: unprivileged — clusterID set, not elevated.
 				{clusterID: "mgmt-cluster-id", elevated: false, elevationReason: ""},
@@ -849,24 +612,23 @@ Run ` + "`velero backup describe " + clusterID + `-daily-20260319184212` + "`" +
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			readBuilder := fake.NewClientBuilder()
+			// Both clients share one fake store, as both log into the same
+			// management cluster in production.
+			fakeBuilder := fake.NewClientBuilder()
 			for _, o := range tt.readObjs() {
-				readBuilder = readBuilder.WithObjects(o)
+				fakeBuilder = fakeBuilder.WithObjects(o)
 			}
-			readClient := newTestClient(readBuilder.Build())
-
-			execBuilder := fake.NewClientBuilder()
-			for _, o := range tt.execObjs() {
-				execBuilder = execBuilder.WithObjects(o)
-			}
-			var execFn func(ctx context.Context, namespace, pod, container string, cmd []string) (string, error)
-			if tt.execFn != nil {
-				ttExecFn := tt.execFn // capture loop var
-				execFn = func(ctx context.Context, namespace, pod, container string, cmd []string) (string, error) {
-					return ttExecFn(t, ctx, namespace, pod, container, cmd)
+			store := fakeBuilder.Build()
+			readClient := newTestClient(store)
+
+			var createFn func(ctx context.Context, obj client.Object) error
+			if tt.createFn != nil {
+				ttCreateFn := tt.createFn // capture loop var
+				createFn = func(ctx context.Context, obj client.Object) error {
+					return ttCreateFn(t, ctx, obj)
 				}
 			}
-			execClient := &testKubeClient{Client: execBuilder.Build(), execFn: execFn}
+			writeClient := &testKubeClient{Client: store, createFn: createFn}
 
 			var out strings.Builder
 			opts := []DefaultBackupRunnerOption{
@@ -878,7 +640,7 @@ Run ` + "`velero backup describe " + clusterID + `-daily-20260319184212` + "`" +
 
 			clientBuilder := &staticKubeClientBuilder{
 				unprivilegedClient: readClient,
-				privilegedClient:   execClient,
+				privilegedClient:   writeClient,
 				unprivilegedErr:    tt.unprivilegedErr,
 				privilegedErr:      tt.privilegedErr,
 			}
@@ -915,46 +677,22 @@ Run ` + "`velero backup describe " + clusterID + `-daily-20260319184212` + "`" +
 				assert.Equal(t, tt.wantBuildCalls, clientBuilder.calls,
 					"Build calls should match expected clusterID and elevation options")
 			}
-		})
-	}
-}
-
-// ── joinSortedMap ──────────────────────────────────────────────────────────
-
-func TestJoinSortedMap(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name string
-		m    map[string]string
-		want string
-	}{
-		{
-			name: "empty map returns empty string",
-			m:    map[string]string{},
-			want: "",
-		},
-		{
-			name: "single entry",
-			m:    map[string]string{"incident": "OHSS-1234"},
-			want: "incident=OHSS-1234",
-		},
-		{
-			name: "multiple entries sorted alphabetically by key",
-			m:    map[string]string{"zzz": "last", "aaa": "first", "mmm": "middle"},
-			want: "aaa=first,mmm=middle,zzz=last",
-		},
-		{
-			name: "keys with equal prefix sorted correctly",
-			m:    map[string]string{"env": "prod", "env2": "staging"},
-			want: "env=prod,env2=staging",
-		},
-	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			assert.Equal(t, tt.want, joinSortedMap(tt.m))
+			backups := &unstructured.UnstructuredList{}
+			backups.SetGroupVersionKind(backupGVK.GroupVersion().WithKind("BackupList"))
+			assert.NoError(t, store.List(context.Background(), backups, client.InNamespace("openshift-adp")))
+			if tt.wantNoBackup {
+				assert.Empty(t, backups.Items)
+				return
+			}
+			if assert.Len(t, backups.Items, 1) {
+				if tt.wantLabels != nil {
+					assert.Equal(t, tt.wantLabels, backups.Items[0].GetLabels())
+				}
+				if tt.wantAnnotations != nil {
+					assert.Equal(t, tt.wantAnnotations, backups.Items[0].GetAnnotations())
+				}
+			}
 		})
 	}
 }
@@ -996,6 +734,21 @@ func TestBackupFlags_AddFlags(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Nil(t, f.labels)
 		assert.Nil(t, f.annotations)
+		assert.False(t, f.wait)
+		assert.Equal(t, defaultWaitTimeout, f.timeout)
+	})
+
+	t.Run("--wait and --timeout flags are registered and parsed", func(t *testing.T) {
+		t.Parallel()
+
+		f := &backupFlags{}
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		f.AddFlags(fs)
+
+		err := fs.Parse([]string{"--cluster-id", "abc123", "--reason", "OHSS-1234", "--wait", "--timeout", "1h"})
+		assert.NoError(t, err)
+		assert.True(t, f.wait)
+		assert.Equal(t, time.Hour, f.timeout)
 	})
 }
 
@@ -28,8 +28,20 @@ type forceUpgradeOptions struct {
 	dryRun             bool
 	serviceLogTemplate string
 
+	// Wave-based rollout options
+	waveBy       string
+	waveSize     int
+	canarySize   int
+	maxFailures  int
+	pollInterval time.Duration
+	waveTimeout  time.Duration
+	progressFile string
+	resume       bool
+
 	// Parsed cluster IDs (populated during validation)
 	clusterIDs []string
+	// Progress of the rollout being resumed (populated during validation)
+	progress *rolloutProgress
 }
 
 // Service log template mappings
@@ -64,7 +76,19 @@ This serves two purposes:
 1. Force upgrades to latest z-stream of the SAME y-stream for critical bug fixes
 2. Force upgrades to latest z-stream of a SUBSEQUENT y-stream when current y-stream goes out of support
 
-Example: --target-y 4.15 will upgrade to the latest available 4.15.z version (e.g., 4.15.32).`,
+Example: --target-y 4.15 will upgrade to the latest available 4.15.z version (e.g., 4.15.32).
+
+WAVE-BASED ROLLOUT:
+By default all clusters are scheduled at once. Setting --wave-size, --canary-size or --wave-by mc rolls the
+upgrade out in waves instead: the first --canary-size clusters form a canary wave, and the remaining clusters are
+split in the given order into waves of --wave-size clusters with --wave-by count, the default, or into one wave
+per management cluster with --wave-by mc. Each wave is scheduled only once all upgrades of the previous wave
+finished, which is determined by polling the state of the OCM upgrade policies every --poll-interval. Once OCM
+consumed a policy, the upgrade finished when the cluster reports the target version.
+
+The rollout halts when any canary upgrade fails, when more than --max-failures upgrades failed in total, or when
+a wave doesn't finish within --wave-timeout. The state of every cluster is written to --progress-file, so a halted
+or interrupted rollout can be continued with --resume, optionally with a higher --max-failures.`,
 		Example: `  # Force upgrade without service log
   osdctl hcp force-upgrade -C cluster123 --target-y 4.15
 
@@ -77,6 +101,15 @@ Example: --target-y 4.15 will upgrade to the latest available 4.15.z version (e.
   # Force upgrade with custom service log template file
   osdctl hcp force-upgrade -C cluster123 --target-y 4.15 --send-service-log /path/to/custom-template.json
 
+  # Upgrade a canary cluster first, then the remaining clusters in waves of 10, tolerating up to 2 failures
+  osdctl hcp force-upgrade --clusters-file clusters.json --target-y 4.16 --canary-size 1 --wave-size 10 --max-failures 2
+
+  # Upgrade the clusters one management cluster at a time
+  osdctl hcp force-upgrade --clusters-file clusters.json --target-y 4.16 --canary-size 1 --wave-by mc
+
+  # Resume a halted rollout
+  osdctl hcp force-upgrade --target-y 4.16 --resume --progress-file force-upgrade-progress.json
+
 `,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
@@ -97,6 +130,16 @@ Example: --target-y 4.15 will upgrade to the latest available 4.15.z version (e.
 	// Service log flags
 	cmd.Flags().StringVar(&opts.serviceLogTemplate, "send-service-log", "", "Send service log notification after scheduling upgrade. Specify template name (e.g., 'end-of-support') or file path (e.g., '/path/to/template.json')")
 
+	// Wave-based rollout flags
+	cmd.Flags().StringVar(&opts.waveBy, "wave-by", waveByCount, "Split the clusters into waves by 'count' (waves of --wave-size clusters, in the given order) or 'mc' (one wave per management cluster)")
+	cmd.Flags().IntVar(&opts.waveSize, "wave-size", 0, "Maximum number of clusters per wave, 0 for no limit")
+	cmd.Flags().IntVar(&opts.canarySize, "canary-size", 0, "Number of clusters to upgrade in a canary wave before all others")
+	cmd.Flags().IntVar(&opts.maxFailures, "max-failures", 0, "Number of failed upgrades tolerated before the rollout halts")
+	cmd.Flags().DurationVar(&opts.pollInterval, "poll-interval", time.Minute, "Interval for polling the upgrade state of a wave")
+	cmd.Flags().DurationVar(&opts.waveTimeout, "wave-timeout", 2*time.Hour, "Maximum time to wait for the upgrades of a wave to finish")
+	cmd.Flags().StringVar(&opts.progressFile, "progress-file", "force-upgrade-progress.json", "File to store the rollout progress in, for resuming it")
+	cmd.Flags().BoolVar(&opts.resume, "resume", false, "Resume the rollout stored in --progress-file instead of planning a new one")
+
 	// Mark required flags
 	_ = cmd.MarkFlagRequired("target-y")
 
@@ -104,8 +147,19 @@ Example: --target-y 4.15 will upgrade to the latest available 4.15.z version (e.
 }
 
 func (o *forceUpgradeOptions) validate() error {
+	if err := o.validateRollout(); err != nil {
+		return err
+	}
+
+	// Resumed rollouts take their clusters from the progress file
+	if o.resume {
+		if o.clusterID != "" || o.clustersFile != "" {
+			return fmt.Errorf("--resume takes the clusters from the progress file and cannot be combined with --cluster-id or --clusters-file")
+		}
+	}
+
 	// Exactly one cluster targeting method must be provided
-	if o.clusterID == "" && o.clustersFile == "" {
+	if o.clusterID == "" && o.clustersFile == "" && !o.resume {
 		return fmt.Errorf("no cluster identifier has been found, please specify either --cluster-id or --clusters-file")
 	}
 
@@ -140,7 +194,17 @@ func (o *forceUpgradeOptions) validate() error {
 	}
 
 	// Parse and validate cluster targets
-	if o.clustersFile != "" {
+	if o.resume {
+		progress, err := loadProgress(o.progressFile)
+		if err != nil {
+			return err
+		}
+		if progress.TargetYStream != o.targetYStream {
+			return fmt.Errorf("progress file %s is for target Y-stream %s, not %s", o.progressFile, progress.TargetYStream, o.targetYStream)
+		}
+		o.progress = progress
+		o.clusterIDs = progress.clusterIDs()
+	} else if o.clustersFile != "" {
 		clusterIDs, err := io.ParseAndValidateClustersFile(o.clustersFile)
 		if err != nil {
 			return err
@@ -157,6 +221,36 @@ func (o *forceUpgradeOptions) validate() error {
 	return nil
 }
 
+// validateRollout validates the options of a wave-based rollout
+func (o *forceUpgradeOptions) validateRollout() error {
+	if o.waveBy != "" && o.waveBy != waveByCount && o.waveBy != waveByMC {
+		return fmt.Errorf("invalid --wave-by '%s', must be one of [%s %s]", o.waveBy, waveByCount, waveByMC)
+	}
+
+	if o.waveSize < 0 || o.canarySize < 0 || o.maxFailures < 0 {
+		return fmt.Errorf("--wave-size, --canary-size and --max-failures must not be negative")
+	}
+
+	if !o.rolloutEnabled() {
+		return nil
+	}
+
+	if o.pollInterval <= 0 || o.waveTimeout <= 0 {
+		return fmt.Errorf("--poll-interval and --wave-timeout must be positive")
+	}
+
+	if o.progressFile == "" {
+		return fmt.Errorf("--progress-file is required for wave-based rollouts")
+	}
+
+	return nil
+}
+
+// rolloutEnabled returns whether the clusters are upgraded in waves rather than all at once
+func (o *forceUpgradeOptions) rolloutEnabled() bool {
+	return o.waveSize > 0 || o.canarySize > 0 || o.waveBy == waveByMC || o.resume
+}
+
 func (o *forceUpgradeOptions) Run() error {
 	if err := o.validate(); err != nil {
 		return err
@@ -183,6 +277,17 @@ func (o *forceUpgradeOptions) Run() error {
 		return fmt.Errorf("failed to display pre-processing summary: %w", err)
 	}
 
+	progress := o.progress
+	if o.rolloutEnabled() {
+		if progress == nil {
+			progress, err = o.planRollout(clusters)
+			if err != nil {
+				return fmt.Errorf("failed to plan rollout: %w", err)
+			}
+		}
+		printRolloutPlan(progress)
+	}
+
 	// Ask for confirmation before proceeding (unless in dry-run mode)
 	if !o.dryRun {
 		if !ocmutils.ConfirmPrompt() {
@@ -192,13 +297,17 @@ func (o *forceUpgradeOptions) Run() error {
 		fmt.Println()
 	}
 
+	if o.rolloutEnabled() {
+		return o.runRollout(ocmClient, clusters, progress)
+	}
+
 	var successful, failed []string
 	var serviceLogSuccessful, serviceLogFailed []string
 
 	for i, cluster := range clusters {
 		fmt.Printf("\n[%d/%d] Processing cluster: %s (%s)\n", i+1, len(clusters), cluster.ID(), cluster.Name())
 
-		targetVersion, err := o.processCluster(ocmClient, cluster)
+		targetVersion, _, err := o.processCluster(ocmClient, cluster)
 		if err != nil {
 			failed = append(failed, fmt.Sprintf("%s: %s", cluster.ExternalID(), err.Error()))
 			fmt.Printf("  ⚠️  Failed to create upgrade policy: %v\n", err)
@@ -257,27 +366,27 @@ func (o *forceUpgradeOptions) getClusters(ocmClient *sdk.Connection) ([]*v1.Clus
 	return clusters, nil
 }
 
-func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster *v1.Cluster) (string, error) {
+func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster *v1.Cluster) (string, string, error) {
 	// Some sanity checking - we should only ever be upgrading ROSA HCP clusters.
 	if !cluster.Hypershift().Enabled() {
-		return "", fmt.Errorf("force upgrading is only allowed on ROSA HCP clusters")
+		return "", "", fmt.Errorf("force upgrading is only allowed on ROSA HCP clusters")
 	}
 
 	// Check cluster state
 	if cluster.State() != v1.ClusterStateReady {
-		return "", fmt.Errorf("cluster is not ready (current state: %s)", cluster.State())
+		return "", "", fmt.Errorf("cluster is not ready (current state: %s)", cluster.State())
 	}
 
 	// Check for available upgrades
 	if len(cluster.Version().AvailableUpgrades()) == 0 {
-		return "", fmt.Errorf("no available upgrades path")
+		return "", "", fmt.Errorf("no available upgrades path")
 	}
 
 	// Check for existing upgrade policies
 	policiesResponse, err := ocmClient.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).
 		ControlPlane().UpgradePolicies().List().Send()
 	if err != nil {
-		return "", fmt.Errorf("failed to list existing upgrade policies: %w", err)
+		return "", "", fmt.Errorf("failed to list existing upgrade policies: %w", err)
 	}
 
 	var automaticPolicyIDs []string
@@ -285,7 +394,7 @@ func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster
 		if policy.ScheduleType() == v1.ScheduleTypeAutomatic {
 			automaticPolicyIDs = append(automaticPolicyIDs, policy.ID())
 		} else {
-			return "", fmt.Errorf("existing manual upgrade policy found: target version %s scheduled at %s",
+			return "", "", fmt.Errorf("existing manual upgrade policy found: target version %s scheduled at %s",
 				policy.Version(), policy.NextRun().Format(time.RFC3339))
 		}
 	}
@@ -293,11 +402,11 @@ func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster
 	// Find target version
 	targetVersion, err := o.determineTargetVersion(cluster.Version().AvailableUpgrades())
 	if err != nil {
-		return "", fmt.Errorf("failed to determine target version: %w", err)
+		return "", "", fmt.Errorf("failed to determine target version: %w", err)
 	}
 
 	if targetVersion == "" {
-		return "", fmt.Errorf("no valid upgrade version found for Y-stream '%s'", o.targetYStream)
+		return "", "", fmt.Errorf("no valid upgrade version found for Y-stream '%s'", o.targetYStream)
 	}
 
 	scheduleTime := time.Now().UTC().Add(time.Duration(o.nextRunMinutes) * time.Minute)
@@ -305,7 +414,7 @@ func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster
 	if o.dryRun {
 		fmt.Printf("  🔍 DRY RUN: Would schedule force upgrade to %s at %s\n",
 			targetVersion, scheduleTime.Format(time.RFC3339))
-		return targetVersion, nil
+		return targetVersion, "", nil
 	}
 
 	// Delete automatic Z-stream upgrade policies
@@ -314,7 +423,7 @@ func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster
 		_, err := ocmClient.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).
 			ControlPlane().UpgradePolicies().ControlPlaneUpgradePolicy(id).Delete().Send()
 		if err != nil {
-			return "", fmt.Errorf("failed to delete automatic upgrade policy (ID: %s): %w", id, err)
+			return "", "", fmt.Errorf("failed to delete automatic upgrade policy (ID: %s): %w", id, err)
 		}
 	}
 
@@ -325,19 +434,19 @@ func (o *forceUpgradeOptions) processCluster(ocmClient *sdk.Connection, cluster
 		NextRun(scheduleTime).
 		Build()
 	if err != nil {
-		return "", fmt.Errorf("failed to build upgrade policy: %w", err)
+		return "", "", fmt.Errorf("failed to build upgrade policy: %w", err)
 	}
 
-	_, err = ocmClient.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).
+	response, err := ocmClient.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).
 		ControlPlane().UpgradePolicies().Add().Body(policy).Send()
 	if err != nil {
-		return "", fmt.Errorf("failed to create upgrade policy: %w", err)
+		return "", "", fmt.Errorf("failed to create upgrade policy: %w", err)
 	}
 
 	fmt.Printf("  ✅ Scheduled force upgrade to version %s at %s\n",
 		targetVersion, scheduleTime.Format(time.RFC3339))
 
-	return targetVersion, nil
+	return targetVersion, response.Body().ID(), nil
 }
 
 func (o *forceUpgradeOptions) determineTargetVersion(availableUpgrades []string) (string, error) {
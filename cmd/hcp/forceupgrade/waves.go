@@ -0,0 +1,494 @@
+package forceupgrade
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmutils "github.com/openshift/osdctl/pkg/utils"
+)
+
+// Ways of splitting the clusters into waves
+const (
+	waveByCount = "count"
+	waveByMC    = "mc"
+)
+
+// Status of a cluster in the rollout
+const (
+	clusterStatusPending   = "pending"
+	clusterStatusScheduled = "scheduled"
+	clusterStatusCompleted = "completed"
+	clusterStatusFailed    = "failed"
+)
+
+const canaryWaveName = "canary"
+
+// errRolloutHalted is returned when the rollout stopped because of failures or a timeout
+var errRolloutHalted = errors.New("rollout halted")
+
+// rolloutProgress is the state of a wave-based rollout, written to the progress file after every change so an
+// interrupted or halted rollout can be resumed
+type rolloutProgress struct {
+	TargetYStream string        `json:"targetYStream"`
+	StartedAt     time.Time     `json:"startedAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+	HaltReason    string        `json:"haltReason,omitempty"`
+	Waves         []rolloutWave `json:"waves"`
+}
+
+type rolloutWave struct {
+	Name     string           `json:"name"`
+	Clusters []rolloutCluster `json:"clusters"`
+}
+
+type rolloutCluster struct {
+	ID                string `json:"id"`
+	ExternalID        string `json:"externalId"`
+	Name              string `json:"name"`
+	ManagementCluster string `json:"managementCluster,omitempty"`
+	Status            string `json:"status"`
+	TargetVersion     string `json:"targetVersion,omitempty"`
+	PolicyID          string `json:"policyId,omitempty"`
+	Error             string `json:"error,omitempty"`
+	ServiceLogError   string `json:"serviceLogError,omitempty"`
+}
+
+// planWaves splits the clusters into waves. The first canarySize clusters form the canary wave. The remaining
+// clusters are split in order into waves of size clusters, or into one wave per management cluster when splitting by MC,
+// where size caps the clusters per wave. A size of 0 puts all remaining clusters, or all clusters of an MC, into a
+// single wave.
+func planWaves(clusters []rolloutCluster, by string, size, canarySize int) []rolloutWave {
+	var waves []rolloutWave
+
+	if canarySize > len(clusters) {
+		canarySize = len(clusters)
+	}
+	if canarySize > 0 {
+		waves = append(waves, rolloutWave{Name: canaryWaveName, Clusters: clusters[:canarySize]})
+	}
+	remaining := clusters[canarySize:]
+
+	var groups [][]rolloutCluster
+	if by == waveByMC {
+		index := map[string]int{}
+		for _, c := range remaining {
+			i, ok := index[c.ManagementCluster]
+			if !ok {
+				i = len(groups)
+				index[c.ManagementCluster] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], c)
+		}
+	} else if len(remaining) > 0 {
+		groups = [][]rolloutCluster{remaining}
+	}
+
+	for _, group := range groups {
+		for len(group) > 0 {
+			n := len(group)
+			if size > 0 && size < n {
+				n = size
+			}
+			name := fmt.Sprintf("wave-%d", len(waves)+1)
+			if canarySize > 0 {
+				name = fmt.Sprintf("wave-%d", len(waves))
+			}
+			if by == waveByMC {
+				name += " (" + group[0].ManagementCluster + ")"
+			}
+			waves = append(waves, rolloutWave{Name: name, Clusters: group[:n]})
+			group = group[n:]
+		}
+	}
+
+	return waves
+}
+
+// loadProgress reads the progress file of a rollout
+func loadProgress(path string) (*rolloutProgress, error) {
+	progress := &rolloutProgress{}
+	found, err := ocmutils.LoadJSON(path, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load progress file: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("progress file %s doesn't exist", path)
+	}
+	return progress, nil
+}
+
+// save writes the progress file
+func (p *rolloutProgress) save(path string) error {
+	p.UpdatedAt = time.Now().UTC()
+	if err := ocmutils.SaveJSONAtomic(path, p); err != nil {
+		return fmt.Errorf("failed to save progress file: %w", err)
+	}
+	return nil
+}
+
+// clusterIDs returns the IDs of all clusters of the rollout
+func (p *rolloutProgress) clusterIDs() []string {
+	var ids []string
+	for _, wave := range p.Waves {
+		for _, c := range wave.Clusters {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids
+}
+
+// count returns the number of clusters with the given status across all waves
+func (p *rolloutProgress) count(status string) int {
+	n := 0
+	for _, wave := range p.Waves {
+		n += wave.count(status)
+	}
+	return n
+}
+
+func (w *rolloutWave) count(status string) int {
+	n := 0
+	for _, c := range w.Clusters {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// done returns whether all clusters of the wave finished upgrading, successfully or not
+func (w *rolloutWave) done() bool {
+	return w.count(clusterStatusPending)+w.count(clusterStatusScheduled) == 0
+}
+
+// haltReason returns why the rollout must stop after a wave, or an empty string if it can continue. Any failure in
+// the canary wave halts the rollout, otherwise it halts once the failures across all waves exceed maxFailures.
+func (p *rolloutProgress) haltReason(wave *rolloutWave, maxFailures int) string {
+	if wave.Name == canaryWaveName && wave.count(clusterStatusFailed) > 0 {
+		return fmt.Sprintf("%d of %d canary clusters failed", wave.count(clusterStatusFailed), len(wave.Clusters))
+	}
+	if failed := p.count(clusterStatusFailed); failed > maxFailures {
+		return fmt.Sprintf("%d clusters failed, exceeding the threshold of %d", failed, maxFailures)
+	}
+	return ""
+}
+
+// upgradeTracker reports the progress of a scheduled control plane upgrade
+type upgradeTracker interface {
+	// upgradeStatus returns clusterStatusScheduled while the upgrade is pending or running, clusterStatusCompleted
+	// or clusterStatusFailed once it finished, and a description of the state
+	upgradeStatus(c rolloutCluster) (string, string, error)
+}
+
+// ocmUpgradeTracker tracks upgrades through the state of their OCM control plane upgrade policy
+type ocmUpgradeTracker struct {
+	ocmClient *sdk.Connection
+}
+
+func (t *ocmUpgradeTracker) upgradeStatus(c rolloutCluster) (string, string, error) {
+	cluster := t.ocmClient.ClustersMgmt().V1().Clusters().Cluster(c.ID)
+
+	response, err := cluster.ControlPlane().UpgradePolicies().ControlPlaneUpgradePolicy(c.PolicyID).Get().Send()
+	if err != nil {
+		if response == nil || response.Status() != http.StatusNotFound {
+			return "", "", fmt.Errorf("failed to get upgrade policy %s: %w", c.PolicyID, err)
+		}
+
+		// Manual policies are removed once they were executed, so check whether the cluster reached the target
+		clusterResponse, err := cluster.Get().Send()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get cluster: %w", err)
+		}
+		status, description := consumedPolicyStatus(clusterResponse.Body().Version().RawID(), c.TargetVersion)
+		return status, description, nil
+	}
+
+	return policyStateStatus(response.Body().State())
+}
+
+// consumedPolicyStatus returns the status of a cluster whose upgrade policy was removed, which is how OCM consumes
+// manual policies once the upgrade started. The upgrade is only complete once the cluster reports the target version,
+// until then it is still running and the version is checked again on the next poll. Upgrades which never get there
+// are reported when the wave times out.
+func consumedPolicyStatus(version, targetVersion string) (string, string) {
+	if version == targetVersion {
+		return clusterStatusCompleted, "control plane is at " + version
+	}
+	return clusterStatusScheduled, fmt.Sprintf("upgrade policy was consumed, control plane is at %s", version)
+}
+
+// policyStateStatus maps the state of an upgrade policy to the status of the cluster in the rollout
+func policyStateStatus(state *v1.UpgradePolicyState) (string, string, error) {
+	description := string(state.Value())
+	if state.Description() != "" {
+		description += ": " + state.Description()
+	}
+
+	switch state.Value() {
+	case v1.UpgradePolicyStateValueCompleted:
+		return clusterStatusCompleted, description, nil
+	case v1.UpgradePolicyStateValueFailed, v1.UpgradePolicyStateValueCancelled:
+		return clusterStatusFailed, description, nil
+	default:
+		return clusterStatusScheduled, description, nil
+	}
+}
+
+// waitForWave polls the scheduled upgrades of a wave until all of them finished or the timeout elapsed, calling
+// save after every change. Errors polling a single cluster are reported and retried on the next poll.
+func waitForWave(wave *rolloutWave, tracker upgradeTracker, interval, timeout time.Duration, save func() error) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		for i := range wave.Clusters {
+			c := &wave.Clusters[i]
+			if c.Status != clusterStatusScheduled {
+				continue
+			}
+
+			status, description, err := tracker.upgradeStatus(*c)
+			if err != nil {
+				fmt.Printf("  ⚠️  %s: failed to get upgrade status: %v\n", c.ExternalID, err)
+				continue
+			}
+			if status == clusterStatusScheduled {
+				continue
+			}
+
+			c.Status = status
+			if status == clusterStatusFailed {
+				c.Error = description
+				fmt.Printf("  ❌ %s (%s): upgrade failed: %s\n", c.ExternalID, c.Name, description)
+			} else {
+				fmt.Printf("  ✅ %s (%s): upgrade completed\n", c.ExternalID, c.Name)
+			}
+			if err := save(); err != nil {
+				return err
+			}
+		}
+
+		pending := wave.count(clusterStatusScheduled)
+		if pending == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %d upgrades of wave %s", timeout, pending, wave.Name)
+		}
+		fmt.Printf("  ⏳ Waiting for %d upgrades of wave %s...\n", pending, wave.Name)
+		time.Sleep(interval)
+	}
+}
+
+// planRollout creates the progress of a new rollout of the clusters
+func (o *forceUpgradeOptions) planRollout(clusters []*v1.Cluster) (*rolloutProgress, error) {
+	var planned []rolloutCluster
+	for _, cluster := range clusters {
+		c := rolloutCluster{
+			ID:         cluster.ID(),
+			ExternalID: cluster.ExternalID(),
+			Name:       cluster.Name(),
+			Status:     clusterStatusPending,
+		}
+		if o.waveBy == waveByMC {
+			mc, err := ocmutils.GetManagementCluster(cluster.ID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get management cluster of %s: %w", cluster.ID(), err)
+			}
+			c.ManagementCluster = mc.Name()
+		}
+		planned = append(planned, c)
+	}
+
+	return &rolloutProgress{
+		TargetYStream: o.targetYStream,
+		StartedAt:     time.Now().UTC(),
+		Waves:         planWaves(planned, o.waveBy, o.waveSize, o.canarySize),
+	}, nil
+}
+
+// runRollout schedules the upgrades wave by wave, waiting for each wave to finish before scheduling the next one.
+// Clusters which were already scheduled or finished, e.g. when resuming, are not scheduled again.
+func (o *forceUpgradeOptions) runRollout(ocmClient *sdk.Connection, clusters []*v1.Cluster, progress *rolloutProgress) error {
+	return o.rollout(progress, clusters, &ocmUpgradeTracker{ocmClient: ocmClient},
+		func(cluster *v1.Cluster) (string, string, error) {
+			return o.processCluster(ocmClient, cluster)
+		},
+		func(cluster *v1.Cluster, targetVersion string) error {
+			return sendUpgradeServiceLog(ocmClient, cluster, o.serviceLogTemplate, targetVersion)
+		})
+}
+
+// rollout implements runRollout, with scheduling and service logs passed in as functions
+func (o *forceUpgradeOptions) rollout(progress *rolloutProgress, clusters []*v1.Cluster, tracker upgradeTracker,
+	schedule func(*v1.Cluster) (string, string, error), sendServiceLog func(*v1.Cluster, string) error) error {
+	save := func() error {
+		if o.dryRun {
+			return nil
+		}
+		if err := progress.save(o.progressFile); err != nil {
+			return fmt.Errorf("failed to save rollout progress: %w", err)
+		}
+		return nil
+	}
+	// Dry runs don't write the progress file, so there is nothing to resume from
+	resumeFile := o.progressFile
+	if o.dryRun {
+		resumeFile = ""
+	}
+	halt := func(reason string) error {
+		progress.HaltReason = reason
+		if err := save(); err != nil {
+			return err
+		}
+		printRolloutSummary(progress, resumeFile)
+		return fmt.Errorf("%w: %s", errRolloutHalted, reason)
+	}
+
+	clustersByID := map[string]*v1.Cluster{}
+	for _, cluster := range clusters {
+		clustersByID[cluster.ID()] = cluster
+	}
+
+	progress.HaltReason = ""
+	if err := save(); err != nil {
+		return err
+	}
+
+	for w := range progress.Waves {
+		wave := &progress.Waves[w]
+		if wave.done() {
+			fmt.Printf("\n[wave %d/%d] %s already finished, skipping\n", w+1, len(progress.Waves), wave.Name)
+			continue
+		}
+		fmt.Printf("\n[wave %d/%d] %s: %d clusters\n", w+1, len(progress.Waves), wave.Name, len(wave.Clusters))
+
+		for i := range wave.Clusters {
+			c := &wave.Clusters[i]
+			if c.Status != clusterStatusPending {
+				continue
+			}
+
+			fmt.Printf("\nProcessing cluster: %s (%s)\n", c.ID, c.Name)
+			cluster, ok := clustersByID[c.ID]
+			if !ok {
+				c.Status = clusterStatusFailed
+				c.Error = "cluster not found in OCM"
+				fmt.Printf("  ⚠️  Failed to create upgrade policy: %s\n", c.Error)
+			} else if targetVersion, policyID, err := schedule(cluster); err != nil {
+				c.Status = clusterStatusFailed
+				c.Error = err.Error()
+				fmt.Printf("  ⚠️  Failed to create upgrade policy: %v\n", err)
+			} else {
+				c.Status = clusterStatusScheduled
+				c.TargetVersion = targetVersion
+				c.PolicyID = policyID
+
+				if o.serviceLogTemplate != "" {
+					if o.dryRun {
+						fmt.Printf("  📧 DRY-RUN: Would send service log notification\n")
+					} else if err := sendServiceLog(cluster, targetVersion); err != nil {
+						c.ServiceLogError = err.Error()
+						fmt.Printf("  ⚠️  Failed to send service log: %v\n", err)
+					} else {
+						fmt.Printf("  📧 Service log notification sent successfully\n")
+					}
+				}
+			}
+			if err := save(); err != nil {
+				return err
+			}
+
+			// Don't schedule further upgrades once too many failed, the scheduled ones are tracked on resume
+			if reason := progress.haltReason(wave, o.maxFailures); reason != "" {
+				return halt(reason)
+			}
+		}
+
+		if o.dryRun {
+			fmt.Printf("  🔍 DRY RUN: Would wait for the upgrades of wave %s to finish\n", wave.Name)
+			continue
+		}
+
+		if err := waitForWave(wave, tracker, o.pollInterval, o.waveTimeout, save); err != nil {
+			return halt(err.Error())
+		}
+		if reason := progress.haltReason(wave, o.maxFailures); reason != "" {
+			return halt(reason)
+		}
+	}
+
+	printRolloutSummary(progress, resumeFile)
+	return nil
+}
+
+// printRolloutPlan prints the clusters of every wave
+func printRolloutPlan(progress *rolloutProgress) {
+	fmt.Printf("\nRollout plan (%d waves):\n", len(progress.Waves))
+	for _, wave := range progress.Waves {
+		fmt.Printf("  %s: %d clusters\n", wave.Name, len(wave.Clusters))
+		for _, c := range wave.Clusters {
+			line := fmt.Sprintf("    - %s (%s)", c.ExternalID, c.Name)
+			if c.ManagementCluster != "" {
+				line += " on " + c.ManagementCluster
+			}
+			if c.Status != clusterStatusPending {
+				line += " [" + c.Status + "]"
+			}
+			fmt.Println(line)
+		}
+	}
+}
+
+// printRolloutSummary prints the outcome of the rollout per wave
+func printRolloutSummary(progress *rolloutProgress, progressFile string) {
+	fmt.Print("\n" + strings.Repeat("=", 60) + "\n")
+	fmt.Print("ROLLOUT SUMMARY\n")
+	fmt.Print(strings.Repeat("=", 60) + "\n")
+
+	for _, wave := range progress.Waves {
+		fmt.Printf("%s: %d completed, %d failed, %d in progress, %d pending\n", wave.Name,
+			wave.count(clusterStatusCompleted), wave.count(clusterStatusFailed),
+			wave.count(clusterStatusScheduled), wave.count(clusterStatusPending))
+	}
+
+	if progress.count(clusterStatusFailed) > 0 {
+		fmt.Printf("\n⚠️ The following clusters failed (please follow-up manually):\n")
+		for _, wave := range progress.Waves {
+			for _, c := range wave.Clusters {
+				if c.Status == clusterStatusFailed {
+					fmt.Printf("  - %s: %s\n", c.ExternalID, c.Error)
+				}
+			}
+		}
+	}
+
+	var serviceLogFailed []string
+	for _, wave := range progress.Waves {
+		for _, c := range wave.Clusters {
+			if c.ServiceLogError != "" {
+				serviceLogFailed = append(serviceLogFailed, fmt.Sprintf("%s: %s", c.ExternalID, c.ServiceLogError))
+			}
+		}
+	}
+	if len(serviceLogFailed) > 0 {
+		fmt.Printf("\n⚠️  Failed to send service logs for the following clusters (please follow-up manually):\n")
+		for _, entry := range serviceLogFailed {
+			fmt.Printf("  - %s\n", entry)
+		}
+	}
+
+	if progress.HaltReason != "" {
+		fmt.Printf("\n🛑 Rollout halted: %s\n", progress.HaltReason)
+	}
+	if progressFile != "" && (progress.HaltReason != "" || progress.count(clusterStatusPending)+progress.count(clusterStatusScheduled) > 0) {
+		fmt.Printf("Resume with: --resume --progress-file %s\n", progressFile)
+	}
+
+	fmt.Print(strings.Repeat("=", 60) + "\n")
+}
@@ -0,0 +1,565 @@
+package forceupgrade
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func newRolloutClusters(mcs ...string) []rolloutCluster {
+	var clusters []rolloutCluster
+	for i, mc := range mcs {
+		clusters = append(clusters, rolloutCluster{
+			ID:                fmt.Sprintf("c%d", i+1),
+			ManagementCluster: mc,
+			Status:            clusterStatusPending,
+		})
+	}
+	return clusters
+}
+
+// waveLayout returns the wave names and the IDs of their clusters
+func waveLayout(waves []rolloutWave) []string {
+	var layout []string
+	for _, wave := range waves {
+		var ids []string
+		for _, c := range wave.Clusters {
+			ids = append(ids, c.ID)
+		}
+		layout = append(layout, wave.Name+": "+strings.Join(ids, ","))
+	}
+	return layout
+}
+
+func TestPlanWaves(t *testing.T) {
+	tests := []struct {
+		name       string
+		mcs        []string
+		by         string
+		size       int
+		canarySize int
+		want       []string
+	}{
+		{
+			name: "single wave without size",
+			mcs:  []string{"", "", ""},
+			by:   waveByCount,
+			want: []string{"wave-1: c1,c2,c3"},
+		},
+		{
+			name: "waves by size",
+			mcs:  []string{"", "", "", "", ""},
+			by:   waveByCount,
+			size: 2,
+			want: []string{"wave-1: c1,c2", "wave-2: c3,c4", "wave-3: c5"},
+		},
+		{
+			name:       "canary before waves by size",
+			mcs:        []string{"", "", "", ""},
+			by:         waveByCount,
+			size:       2,
+			canarySize: 1,
+			want:       []string{"canary: c1", "wave-1: c2,c3", "wave-2: c4"},
+		},
+		{
+			name:       "canary larger than the clusters",
+			mcs:        []string{"", ""},
+			by:         waveByCount,
+			canarySize: 5,
+			want:       []string{"canary: c1,c2"},
+		},
+		{
+			name:       "waves by management cluster",
+			mcs:        []string{"mc1", "mc2", "mc1", "mc3", "mc2"},
+			by:         waveByMC,
+			canarySize: 1,
+			want:       []string{"canary: c1", "wave-1 (mc2): c2,c5", "wave-2 (mc1): c3", "wave-3 (mc3): c4"},
+		},
+		{
+			name: "waves by management cluster capped by size",
+			mcs:  []string{"mc1", "mc1", "mc1", "mc2"},
+			by:   waveByMC,
+			size: 2,
+			want: []string{"wave-1 (mc1): c1,c2", "wave-2 (mc1): c3", "wave-3 (mc2): c4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := waveLayout(planWaves(newRolloutClusters(tt.mcs...), tt.by, tt.size, tt.canarySize))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planWaves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolloutProgressSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress", "rollout.json")
+	progress := &rolloutProgress{
+		TargetYStream: "4.16",
+		Waves:         planWaves(newRolloutClusters("", "", ""), waveByCount, 2, 0),
+	}
+	progress.Waves[0].Clusters[0].Status = clusterStatusScheduled
+	progress.Waves[0].Clusters[0].PolicyID = "policy-1"
+
+	if err := progress.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	loaded, err := loadProgress(path)
+	if err != nil {
+		t.Fatalf("loadProgress() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Waves, progress.Waves) {
+		t.Errorf("loadProgress() waves = %+v, want %+v", loaded.Waves, progress.Waves)
+	}
+	if got := loaded.clusterIDs(); !reflect.DeepEqual(got, []string{"c1", "c2", "c3"}) {
+		t.Errorf("clusterIDs() = %v", got)
+	}
+
+	if _, err := loadProgress(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loadProgress() expected error for a missing file")
+	}
+}
+
+func TestHaltReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		waveName    string
+		failed      int
+		maxFailures int
+		wantHalt    bool
+	}{
+		{name: "no failures", waveName: "wave-1", failed: 0, maxFailures: 0, wantHalt: false},
+		{name: "failures within threshold", waveName: "wave-1", failed: 2, maxFailures: 2, wantHalt: false},
+		{name: "failures above threshold", waveName: "wave-1", failed: 3, maxFailures: 2, wantHalt: true},
+		{name: "canary failure ignores threshold", waveName: canaryWaveName, failed: 1, maxFailures: 5, wantHalt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wave := rolloutWave{Name: tt.waveName, Clusters: newRolloutClusters("", "", "", "")}
+			for i := 0; i < tt.failed; i++ {
+				wave.Clusters[i].Status = clusterStatusFailed
+			}
+			progress := &rolloutProgress{Waves: []rolloutWave{wave}}
+
+			reason := progress.haltReason(&progress.Waves[0], tt.maxFailures)
+			if (reason != "") != tt.wantHalt {
+				t.Errorf("haltReason() = %q, wantHalt %v", reason, tt.wantHalt)
+			}
+		})
+	}
+}
+
+func TestConsumedPolicyStatus(t *testing.T) {
+	if got, _ := consumedPolicyStatus("4.16.2", "4.16.2"); got != clusterStatusCompleted {
+		t.Errorf("consumedPolicyStatus() = %s, want %s once the target version is reached", got, clusterStatusCompleted)
+	}
+
+	// The policy is consumed when the upgrade starts, so the cluster keeps being polled until it reports the target
+	got, description := consumedPolicyStatus("4.15.9", "4.16.2")
+	if got != clusterStatusScheduled {
+		t.Errorf("consumedPolicyStatus() = %s, want %s while the upgrade is running", got, clusterStatusScheduled)
+	}
+	if !strings.Contains(description, "4.15.9") {
+		t.Errorf("consumedPolicyStatus() description = %q, want the current version", description)
+	}
+}
+
+func TestPolicyStateStatus(t *testing.T) {
+	tests := []struct {
+		value v1.UpgradePolicyStateValue
+		want  string
+	}{
+		{value: v1.UpgradePolicyStateValueScheduled, want: clusterStatusScheduled},
+		{value: v1.UpgradePolicyStateValueStarted, want: clusterStatusScheduled},
+		{value: v1.UpgradePolicyStateValueDelayed, want: clusterStatusScheduled},
+		{value: v1.UpgradePolicyStateValueCompleted, want: clusterStatusCompleted},
+		{value: v1.UpgradePolicyStateValueFailed, want: clusterStatusFailed},
+		{value: v1.UpgradePolicyStateValueCancelled, want: clusterStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.value), func(t *testing.T) {
+			state, err := v1.NewUpgradePolicyState().Value(tt.value).Description("details").Build()
+			if err != nil {
+				t.Fatalf("failed to build state: %v", err)
+			}
+
+			got, description, err := policyStateStatus(state)
+			if err != nil {
+				t.Fatalf("policyStateStatus() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("policyStateStatus() = %s, want %s", got, tt.want)
+			}
+			if description != string(tt.value)+": details" {
+				t.Errorf("policyStateStatus() description = %q", description)
+			}
+		})
+	}
+}
+
+// fakeTracker returns the statuses of each cluster in order, repeating the last one
+type fakeTracker struct {
+	statuses map[string][]string
+	polls    map[string]int
+}
+
+func (f *fakeTracker) upgradeStatus(c rolloutCluster) (string, string, error) {
+	if f.polls == nil {
+		f.polls = map[string]int{}
+	}
+	statuses := f.statuses[c.ID]
+	if len(statuses) == 0 {
+		return "", "", errors.New("unknown cluster")
+	}
+	i := f.polls[c.ID]
+	f.polls[c.ID]++
+	if i >= len(statuses) {
+		i = len(statuses) - 1
+	}
+	return statuses[i], "state of " + c.ID, nil
+}
+
+func TestWaitForWave(t *testing.T) {
+	t.Run("polls until all upgrades finished", func(t *testing.T) {
+		wave := &rolloutWave{Name: "wave-1", Clusters: newRolloutClusters("", "", "")}
+		wave.Clusters[0].Status = clusterStatusScheduled
+		wave.Clusters[1].Status = clusterStatusScheduled
+		wave.Clusters[2].Status = clusterStatusCompleted
+		tracker := &fakeTracker{statuses: map[string][]string{
+			"c1": {clusterStatusScheduled, clusterStatusCompleted},
+			"c2": {clusterStatusScheduled, clusterStatusScheduled, clusterStatusFailed},
+		}}
+		saves := 0
+
+		err := waitForWave(wave, tracker, time.Millisecond, time.Minute, func() error {
+			saves++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("waitForWave() error = %v", err)
+		}
+		if wave.Clusters[0].Status != clusterStatusCompleted || wave.Clusters[1].Status != clusterStatusFailed {
+			t.Errorf("unexpected statuses %+v", wave.Clusters)
+		}
+		if wave.Clusters[1].Error != "state of c2" {
+			t.Errorf("failed cluster error = %q", wave.Clusters[1].Error)
+		}
+		if saves != 2 {
+			t.Errorf("progress saved %d times, want 2", saves)
+		}
+		if _, polled := tracker.polls["c3"]; polled {
+			t.Error("finished cluster was polled")
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		wave := &rolloutWave{Name: "wave-1", Clusters: newRolloutClusters("")}
+		wave.Clusters[0].Status = clusterStatusScheduled
+		tracker := &fakeTracker{statuses: map[string][]string{"c1": {clusterStatusScheduled}}}
+
+		err := waitForWave(wave, tracker, time.Millisecond, 5*time.Millisecond, func() error { return nil })
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Errorf("waitForWave() error = %v, want timeout", err)
+		}
+		if wave.Clusters[0].Status != clusterStatusScheduled {
+			t.Errorf("status = %s, want %s", wave.Clusters[0].Status, clusterStatusScheduled)
+		}
+	})
+}
+
+func newTestClusters(t *testing.T, ids ...string) []*v1.Cluster {
+	var clusters []*v1.Cluster
+	for _, id := range ids {
+		cluster, err := v1.NewCluster().ID(id).ExternalID("ext-" + id).Name("name-" + id).Build()
+		if err != nil {
+			t.Fatalf("failed to build cluster: %v", err)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+func TestRollout(t *testing.T) {
+	tests := []struct {
+		name         string
+		planned      []string
+		maxFailures  int
+		dryRun       bool
+		failSchedule map[string]bool
+		statuses     map[string][]string
+		wantErr      bool
+		wantStatus   map[string]string
+		wantNoSched  []string
+	}{
+		{
+			name:     "all waves complete",
+			planned:  []string{"c1", "c2", "c3"},
+			statuses: map[string][]string{"c1": {clusterStatusCompleted}, "c2": {clusterStatusCompleted}, "c3": {clusterStatusCompleted}},
+			wantStatus: map[string]string{
+				"c1": clusterStatusCompleted, "c2": clusterStatusCompleted, "c3": clusterStatusCompleted,
+			},
+		},
+		{
+			name:     "canary failure halts before the next wave",
+			planned:  []string{"c1", "c2", "c3"},
+			statuses: map[string][]string{"c1": {clusterStatusFailed}},
+			wantErr:  true,
+			wantStatus: map[string]string{
+				"c1": clusterStatusFailed, "c2": clusterStatusPending, "c3": clusterStatusPending,
+			},
+			wantNoSched: []string{"c2", "c3"},
+		},
+		{
+			name:         "scheduling failures within threshold continue",
+			planned:      []string{"c1", "c2", "c3"},
+			maxFailures:  1,
+			failSchedule: map[string]bool{"c2": true},
+			statuses:     map[string][]string{"c1": {clusterStatusCompleted}, "c3": {clusterStatusCompleted}},
+			wantStatus: map[string]string{
+				"c1": clusterStatusCompleted, "c2": clusterStatusFailed, "c3": clusterStatusCompleted,
+			},
+		},
+		{
+			name:         "scheduling failures above threshold halt within the wave",
+			planned:      []string{"c1", "c2", "c3"},
+			failSchedule: map[string]bool{"c2": true},
+			statuses:     map[string][]string{"c1": {clusterStatusCompleted}},
+			wantErr:      true,
+			wantStatus: map[string]string{
+				"c1": clusterStatusCompleted, "c2": clusterStatusFailed, "c3": clusterStatusPending,
+			},
+			wantNoSched: []string{"c3"},
+		},
+		{
+			name:    "dry run schedules all waves without waiting",
+			planned: []string{"c1", "c2", "c3"},
+			dryRun:  true,
+			wantStatus: map[string]string{
+				"c1": clusterStatusScheduled, "c2": clusterStatusScheduled, "c3": clusterStatusScheduled,
+			},
+		},
+		{
+			name:        "cluster missing in OCM fails",
+			planned:     []string{"c1", "c2", "missing"},
+			maxFailures: 1,
+			statuses:    map[string][]string{"c1": {clusterStatusCompleted}, "c2": {clusterStatusCompleted}},
+			wantStatus: map[string]string{
+				"c1": clusterStatusCompleted, "c2": clusterStatusCompleted, "missing": clusterStatusFailed,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progressFile := filepath.Join(t.TempDir(), "progress.json")
+			opts := &forceUpgradeOptions{
+				targetYStream: "4.16",
+				dryRun:        tt.dryRun,
+				pollInterval:  time.Millisecond,
+				waveTimeout:   time.Minute,
+				progressFile:  progressFile,
+				maxFailures:   tt.maxFailures,
+			}
+			var planned []rolloutCluster
+			for _, id := range tt.planned {
+				planned = append(planned, rolloutCluster{ID: id, Status: clusterStatusPending})
+			}
+			progress := &rolloutProgress{TargetYStream: "4.16", Waves: planWaves(planned, waveByCount, 1, 1)}
+
+			scheduled := map[string]bool{}
+			schedule := func(cluster *v1.Cluster) (string, string, error) {
+				scheduled[cluster.ID()] = true
+				if tt.failSchedule[cluster.ID()] {
+					return "", "", errors.New("scheduling failed")
+				}
+				return "4.16.10", "policy-" + cluster.ID(), nil
+			}
+
+			err := opts.rollout(progress, newTestClusters(t, "c1", "c2", "c3"), &fakeTracker{statuses: tt.statuses}, schedule, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rollout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errRolloutHalted) {
+				t.Errorf("rollout() error = %v, want %v", err, errRolloutHalted)
+			}
+
+			for _, wave := range progress.Waves {
+				for _, c := range wave.Clusters {
+					if c.Status != tt.wantStatus[c.ID] {
+						t.Errorf("cluster %s status = %s, want %s", c.ID, c.Status, tt.wantStatus[c.ID])
+					}
+				}
+			}
+			for _, id := range tt.wantNoSched {
+				if scheduled[id] {
+					t.Errorf("cluster %s was scheduled after the rollout halted", id)
+				}
+			}
+
+			if tt.dryRun {
+				if _, err := loadProgress(progressFile); err == nil {
+					t.Error("dry run wrote the progress file")
+				}
+				return
+			}
+			saved, err := loadProgress(progressFile)
+			if err != nil {
+				t.Fatalf("failed to load the saved progress: %v", err)
+			}
+			if !reflect.DeepEqual(saved.Waves, progress.Waves) {
+				t.Errorf("saved progress = %+v, want %+v", saved.Waves, progress.Waves)
+			}
+			if (saved.HaltReason != "") != tt.wantErr {
+				t.Errorf("saved halt reason = %q", saved.HaltReason)
+			}
+		})
+	}
+}
+
+func TestRolloutResume(t *testing.T) {
+	opts := &forceUpgradeOptions{
+		targetYStream: "4.16",
+		pollInterval:  time.Millisecond,
+		waveTimeout:   time.Minute,
+		progressFile:  filepath.Join(t.TempDir(), "progress.json"),
+	}
+	progress := &rolloutProgress{
+		TargetYStream: "4.16",
+		HaltReason:    "interrupted",
+		Waves: []rolloutWave{
+			{Name: canaryWaveName, Clusters: []rolloutCluster{{ID: "c1", Status: clusterStatusCompleted}}},
+			{Name: "wave-1", Clusters: []rolloutCluster{
+				{ID: "c2", Status: clusterStatusScheduled, PolicyID: "policy-c2"},
+				{ID: "c3", Status: clusterStatusPending},
+			}},
+		},
+	}
+
+	var scheduled []string
+	schedule := func(cluster *v1.Cluster) (string, string, error) {
+		scheduled = append(scheduled, cluster.ID())
+		return "4.16.10", "policy-" + cluster.ID(), nil
+	}
+	tracker := &fakeTracker{statuses: map[string][]string{"c2": {clusterStatusCompleted}, "c3": {clusterStatusCompleted}}}
+
+	if err := opts.rollout(progress, newTestClusters(t, "c1", "c2", "c3"), tracker, schedule, nil); err != nil {
+		t.Fatalf("rollout() error = %v", err)
+	}
+	if !reflect.DeepEqual(scheduled, []string{"c3"}) {
+		t.Errorf("scheduled %v, want only the pending cluster", scheduled)
+	}
+	if _, polled := tracker.polls["c1"]; polled {
+		t.Error("completed cluster was polled")
+	}
+	if progress.HaltReason != "" {
+		t.Errorf("halt reason = %q, want it cleared", progress.HaltReason)
+	}
+	if progress.count(clusterStatusCompleted) != 3 {
+		t.Errorf("completed = %d, want 3", progress.count(clusterStatusCompleted))
+	}
+}
+
+func TestValidateRollout(t *testing.T) {
+	progressFile := filepath.Join(t.TempDir(), "progress.json")
+	progress := &rolloutProgress{
+		TargetYStream: "4.16",
+		Waves:         []rolloutWave{{Name: "wave-1", Clusters: newRolloutClusters("", "")}},
+	}
+	if err := progress.save(progressFile); err != nil {
+		t.Fatalf("failed to save progress: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		opts           *forceUpgradeOptions
+		wantErr        string
+		wantClusterIDs []string
+	}{
+		{
+			name: "valid waves",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", targetYStream: "4.16", nextRunMinutes: 10,
+				waveBy: waveByCount, waveSize: 5, canarySize: 1, pollInterval: time.Minute, waveTimeout: time.Hour, progressFile: progressFile,
+			},
+			wantClusterIDs: []string{"c1"},
+		},
+		{
+			name: "invalid wave-by",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", nextRunMinutes: 10, waveBy: "region",
+			},
+			wantErr: "invalid --wave-by",
+		},
+		{
+			name: "negative wave size",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", nextRunMinutes: 10, waveSize: -1,
+			},
+			wantErr: "must not be negative",
+		},
+		{
+			name: "missing poll interval",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", nextRunMinutes: 10, waveSize: 5, waveTimeout: time.Hour, progressFile: progressFile,
+			},
+			wantErr: "must be positive",
+		},
+		{
+			name: "missing progress file",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", nextRunMinutes: 10, waveBy: waveByMC, pollInterval: time.Minute, waveTimeout: time.Hour,
+			},
+			wantErr: "--progress-file is required",
+		},
+		{
+			name: "resume takes clusters from the progress file",
+			opts: &forceUpgradeOptions{
+				targetYStream: "4.16", nextRunMinutes: 10, resume: true, pollInterval: time.Minute, waveTimeout: time.Hour, progressFile: progressFile,
+			},
+			wantClusterIDs: []string{"c1", "c2"},
+		},
+		{
+			name: "resume with cluster ID",
+			opts: &forceUpgradeOptions{
+				clusterID: "c1", targetYStream: "4.16", nextRunMinutes: 10, resume: true, pollInterval: time.Minute, waveTimeout: time.Hour, progressFile: progressFile,
+			},
+			wantErr: "cannot be combined",
+		},
+		{
+			name: "resume with a different target",
+			opts: &forceUpgradeOptions{
+				targetYStream: "4.17", nextRunMinutes: 10, resume: true, pollInterval: time.Minute, waveTimeout: time.Hour, progressFile: progressFile,
+			},
+			wantErr: "is for target Y-stream 4.16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.opts.clusterIDs, tt.wantClusterIDs) {
+				t.Errorf("clusterIDs = %v, want %v", tt.opts.clusterIDs, tt.wantClusterIDs)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"regexp"
 	"sort"
+	"strconv"
 	"time"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
@@ -36,6 +37,16 @@ type options struct {
 	output        string
 	showOnly      string
 	noHeaders     bool
+
+	// recommend measures the API load of each hosted cluster and recommends size changes and override removals
+	recommend        bool
+	scaleUpPercent   int
+	scaleDownPercent int
+
+	// history records each audit and bases recommendations on the peak API load across the audits recorded at the current size
+	history          bool
+	historyFile      string
+	historyRetention time.Duration
 }
 
 type clusterInfo struct {
@@ -46,6 +57,11 @@ type clusterInfo struct {
 	HasOverrideAnnotation bool   `json:"has_override" yaml:"has_override"`
 	CurrentSize           string `json:"current_size" yaml:"current_size"`
 	RecommendedSize       string `json:"recommended_size" yaml:"recommended_size"`
+
+	RequestServingInstanceType string          `json:"request_serving_instance_type,omitempty" yaml:"request_serving_instance_type,omitempty"`
+	APILoad                    *apiLoad        `json:"api_load,omitempty" yaml:"api_load,omitempty"`
+	History                    *clusterTrend   `json:"history,omitempty" yaml:"history,omitempty"`
+	Recommendation             *recommendation `json:"recommendation,omitempty" yaml:"recommendation,omitempty"`
 }
 
 type auditResults struct {
@@ -64,7 +80,18 @@ func newCmdAutoscalingAudit() *cobra.Command {
 		Long: `Query a single HCP management cluster to retrieve autoscaling status for all hosted clusters.
 
 This command is useful for checking the autoscaling configuration status of hosted clusters
-on a specific management cluster during day-to-day operations.`,
+on a specific management cluster during day-to-day operations.
+
+With --recommend, the kube-apiserver load of each hosted cluster is measured relative to the
+request-serving node it runs on. Hosted clusters whose API load reaches --scale-up-percent are
+recommended to move to the next larger size. Overrides are recommended for removal when autoscaling
+is enabled and the autoscaler recommends the current or a larger size, or the API load stays below
+--scale-down-percent. Use --show-only needs-action to list only hosted clusters with a recommendation.
+
+With --history, each audit is recorded locally and the report includes how many audits each hosted
+cluster was seen in, how often its size changed and its peak API load at its current size.
+Recommendations are then based on the peak API load across the audits recorded within
+--history-retention since the hosted cluster was moved to its current size.`,
 		Example: `
   # Get autoscaling status for all hosted clusters on a management cluster
   osdctl hcp get-cp-autoscaling-status --mgmt-cluster-id <cluster-id>
@@ -79,10 +106,16 @@ on a specific management cluster during day-to-day operations.`,
   osdctl hcp get-cp-autoscaling-status --mgmt-cluster-id <cluster-id> --show-only needs-removal
 
   # Show only clusters safe to remove override
-  osdctl hcp get-cp-autoscaling-status --mgmt-cluster-id <cluster-id> --show-only safe-to-remove-override`,
+  osdctl hcp get-cp-autoscaling-status --mgmt-cluster-id <cluster-id> --show-only safe-to-remove-override
+
+  # Record the audit and recommend size changes and override removals based on the recorded API load
+  osdctl hcp get-cp-autoscaling-status --mgmt-cluster-id <cluster-id> --recommend --history --show-only needs-action`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(); err != nil {
+				return err
+			}
 			return opts.run(context.Background())
 		},
 	}
@@ -92,9 +125,21 @@ on a specific management cluster during day-to-day operations.`,
 	cmd.Flags().StringVar(&opts.output, "output", "text",
 		"Output format: text, json, yaml, csv")
 	cmd.Flags().StringVar(&opts.showOnly, "show-only", "",
-		"Filter output: needs-removal, ready-for-migration, safe-to-remove-override")
+		"Filter output: needs-removal, ready-for-migration, safe-to-remove-override, needs-action (requires --recommend)")
 	cmd.Flags().BoolVar(&opts.noHeaders, "no-headers", false,
 		"Skip table headers in output")
+	cmd.Flags().BoolVar(&opts.recommend, "recommend", false,
+		"Measure the API load of each hosted cluster and recommend size increases and override removals")
+	cmd.Flags().IntVar(&opts.scaleUpPercent, "scale-up-percent", defaultScaleUpPercent,
+		"Recommend a larger size for hosted clusters whose API load reaches this percentage of their request-serving node")
+	cmd.Flags().IntVar(&opts.scaleDownPercent, "scale-down-percent", defaultScaleDownPercent,
+		"Recommend removing the override of autoscaled hosted clusters whose API load stays below this percentage of their request-serving node")
+	cmd.Flags().BoolVar(&opts.history, "history", false,
+		"Record this audit and report the trend of each hosted cluster across the recorded audits")
+	cmd.Flags().StringVar(&opts.historyFile, "history-file", "",
+		"File the audit history is recorded in. Defaults to hcp/cp-autoscaling-history.json in the osdctl cache directory")
+	cmd.Flags().DurationVar(&opts.historyRetention, "history-retention", defaultHistoryRetention,
+		"How long recorded audits are kept in the history")
 
 	if err := cmd.MarkFlagRequired("mgmt-cluster-id"); err != nil {
 		panic(fmt.Sprintf("failed to mark flag as required: %v", err))
@@ -103,19 +148,46 @@ on a specific management cluster during day-to-day operations.`,
 	return cmd
 }
 
-func (o *options) run(ctx context.Context) error {
+func (o *options) validate() error {
 	validOutputs := map[string]bool{"text": true, "json": true, "yaml": true, "csv": true}
 	if !validOutputs[o.output] {
 		return fmt.Errorf("invalid output format '%s'. Valid options: text, json, yaml, csv", o.output)
 	}
 
 	if o.showOnly != "" {
-		validFilters := map[string]bool{"needs-removal": true, "ready-for-migration": true, "safe-to-remove-override": true}
+		validFilters := map[string]bool{"needs-removal": true, "ready-for-migration": true, "safe-to-remove-override": true, "needs-action": true}
 		if !validFilters[o.showOnly] {
-			return fmt.Errorf("invalid show-only filter '%s'. Valid options: needs-removal, ready-for-migration, safe-to-remove-override", o.showOnly)
+			return fmt.Errorf("invalid show-only filter '%s'. Valid options: needs-removal, ready-for-migration, safe-to-remove-override, needs-action", o.showOnly)
+		}
+		if o.showOnly == "needs-action" && !o.recommend {
+			return fmt.Errorf("the needs-action filter requires --recommend")
 		}
 	}
 
+	if o.scaleUpPercent <= 0 || o.scaleUpPercent > 100 {
+		return fmt.Errorf("--scale-up-percent must be between 1 and 100")
+	}
+	if o.scaleDownPercent < 0 || o.scaleDownPercent >= o.scaleUpPercent {
+		return fmt.Errorf("--scale-down-percent must be between 0 and --scale-up-percent")
+	}
+
+	if o.history {
+		if o.historyRetention <= 0 {
+			return fmt.Errorf("--history-retention must be greater than 0")
+		}
+		if o.historyFile == "" {
+			path, err := defaultHistoryPath()
+			if err != nil {
+				return err
+			}
+			o.historyFile = path
+		}
+	}
+
+	return nil
+}
+
+func (o *options) run(ctx context.Context) error {
 	connection, err := utils.CreateConnection()
 	if err != nil {
 		return fmt.Errorf("failed to create OCM connection: %v", err)
@@ -194,6 +266,20 @@ func (o *options) auditManagementCluster(ctx context.Context, conn *sdk.Connecti
 
 	results.TotalClusters = len(results.Clusters)
 
+	if o.recommend {
+		collectAPILoad(ctx, mgmtClient, results)
+	}
+
+	if o.history {
+		if err := o.recordHistory(results); err != nil {
+			return err
+		}
+	}
+
+	if o.recommend {
+		o.addRecommendations(ctx, mgmtClient, results)
+	}
+
 	if o.showOnly != "" {
 		results = o.applyFilter(results)
 	}
@@ -201,6 +287,63 @@ func (o *options) auditManagementCluster(ctx context.Context, conn *sdk.Connecti
 	return o.outputResults(results)
 }
 
+// collectAPILoad sets the API load and request-serving instance type of each hosted cluster. Hosted clusters whose
+// load can't be measured are left without it.
+func collectAPILoad(ctx context.Context, kubeClient client.Client, results *auditResults) {
+	nodes, err := getRequestServingNodes(ctx, kubeClient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	for i := range results.Clusters {
+		c := &results.Clusters[i]
+		hcpNamespace := fmt.Sprintf("%s-%s", c.Namespace, c.ClusterName)
+		load, instanceType, err := getAPILoad(ctx, kubeClient, hcpNamespace, nodes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to measure the API load of %s: %v\n", c.ClusterName, err)
+			continue
+		}
+		c.APILoad = load
+		c.RequestServingInstanceType = instanceType
+	}
+}
+
+// recordHistory records the audit in the history file and sets the trend of each hosted cluster
+func (o *options) recordHistory(results *auditResults) error {
+	history, err := loadHistory(o.historyFile)
+	if err != nil {
+		return err
+	}
+
+	history.record(results.ManagementCluster, results.Clusters, results.Timestamp, o.historyRetention)
+	if err := history.save(o.historyFile); err != nil {
+		return err
+	}
+
+	for i := range results.Clusters {
+		results.Clusters[i].History = history.trend(results.ManagementCluster, results.Clusters[i])
+	}
+	return nil
+}
+
+// addRecommendations sets the recommendation of each hosted cluster
+func (o *options) addRecommendations(ctx context.Context, kubeClient client.Client, results *auditResults) {
+	sizes, err := getAvailableSizes(ctx, kubeClient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, size increases can't name a target size\n", err)
+	}
+
+	thresholds := recommendationThresholds{
+		scaleUpPercent:   float64(o.scaleUpPercent),
+		scaleDownPercent: float64(o.scaleDownPercent),
+	}
+	for i := range results.Clusters {
+		r := recommend(results.Clusters[i], sizes, thresholds)
+		results.Clusters[i].Recommendation = &r
+	}
+}
+
 func listOcmNamespaces(ctx context.Context, kubeClient client.Client) ([]corev1.Namespace, error) {
 	nsList := &corev1.NamespaceList{}
 	if err := kubeClient.List(ctx, nsList); err != nil {
@@ -298,6 +441,10 @@ func (o *options) applyFilter(results *auditResults) *auditResults {
 			if isSafeToRemoveOverride {
 				filtered.Clusters = append(filtered.Clusters, cluster)
 			}
+		case "needs-action":
+			if cluster.Recommendation != nil && cluster.Recommendation.Action != actionNone {
+				filtered.Clusters = append(filtered.Clusters, cluster)
+			}
 		}
 	}
 
@@ -337,7 +484,7 @@ func (o *options) printTable(results *auditResults) error {
 	p := printer.NewTablePrinter(os.Stdout, 20, 1, 3, ' ')
 
 	if !o.noHeaders {
-		p.AddRow([]string{
+		header := []string{
 			"CLUSTER ID",
 			"CLUSTER NAME",
 			"NAMESPACE",
@@ -345,7 +492,17 @@ func (o *options) printTable(results *auditResults) error {
 			"HAS OVERRIDE",
 			"CURRENT SIZE",
 			"RECOMMENDED SIZE",
-		})
+		}
+		if o.recommend {
+			header = append(header, "INSTANCE TYPE", "API LOAD")
+		}
+		if o.history {
+			header = append(header, "AUDITS", "SIZE CHANGES", "PEAK LOAD")
+		}
+		if o.recommend {
+			header = append(header, "RECOMMENDATION", "REASON")
+		}
+		p.AddRow(header)
 	}
 
 	for _, c := range results.Clusters {
@@ -359,7 +516,7 @@ func (o *options) printTable(results *auditResults) error {
 			overrideStr = "✅"
 		}
 
-		p.AddRow([]string{
+		row := []string{
 			c.ClusterID,
 			c.ClusterName,
 			c.Namespace,
@@ -367,7 +524,9 @@ func (o *options) printTable(results *auditResults) error {
 			overrideStr,
 			c.CurrentSize,
 			c.RecommendedSize,
-		})
+		}
+		row = append(row, o.extraColumns(c)...)
+		p.AddRow(row)
 	}
 
 	p.Flush()
@@ -396,7 +555,7 @@ func (o *options) printCSV(results *auditResults) error {
 	defer w.Flush()
 
 	if !o.noHeaders {
-		if err := w.Write([]string{
+		header := []string{
 			"cluster_id",
 			"cluster_name",
 			"namespace",
@@ -404,7 +563,17 @@ func (o *options) printCSV(results *auditResults) error {
 			"has_override",
 			"current_size",
 			"recommended_size",
-		}); err != nil {
+		}
+		if o.recommend {
+			header = append(header, "request_serving_instance_type", "api_load_percent")
+		}
+		if o.history {
+			header = append(header, "audits", "size_changes", "peak_load_percent")
+		}
+		if o.recommend {
+			header = append(header, "recommendation", "reason")
+		}
+		if err := w.Write(header); err != nil {
 			return fmt.Errorf("failed to write CSV header: %v", err)
 		}
 	}
@@ -420,7 +589,7 @@ func (o *options) printCSV(results *auditResults) error {
 			overrideStr = "true"
 		}
 
-		if err := w.Write([]string{
+		row := []string{
 			c.ClusterID,
 			c.ClusterName,
 			c.Namespace,
@@ -428,10 +597,52 @@ func (o *options) printCSV(results *auditResults) error {
 			overrideStr,
 			c.CurrentSize,
 			c.RecommendedSize,
-		}); err != nil {
+		}
+		row = append(row, o.extraColumns(c)...)
+		if err := w.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %v", err)
 		}
 	}
 
 	return nil
 }
+
+// extraColumns returns the values of the load, history and recommendation columns enabled by --recommend and
+// --history, in the order of the table and CSV headers
+func (o *options) extraColumns(c clusterInfo) []string {
+	var columns []string
+	if o.recommend {
+		load := "N/A"
+		if c.APILoad != nil {
+			load = formatPercent(c.APILoad.percent())
+		}
+		instanceType := c.RequestServingInstanceType
+		if instanceType == "" {
+			instanceType = "N/A"
+		}
+		columns = append(columns, instanceType, load)
+	}
+	if o.history {
+		audits, sizeChanges, peak := "0", "0", "N/A"
+		if c.History != nil {
+			audits = strconv.Itoa(c.History.Samples)
+			sizeChanges = strconv.Itoa(c.History.SizeChanges)
+			if c.History.PeakLoadPercent != nil {
+				peak = formatPercent(*c.History.PeakLoadPercent)
+			}
+		}
+		columns = append(columns, audits, sizeChanges, peak)
+	}
+	if o.recommend {
+		action, reason := actionNone, ""
+		if c.Recommendation != nil {
+			action, reason = c.Recommendation.String(), c.Recommendation.Reason
+		}
+		columns = append(columns, action, reason)
+	}
+	return columns
+}
+
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%.0f%%", percent)
+}
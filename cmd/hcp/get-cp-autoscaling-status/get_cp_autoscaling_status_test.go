@@ -388,3 +388,55 @@ type validationError struct {
 func (e *validationError) Error() string {
 	return e.message
 }
+
+func TestValidateRecommendAndHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    options
+		wantErr string
+	}{
+		{
+			name: "defaults are valid",
+			opts: options{output: "text", scaleUpPercent: defaultScaleUpPercent, scaleDownPercent: defaultScaleDownPercent},
+		},
+		{
+			name:    "needs-action requires recommend",
+			opts:    options{output: "text", showOnly: "needs-action", scaleUpPercent: 80, scaleDownPercent: 30},
+			wantErr: "the needs-action filter requires --recommend",
+		},
+		{
+			name: "needs-action with recommend",
+			opts: options{output: "text", showOnly: "needs-action", recommend: true, scaleUpPercent: 80, scaleDownPercent: 30},
+		},
+		{
+			name:    "scale up percent above 100",
+			opts:    options{output: "text", scaleUpPercent: 120, scaleDownPercent: 30},
+			wantErr: "--scale-up-percent must be between 1 and 100",
+		},
+		{
+			name:    "scale down percent above scale up percent",
+			opts:    options{output: "text", scaleUpPercent: 50, scaleDownPercent: 60},
+			wantErr: "--scale-down-percent must be between 0 and --scale-up-percent",
+		},
+		{
+			name:    "history without retention",
+			opts:    options{output: "text", scaleUpPercent: 80, scaleDownPercent: 30, history: true, historyFile: "history.json"},
+			wantErr: "--history-retention must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("validate() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
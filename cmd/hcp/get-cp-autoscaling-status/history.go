@@ -0,0 +1,147 @@
+package getcpautoscalingstatus
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/utils"
+)
+
+// defaultHistoryRetention is how long audit samples are kept in the history file
+const defaultHistoryRetention = 14 * 24 * time.Hour
+
+// autoscalingHistory is the locally recorded history of audits, per management cluster
+type autoscalingHistory struct {
+	ManagementClusters map[string]*managementClusterHistory `json:"managementClusters"`
+}
+
+// managementClusterHistory holds the audit samples of each hosted cluster of a management cluster
+type managementClusterHistory struct {
+	Clusters map[string][]historySample `json:"clusters"`
+}
+
+// historySample is the state of a hosted cluster in a single audit
+type historySample struct {
+	Timestamp          time.Time `json:"timestamp"`
+	Size               string    `json:"size"`
+	RecommendedSize    string    `json:"recommendedSize"`
+	AutoscalingEnabled bool      `json:"autoscalingEnabled"`
+	HasOverride        bool      `json:"hasOverride"`
+	LoadPercent        *float64  `json:"loadPercent,omitempty"`
+}
+
+// clusterTrend summarizes the recorded samples of a hosted cluster. The peak load only covers the samples recorded at
+// the cluster's current size, as loads recorded at earlier sizes don't tell whether the current size is large enough.
+type clusterTrend struct {
+	Samples         int       `json:"samples" yaml:"samples"`
+	FirstSeen       time.Time `json:"first_seen" yaml:"first_seen"`
+	SizeChanges     int       `json:"size_changes" yaml:"size_changes"`
+	PeakLoadPercent *float64  `json:"peak_load_percent,omitempty" yaml:"peak_load_percent,omitempty"`
+}
+
+// defaultHistoryPath returns the path of the history file in the osdctl cache directory
+func defaultHistoryPath() (string, error) {
+	cacheDir, err := utils.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "hcp", "cp-autoscaling-history.json"), nil
+}
+
+// loadHistory reads the history file, returning an empty history if it doesn't exist yet
+func loadHistory(path string) (*autoscalingHistory, error) {
+	history := &autoscalingHistory{}
+	if _, err := utils.LoadJSON(path, history); err != nil {
+		return nil, fmt.Errorf("failed to load autoscaling history: %w", err)
+	}
+	if history.ManagementClusters == nil {
+		history.ManagementClusters = map[string]*managementClusterHistory{}
+	}
+	return history, nil
+}
+
+// save writes the history file
+func (h *autoscalingHistory) save(path string) error {
+	if err := utils.SaveJSONAtomic(path, h); err != nil {
+		return fmt.Errorf("failed to save autoscaling history: %w", err)
+	}
+	return nil
+}
+
+// record adds a sample of each hosted cluster of an audit to the management cluster's history and forgets samples
+// older than the retention, along with hosted clusters which have no samples left
+func (h *autoscalingHistory) record(mgmtCluster string, clusters []clusterInfo, now time.Time, retention time.Duration) {
+	mc, ok := h.ManagementClusters[mgmtCluster]
+	if !ok || mc.Clusters == nil {
+		mc = &managementClusterHistory{Clusters: map[string][]historySample{}}
+		h.ManagementClusters[mgmtCluster] = mc
+	}
+
+	for _, c := range clusters {
+		sample := historySample{
+			Timestamp:          now,
+			Size:               c.CurrentSize,
+			RecommendedSize:    c.RecommendedSize,
+			AutoscalingEnabled: c.AutoscalingEnabled,
+			HasOverride:        c.HasOverrideAnnotation,
+		}
+		if c.APILoad != nil {
+			load := c.APILoad.percent()
+			sample.LoadPercent = &load
+		}
+		mc.Clusters[c.historyKey()] = append(mc.Clusters[c.historyKey()], sample)
+	}
+
+	for key, samples := range mc.Clusters {
+		var kept []historySample
+		for _, s := range samples {
+			if now.Sub(s.Timestamp) <= retention {
+				kept = append(kept, s)
+			}
+		}
+		if len(kept) == 0 {
+			delete(mc.Clusters, key)
+			continue
+		}
+		mc.Clusters[key] = kept
+	}
+}
+
+// trend summarizes the recorded samples of a hosted cluster, returning nil if it has none
+func (h *autoscalingHistory) trend(mgmtCluster string, c clusterInfo) *clusterTrend {
+	mc, ok := h.ManagementClusters[mgmtCluster]
+	if !ok {
+		return nil
+	}
+	samples := mc.Clusters[c.historyKey()]
+	if len(samples) == 0 {
+		return nil
+	}
+
+	trend := &clusterTrend{
+		Samples:   len(samples),
+		FirstSeen: samples[0].Timestamp,
+	}
+	for i, s := range samples {
+		if i > 0 && s.Size != samples[i-1].Size {
+			trend.SizeChanges++
+		}
+		if s.Size != c.CurrentSize || s.LoadPercent == nil {
+			continue
+		}
+		if trend.PeakLoadPercent == nil || *s.LoadPercent > *trend.PeakLoadPercent {
+			peak := *s.LoadPercent
+			trend.PeakLoadPercent = &peak
+		}
+	}
+	return trend
+}
+
+// historyKey identifies a hosted cluster in the history, falling back to its namespace if it has no cluster ID label
+func (c clusterInfo) historyKey() string {
+	if c.ClusterID != "" {
+		return c.ClusterID
+	}
+	return c.Namespace
+}
@@ -0,0 +1,97 @@
+package getcpautoscalingstatus
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryRecordAndTrend(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	retention := 7 * 24 * time.Hour
+	history := &autoscalingHistory{ManagementClusters: map[string]*managementClusterHistory{}}
+
+	audit := func(at time.Time, size string, load float64, clusters ...clusterInfo) {
+		c := clusterInfo{ClusterID: "cluster-001", Namespace: "ocm-production-001", CurrentSize: size, APILoad: &apiLoad{CPUPercent: load}}
+		history.record("mc-1", append([]clusterInfo{c}, clusters...), at, retention)
+	}
+
+	audit(now.Add(-10*24*time.Hour), "small", 99, clusterInfo{ClusterID: "cluster-002", CurrentSize: "small"})
+	audit(now.Add(-2*24*time.Hour), "small", 60)
+	audit(now.Add(-24*time.Hour), "medium", 85)
+	audit(now, "medium", 40)
+
+	trend := history.trend("mc-1", clusterInfo{ClusterID: "cluster-001", CurrentSize: "medium"})
+	if trend == nil {
+		t.Fatal("trend() returned nil for a recorded cluster")
+	}
+	if trend.Samples != 3 {
+		t.Errorf("trend() samples = %d, want 3 after expired samples are forgotten", trend.Samples)
+	}
+	if !trend.FirstSeen.Equal(now.Add(-2 * 24 * time.Hour)) {
+		t.Errorf("trend() first seen = %s", trend.FirstSeen)
+	}
+	if trend.SizeChanges != 1 {
+		t.Errorf("trend() size changes = %d, want 1", trend.SizeChanges)
+	}
+	if trend.PeakLoadPercent == nil || *trend.PeakLoadPercent != 85 {
+		t.Errorf("trend() peak load = %v, want 85", trend.PeakLoadPercent)
+	}
+
+	if trend := history.trend("mc-1", clusterInfo{ClusterID: "cluster-002"}); trend != nil {
+		t.Errorf("trend() = %+v, want nil for a cluster whose samples expired", trend)
+	}
+	if trend := history.trend("mc-2", clusterInfo{ClusterID: "cluster-001"}); trend != nil {
+		t.Errorf("trend() = %+v, want nil for an unrecorded management cluster", trend)
+	}
+}
+
+func TestHistoryTrendAfterResize(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	history := &autoscalingHistory{ManagementClusters: map[string]*managementClusterHistory{}}
+	audit := func(at time.Time, size string, load float64) {
+		c := clusterInfo{ClusterID: "cluster-001", CurrentSize: size, APILoad: &apiLoad{CPUPercent: load}}
+		history.record("mc-1", []clusterInfo{c}, at, defaultHistoryRetention)
+	}
+
+	// The cluster was moved up a size because of the high load, which dropped afterwards
+	audit(now.Add(-2*time.Hour), "small", 95)
+	audit(now.Add(-time.Hour), "medium", 30)
+	c := clusterInfo{ClusterID: "cluster-001", CurrentSize: "medium", APILoad: &apiLoad{CPUPercent: 35}}
+	audit(now, c.CurrentSize, c.APILoad.CPUPercent)
+
+	c.History = history.trend("mc-1", c)
+	if c.History == nil || c.History.PeakLoadPercent == nil || *c.History.PeakLoadPercent != 35 {
+		t.Fatalf("trend() = %+v, want a peak load of 35 recorded at the current size", c.History)
+	}
+	if c.History.SizeChanges != 1 {
+		t.Errorf("trend() size changes = %d, want 1", c.History.SizeChanges)
+	}
+
+	got := recommend(c, []string{"small", "medium", "large"}, recommendationThresholds{scaleUpPercent: 80, scaleDownPercent: 50})
+	if got.Action == actionIncreaseSize {
+		t.Errorf("recommend() = %+v, the load recorded before the resize must not recommend another increase", got)
+	}
+}
+
+func TestHistorySaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hcp", "history.json")
+
+	history, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() unexpected error for a missing file: %v", err)
+	}
+	history.record("mc-1", []clusterInfo{{Namespace: "ocm-production-001", CurrentSize: "small"}}, time.Now(), time.Hour)
+	if err := history.save(path); err != nil {
+		t.Fatalf("save() unexpected error: %v", err)
+	}
+
+	loaded, err := loadHistory(path)
+	if err != nil {
+		t.Fatalf("loadHistory() unexpected error: %v", err)
+	}
+	samples := loaded.ManagementClusters["mc-1"].Clusters["ocm-production-001"]
+	if len(samples) != 1 || samples[0].Size != "small" {
+		t.Errorf("loadHistory() samples = %+v, want the recorded sample keyed by namespace", samples)
+	}
+}
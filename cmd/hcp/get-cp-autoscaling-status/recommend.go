@@ -0,0 +1,279 @@
+package getcpautoscalingstatus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	actionNone           = "none"
+	actionIncreaseSize   = "increase-size"
+	actionRemoveOverride = "remove-override"
+
+	defaultScaleUpPercent   = 80
+	defaultScaleDownPercent = 30
+
+	labelApp            = "app"
+	kubeAPIServerApp    = "kube-apiserver"
+	clusterSizingConfig = "cluster"
+)
+
+// apiLoad is the kube-apiserver resource usage of a hosted cluster relative to the allocatable resources of the
+// request-serving node it runs on, of the most utilized kube-apiserver pod
+type apiLoad struct {
+	CPUPercent    float64 `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent" yaml:"memory_percent"`
+}
+
+// percent returns the utilization of the most constrained resource
+func (l apiLoad) percent() float64 {
+	return max(l.CPUPercent, l.MemoryPercent)
+}
+
+type recommendation struct {
+	Action     string `json:"action" yaml:"action"`
+	TargetSize string `json:"target_size,omitempty" yaml:"target_size,omitempty"`
+	Reason     string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+func (r recommendation) String() string {
+	if r.TargetSize != "" {
+		return fmt.Sprintf("%s (%s)", r.Action, r.TargetSize)
+	}
+	return r.Action
+}
+
+// recommendationThresholds are the API load percentages above which a larger size is recommended, and below which
+// an override holding a cluster on its current size is no longer needed
+type recommendationThresholds struct {
+	scaleUpPercent   float64
+	scaleDownPercent float64
+}
+
+// peakLoad returns the highest API load recorded for the cluster at its current size, and whether any load is known
+func (c clusterInfo) peakLoad() (float64, bool) {
+	var load float64
+	known := false
+	if c.APILoad != nil {
+		load = c.APILoad.percent()
+		known = true
+	}
+	if c.History != nil && c.History.PeakLoadPercent != nil {
+		load = max(load, *c.History.PeakLoadPercent)
+		known = true
+	}
+	return load, known
+}
+
+// recommend suggests moving a hosted cluster to a larger size when the API load on its request-serving node reaches
+// the scale up threshold, or removing its cluster-size-override annotation when the autoscaler can take over sizing.
+// sizes are the available cluster sizes, ordered from smallest to largest.
+func recommend(c clusterInfo, sizes []string, t recommendationThresholds) recommendation {
+	load, hasLoad := c.peakLoad()
+	current := slices.Index(sizes, c.CurrentSize)
+
+	if hasLoad && load >= t.scaleUpPercent {
+		if current == -1 {
+			return recommendation{
+				Action: actionIncreaseSize,
+				Reason: fmt.Sprintf("API load peaked at %.0f%% of the request-serving node, current size %s is unknown", load, c.CurrentSize),
+			}
+		}
+		if current == len(sizes)-1 {
+			return recommendation{
+				Action: actionNone,
+				Reason: fmt.Sprintf("API load peaked at %.0f%% but %s is already the largest size", load, c.CurrentSize),
+			}
+		}
+		return recommendation{
+			Action:     actionIncreaseSize,
+			TargetSize: sizes[current+1],
+			Reason:     fmt.Sprintf("API load peaked at %.0f%% of the %s request-serving node", load, c.CurrentSize),
+		}
+	}
+
+	if !c.HasOverrideAnnotation || !c.AutoscalingEnabled {
+		return recommendation{Action: actionNone}
+	}
+
+	knownRecommendation := c.RecommendedSize != "" && c.RecommendedSize != "N/A"
+	if knownRecommendation && c.RecommendedSize == c.CurrentSize {
+		return recommendation{
+			Action: actionRemoveOverride,
+			Reason: "the autoscaler recommends the current size",
+		}
+	}
+	if knownRecommendation && current != -1 && slices.Index(sizes, c.RecommendedSize) > current {
+		return recommendation{
+			Action:     actionRemoveOverride,
+			TargetSize: c.RecommendedSize,
+			Reason:     fmt.Sprintf("the override holds the cluster below the recommended size %s", c.RecommendedSize),
+		}
+	}
+	if hasLoad && load <= t.scaleDownPercent {
+		r := recommendation{
+			Action: actionRemoveOverride,
+			Reason: fmt.Sprintf("API load peaked at only %.0f%% of the %s request-serving node", load, c.CurrentSize),
+		}
+		if knownRecommendation {
+			r.TargetSize = c.RecommendedSize
+		}
+		return r
+	}
+
+	return recommendation{Action: actionNone}
+}
+
+// getAvailableSizes returns the cluster sizes of the management cluster's ClusterSizingConfiguration, ordered from
+// smallest to largest
+func getAvailableSizes(ctx context.Context, kubeClient client.Client) ([]string, error) {
+	config := &unstructured.Unstructured{}
+	config.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "scheduling.hypershift.openshift.io",
+		Version: "v1alpha1",
+		Kind:    "ClusterSizingConfiguration",
+	})
+	if err := kubeClient.Get(ctx, client.ObjectKey{Name: clusterSizingConfig}, config); err != nil {
+		return nil, fmt.Errorf("failed to get cluster sizing configuration: %v", err)
+	}
+
+	sizesRaw, found, err := unstructured.NestedSlice(config.Object, "spec", "sizes")
+	if err != nil || !found {
+		return nil, fmt.Errorf("failed to get sizes from cluster sizing configuration: %v", err)
+	}
+
+	var sizes []string
+	for _, sizeRaw := range sizesRaw {
+		sizeMap, ok := sizeRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(sizeMap, "name"); name != "" {
+			sizes = append(sizes, name)
+		}
+	}
+
+	return sizes, nil
+}
+
+// getRequestServingNodes returns the request-serving nodes of the management cluster by name
+func getRequestServingNodes(ctx context.Context, kubeClient client.Client) (map[string]corev1.Node, error) {
+	nodeList := &corev1.NodeList{}
+	if err := kubeClient.List(ctx, nodeList, client.HasLabels{hypershiftv1beta1.RequestServingComponentLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list request-serving nodes: %v", err)
+	}
+
+	nodes := make(map[string]corev1.Node, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodes[node.Name] = node
+	}
+	return nodes, nil
+}
+
+// getAPILoad measures the kube-apiserver load of a hosted control plane from the metrics API
+func getAPILoad(ctx context.Context, kubeClient client.Client, hcpNamespace string, nodes map[string]corev1.Node) (*apiLoad, string, error) {
+	listOpts := []client.ListOption{client.InNamespace(hcpNamespace), client.MatchingLabels{labelApp: kubeAPIServerApp}}
+
+	pods := &corev1.PodList{}
+	if err := kubeClient.List(ctx, pods, listOpts...); err != nil {
+		return nil, "", fmt.Errorf("failed to list kube-apiserver pods: %v", err)
+	}
+
+	podMetrics := &unstructured.UnstructuredList{}
+	podMetrics.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "metrics.k8s.io",
+		Version: "v1beta1",
+		Kind:    "PodMetricsList",
+	})
+	if err := kubeClient.List(ctx, podMetrics, listOpts...); err != nil {
+		return nil, "", fmt.Errorf("failed to get kube-apiserver metrics: %v", err)
+	}
+
+	usage, err := podUsage(podMetrics)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return calculateAPILoad(pods.Items, usage, nodes)
+}
+
+// podUsage sums the container usage of each pod in a PodMetricsList
+func podUsage(podMetrics *unstructured.UnstructuredList) (map[string]corev1.ResourceList, error) {
+	usage := map[string]corev1.ResourceList{}
+	for _, item := range podMetrics.Items {
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metrics of pod %s: %v", item.GetName(), err)
+		}
+
+		cpu := resource.Quantity{}
+		memory := resource.Quantity{}
+		for _, containerRaw := range containers {
+			container, ok := containerRaw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for name, total := range map[string]*resource.Quantity{"cpu": &cpu, "memory": &memory} {
+				value, _, _ := unstructured.NestedString(container, "usage", name)
+				if value == "" {
+					continue
+				}
+				q, err := resource.ParseQuantity(value)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse %s usage of pod %s: %v", name, item.GetName(), err)
+				}
+				total.Add(q)
+			}
+		}
+		usage[item.GetName()] = corev1.ResourceList{corev1.ResourceCPU: cpu, corev1.ResourceMemory: memory}
+	}
+	return usage, nil
+}
+
+// calculateAPILoad returns the load of the most utilized kube-apiserver pod relative to the request-serving node it
+// runs on, along with the instance type of that node
+func calculateAPILoad(pods []corev1.Pod, usage map[string]corev1.ResourceList, nodes map[string]corev1.Node) (*apiLoad, string, error) {
+	var load *apiLoad
+	var instanceType string
+
+	for _, pod := range pods {
+		podUsage, ok := usage[pod.Name]
+		if !ok {
+			continue
+		}
+		node, ok := nodes[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+
+		allocatable := node.Status.Allocatable
+		podLoad := apiLoad{
+			CPUPercent:    utilizationPercent(podUsage[corev1.ResourceCPU], allocatable[corev1.ResourceCPU]),
+			MemoryPercent: utilizationPercent(podUsage[corev1.ResourceMemory], allocatable[corev1.ResourceMemory]),
+		}
+		if load == nil || podLoad.percent() > load.percent() {
+			load = &podLoad
+			instanceType = node.Labels[corev1.LabelInstanceTypeStable]
+		}
+	}
+
+	if load == nil {
+		return nil, "", fmt.Errorf("no kube-apiserver metrics found on request-serving nodes")
+	}
+	return load, instanceType, nil
+}
+
+func utilizationPercent(used, allocatable resource.Quantity) float64 {
+	if allocatable.IsZero() {
+		return 0
+	}
+	return float64(used.MilliValue()) * 100 / float64(allocatable.MilliValue())
+}
@@ -0,0 +1,218 @@
+package getcpautoscalingstatus
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRecommend(t *testing.T) {
+	sizes := []string{"small", "medium", "large"}
+	thresholds := recommendationThresholds{scaleUpPercent: 80, scaleDownPercent: 30}
+	peak := func(p float64) *clusterTrend { return &clusterTrend{Samples: 3, PeakLoadPercent: &p} }
+
+	tests := []struct {
+		name       string
+		cluster    clusterInfo
+		wantAction string
+		wantTarget string
+	}{
+		{
+			name: "high load moves to the next size",
+			cluster: clusterInfo{
+				CurrentSize: "small",
+				APILoad:     &apiLoad{CPUPercent: 85, MemoryPercent: 40},
+			},
+			wantAction: actionIncreaseSize,
+			wantTarget: "medium",
+		},
+		{
+			name: "high memory load counts as high load",
+			cluster: clusterInfo{
+				CurrentSize: "medium",
+				APILoad:     &apiLoad{CPUPercent: 10, MemoryPercent: 90},
+			},
+			wantAction: actionIncreaseSize,
+			wantTarget: "large",
+		},
+		{
+			name: "peak load from history takes precedence over current load",
+			cluster: clusterInfo{
+				CurrentSize: "small",
+				APILoad:     &apiLoad{CPUPercent: 20},
+				History:     peak(95),
+			},
+			wantAction: actionIncreaseSize,
+			wantTarget: "medium",
+		},
+		{
+			name: "high load on the largest size",
+			cluster: clusterInfo{
+				CurrentSize: "large",
+				APILoad:     &apiLoad{CPUPercent: 95},
+			},
+			wantAction: actionNone,
+		},
+		{
+			name: "high load on an unknown size",
+			cluster: clusterInfo{
+				CurrentSize: "N/A",
+				APILoad:     &apiLoad{CPUPercent: 95},
+			},
+			wantAction: actionIncreaseSize,
+		},
+		{
+			name: "override matching the recommended size",
+			cluster: clusterInfo{
+				AutoscalingEnabled:    true,
+				HasOverrideAnnotation: true,
+				CurrentSize:           "medium",
+				RecommendedSize:       "medium",
+			},
+			wantAction: actionRemoveOverride,
+		},
+		{
+			name: "override holding the cluster below the recommended size",
+			cluster: clusterInfo{
+				AutoscalingEnabled:    true,
+				HasOverrideAnnotation: true,
+				CurrentSize:           "small",
+				RecommendedSize:       "large",
+				APILoad:               &apiLoad{CPUPercent: 50},
+			},
+			wantAction: actionRemoveOverride,
+			wantTarget: "large",
+		},
+		{
+			name: "override with low load",
+			cluster: clusterInfo{
+				AutoscalingEnabled:    true,
+				HasOverrideAnnotation: true,
+				CurrentSize:           "large",
+				RecommendedSize:       "small",
+				History:               peak(20),
+			},
+			wantAction: actionRemoveOverride,
+			wantTarget: "small",
+		},
+		{
+			name: "override with moderate load",
+			cluster: clusterInfo{
+				AutoscalingEnabled:    true,
+				HasOverrideAnnotation: true,
+				CurrentSize:           "large",
+				RecommendedSize:       "small",
+				APILoad:               &apiLoad{CPUPercent: 50},
+			},
+			wantAction: actionNone,
+		},
+		{
+			name: "override without autoscaling",
+			cluster: clusterInfo{
+				HasOverrideAnnotation: true,
+				CurrentSize:           "medium",
+				RecommendedSize:       "medium",
+				APILoad:               &apiLoad{CPUPercent: 10},
+			},
+			wantAction: actionNone,
+		},
+		{
+			name: "no override and unknown load",
+			cluster: clusterInfo{
+				AutoscalingEnabled: true,
+				CurrentSize:        "small",
+				RecommendedSize:    "medium",
+			},
+			wantAction: actionNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recommend(tt.cluster, sizes, thresholds)
+			if got.Action != tt.wantAction {
+				t.Errorf("recommend() action = %s, want %s (reason: %s)", got.Action, tt.wantAction, got.Reason)
+			}
+			if got.TargetSize != tt.wantTarget {
+				t.Errorf("recommend() target size = %s, want %s", got.TargetSize, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestPodUsage(t *testing.T) {
+	podMetrics := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{{
+			Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "kube-apiserver-0"},
+				"containers": []interface{}{
+					map[string]interface{}{"name": "kube-apiserver", "usage": map[string]interface{}{"cpu": "1500m", "memory": "2Gi"}},
+					map[string]interface{}{"name": "konnectivity", "usage": map[string]interface{}{"cpu": "500m", "memory": "1Gi"}},
+				},
+			},
+		}},
+	}
+
+	usage, err := podUsage(podMetrics)
+	if err != nil {
+		t.Fatalf("podUsage() unexpected error: %v", err)
+	}
+
+	got := usage["kube-apiserver-0"]
+	if cpu := got[corev1.ResourceCPU]; cpu.MilliValue() != 2000 {
+		t.Errorf("podUsage() cpu = %s, want 2", cpu.String())
+	}
+	if memory := got[corev1.ResourceMemory]; memory.Value() != 3*1024*1024*1024 {
+		t.Errorf("podUsage() memory = %s, want 3Gi", memory.String())
+	}
+}
+
+func TestCalculateAPILoad(t *testing.T) {
+	node := func(name, instanceType string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelInstanceTypeStable: instanceType}},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			}},
+		}
+	}
+	pod := func(name, nodeName string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1.PodSpec{NodeName: nodeName}}
+	}
+	usage := func(cpu, memory string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+	}
+	nodes := map[string]corev1.Node{"node-a": node("node-a", "m5.xlarge"), "node-b": node("node-b", "m5.2xlarge")}
+
+	t.Run("reports the most utilized pod", func(t *testing.T) {
+		load, instanceType, err := calculateAPILoad(
+			[]corev1.Pod{pod("kas-0", "node-a"), pod("kas-1", "node-b")},
+			map[string]corev1.ResourceList{"kas-0": usage("1", "4Gi"), "kas-1": usage("3", "4Gi")},
+			nodes,
+		)
+		if err != nil {
+			t.Fatalf("calculateAPILoad() unexpected error: %v", err)
+		}
+		if load.CPUPercent != 75 || load.MemoryPercent != 25 {
+			t.Errorf("calculateAPILoad() = %+v, want 75%% cpu and 25%% memory", *load)
+		}
+		if instanceType != "m5.2xlarge" {
+			t.Errorf("calculateAPILoad() instance type = %s, want m5.2xlarge", instanceType)
+		}
+	})
+
+	t.Run("ignores pods outside request-serving nodes", func(t *testing.T) {
+		_, _, err := calculateAPILoad(
+			[]corev1.Pod{pod("kas-0", "worker")},
+			map[string]corev1.ResourceList{"kas-0": usage("1", "4Gi")},
+			nodes,
+		)
+		if err == nil {
+			t.Error("calculateAPILoad() expected an error without pods on request-serving nodes")
+		}
+	})
+}
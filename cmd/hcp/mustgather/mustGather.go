@@ -5,8 +5,10 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -21,32 +23,69 @@ import (
 	"github.com/openshift/osdctl/cmd/common"
 	"github.com/openshift/osdctl/cmd/dynatrace"
 	"github.com/openshift/osdctl/pkg/osdctlConfig"
+	osdctlaws "github.com/openshift/osdctl/pkg/provider/aws"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/sync/errgroup"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// defaultDynatraceSince is the number of hours of Dynatrace logs gathered for the hcp target without --since
+const defaultDynatraceSince = 72
+
 type mustGather struct {
 	clusterId          string
 	reason             string
 	gatherTargets      string
 	acmMustGatherImage string
+	// parallelism limits the number of targets gathered at the same time, 0 gathers all targets at once
+	parallelism int
+	// since restricts the collected logs to the given time window, 0 collects the default window of each gather
+	since time.Duration
+	// namespaces restricts the collected namespaced resources and logs to the namespaces matching these patterns
+	namespaces    []string
+	skipRedaction bool
+	upload        bool
+	uploadOptions uploadOptions
 }
 
+var validGatherTargets = map[string]bool{"sc": true, "sc_acm": true, "mc": true, "hcp": true}
+
 func NewCmdMustGather() *cobra.Command {
 	mg := &mustGather{}
 
 	mustGatherCommand := &cobra.Command{
-		Use:     "must-gather --cluster-id <cluster-identifier>",
-		Short:   "Create a must-gather for HCP cluster",
-		Long:    "Create a must-gather for an HCP cluster with optional gather targets",
-		Example: "osdctl hcp must-gather --cluster-id CLUSTER_ID --gather sc,mc,sc_acm --reason OHSS-1234",
+		Use:   "must-gather --cluster-id <cluster-identifier>",
+		Short: "Create a must-gather for HCP cluster",
+		Long: `Create a must-gather for an HCP cluster with optional gather targets.
+
+Targets are gathered in parallel, limited by --parallelism. The collected data can be reduced with --since, which
+restricts logs to a time window, and --namespaces, which keeps only the namespaces matching the given shell patterns.
+
+Secrets, tokens, passwords and private keys are redacted from all collected files before the archive is created,
+unless --skip-redaction is set.
+
+With --upload, the archive is uploaded to an S3 bucket or S3-compatible service and a shareable URL is printed. The
+destination can be set in the osdctl config with the keys must_gather_upload_bucket, must_gather_upload_endpoint and
+must_gather_upload_region, and is overridden by the matching flags. Credentials are read from the default AWS
+credential chain, e.g. AWS_PROFILE or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY.`,
+		Example: `  osdctl hcp must-gather --cluster-id CLUSTER_ID --gather sc,mc,sc_acm --reason OHSS-1234
+
+  # Gather the last 6 hours of the HCP namespaces only, 2 targets at a time
+  osdctl hcp must-gather --cluster-id CLUSTER_ID --gather hcp,mc --since 6h --namespaces 'ocm-*,hypershift' --parallelism 2 --reason OHSS-1234
+
+  # Upload the archive to a MinIO bucket
+  osdctl hcp must-gather --cluster-id CLUSTER_ID --reason OHSS-1234 --upload --upload-bucket must-gathers --upload-endpoint http://localhost:9000`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return mg.complete()
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			return mg.Run()
+			return mg.Run(cmd.Context())
 		},
 	}
 
@@ -55,6 +94,15 @@ func NewCmdMustGather() *cobra.Command {
 	mustGatherCommand.Flags().StringVar(&mg.reason, "reason", "", "The reason for this command, which requires elevation (e.g., OHSS ticket or PD incident).")
 	mustGatherCommand.Flags().StringVar(&mg.gatherTargets, "gather", "hcp", "Comma-separated list of gather targets (available: sc, sc_acm, mc, hcp).")
 	mustGatherCommand.Flags().StringVar(&mg.acmMustGatherImage, "acm_image", defaultAcmImage, "Overrides the acm must-gather image being used for acm mc, sc as well as hcp must-gathers.")
+	mustGatherCommand.Flags().IntVar(&mg.parallelism, "parallelism", 0, "Maximum number of gather targets to run at the same time (0 runs all targets at once).")
+	mustGatherCommand.Flags().DurationVar(&mg.since, "since", 0, "Only collect logs newer than this duration, e.g. 6h (default: the default window of each gather).")
+	mustGatherCommand.Flags().StringSliceVar(&mg.namespaces, "namespaces", nil, "Comma-separated list of namespaces or shell patterns to keep (default: all gathered namespaces).")
+	mustGatherCommand.Flags().BoolVar(&mg.skipRedaction, "skip-redaction", false, "Do not redact secrets and tokens from the collected files.")
+	mustGatherCommand.Flags().BoolVar(&mg.upload, "upload", false, "Upload the archive and print a shareable URL.")
+	mustGatherCommand.Flags().StringVar(&mg.uploadOptions.bucket, "upload-bucket", "", "Bucket to upload the archive to (config: "+uploadBucketConfigKey+").")
+	mustGatherCommand.Flags().StringVar(&mg.uploadOptions.endpoint, "upload-endpoint", "", "URL of an S3-compatible service, e.g. MinIO (default: AWS S3, config: "+uploadEndpointConfigKey+").")
+	mustGatherCommand.Flags().StringVar(&mg.uploadOptions.region, "upload-region", "", "Region of the upload bucket (default: the AWS config region, config: "+uploadRegionConfigKey+").")
+	mustGatherCommand.Flags().DurationVar(&mg.uploadOptions.expiry, "upload-expiry", defaultUploadExpiry, "How long the shareable URL of the upload stays valid, at most 168h.")
 
 	mustGatherCommand.MarkFlagRequired("cluster-id")
 	mustGatherCommand.MarkFlagRequired("reason")
@@ -62,7 +110,66 @@ func NewCmdMustGather() *cobra.Command {
 	return mustGatherCommand
 }
 
-func (mg *mustGather) Run() error {
+// complete validates the flags and fills in the upload destination from the osdctl config
+func (mg *mustGather) complete() error {
+	for _, target := range strings.Split(mg.gatherTargets, ",") {
+		if !validGatherTargets[target] {
+			return fmt.Errorf("unknown gather target %q, available: sc, sc_acm, mc, hcp", target)
+		}
+	}
+	if mg.parallelism < 0 {
+		return errors.New("--parallelism must not be negative")
+	}
+	if mg.since < 0 {
+		return errors.New("--since must not be negative")
+	}
+	for _, pattern := range mg.namespaces {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --namespaces pattern %q: %w", pattern, err)
+		}
+	}
+
+	if !mg.upload {
+		return nil
+	}
+	if mg.uploadOptions.bucket == "" {
+		mg.uploadOptions.bucket = viper.GetString(uploadBucketConfigKey)
+	}
+	if mg.uploadOptions.endpoint == "" {
+		mg.uploadOptions.endpoint = viper.GetString(uploadEndpointConfigKey)
+	}
+	if mg.uploadOptions.region == "" {
+		mg.uploadOptions.region = viper.GetString(uploadRegionConfigKey)
+	}
+	if mg.uploadOptions.bucket == "" {
+		return fmt.Errorf("--upload requires --upload-bucket or %s in the osdctl config", uploadBucketConfigKey)
+	}
+	if mg.uploadOptions.expiry <= 0 || mg.uploadOptions.expiry > maxUploadExpiry {
+		return fmt.Errorf("--upload-expiry must be between 0 and %s", maxUploadExpiry)
+	}
+	mg.uploadOptions.prefix = mg.clusterId
+
+	return nil
+}
+
+// mustGatherFlags returns the flags passed to oc adm must-gather to gather into destDir
+func (mg *mustGather) mustGatherFlags(destDir string, additionalFlags ...string) []string {
+	flags := []string{"--dest-dir=" + destDir}
+	if mg.since > 0 {
+		flags = append(flags, "--since="+mg.since.String())
+	}
+	return append(flags, additionalFlags...)
+}
+
+// dynatraceSince returns the number of hours of Dynatrace logs to gather
+func (mg *mustGather) dynatraceSince() int {
+	if mg.since <= 0 {
+		return defaultDynatraceSince
+	}
+	return int(math.Ceil(mg.since.Hours()))
+}
+
+func (mg *mustGather) Run(ctx context.Context) error {
 	ocmClient, err := utils.CreateConnection()
 	if err != nil {
 		return err
@@ -144,72 +251,92 @@ func (mg *mustGather) Run() error {
 		}
 	}()
 
-	// Start must-gathers in parallel
-	var wg sync.WaitGroup
-	for _, gatherTarget := range gatherTargets {
-		wg.Add(1)
-		go func(gatherTarget string) {
-			defer wg.Done()
-			// Mark this gatherTarget as completed for progress tracking
-			defer completed.Store(gatherTarget, true)
+	gather := func(gatherTarget string) error {
+		switch gatherTarget {
+		case "sc":
+			return createMustGather(scRestCfg, scK8sCli, mg.mustGatherFlags(outputDir+"/sc_infra"))
+		case "sc_acm":
+			return createMustGather(scRestCfg, scK8sCli, mg.mustGatherFlags(outputDir+"/sc_acm", "--image="+mg.acmMustGatherImage))
+		case "mc":
+			return createMustGather(mcRestCfg, mcK8sCli, mg.mustGatherFlags(outputDir+"/mc_infra"))
+		case "hcp":
+			destDir := outputDir + "/hcp"
+
+			// 1. Gather logs from DT
+			gatherOptions := &dynatrace.GatherLogsOpts{Since: mg.dynatraceSince(), SortOrder: "asc", DestDir: destDir, Namespaces: mg.namespaces}
+			if err := gatherOptions.GatherLogs(mg.clusterId); err != nil {
+				fmt.Printf("failed to gather HCP dynatrace logs: %v\n", err)
+			}
 
-			switch gatherTarget {
-			case "sc":
-				destDir := outputDir + "/sc_infra"
-				if err := createMustGather(scRestCfg, scK8sCli, []string{"--dest-dir=" + destDir}); err != nil {
-					fmt.Printf("failed to gather %s: %v\n", gatherTarget, err)
-				}
-			case "sc_acm":
-				destDir := outputDir + "/sc_acm"
-				if err := createMustGather(scRestCfg, scK8sCli, []string{"--dest-dir=" + destDir, "--image=" + mg.acmMustGatherImage}); err != nil {
-					fmt.Printf("failed to gather %s: %v\n", gatherTarget, err)
-				}
-			case "mc":
-				destDir := outputDir + "/mc_infra"
-				if err := createMustGather(mcRestCfg, mcK8sCli, []string{"--dest-dir=" + destDir}); err != nil {
-					fmt.Printf("failed to gather %s: %v\n", gatherTarget, err)
-				}
-			case "hcp":
-				destDir := outputDir + "/hcp"
+			// 2. ACM must-gather which includes running the hypershift binary for a dump
+			clusterHyperShift, err := ocmClient.ClustersMgmt().V1().Clusters().Cluster(mg.clusterId).Hypershift().Get().Send()
+			if err != nil {
+				return fmt.Errorf("collected HCP dynatrace logs but failed to get OCM cluster hypershift info for %s: %v", mg.clusterId, err)
+			}
 
-				// 1. Gather logs from DT
-				gatherOptions := &dynatrace.GatherLogsOpts{Since: 72, SortOrder: "asc", DestDir: destDir}
-				if err := gatherOptions.GatherLogs(mg.clusterId); err != nil {
-					fmt.Printf("failed to gather HCP dynatrace logs: %v\n", err)
-				}
+			hcpNamespace, ok := clusterHyperShift.Body().GetHCPNamespace()
+			if !ok {
+				return errors.New("collected HCP dynatrace logs but failed to get HCP namespace")
+			}
 
-				// 2. ACM must-gather which includes running the hypershift binary for a dump
-				clusterHyperShift, err := ocmClient.ClustersMgmt().V1().Clusters().Cluster(mg.clusterId).Hypershift().Get().Send()
-				if err != nil {
-					fmt.Printf("collected HCP dynatrace logs but failed to get OCM cluster hypershift info for %s: %v\n", mg.clusterId, err)
-					return
-				}
+			hcName := cluster.DomainPrefix()
+			hcNamespace := strings.TrimSuffix(hcpNamespace, "-"+hcName)
 
-				hcpNamespace, ok := clusterHyperShift.Body().GetHCPNamespace()
-				if !ok {
-					fmt.Println("collected HCP dynatrace logs but failed to get HCP namespace")
-					return
-				}
+			// TODO(ACM-16170): replace this with an official ACM release image once it's available
+			acmHyperShiftImage := "quay.io/rokejungrh/must-gather:v2.13.0-33-linux"
+			gatherScript := fmt.Sprintf("/usr/bin/gather hosted-cluster-namespace=%s hosted-cluster-name=%s", hcNamespace, hcName)
+			if err := createMustGather(mcRestCfg, mcK8sCli, mg.mustGatherFlags(destDir, "--image="+acmHyperShiftImage, gatherScript)); err != nil {
+				return fmt.Errorf("collected HCP dynatrace logs but failed to gather: %v", err)
+			}
+			return nil
 
-				hcName := cluster.DomainPrefix()
-				hcNamespace := strings.TrimSuffix(hcpNamespace, "-"+hcName)
+		default:
+			return fmt.Errorf("unknown gather type: %s", gatherTarget)
+		}
+	}
 
-				// TODO(ACM-16170): replace this with an official ACM release image once it's available
-				acmHyperShiftImage := "quay.io/rokejungrh/must-gather:v2.13.0-33-linux"
-				gatherScript := fmt.Sprintf("/usr/bin/gather hosted-cluster-namespace=%s hosted-cluster-name=%s", hcNamespace, hcName)
-				if err := createMustGather(mcRestCfg, mcK8sCli, []string{"--dest-dir=" + destDir, "--image=" + acmHyperShiftImage, gatherScript}); err != nil {
-					fmt.Printf("collected HCP dynatrace logs but failed to gather %s: %v\n", gatherTarget, err.Error())
-				}
+	// Start must-gathers in parallel, failed targets are reported but don't prevent creating the archive
+	var g errgroup.Group
+	if mg.parallelism > 0 {
+		g.SetLimit(mg.parallelism)
+	}
+	for _, gatherTarget := range gatherTargets {
+		g.Go(func() error {
+			// Mark this gatherTarget as completed for progress tracking
+			defer completed.Store(gatherTarget, true)
 
-			default:
-				fmt.Printf("unknown gather type: %s\n", gatherTarget)
+			fmt.Printf("Started gathering %s\n", gatherTarget)
+			start := time.Now()
+			if err := gather(gatherTarget); err != nil {
+				fmt.Printf("failed to gather %s after %s: %v\n", gatherTarget, time.Since(start).Round(time.Second), err)
+				return nil
 			}
-		}(gatherTarget)
+			fmt.Printf("Finished gathering %s in %s\n", gatherTarget, time.Since(start).Round(time.Second))
+			return nil
+		})
 	}
 
-	wg.Wait()
+	_ = g.Wait()
 	fmt.Println()
-	fmt.Println("All must-gather tasks completed. Creating tarball.")
+	fmt.Println("All must-gather tasks completed.")
+
+	if len(mg.namespaces) > 0 {
+		removed, err := filterNamespaces(outputDir, mg.namespaces)
+		if err != nil {
+			return fmt.Errorf("failed to filter namespaces: %w", err)
+		}
+		fmt.Printf("Removed %d namespaces not matching %s.\n", removed, strings.Join(mg.namespaces, ","))
+	}
+
+	if !mg.skipRedaction {
+		redacted, err := redactDir(outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to redact collected data: %w", err)
+		}
+		fmt.Printf("Redacted secrets in %d files.\n", redacted)
+	}
+
+	fmt.Println("Creating tarball.")
 
 	// Create a tarball with all collected data
 	if err := createTarball(outputDir, outputTarballTmp); err != nil {
@@ -225,6 +352,23 @@ func (mg *mustGather) Run() error {
 	fmt.Println("Data collection completed successfully in:", outputDir)
 	fmt.Println("Compressed archive has been created at:", outputTarballPath)
 
+	if !mg.upload {
+		return nil
+	}
+
+	// The presigned URL and custom endpoints need the S3 client itself, so only the config is shared with the AWS
+	// context client, e.g. its proxy settings and retry policy
+	awsCfg, err := osdctlaws.NewAwsConfigWithContext(ctx, "", mg.uploadOptions.region, "")
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config for upload: %w", err)
+	}
+	result, err := newS3Uploader(*awsCfg, mg.uploadOptions).Upload(ctx, outputTarballPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Compressed archive has been uploaded to:", result.location)
+	fmt.Printf("Shareable URL (valid for %s): %s\n", mg.uploadOptions.expiry, result.url)
+
 	return nil
 }
 
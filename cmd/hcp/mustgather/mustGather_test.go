@@ -6,7 +6,9 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/rest"
@@ -94,3 +96,84 @@ func TestCreateTarball_FileError(t *testing.T) {
 	err := createTarball("/nonexistent/path", "/tmp/testdata.tar.gz")
 	assert.Error(t, err)
 }
+
+func TestMustGatherComplete(t *testing.T) {
+	tests := []struct {
+		name        string
+		mg          mustGather
+		config      map[string]string
+		expectedErr string
+		expected    uploadOptions
+	}{
+		{
+			name: "valid targets without upload",
+			mg:   mustGather{clusterId: "abc", gatherTargets: "sc,sc_acm,mc,hcp"},
+		},
+		{
+			name:        "unknown target",
+			mg:          mustGather{clusterId: "abc", gatherTargets: "hcp,dp"},
+			expectedErr: `unknown gather target "dp"`,
+		},
+		{
+			name:        "negative parallelism",
+			mg:          mustGather{clusterId: "abc", gatherTargets: "hcp", parallelism: -1},
+			expectedErr: "--parallelism",
+		},
+		{
+			name:        "invalid namespace pattern",
+			mg:          mustGather{clusterId: "abc", gatherTargets: "hcp", namespaces: []string{"ocm-["}},
+			expectedErr: "invalid --namespaces pattern",
+		},
+		{
+			name:        "upload without bucket",
+			mg:          mustGather{clusterId: "abc", gatherTargets: "hcp", upload: true, uploadOptions: uploadOptions{expiry: time.Hour}},
+			expectedErr: "--upload requires --upload-bucket",
+		},
+		{
+			name:        "upload expiry too long",
+			mg:          mustGather{clusterId: "abc", gatherTargets: "hcp", upload: true, uploadOptions: uploadOptions{bucket: "b", expiry: 8 * 24 * time.Hour}},
+			expectedErr: "--upload-expiry",
+		},
+		{
+			name: "upload destination from config",
+			mg:   mustGather{clusterId: "abc", gatherTargets: "hcp", upload: true, uploadOptions: uploadOptions{region: "eu-west-1", expiry: time.Hour}},
+			config: map[string]string{
+				uploadBucketConfigKey:   "must-gathers",
+				uploadEndpointConfigKey: "http://localhost:9000",
+				uploadRegionConfigKey:   "us-east-1",
+			},
+			expected: uploadOptions{bucket: "must-gathers", endpoint: "http://localhost:9000", region: "eu-west-1", prefix: "abc", expiry: time.Hour},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.config {
+				viper.Set(key, value)
+			}
+			defer func() {
+				for key := range tt.config {
+					viper.Set(key, "")
+				}
+			}()
+
+			err := tt.mg.complete()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.mg.uploadOptions)
+		})
+	}
+}
+
+func TestMustGatherFlags(t *testing.T) {
+	mg := &mustGather{}
+	assert.Equal(t, []string{"--dest-dir=/tmp/out"}, mg.mustGatherFlags("/tmp/out"))
+	assert.Equal(t, 72, mg.dynatraceSince())
+
+	mg.since = 90 * time.Minute
+	assert.Equal(t, []string{"--dest-dir=/tmp/out", "--since=1h30m0s", "--image=img"}, mg.mustGatherFlags("/tmp/out", "--image=img"))
+	assert.Equal(t, 2, mg.dynatraceSince())
+}
@@ -0,0 +1,170 @@
+package mustgather
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/openshift/osdctl/cmd/dynatrace"
+)
+
+// redactedValue replaces the secrets found in collected files.
+const redactedValue = "<REDACTED>"
+
+// redactionPatterns are applied to every text file collected by the must-gather. Each pattern has three groups: the
+// prefix to keep, the secret to replace, and the suffix to keep.
+var redactionPatterns = []*regexp.Regexp{
+	// PEM private keys
+	regexp.MustCompile(`(?s)(-----BEGIN [A-Z ]*PRIVATE KEY-----)(.*?)(-----END [A-Z ]*PRIVATE KEY-----)`),
+	// Bearer tokens, e.g. in Authorization headers
+	regexp.MustCompile(`(?i)(bearer[ \t]+)([A-Za-z0-9\-._~+/]+=*)()`),
+	// JSON web tokens, e.g. service account tokens
+	regexp.MustCompile(`()(eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]+)()`),
+	// Values of sensitive keys in YAML, JSON and key=value formats
+	regexp.MustCompile(`(?i)(["']?(?:[a-z_.-]*password|[a-z_.-]*token|[a-z_.-]*secret|api[_-]?key|access[_-]?key(?:[_-]?id)?|\.dockerconfigjson|tls\.key)["']?[ \t]*[:=][ \t]*["']?)([^"'\s,}{]+)(["']?)`),
+}
+
+// secretManifestPattern matches the kind of a Secret manifest in YAML.
+var secretManifestPattern = regexp.MustCompile(`(?m)^\s*kind:\s*Secret\s*$`)
+
+// secretDataKeyPattern matches the start of the data or stringData section of a Secret in YAML.
+var secretDataKeyPattern = regexp.MustCompile(`^(\s*)(data|stringData):\s*$`)
+
+// secretDataValuePattern matches a single key of a Secret's data section.
+var secretDataValuePattern = regexp.MustCompile(`^(\s+[^:\s]+:[ \t]*)(\S.*)$`)
+
+// redactSecretData replaces the values of the data and stringData sections of Secret manifests in YAML. A section
+// ends at the first line which isn't indented deeper than its key.
+func redactSecretData(content []byte) ([]byte, int) {
+	if !secretManifestPattern.Match(content) {
+		return content, 0
+	}
+
+	count := 0
+	lines := bytes.Split(content, []byte("\n"))
+	sectionIndent := -1
+	for i, line := range lines {
+		if sectionIndent >= 0 {
+			indent := len(line) - len(bytes.TrimLeft(line, " "))
+			if len(bytes.TrimSpace(line)) > 0 && indent <= sectionIndent {
+				sectionIndent = -1
+			} else if m := secretDataValuePattern.FindSubmatch(line); m != nil && string(m[2]) != redactedValue {
+				lines[i] = append(append([]byte{}, m[1]...), redactedValue...)
+				count++
+				continue
+			}
+		}
+		if m := secretDataKeyPattern.FindSubmatch(line); m != nil {
+			sectionIndent = len(m[1])
+		}
+	}
+
+	return bytes.Join(lines, []byte("\n")), count
+}
+
+// redact returns content with the secrets replaced, and the number of replacements made.
+func redact(content []byte) ([]byte, int) {
+	content, count := redactSecretData(content)
+
+	for _, pattern := range redactionPatterns {
+		content = pattern.ReplaceAllFunc(content, func(match []byte) []byte {
+			submatches := pattern.FindSubmatch(match)
+			if bytes.Equal(submatches[2], []byte(redactedValue)) {
+				return match
+			}
+			count++
+			return append(append(append([]byte{}, submatches[1]...), redactedValue...), submatches[3]...)
+		})
+	}
+
+	return content, count
+}
+
+// redactDir redacts the secrets of all text files below dir in place. It returns the number of files changed.
+func redactDir(dir string) (int, error) {
+	redactedFiles := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s for redaction: %w", path, err)
+		}
+		// Binary files, e.g. compressed logs, can't be redacted line by line
+		if !utf8.Valid(content) {
+			return nil
+		}
+
+		redacted, count := redact(content)
+		if count == 0 {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, redacted, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write redacted %s: %w", path, err)
+		}
+		redactedFiles++
+		return nil
+	})
+	return redactedFiles, err
+}
+
+// filterNamespaces removes the namespaced resources of every namespace not matching one of the patterns. oc adm
+// must-gather stores them below a "namespaces" directory, one subdirectory per namespace. Cluster-scoped resources are
+// kept. It returns the number of namespaces removed.
+func filterNamespaces(dir string, patterns []string) (int, error) {
+	var namespaceDirs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == dir {
+			return nil
+		}
+		// The Namespace objects themselves are stored as cluster-scoped resources
+		if d.Name() == "cluster-scoped-resources" {
+			return filepath.SkipDir
+		}
+		if filepath.Base(filepath.Dir(path)) != "namespaces" {
+			return nil
+		}
+		namespaceDirs = append(namespaceDirs, path)
+		// Namespace directories never nest other namespaces
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var names []string
+	for _, path := range namespaceDirs {
+		names = append(names, filepath.Base(path))
+	}
+	keep := dynatrace.FilterNamespaces(names, patterns)
+
+	removed := 0
+	for _, path := range namespaceDirs {
+		if slices.Contains(keep, filepath.Base(path)) {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return 0, fmt.Errorf("failed to remove filtered namespace %s: %w", path, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
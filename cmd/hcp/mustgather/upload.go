@@ -0,0 +1,109 @@
+package mustgather
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// Config keys for the upload destination, used when the matching flag isn't set
+	uploadBucketConfigKey   = "must_gather_upload_bucket"
+	uploadEndpointConfigKey = "must_gather_upload_endpoint"
+	uploadRegionConfigKey   = "must_gather_upload_region"
+
+	defaultUploadExpiry = 7 * 24 * time.Hour
+	// maxUploadExpiry is the longest validity of a SigV4 presigned URL
+	maxUploadExpiry = 7 * 24 * time.Hour
+)
+
+// uploadOptions configure where the must-gather archive is uploaded to.
+type uploadOptions struct {
+	bucket string
+	// endpoint is the URL of an S3-compatible service, empty for AWS S3
+	endpoint string
+	region   string
+	// prefix is prepended to the archive name to form the object key
+	prefix string
+	// expiry is how long the shareable URL stays valid
+	expiry time.Duration
+}
+
+// uploadResult references an uploaded archive.
+type uploadResult struct {
+	// location is the s3:// URI of the object
+	location string
+	// url is a presigned URL to download the object without credentials
+	url string
+}
+
+// s3Uploader uploads archives to AWS S3 or an S3-compatible service.
+type s3Uploader struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	opts    uploadOptions
+}
+
+// newS3Uploader creates an uploader from an AWS config. Custom endpoints are addressed path-style and only send
+// checksums when required, as S3-compatible services commonly lack support for virtual hosts and newer checksums.
+func newS3Uploader(cfg aws.Config, opts uploadOptions) *s3Uploader {
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if opts.region != "" {
+			o.Region = opts.region
+		}
+		// S3-compatible services like MinIO accept any region, but requests still need to be signed with one
+		if o.Region == "" {
+			o.Region = "us-east-1"
+		}
+		if opts.endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.endpoint)
+			o.UsePathStyle = true
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	})
+
+	return &s3Uploader{
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		opts:    opts,
+	}
+}
+
+// Upload stores the file at filePath and returns a reference which can be shared to download it.
+func (u *s3Uploader) Upload(ctx context.Context, filePath string) (*uploadResult, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s for upload: %w", filePath, err)
+	}
+	defer file.Close()
+
+	key := path.Join(u.opts.prefix, filepath.Base(filePath))
+	if _, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(u.opts.bucket),
+		Key:         aws.String(key),
+		Body:        file,
+		ContentType: aws.String("application/gzip"),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to upload %s to bucket %s: %w", filePath, u.opts.bucket, err)
+	}
+
+	presigned, err := u.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(u.opts.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(u.opts.expiry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a shareable URL for %s: %w", key, err)
+	}
+
+	return &uploadResult{
+		location: fmt.Sprintf("s3://%s/%s", u.opts.bucket, key),
+		url:      presigned.URL,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package mustgather
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeObjectStore is a minimal stand-in for an S3-compatible service like MinIO, storing objects put with
+// path-style addressing in memory.
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s.mu.Lock()
+	s.objects[r.URL.Path] = body
+	s.mu.Unlock()
+	w.Header().Set("ETag", `"etag"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestS3Uploader_Upload(t *testing.T) {
+	store := &fakeObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	archive := filepath.Join(t.TempDir(), "cluster_dump_abc_20260319184212.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("archive content"), 0600))
+
+	cfg := aws.Config{Credentials: credentials.NewStaticCredentialsProvider("minioadmin", "minioadmin", "")}
+	uploader := newS3Uploader(cfg, uploadOptions{
+		bucket:   "must-gathers",
+		endpoint: server.URL,
+		prefix:   "abc",
+		expiry:   time.Hour,
+	})
+
+	result, err := uploader.Upload(context.Background(), archive)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://must-gathers/abc/cluster_dump_abc_20260319184212.tar.gz", result.location)
+	assert.Equal(t, []byte("archive content"), store.objects["/must-gathers/abc/cluster_dump_abc_20260319184212.tar.gz"])
+
+	presigned, err := url.Parse(result.url)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.url, server.URL+"/must-gathers/abc/cluster_dump_abc_20260319184212.tar.gz?"))
+	assert.Equal(t, "3600", presigned.Query().Get("X-Amz-Expires"))
+	assert.Contains(t, presigned.Query().Get("X-Amz-Credential"), "us-east-1")
+}
+
+func TestS3Uploader_UploadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	archive := filepath.Join(t.TempDir(), "archive.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("archive content"), 0600))
+
+	cfg := aws.Config{
+		Credentials:      credentials.NewStaticCredentialsProvider("minioadmin", "minioadmin", ""),
+		RetryMaxAttempts: 1,
+	}
+	uploader := newS3Uploader(cfg, uploadOptions{bucket: "must-gathers", endpoint: server.URL, expiry: time.Hour})
+
+	_, err := uploader.Upload(context.Background(), archive)
+	assert.ErrorContains(t, err, "failed to upload")
+}
@@ -0,0 +1,373 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/osdctl/pkg/k8s"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultFleetConcurrency   = 10
+	defaultCertExpiryWarning  = 14 * 24 * time.Hour
+	defaultNodePoolStuckAfter = time.Hour
+
+	labelClusterID = "api.openshift.com/id"
+)
+
+// hostedClusterHealthyWhenTrue are HostedCluster conditions which are failing unless True
+var hostedClusterHealthyWhenTrue = map[string]bool{
+	"Available":                            true,
+	"ValidConfiguration":                   true,
+	"ValidReleaseImage":                    true,
+	"ValidHostedControlPlaneConfiguration": true,
+	"ReconciliationSucceeded":              true,
+	"ClusterVersionAvailable":              true,
+	"ClusterVersionSucceeding":             true,
+	"EtcdAvailable":                        true,
+	"KubeAPIServerAvailable":               true,
+	"InfrastructureReady":                  true,
+	"IgnitionEndpointAvailable":            true,
+}
+
+// hostedClusterHealthyWhenFalse are HostedCluster conditions which are failing when True
+var hostedClusterHealthyWhenFalse = map[string]bool{
+	"Degraded":              true,
+	"ClusterVersionFailing": true,
+}
+
+// FleetClusterSummary holds the problems found in the status of a single hosted cluster.
+type FleetClusterSummary struct {
+	ClusterID             string   `json:"clusterID"`
+	ClusterName           string   `json:"clusterName"`
+	ClusterState          string   `json:"clusterState,omitempty"`
+	Version               string   `json:"version,omitempty"`
+	FailingConditions     []string `json:"failingConditions,omitempty"`
+	UnsyncedManifestWorks []string `json:"unsyncedManifestWorks,omitempty"`
+	CertificateIssues     []string `json:"certificateIssues,omitempty"`
+	StuckNodePools        []string `json:"stuckNodePools,omitempty"`
+	Error                 string   `json:"error,omitempty"`
+}
+
+// healthy returns whether no problems were found for the cluster.
+func (s FleetClusterSummary) healthy() bool {
+	return len(s.FailingConditions) == 0 && len(s.UnsyncedManifestWorks) == 0 && len(s.CertificateIssues) == 0 &&
+		len(s.StuckNodePools) == 0 && s.Error == ""
+}
+
+// FleetReport is the roll-up of the status of all hosted clusters checked.
+type FleetReport struct {
+	Scope                 string                `json:"scope"`
+	Checked               int                   `json:"checked"`
+	Unhealthy             int                   `json:"unhealthy"`
+	FailingConditions     int                   `json:"failingConditions"`
+	UnsyncedManifestWorks int                   `json:"unsyncedManifestWorks"`
+	CertificateIssues     int                   `json:"certificateIssues"`
+	StuckNodePools        int                   `json:"stuckNodePools"`
+	Errors                int                   `json:"errors"`
+	Clusters              []FleetClusterSummary `json:"clusters"`
+}
+
+// fleetThresholds configures when certificates and NodePools are reported.
+type fleetThresholds struct {
+	certExpiryWarning  time.Duration
+	nodePoolStuckAfter time.Duration
+}
+
+// fleetTarget is a hosted cluster to check, the cluster is looked up by id when it isn't known yet.
+type fleetTarget struct {
+	id      string
+	cluster *cmv1.Cluster
+}
+
+// summarize returns the problems found in the status of a hosted cluster.
+func summarize(s *HCPStatus, thresholds fleetThresholds, now time.Time) FleetClusterSummary {
+	summary := FleetClusterSummary{
+		ClusterID:    s.ClusterID,
+		ClusterName:  s.ClusterName,
+		ClusterState: s.ClusterState,
+		Version:      s.Version.Current,
+	}
+
+	for _, c := range s.HostedClusterConditions {
+		if (hostedClusterHealthyWhenTrue[c.Type] && c.Status != "True") ||
+			(hostedClusterHealthyWhenFalse[c.Type] && c.Status == "True") {
+			summary.FailingConditions = append(summary.FailingConditions, c.Type+"="+c.Status)
+		}
+	}
+
+	for _, mw := range s.ManifestWorks {
+		if !mw.Applied || !mw.Available {
+			summary.UnsyncedManifestWorks = append(summary.UnsyncedManifestWorks, mw.Name)
+		}
+	}
+
+	for name, cert := range map[string]*CertificateStatus{"api server": s.APIServerCertificate, "ingress": s.IngressCertificate} {
+		if issue := certificateIssue(name, cert, thresholds.certExpiryWarning, now); issue != "" {
+			summary.CertificateIssues = append(summary.CertificateIssues, issue)
+		}
+	}
+	sort.Strings(summary.CertificateIssues)
+
+	for _, np := range s.NodePools {
+		if stuck, reason := nodePoolStuck(np, thresholds.nodePoolStuckAfter, now); stuck {
+			summary.StuckNodePools = append(summary.StuckNodePools, np.Name+" ("+reason+")")
+		}
+	}
+
+	return summary
+}
+
+// certificateIssue returns why a certificate needs attention, or an empty string if it doesn't.
+func certificateIssue(name string, cert *CertificateStatus, expiryWarning time.Duration, now time.Time) string {
+	switch {
+	case cert == nil:
+		return ""
+	case cert.Ready != nil && !*cert.Ready:
+		return name + " certificate not ready"
+	case !cert.NotAfter.IsZero() && cert.NotAfter.Before(now):
+		return name + " certificate expired " + cert.NotAfter.Format("2006-01-02")
+	case !cert.NotAfter.IsZero() && cert.NotAfter.Sub(now) < expiryWarning:
+		return name + " certificate expires " + cert.NotAfter.Format("2006-01-02")
+	}
+	return ""
+}
+
+// nodePoolStuck returns whether a NodePool has not been ready, or has been updating, for longer than stuckAfter.
+// Conditions without a transition time are reported right away, as it's unknown how long they have persisted.
+func nodePoolStuck(np NodePoolStatus, stuckAfter time.Duration, now time.Time) (bool, string) {
+	for _, c := range np.Conditions {
+		var reason string
+		switch {
+		case c.Type == "Ready" && c.Status != "True":
+			reason = "not ready"
+		case (c.Type == "UpdatingVersion" || c.Type == "UpdatingConfig") && c.Status == "True":
+			reason = strings.ToLower(strings.TrimPrefix(c.Type, "Updating")) + " updating"
+		default:
+			continue
+		}
+
+		if t, err := time.Parse(time.RFC3339, c.LastTransitionTime); err == nil {
+			if now.Sub(t) < stuckAfter {
+				continue
+			}
+			reason += " since " + t.Format(time.RFC3339)
+		}
+		return true, reason
+	}
+	return false, ""
+}
+
+// checkFleet gets the status of all targets with bounded concurrency. Failures of single clusters are recorded in
+// their summary instead of aborting the roll-up.
+func checkFleet(targets []fleetTarget, concurrency int, thresholds fleetThresholds, now time.Time, fetch func(fleetTarget) (*HCPStatus, error)) []FleetClusterSummary {
+	var (
+		summaries []FleetClusterSummary
+		mutex     sync.Mutex
+	)
+
+	eg := errgroup.Group{}
+	eg.SetLimit(concurrency)
+	for _, target := range targets {
+		target := target
+		eg.Go(func() error {
+			var summary FleetClusterSummary
+			status, err := fetch(target)
+			if err != nil {
+				summary = FleetClusterSummary{ClusterID: target.id, Error: err.Error()}
+				if target.cluster != nil {
+					summary.ClusterID = target.cluster.ExternalID()
+					summary.ClusterName = target.cluster.Name()
+				}
+			} else {
+				summary = summarize(status, thresholds, now)
+			}
+
+			mutex.Lock()
+			summaries = append(summaries, summary)
+			mutex.Unlock()
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ClusterName < summaries[j].ClusterName
+	})
+	return summaries
+}
+
+// newFleetReport counts the problems across all summaries.
+func newFleetReport(scope string, summaries []FleetClusterSummary) FleetReport {
+	report := FleetReport{Scope: scope, Checked: len(summaries), Clusters: []FleetClusterSummary{}}
+	for _, s := range summaries {
+		if s.healthy() {
+			continue
+		}
+		report.Unhealthy++
+		if len(s.FailingConditions) > 0 {
+			report.FailingConditions++
+		}
+		if len(s.UnsyncedManifestWorks) > 0 {
+			report.UnsyncedManifestWorks++
+		}
+		if len(s.CertificateIssues) > 0 {
+			report.CertificateIssues++
+		}
+		if len(s.StuckNodePools) > 0 {
+			report.StuckNodePools++
+		}
+		if s.Error != "" {
+			report.Errors++
+		}
+		report.Clusters = append(report.Clusters, s)
+	}
+	return report
+}
+
+// printFleetReport renders the roll-up with a row per unhealthy cluster.
+func printFleetReport(w io.Writer, report FleetReport) error {
+	fmt.Fprintf(w, "HCP Fleet Status: %s\n", report.Scope)
+	fmt.Fprintf(w, "Checked: %d  Unhealthy: %d\n", report.Checked, report.Unhealthy)
+	fmt.Fprintf(w, "  Failing HostedCluster conditions: %d\n", report.FailingConditions)
+	fmt.Fprintf(w, "  Unsynced ManifestWorks:           %d\n", report.UnsyncedManifestWorks)
+	fmt.Fprintf(w, "  Certificate issues:               %d\n", report.CertificateIssues)
+	fmt.Fprintf(w, "  Stuck NodePools:                  %d\n", report.StuckNodePools)
+	fmt.Fprintf(w, "  Status unavailable:               %d\n", report.Errors)
+	fmt.Fprintln(w)
+
+	if len(report.Clusters) == 0 {
+		fmt.Fprintln(w, "No problems found")
+		return nil
+	}
+
+	p := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	p.AddRow([]string{"NAME", "ID", "STATE", "VERSION", "FAILING CONDITIONS", "UNSYNCED MANIFESTWORKS", "CERTIFICATE", "STUCK NODEPOOLS"})
+	for _, s := range report.Clusters {
+		if s.Error != "" {
+			p.AddRow([]string{s.ClusterName, s.ClusterID, s.ClusterState, s.Version, "error: " + s.Error, "", "", ""})
+			continue
+		}
+		p.AddRow([]string{
+			s.ClusterName,
+			s.ClusterID,
+			s.ClusterState,
+			s.Version,
+			strings.Join(s.FailingConditions, ", "),
+			strconv.Itoa(len(s.UnsyncedManifestWorks)),
+			strings.Join(s.CertificateIssues, ", "),
+			strings.Join(s.StuckNodePools, ", "),
+		})
+	}
+	return p.Flush()
+}
+
+// listManagementClusterTargets returns the hosted clusters placed on a management cluster, read from its
+// HostedClusters through backplane.
+func listManagementClusterTargets(ctx context.Context, conn *sdk.Connection, mcKey string) (string, []fleetTarget, error) {
+	mc, err := utils.GetCluster(conn, mcKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find management cluster: %w", err)
+	}
+
+	isMC, err := utils.IsManagementCluster(mc.ID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to verify management cluster: %w", err)
+	}
+	if !isMC {
+		return "", nil, fmt.Errorf("cluster %s is not a management cluster", mc.Name())
+	}
+
+	scheme := runtime.NewScheme()
+	if err := hypershiftv1beta1.AddToScheme(scheme); err != nil {
+		return "", nil, fmt.Errorf("failed to add hypershift scheme: %w", err)
+	}
+	mcClient, err := k8s.NewWithConn(mc.ID(), client.Options{Scheme: scheme}, conn)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create management cluster client: %w", err)
+	}
+
+	hostedClusters := &hypershiftv1beta1.HostedClusterList{}
+	if err := mcClient.List(ctx, hostedClusters); err != nil {
+		return "", nil, fmt.Errorf("failed to list hosted clusters on %s: %w", mc.Name(), err)
+	}
+
+	return "management cluster " + mc.Name(), hostedClusterTargets(hostedClusters.Items), nil
+}
+
+// hostedClusterTargets returns a target per HostedCluster labelled with its OCM cluster id.
+func hostedClusterTargets(hostedClusters []hypershiftv1beta1.HostedCluster) []fleetTarget {
+	var targets []fleetTarget
+	for _, hc := range hostedClusters {
+		if id := hc.Labels[labelClusterID]; id != "" {
+			targets = append(targets, fleetTarget{id: id})
+		}
+	}
+	return targets
+}
+
+// searchTargets returns the HCP clusters matching an OCM search query.
+func searchTargets(conn *sdk.Connection, search string) (string, []fleetTarget, error) {
+	clusters, err := utils.ApplyFilters(conn, []string{search, "hypershift.enabled = 'true'"})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to search clusters: %w", err)
+	}
+
+	targets := make([]fleetTarget, 0, len(clusters))
+	for _, cluster := range clusters {
+		targets = append(targets, fleetTarget{id: cluster.ID(), cluster: cluster})
+	}
+	return "clusters matching " + search, targets, nil
+}
+
+// runFleet checks all hosted clusters of a management cluster or OCM search and prints the roll-up.
+func (o *statusOptions) runFleet(ctx context.Context, conn *sdk.Connection, w io.Writer) error {
+	var (
+		scope   string
+		targets []fleetTarget
+		err     error
+	)
+	if o.mc != "" {
+		scope, targets, err = listManagementClusterTargets(ctx, conn, o.mc)
+	} else {
+		scope, targets, err = searchTargets(conn, o.search)
+	}
+	if err != nil {
+		return err
+	}
+
+	thresholds := fleetThresholds{certExpiryWarning: o.certExpiryWarning, nodePoolStuckAfter: o.nodePoolStuckAfter}
+	summaries := checkFleet(targets, o.concurrency, thresholds, time.Now(), func(target fleetTarget) (*HCPStatus, error) {
+		cluster := target.cluster
+		if cluster == nil {
+			resp, err := conn.ClustersMgmt().V1().Clusters().Cluster(target.id).Get().Send()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster: %w", err)
+			}
+			cluster = resp.Body()
+		}
+		return getStatus(conn, cluster)
+	})
+
+	report := newFleetReport(scope, summaries)
+	switch o.output {
+	case "json", "yaml":
+		return printStructured(w, report, o.output)
+	default:
+		return printFleetReport(w, report)
+	}
+}
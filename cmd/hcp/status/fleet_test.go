@@ -0,0 +1,206 @@
+package status
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testThresholds = fleetThresholds{certExpiryWarning: 14 * 24 * time.Hour, nodePoolStuckAfter: time.Hour}
+
+func TestSummarizeHealthy(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	ready := true
+	s := &HCPStatus{
+		ClusterID:   "ext-id",
+		ClusterName: "healthy",
+		HostedClusterConditions: []Condition{
+			{Type: "Available", Status: "True"},
+			{Type: "Degraded", Status: "False"},
+			{Type: "Progressing", Status: "True"},
+		},
+		ManifestWorks:      []ManifestWorkSync{{Name: "mw", Applied: true, Available: true}},
+		IngressCertificate: &CertificateStatus{Ready: &ready, NotAfter: now.Add(60 * 24 * time.Hour)},
+		NodePools:          []NodePoolStatus{{Name: "workers", Conditions: []Condition{{Type: "Ready", Status: "True"}}}},
+	}
+
+	summary := summarize(s, testThresholds, now)
+	if !summary.healthy() {
+		t.Errorf("expected a healthy summary, got %+v", summary)
+	}
+}
+
+func TestSummarizeProblems(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	ready := true
+	s := &HCPStatus{
+		ClusterName: "broken",
+		HostedClusterConditions: []Condition{
+			{Type: "Available", Status: "False"},
+			{Type: "Degraded", Status: "True"},
+			{Type: "SomethingNew", Status: "False"},
+		},
+		ManifestWorks: []ManifestWorkSync{
+			{Name: "mw-synced", Applied: true, Available: true},
+			{Name: "mw-unavailable", Applied: true},
+		},
+		IngressCertificate: &CertificateStatus{Ready: &ready, NotAfter: now.Add(3 * 24 * time.Hour)},
+		NodePools: []NodePoolStatus{
+			{Name: "stuck", Conditions: []Condition{
+				{Type: "Ready", Status: "True"},
+				{Type: "UpdatingVersion", Status: "True", LastTransitionTime: now.Add(-3 * time.Hour).Format(time.RFC3339)},
+			}},
+			{Name: "rolling", Conditions: []Condition{
+				{Type: "Ready", Status: "False", LastTransitionTime: now.Add(-10 * time.Minute).Format(time.RFC3339)},
+			}},
+			{Name: "unknown-since", Conditions: []Condition{{Type: "Ready", Status: "False"}}},
+		},
+	}
+
+	summary := summarize(s, testThresholds, now)
+
+	if want := []string{"Available=False", "Degraded=True"}; !reflect.DeepEqual(summary.FailingConditions, want) {
+		t.Errorf("expected failing conditions %v, got %v", want, summary.FailingConditions)
+	}
+	if want := []string{"mw-unavailable"}; !reflect.DeepEqual(summary.UnsyncedManifestWorks, want) {
+		t.Errorf("expected unsynced ManifestWorks %v, got %v", want, summary.UnsyncedManifestWorks)
+	}
+	if want := []string{"ingress certificate expires 2026-01-13"}; !reflect.DeepEqual(summary.CertificateIssues, want) {
+		t.Errorf("expected certificate issues %v, got %v", want, summary.CertificateIssues)
+	}
+	want := []string{"stuck (version updating since 2026-01-09T21:00:00Z)", "unknown-since (not ready)"}
+	if !reflect.DeepEqual(summary.StuckNodePools, want) {
+		t.Errorf("expected stuck NodePools %v, got %v", want, summary.StuckNodePools)
+	}
+}
+
+func TestSummarizeCertificate(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	notReady := false
+	ready := true
+
+	tests := []struct {
+		name      string
+		apiServer *CertificateStatus
+		ingress   *CertificateStatus
+		want      []string
+	}{
+		{name: "ingress not ready", ingress: &CertificateStatus{Ready: &notReady}, want: []string{"ingress certificate not ready"}},
+		{name: "ingress expired", ingress: &CertificateStatus{Ready: &ready, NotAfter: now.Add(-time.Hour)}, want: []string{"ingress certificate expired 2026-01-09"}},
+		{name: "api server expiring", apiServer: &CertificateStatus{NotAfter: now.Add(24 * time.Hour)}, ingress: &CertificateStatus{Ready: &ready}, want: []string{"api server certificate expires 2026-01-11"}},
+		{
+			name:      "both not ready",
+			apiServer: &CertificateStatus{Ready: &notReady},
+			ingress:   &CertificateStatus{Ready: &notReady},
+			want:      []string{"api server certificate not ready", "ingress certificate not ready"},
+		},
+		{name: "unknown expiry", apiServer: &CertificateStatus{}, ingress: &CertificateStatus{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := summarize(&HCPStatus{APIServerCertificate: tt.apiServer, IngressCertificate: tt.ingress}, testThresholds, now)
+			if !reflect.DeepEqual(summary.CertificateIssues, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, summary.CertificateIssues)
+			}
+		})
+	}
+}
+
+func TestCheckFleet(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	targets := []fleetTarget{{id: "c"}, {id: "a"}, {id: "b"}, {id: "broken"}}
+
+	var running, maxRunning int32
+	fetch := func(target fleetTarget) (*HCPStatus, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+
+		if target.id == "broken" {
+			return nil, errors.New("no live resources")
+		}
+		return &HCPStatus{ClusterID: target.id, ClusterName: target.id}, nil
+	}
+
+	summaries := checkFleet(targets, 2, testThresholds, now, fetch)
+
+	if maxRunning > 2 {
+		t.Errorf("expected at most 2 concurrent fetches, got %d", maxRunning)
+	}
+	var ids []string
+	for _, s := range summaries {
+		ids = append(ids, s.ClusterID)
+	}
+	// The failed cluster has no name, so it's sorted first
+	if want := []string{"broken", "a", "b", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected summaries for %v, got %v", want, ids)
+	}
+	if summaries[0].Error != "no live resources" {
+		t.Errorf("expected the fetch error to be recorded, got %+v", summaries[0])
+	}
+}
+
+func TestNewFleetReport(t *testing.T) {
+	report := newFleetReport("management cluster hs-mc-1", []FleetClusterSummary{
+		{ClusterName: "ok"},
+		{ClusterName: "degraded", FailingConditions: []string{"Degraded=True"}, StuckNodePools: []string{"workers (not ready)"}},
+		{ClusterName: "unsynced", UnsyncedManifestWorks: []string{"mw"}},
+		{ClusterName: "cert", CertificateIssues: []string{"ingress certificate not ready"}},
+		{ClusterID: "id", Error: "failed"},
+	})
+
+	if report.Checked != 5 || report.Unhealthy != 4 {
+		t.Errorf("expected 5 checked and 4 unhealthy, got %d and %d", report.Checked, report.Unhealthy)
+	}
+	if report.FailingConditions != 1 || report.UnsyncedManifestWorks != 1 || report.CertificateIssues != 1 || report.StuckNodePools != 1 || report.Errors != 1 {
+		t.Errorf("unexpected counts %+v", report)
+	}
+	if len(report.Clusters) != 4 {
+		t.Errorf("expected only unhealthy clusters in the report, got %d", len(report.Clusters))
+	}
+
+	out := &bytes.Buffer{}
+	if err := printFleetReport(out, report); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"HCP Fleet Status: management cluster hs-mc-1", "Checked: 5  Unhealthy: 4", "Degraded=True", "error: failed"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestPrintFleetReportNoProblems(t *testing.T) {
+	out := &bytes.Buffer{}
+	if err := printFleetReport(out, newFleetReport("clusters matching x", []FleetClusterSummary{{ClusterName: "ok"}})); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "No problems found") {
+		t.Errorf("expected no problems, got:\n%s", out.String())
+	}
+}
+
+func TestHostedClusterTargets(t *testing.T) {
+	targets := hostedClusterTargets([]hypershiftv1beta1.HostedCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{labelClusterID: "id-a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}},
+	})
+
+	if want := []fleetTarget{{id: "id-a"}}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("expected %+v, got %+v", want, targets)
+	}
+}
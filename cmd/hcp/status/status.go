@@ -1,14 +1,33 @@
 package status
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
+const defaultWatchInterval = 30 * time.Second
+
 type statusOptions struct {
 	clusterID string
+	output    string
+	watch     bool
+	interval  time.Duration
+
+	mc                 string
+	search             string
+	concurrency        int
+	certExpiryWarning  time.Duration
+	nodePoolStuckAfter time.Duration
 }
 
 // NewCmdStatus creates and returns the status command.
@@ -20,32 +39,95 @@ func NewCmdStatus() *cobra.Command {
 		Short: "Show HCP cluster health status from OCM live resources",
 		Long: `Display a comprehensive health overview of a ROSA HCP cluster using
 data from the OCM live resources endpoint. Shows ManifestWork sync status,
-HostedCluster conditions, certificate status, and NodePool health.`,
+HostedCluster conditions, certificate status, and NodePool health.
+
+With --watch, the live resources are polled every --interval and only the
+conditions and versions which changed are printed with the time they were
+observed, e.g. to follow an upgrade or NodePool rollout. The first poll prints
+the current state. With -o json, each change is printed as a JSON object per line.
+
+With --mc or --search, the status of every hosted cluster on a management
+cluster, or matching an OCM search query, is checked concurrently and summarized:
+failing HostedCluster conditions, unsynced ManifestWorks, API server and ingress
+certificates which are not ready or expire within --cert-expiry-warning, and
+NodePools which have not been ready or have been updating for longer than
+--nodepool-stuck-after.
+--mc lists the HostedClusters on the management cluster through backplane, use
+--search when its API is unavailable.`,
 		Example: `  # Show status by cluster name
   osdctl hcp status --cluster-id my-cluster
 
   # Show status by cluster ID
-  osdctl hcp status --cluster-id 2o9r9r1q4tp0bulsfksdc8fesls54sql`,
+  osdctl hcp status --cluster-id 2o9r9r1q4tp0bulsfksdc8fesls54sql
+
+  # Show status as JSON
+  osdctl hcp status --cluster-id my-cluster -o json
+
+  # Follow an upgrade, printing condition transitions as they happen
+  osdctl hcp status --cluster-id my-cluster --watch --interval 1m
+
+  # Summarize the status of all hosted clusters on a management cluster
+  osdctl hcp status --mc hs-mc-example
+
+  # Summarize the status of the HCP clusters in a region
+  osdctl hcp status --search "region.id = 'us-east-1'" -o json`,
 		Args:              cobra.NoArgs,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return opts.run()
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			return opts.run(cmd.Context())
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.clusterID, "cluster-id", "C", "", "Cluster name, ID, or external ID")
-	_ = cmd.MarkFlagRequired("cluster-id")
+	cmd.Flags().StringVar(&opts.mc, "mc", "", "Summarize the status of all hosted clusters on this management cluster (name or ID)")
+	cmd.Flags().StringVar(&opts.search, "search", "", "Summarize the status of all HCP clusters matching this OCM search query")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", defaultFleetConcurrency, "Number of clusters checked at once with --mc or --search")
+	cmd.Flags().DurationVar(&opts.certExpiryWarning, "cert-expiry-warning", defaultCertExpiryWarning, "Report API server and ingress certificates expiring within this duration with --mc or --search")
+	cmd.Flags().DurationVar(&opts.nodePoolStuckAfter, "nodepool-stuck-after", defaultNodePoolStuckAfter, "Report NodePools not ready or updating for longer than this with --mc or --search")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "text", "Output format: text, json, yaml. With --watch: text, json")
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false, "Poll the live resources and print condition transitions until interrupted")
+	cmd.Flags().DurationVar(&opts.interval, "interval", defaultWatchInterval, "Polling interval used with --watch")
+	cmd.MarkFlagsOneRequired("cluster-id", "mc", "search")
+	cmd.MarkFlagsMutuallyExclusive("cluster-id", "mc", "search")
+	cmd.MarkFlagsMutuallyExclusive("watch", "mc")
+	cmd.MarkFlagsMutuallyExclusive("watch", "search")
 
 	return cmd
 }
 
-func (o *statusOptions) run() error {
+func (o *statusOptions) validate() error {
+	switch o.output {
+	case "text", "json":
+	case "yaml":
+		if o.watch {
+			return fmt.Errorf("--watch supports the text and json output formats")
+		}
+	default:
+		return fmt.Errorf("invalid output format '%s'. Valid options: text, json, yaml", o.output)
+	}
+	if o.watch && o.interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+	if (o.mc != "" || o.search != "") && o.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	return nil
+}
+
+func (o *statusOptions) run(ctx context.Context) error {
 	conn, err := utils.CreateConnection()
 	if err != nil {
 		return fmt.Errorf("failed to create OCM connection: %w", err)
 	}
 	defer conn.Close()
 
+	if o.mc != "" || o.search != "" {
+		return o.runFleet(ctx, conn, os.Stdout)
+	}
+
 	cluster, err := utils.GetCluster(conn, o.clusterID)
 	if err != nil {
 		return fmt.Errorf("failed to find cluster: %w", err)
@@ -55,26 +137,70 @@ func (o *statusOptions) run() error {
 		return fmt.Errorf("cluster %q is not an HCP cluster", o.clusterID)
 	}
 
+	if o.watch {
+		if o.output == "text" {
+			fmt.Printf("Watching HCP cluster %s (%s) every %s, press Ctrl+C to stop\n", cluster.Name(), cluster.ExternalID(), o.interval)
+		}
+		return watch(ctx, os.Stdout, os.Stderr, o.interval, o.output, func() (*HCPStatus, error) {
+			// The cluster state changes during upgrades and rollouts, so it's refreshed on each poll
+			current, err := utils.GetClusterAnyStatus(conn, cluster.ID())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cluster: %w", err)
+			}
+			return getStatus(conn, current)
+		})
+	}
+
+	status, err := getStatus(conn, cluster)
+	if err != nil {
+		return err
+	}
+
+	switch o.output {
+	case "json", "yaml":
+		return printStructured(os.Stdout, status, o.output)
+	default:
+		printStatus(status)
+		return nil
+	}
+}
+
+// printStructured writes a value as indented JSON or as YAML.
+func printStructured(w io.Writer, v interface{}, output string) error {
+	if output == "yaml" {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal to YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// getStatus fetches and parses the live resources of an HCP cluster.
+func getStatus(conn *sdk.Connection, cluster *cmv1.Cluster) (*HCPStatus, error) {
 	liveResponse, err := conn.ClustersMgmt().V1().Clusters().Cluster(cluster.ID()).Resources().Live().Get().Send()
 	if err != nil {
-		return fmt.Errorf("failed to get live resources: %w", err)
+		return nil, fmt.Errorf("failed to get live resources: %w", err)
 	}
 
 	resources := liveResponse.Body().Resources()
 	if len(resources) == 0 {
-		return fmt.Errorf("no live resources found for cluster %s", cluster.ID())
+		return nil, fmt.Errorf("no live resources found for cluster %s", cluster.ID())
 	}
 
 	status, err := parseLiveResources(resources, cluster.ID())
 	if err != nil {
-		return fmt.Errorf("failed to parse live resources: %w", err)
+		return nil, fmt.Errorf("failed to parse live resources: %w", err)
 	}
 
 	status.ClusterID = cluster.ExternalID()
 	status.ClusterName = cluster.Name()
 	status.ClusterState = string(cluster.State())
 
-	printStatus(status)
-
-	return nil
+	return status, nil
 }
@@ -4,58 +4,58 @@ import "time"
 
 // HCPStatus holds the parsed status of an HCP cluster from the live endpoint.
 type HCPStatus struct {
-	ClusterID               string
-	ClusterName             string
-	ClusterState            string
-	ManagementCluster       string
-	Version                 VersionInfo
-	APIServerCertificate    *CertificateStatus
-	IngressCertificate      *CertificateStatus
-	ManifestWorks           []ManifestWorkSync
-	HostedClusterConditions []Condition
-	NodePools               []NodePoolStatus
+	ClusterID               string             `json:"clusterID"`
+	ClusterName             string             `json:"clusterName"`
+	ClusterState            string             `json:"clusterState,omitempty"`
+	ManagementCluster       string             `json:"managementCluster,omitempty"`
+	Version                 VersionInfo        `json:"version"`
+	APIServerCertificate    *CertificateStatus `json:"apiServerCertificate,omitempty"`
+	IngressCertificate      *CertificateStatus `json:"ingressCertificate,omitempty"`
+	ManifestWorks           []ManifestWorkSync `json:"manifestWorks"`
+	HostedClusterConditions []Condition        `json:"hostedClusterConditions"`
+	NodePools               []NodePoolStatus   `json:"nodePools"`
 }
 
 // ManifestWorkSync represents the sync status of a single ManifestWork.
 type ManifestWorkSync struct {
-	Name         string
-	Applied      bool
-	Available    bool
-	LastSyncTime time.Time
+	Name         string    `json:"name"`
+	Applied      bool      `json:"applied"`
+	Available    bool      `json:"available"`
+	LastSyncTime time.Time `json:"lastSyncTime"`
 }
 
 // VersionInfo holds cluster version details.
 type VersionInfo struct {
-	Current          string
-	Desired          string
-	Status           string
-	Image            string
-	AvailableUpdates []string
+	Current          string   `json:"current,omitempty"`
+	Desired          string   `json:"desired,omitempty"`
+	Status           string   `json:"status,omitempty"`
+	Image            string   `json:"image,omitempty"`
+	AvailableUpdates []string `json:"availableUpdates,omitempty"`
 }
 
 // CertificateStatus holds the certificate details.
 type CertificateStatus struct {
-	Ready       *bool // nil = unknown, true/false = known status
-	NotAfter    time.Time
-	RenewalTime time.Time
-	DNSNames    []string
+	Ready       *bool     `json:"ready"` // nil = unknown, true/false = known status
+	NotAfter    time.Time `json:"notAfter"`
+	RenewalTime time.Time `json:"renewalTime"`
+	DNSNames    []string  `json:"dnsNames,omitempty"`
 }
 
 // Condition represents a single condition from a HostedCluster or NodePool.
 type Condition struct {
-	Type               string
-	Status             string
-	Reason             string
-	Message            string
-	LastTransitionTime string
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
 }
 
 // NodePoolStatus holds the status of a single NodePool.
 type NodePoolStatus struct {
-	Name       string
-	Replicas   int
-	Version    string
-	Conditions []Condition
+	Name       string      `json:"name"`
+	Replicas   int         `json:"replicas"`
+	Version    string      `json:"version,omitempty"`
+	Conditions []Condition `json:"conditions"`
 }
 
 // mainMWResult holds the parsed output from the main ManifestWork.
@@ -0,0 +1,181 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transition is a change of a watched field between two polls of the live resources.
+type Transition struct {
+	Time     time.Time `json:"time"`
+	Resource string    `json:"resource"`
+	Field    string    `json:"field"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// watchedField is a single value of the status compared between polls.
+type watchedField struct {
+	resource string
+	field    string
+	value    string
+	message  string
+}
+
+// watchedFields flattens the parts of the status which are followed in watch mode, keyed by resource and field.
+func watchedFields(s *HCPStatus) (map[string]watchedField, []string) {
+	fields := map[string]watchedField{}
+	var order []string
+	add := func(f watchedField) {
+		key := f.resource + "/" + f.field
+		if _, ok := fields[key]; !ok {
+			order = append(order, key)
+		}
+		fields[key] = f
+	}
+
+	add(watchedField{resource: "Cluster", field: "State", value: s.ClusterState})
+	add(watchedField{resource: "HostedCluster", field: "Version", value: s.Version.Current})
+	add(watchedField{resource: "HostedCluster", field: "VersionStatus", value: s.Version.Status})
+	for _, c := range s.HostedClusterConditions {
+		add(conditionField("HostedCluster", c))
+	}
+	for _, mw := range s.ManifestWorks {
+		resource := "ManifestWork/" + mw.Name
+		add(watchedField{resource: resource, field: "Applied", value: boolStatus(mw.Applied)})
+		add(watchedField{resource: resource, field: "Available", value: boolStatus(mw.Available)})
+	}
+	for _, np := range s.NodePools {
+		resource := "NodePool/" + np.Name
+		add(watchedField{resource: resource, field: "Replicas", value: strconv.Itoa(np.Replicas)})
+		add(watchedField{resource: resource, field: "Version", value: np.Version})
+		for _, c := range np.Conditions {
+			add(conditionField(resource, c))
+		}
+	}
+	if s.IngressCertificate != nil {
+		add(watchedField{resource: "IngressCertificate", field: "Ready", value: certificateReady(s.IngressCertificate)})
+	}
+
+	return fields, order
+}
+
+func conditionField(resource string, c Condition) watchedField {
+	value := c.Status
+	if c.Reason != "" {
+		value += " (" + c.Reason + ")"
+	}
+	message := strings.TrimSpace(strings.Split(c.Message, "\n")[0])
+	return watchedField{resource: resource, field: c.Type, value: value, message: message}
+}
+
+func certificateReady(c *CertificateStatus) string {
+	if c.Ready == nil {
+		return "Unknown"
+	}
+	return boolStatus(*c.Ready)
+}
+
+// diffStatus returns the fields which changed between two polls, in the order they appear in the current status.
+// Fields which disappeared are reported with an empty To. On the first poll, prev is nil and all fields are reported.
+func diffStatus(prev, cur *HCPStatus, now time.Time) []Transition {
+	var transitions []Transition
+
+	prevFields := map[string]watchedField{}
+	if prev != nil {
+		prevFields, _ = watchedFields(prev)
+	}
+	curFields, order := watchedFields(cur)
+
+	for _, key := range order {
+		f := curFields[key]
+		p, existed := prevFields[key]
+		if existed && p.value == f.value {
+			continue
+		}
+		if !existed && f.value == "" {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			Time:     now,
+			Resource: f.resource,
+			Field:    f.field,
+			From:     p.value,
+			To:       f.value,
+			Message:  f.message,
+		})
+	}
+
+	if prev == nil {
+		return transitions
+	}
+	_, prevOrder := watchedFields(prev)
+	for _, key := range prevOrder {
+		if _, ok := curFields[key]; ok {
+			continue
+		}
+		p := prevFields[key]
+		transitions = append(transitions, Transition{Time: now, Resource: p.resource, Field: p.field, From: p.value})
+	}
+
+	return transitions
+}
+
+// printTransition writes a transition as a single line, or as a JSON object per line for machine-readable output.
+func printTransition(w io.Writer, t Transition, output string) error {
+	if output == "json" {
+		return json.NewEncoder(w).Encode(t)
+	}
+
+	from := t.From
+	if from == "" {
+		from = "<none>"
+	}
+	to := t.To
+	if to == "" {
+		to = "<none>"
+	}
+	line := fmt.Sprintf("%s  %s %s: %s -> %s", t.Time.Format(time.RFC3339), t.Resource, t.Field, from, to)
+	if t.Message != "" {
+		line += ": " + t.Message
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// watch polls the status every interval until the context is cancelled and prints only the fields which changed.
+// Polling errors are reported and retried on the next interval.
+func watch(ctx context.Context, w io.Writer, errOut io.Writer, interval time.Duration, output string, fetch func() (*HCPStatus, error)) error {
+	var prev *HCPStatus
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		cur, err := fetch()
+		if err != nil {
+			fmt.Fprintf(errOut, "%s  failed to get the status: %v\n", time.Now().UTC().Format(time.RFC3339), err)
+		} else {
+			for _, t := range diffStatus(prev, cur, time.Now().UTC()) {
+				if err := printTransition(w, t, output); err != nil {
+					return err
+				}
+			}
+			prev = cur
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newWatchStatus() *HCPStatus {
+	return &HCPStatus{
+		ClusterState: "ready",
+		Version:      VersionInfo{Current: "4.20.1", Status: "Completed"},
+		HostedClusterConditions: []Condition{
+			{Type: "Available", Status: "True", Reason: "AsExpected"},
+			{Type: "Progressing", Status: "False"},
+		},
+		ManifestWorks: []ManifestWorkSync{{Name: "mw-1", Applied: true, Available: true}},
+		NodePools: []NodePoolStatus{
+			{Name: "workers", Replicas: 2, Version: "4.20.1", Conditions: []Condition{{Type: "Ready", Status: "True"}}},
+		},
+	}
+}
+
+func TestDiffStatusFirstPoll(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	transitions := diffStatus(nil, newWatchStatus(), now)
+
+	// State, Version, VersionStatus, 2 conditions, 2 ManifestWork fields, 3 NodePool fields
+	if len(transitions) != 10 {
+		t.Fatalf("expected 10 transitions on the first poll, got %d: %+v", len(transitions), transitions)
+	}
+	for _, tr := range transitions {
+		if tr.From != "" {
+			t.Errorf("expected empty From on the first poll, got %+v", tr)
+		}
+		if !tr.Time.Equal(now) {
+			t.Errorf("expected transition time %s, got %s", now, tr.Time)
+		}
+	}
+	if transitions[3].Resource != "HostedCluster" || transitions[3].Field != "Available" || transitions[3].To != "True (AsExpected)" {
+		t.Errorf("unexpected HostedCluster Available transition: %+v", transitions[3])
+	}
+}
+
+func TestDiffStatusTransitions(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	prev := newWatchStatus()
+	cur := newWatchStatus()
+	cur.Version = VersionInfo{Current: "4.20.1", Status: "Partial"}
+	cur.HostedClusterConditions[1] = Condition{Type: "Progressing", Status: "True", Message: "Upgrading to 4.20.2\ndetails"}
+	cur.NodePools[0].Conditions = nil
+	cur.NodePools = append(cur.NodePools, NodePoolStatus{Name: "infra", Replicas: 1})
+
+	transitions := diffStatus(prev, cur, now)
+
+	expected := []Transition{
+		{Time: now, Resource: "HostedCluster", Field: "VersionStatus", From: "Completed", To: "Partial"},
+		{Time: now, Resource: "HostedCluster", Field: "Progressing", From: "False", To: "True", Message: "Upgrading to 4.20.2"},
+		{Time: now, Resource: "NodePool/infra", Field: "Replicas", To: "1"},
+		{Time: now, Resource: "NodePool/workers", Field: "Ready", From: "True"},
+	}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected %d transitions, got %d: %+v", len(expected), len(transitions), transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("transition %d: expected %+v, got %+v", i, expected[i], transitions[i])
+		}
+	}
+}
+
+func TestDiffStatusUnchanged(t *testing.T) {
+	if transitions := diffStatus(newWatchStatus(), newWatchStatus(), time.Now()); len(transitions) != 0 {
+		t.Errorf("expected no transitions, got %+v", transitions)
+	}
+}
+
+func TestPrintTransition(t *testing.T) {
+	tr := Transition{
+		Time:     time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		Resource: "NodePool/workers",
+		Field:    "Ready",
+		From:     "True",
+		To:       "False (Rolling)",
+		Message:  "1 of 2 machines updated",
+	}
+
+	out := &bytes.Buffer{}
+	if err := printTransition(out, tr, "text"); err != nil {
+		t.Fatal(err)
+	}
+	expected := "2026-01-10T00:00:00Z  NodePool/workers Ready: True -> False (Rolling): 1 of 2 machines updated\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+
+	out.Reset()
+	if err := printTransition(out, tr, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Transition
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected a JSON object, got %q: %v", out.String(), err)
+	}
+	if decoded != tr {
+		t.Errorf("expected %+v, got %+v", tr, decoded)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polls := 0
+	fetch := func() (*HCPStatus, error) {
+		polls++
+		switch polls {
+		case 1:
+			return newWatchStatus(), nil
+		case 2:
+			return nil, errors.New("live resources unavailable")
+		default:
+			s := newWatchStatus()
+			s.ClusterState = "updating"
+			cancel()
+			return s, nil
+		}
+	}
+
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	if err := watch(ctx, out, errOut, time.Millisecond, "text", fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+	if !strings.Contains(errOut.String(), "live resources unavailable") {
+		t.Errorf("expected the polling error to be reported, got %q", errOut.String())
+	}
+	if !strings.Contains(out.String(), "Cluster State: ready -> updating") {
+		t.Errorf("expected the state transition, got %q", out.String())
+	}
+	if strings.Count(out.String(), "\n") != 11 {
+		t.Errorf("expected 10 initial lines and 1 transition, got %q", out.String())
+	}
+}
+
+func TestStatusOptionsValidate(t *testing.T) {
+	tests := []struct {
+		opts    statusOptions
+		wantErr bool
+	}{
+		{opts: statusOptions{output: "text"}},
+		{opts: statusOptions{output: "yaml"}},
+		{opts: statusOptions{output: "json", watch: true, interval: time.Minute}},
+		{opts: statusOptions{output: "yaml", watch: true, interval: time.Minute}, wantErr: true},
+		{opts: statusOptions{output: "text", watch: true, interval: time.Millisecond}, wantErr: true},
+		{opts: statusOptions{output: "csv"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.opts.validate(); (err != nil) != tt.wantErr {
+			t.Errorf("validate(%+v): expected error %v, got %v", tt.opts, tt.wantErr, err)
+		}
+	}
+}
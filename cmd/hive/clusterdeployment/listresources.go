@@ -22,16 +22,26 @@ import (
 func newCmdListResources(streams genericclioptions.IOStreams, client client.Client) *cobra.Command {
 	l := newListResources(streams, client)
 	lrCmd := &cobra.Command{
-		Use:               "listresources",
-		Short:             "List all resources on a hive cluster related to a given cluster",
+		Use:   "listresources",
+		Short: "List all resources on a hive cluster related to a given cluster",
+		Long: `List all resources on a hive cluster related to a given cluster
+
+  With --output dot or mermaid, the ownership and reference graph of the resources is rendered instead, from the
+  ClusterDeployment to its ClusterProvision, ClusterDeprovision, DNSZone, ClusterSync with the SyncSets and
+  SelectorSyncSets it applies, the AccountClaim or ProjectClaim and the Secrets they reference. Resources which
+  failed or are stuck deleting on finalizers are highlighted, to quickly find what blocks installation or deprovision.`,
+		Example: `
+  # Render the graph of a cluster's hive resources as an SVG with graphviz
+  osdctl hive cd listresources -C ${CLUSTER_ID} -o dot | dot -Tsvg > resources.svg`,
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(l.complete(cmd, args))
-			cmdutil.CheckErr(l.RunListResources())
+			cmdutil.CheckErr(l.RunListResources(cmd.Context()))
 		},
 	}
 	lrCmd.Flags().StringVarP(&l.ClusterId, "cluster-id", "C", "", "Cluster ID")
 	lrCmd.Flags().BoolVarP(&l.ExternalResourcesOnly, "external", "e", false, "only list external resources (i.e. exclude resources in cluster namespace)")
+	lrCmd.Flags().StringVarP(&l.Output, "output", "o", "table", "output format ['table', 'dot', 'mermaid']")
 
 	return lrCmd
 }
@@ -44,6 +54,7 @@ type ListResources struct {
 	ClusterId             string
 	P                     Printer
 	ExternalResourcesOnly bool
+	Output                string
 	KubeCli               client.Client
 	Cmd                   *cobra.Command
 }
@@ -76,10 +87,21 @@ func (l *ListResources) complete(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func (l *ListResources) RunListResources() error {
+func (l *ListResources) RunListResources(ctx context.Context) error {
 	if l.ClusterId == "" {
 		return cmdutil.UsageErrorf(l.Cmd, "No cluster ID specified, use -C to set one")
 	}
+
+	switch l.Output {
+	case "", "table":
+	case "dot", "mermaid":
+		if l.ExternalResourcesOnly {
+			return cmdutil.UsageErrorf(l.Cmd, "--external can only be used with the table output")
+		}
+		return l.RunGraph(ctx)
+	default:
+		return cmdutil.UsageErrorf(l.Cmd, "output must be 'table', 'dot' or 'mermaid'")
+	}
 	l.P.AddRow([]string{"Group", "Version", "Kind", "Namespace", "Name"})
 	l.PrintRow(l.ClusterDeployment.ObjectMeta, l.ClusterDeployment.TypeMeta)
 
@@ -0,0 +1,303 @@
+package clusterdeployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	awsv1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
+	gcpv1alpha1 "github.com/openshift/gcp-project-operator/api/v1alpha1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/osdctl/pkg/graphviz"
+)
+
+// failedClusterDeploymentConditions are ClusterDeployment conditions which block installation or deprovision when true
+var failedClusterDeploymentConditions = map[hivev1.ClusterDeploymentConditionType]bool{
+	hivev1.ProvisionFailedCondition:                        true,
+	hivev1.ProvisionStoppedCondition:                       true,
+	hivev1.InstallLaunchErrorCondition:                     true,
+	hivev1.DeprovisionLaunchErrorCondition:                 true,
+	hivev1.DNSNotReadyCondition:                            true,
+	hivev1.InstallImagesNotResolvedCondition:               true,
+	hivev1.AuthenticationFailureClusterDeploymentCondition: true,
+	hivev1.ClusterImageSetNotFoundCondition:                true,
+	hivev1.SyncSetFailedCondition:                          true,
+}
+
+// resourceGraph builds the ownership and reference graph of the resources related to a ClusterDeployment
+type resourceGraph struct {
+	l     *ListResources
+	graph *graphviz.Graph
+}
+
+// RunGraph renders the resources related to the ClusterDeployment as a dot or mermaid graph. Resources which failed
+// or are stuck deleting are highlighted.
+func (l *ListResources) RunGraph(ctx context.Context) error {
+	g := &resourceGraph{l: l, graph: graphviz.NewGraph()}
+	if err := g.build(ctx); err != nil {
+		return err
+	}
+
+	if l.Output == "mermaid" {
+		return g.graph.RenderMermaid(l.Out)
+	}
+	return g.graph.RenderDot(l.Out)
+}
+
+func (g *resourceGraph) build(ctx context.Context) error {
+	cd := g.l.ClusterDeployment
+	cdId := g.addNode("ClusterDeployment", cd.ObjectMeta, clusterDeploymentStatus(cd))
+
+	if cd.Spec.PullSecretRef != nil {
+		g.addSecret(cdId, cd.Namespace, cd.Spec.PullSecretRef.Name, "pull secret")
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		g.addSecret(cdId, cd.Namespace, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, "admin kubeconfig")
+		if cd.Spec.ClusterMetadata.AdminPasswordSecretRef != nil {
+			g.addSecret(cdId, cd.Namespace, cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name, "admin password")
+		}
+	}
+	if cd.Spec.Platform.AWS != nil {
+		g.addSecret(cdId, cd.Namespace, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, "credentials")
+	}
+	if cd.Spec.Platform.GCP != nil {
+		g.addSecret(cdId, cd.Namespace, cd.Spec.Platform.GCP.CredentialsSecretRef.Name, "credentials")
+	}
+
+	if cd.Status.ProvisionRef != nil {
+		var provision hivev1.ClusterProvision
+		if err := g.l.KubeCli.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Status.ProvisionRef.Name}, &provision); err != nil {
+			g.addMissing(cdId, "ClusterProvision", cd.Namespace, cd.Status.ProvisionRef.Name, "provision", err)
+		} else {
+			id := g.addNode("ClusterProvision", provision.ObjectMeta, nodeStatus{text: string(provision.Spec.Stage), failed: provision.Spec.Stage == hivev1.ClusterProvisionStageFailed})
+			g.graph.AddEdge(graphviz.Edge{From: cdId, To: id, Label: "provision"})
+		}
+	}
+
+	var deprovisions hivev1.ClusterDeprovisionList
+	if err := g.l.KubeCli.List(ctx, &deprovisions, &client.ListOptions{Namespace: cd.Namespace}); err != nil {
+		return err
+	}
+	for _, deprovision := range deprovisions.Items {
+		status := nodeStatus{text: "in progress"}
+		if deprovision.Status.Completed {
+			status.text = "completed"
+		}
+		id := g.addNode("ClusterDeprovision", deprovision.ObjectMeta, status)
+		g.graph.AddEdge(graphviz.Edge{From: cdId, To: id, Label: "deprovision"})
+	}
+
+	var dnsZones hivev1.DNSZoneList
+	if err := g.l.KubeCli.List(ctx, &dnsZones, &client.ListOptions{Namespace: cd.Namespace}); err != nil {
+		return err
+	}
+	for _, zone := range dnsZones.Items {
+		id := g.addNode("DNSZone", zone.ObjectMeta, dnsZoneStatus(zone))
+		g.graph.AddEdge(graphviz.Edge{From: cdId, To: id, Label: "manages"})
+	}
+
+	if err := g.addClusterSync(ctx, cdId); err != nil {
+		return err
+	}
+
+	if cd.Spec.Platform.AWS != nil {
+		g.addAccountClaim(cdId)
+	}
+	if cd.Spec.Platform.GCP != nil {
+		g.addProjectClaim(cdId)
+	}
+
+	return nil
+}
+
+// addClusterSync adds the ClusterSync of the ClusterDeployment and the SyncSets and SelectorSyncSets it applies
+func (g *resourceGraph) addClusterSync(ctx context.Context, cdId string) error {
+	cd := g.l.ClusterDeployment
+
+	var clusterSyncs hiveinternalv1alpha1.ClusterSyncList
+	if err := g.l.KubeCli.List(ctx, &clusterSyncs, &client.ListOptions{Namespace: cd.Namespace}); err != nil {
+		return err
+	}
+
+	for _, cs := range clusterSyncs.Items {
+		status := nodeStatus{}
+		for _, condition := range cs.Status.Conditions {
+			if condition.Type == hiveinternalv1alpha1.ClusterSyncFailed && condition.Status == corev1.ConditionTrue {
+				status = nodeStatus{text: "failed", failed: true}
+			}
+		}
+		csId := g.addNode("ClusterSync", cs.ObjectMeta, status)
+		g.graph.AddEdge(graphviz.Edge{From: cdId, To: csId, Label: "syncs"})
+
+		for _, ss := range cs.Status.SyncSets {
+			var syncSet hivev1.SyncSet
+			if err := g.l.KubeCli.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: ss.Name}, &syncSet); err != nil {
+				g.addMissing(csId, "SyncSet", cd.Namespace, ss.Name, "applies", err)
+				continue
+			}
+			id := g.addNode("SyncSet", syncSet.ObjectMeta, syncStatus(ss))
+			g.graph.AddEdge(graphviz.Edge{From: csId, To: id, Label: "applies"})
+			for _, secret := range syncSet.Spec.Secrets {
+				g.addSecretMapping(id, syncSet.Namespace, secret)
+			}
+		}
+
+		for _, sss := range cs.Status.SelectorSyncSets {
+			var selectorSyncSet hivev1.SelectorSyncSet
+			if err := g.l.KubeCli.Get(ctx, types.NamespacedName{Name: sss.Name}, &selectorSyncSet); err != nil {
+				g.addMissing(csId, "SelectorSyncSet", "", sss.Name, "applies", err)
+				continue
+			}
+			id := g.addNode("SelectorSyncSet", selectorSyncSet.ObjectMeta, syncStatus(sss))
+			g.graph.AddEdge(graphviz.Edge{From: csId, To: id, Label: "applies"})
+			for _, secret := range selectorSyncSet.Spec.Secrets {
+				g.addSecretMapping(id, "", secret)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *resourceGraph) addAccountClaim(cdId string) {
+	accountClaim, err := g.l.getAccountClaim(g.l.ClusterDeployment)
+	if err != nil {
+		g.addMissing(cdId, "AccountClaim", g.l.ClusterDeployment.Namespace, "", "claims", err)
+		return
+	}
+	claimId := g.addNode("AccountClaim", accountClaim.ObjectMeta, nodeStatus{
+		text:   string(accountClaim.Status.State),
+		failed: accountClaim.Status.State == awsv1alpha1.ClaimStatusError,
+	})
+	g.graph.AddEdge(graphviz.Edge{From: cdId, To: claimId, Label: "claims"})
+
+	account, err := g.l.getAccount(accountClaim)
+	if err != nil {
+		g.addMissing(claimId, "Account", "aws-account-operator", accountClaim.Spec.AccountLink, "account", err)
+		return
+	}
+	accountId := g.addNode("Account", account.ObjectMeta, nodeStatus{
+		text:   account.Status.State,
+		failed: account.Status.State == string(awsv1alpha1.AccountFailed),
+	})
+	g.graph.AddEdge(graphviz.Edge{From: claimId, To: accountId, Label: "account"})
+	g.addSecret(accountId, account.Namespace, account.Spec.IAMUserSecret, "IAM user")
+}
+
+func (g *resourceGraph) addProjectClaim(cdId string) {
+	projectClaim, err := g.l.getProjectClaim(g.l.ClusterDeployment)
+	if err != nil {
+		g.addMissing(cdId, "ProjectClaim", g.l.ClusterDeployment.Namespace, "", "claims", err)
+		return
+	}
+	claimId := g.addNode("ProjectClaim", projectClaim.ObjectMeta, nodeStatus{
+		text:   string(projectClaim.Status.State),
+		failed: projectClaim.Status.State == gcpv1alpha1.ClaimStatusError,
+	})
+	g.graph.AddEdge(graphviz.Edge{From: cdId, To: claimId, Label: "claims"})
+
+	projectReference, err := g.l.getProjectReference(projectClaim)
+	if err != nil {
+		link := projectClaim.Spec.ProjectReferenceCRLink
+		g.addMissing(claimId, "ProjectReference", link.Namespace, link.Name, "project", err)
+		return
+	}
+	referenceId := g.addNode("ProjectReference", projectReference.ObjectMeta, nodeStatus{
+		text:   string(projectReference.Status.State),
+		failed: projectReference.Status.State == gcpv1alpha1.ProjectReferenceStatusError,
+	})
+	g.graph.AddEdge(graphviz.Edge{From: claimId, To: referenceId, Label: "project"})
+}
+
+func (g *resourceGraph) addSecretMapping(fromId, namespace string, secret hivev1.SecretMapping) {
+	if secret.SourceRef.Namespace != "" {
+		namespace = secret.SourceRef.Namespace
+	}
+	g.addSecret(fromId, namespace, secret.SourceRef.Name, "syncs")
+}
+
+// addSecret references a secret by name, as listing secrets is not allowed for SREs
+func (g *resourceGraph) addSecret(fromId, namespace, name, label string) {
+	if name == "" {
+		return
+	}
+	id := g.addNode("Secret", v1.ObjectMeta{Namespace: namespace, Name: name}, nodeStatus{})
+	g.graph.AddEdge(graphviz.Edge{From: fromId, To: id, Label: label})
+}
+
+// addMissing adds a highlighted node for a resource which could not be retrieved
+func (g *resourceGraph) addMissing(fromId, kind, namespace, name, label string, err error) {
+	id := g.addNode(kind, v1.ObjectMeta{Namespace: namespace, Name: name}, nodeStatus{text: err.Error(), failed: true})
+	g.graph.AddEdge(graphviz.Edge{From: fromId, To: id, Label: label})
+}
+
+// nodeStatus is the status shown for a resource, failed resources are highlighted
+type nodeStatus struct {
+	text   string
+	failed bool
+}
+
+// addNode adds a resource to the graph, resources which are being deleted are highlighted with their finalizers
+func (g *resourceGraph) addNode(kind string, m v1.ObjectMeta, status nodeStatus) string {
+	id := kind + "/" + m.Namespace + "/" + m.Name
+
+	name := m.Name
+	if m.Namespace != "" {
+		name = m.Namespace + "/" + m.Name
+	}
+	lines := []string{kind, name}
+	if status.text != "" {
+		lines = append(lines, status.text)
+	}
+	highlight := status.failed
+	if m.DeletionTimestamp != nil {
+		lines = append(lines, fmt.Sprintf("deleting, finalizers: %s", strings.Join(m.Finalizers, ", ")))
+		highlight = highlight || len(m.Finalizers) > 0
+	}
+
+	g.graph.AddNode(graphviz.GraphNode{Id: id, Label: strings.Join(lines, "\n"), Highlight: highlight})
+	return id
+}
+
+func clusterDeploymentStatus(cd hivev1.ClusterDeployment) nodeStatus {
+	status := nodeStatus{text: "installing"}
+	if cd.Spec.Installed {
+		status.text = "installed"
+	}
+
+	var failed []string
+	for _, condition := range cd.Status.Conditions {
+		if failedClusterDeploymentConditions[condition.Type] && condition.Status == corev1.ConditionTrue {
+			failed = append(failed, string(condition.Type))
+		}
+	}
+	if len(failed) > 0 {
+		status.text += ", " + strings.Join(failed, ", ")
+		status.failed = true
+	}
+	return status
+}
+
+func dnsZoneStatus(zone hivev1.DNSZone) nodeStatus {
+	for _, condition := range zone.Status.Conditions {
+		if condition.Type == hivev1.ZoneAvailableDNSZoneCondition && condition.Status == corev1.ConditionTrue {
+			return nodeStatus{text: "available"}
+		}
+	}
+	for _, condition := range zone.Status.Conditions {
+		if condition.Type != hivev1.ZoneAvailableDNSZoneCondition && condition.Type != hivev1.ParentLinkCreatedCondition && condition.Status == corev1.ConditionTrue {
+			return nodeStatus{text: string(condition.Type), failed: true}
+		}
+	}
+	return nodeStatus{text: "not available"}
+}
+
+func syncStatus(status hiveinternalv1alpha1.SyncStatus) nodeStatus {
+	return nodeStatus{text: string(status.Result), failed: status.Result == hiveinternalv1alpha1.FailureSyncSetResult}
+}
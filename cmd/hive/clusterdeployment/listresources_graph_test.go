@@ -0,0 +1,125 @@
+package clusterdeployment_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	awsv1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
+	gcpv1alpha1 "github.com/openshift/gcp-project-operator/api/v1alpha1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveaws "github.com/openshift/hive/apis/hive/v1/aws"
+	hiveinternalv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/openshift/osdctl/cmd/hive/clusterdeployment"
+)
+
+func TestRunGraph(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, hivev1.AddToScheme(scheme))
+	require.NoError(t, hiveinternalv1alpha1.AddToScheme(scheme))
+	require.NoError(t, awsv1alpha1.AddToScheme(scheme))
+	require.NoError(t, gcpv1alpha1.AddToScheme(scheme))
+
+	now := v1.Now()
+	cd := hivev1.ClusterDeployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "fake-cluster",
+			Namespace:         "uhc-production-fake-id",
+			DeletionTimestamp: &now,
+			Finalizers:        []string{"hive.openshift.io/deprovision"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: true,
+			Platform: hivev1.Platform{
+				AWS: &hiveaws.Platform{CredentialsSecretRef: corev1.LocalObjectReference{Name: "aws-creds"}},
+			},
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			Conditions: []hivev1.ClusterDeploymentCondition{
+				{Type: hivev1.DeprovisionLaunchErrorCondition, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	objs := []runtime.Object{
+		&hiveinternalv1alpha1.ClusterSync{
+			ObjectMeta: v1.ObjectMeta{Name: "fake-cluster", Namespace: cd.Namespace},
+			Status: hiveinternalv1alpha1.ClusterSyncStatus{
+				SyncSets:         []hiveinternalv1alpha1.SyncStatus{{Name: "fake-syncset", Result: hiveinternalv1alpha1.FailureSyncSetResult}},
+				SelectorSyncSets: []hiveinternalv1alpha1.SyncStatus{{Name: "deleted-selectorsyncset", Result: hiveinternalv1alpha1.SuccessSyncSetResult}},
+			},
+		},
+		&hivev1.SyncSet{
+			ObjectMeta: v1.ObjectMeta{Name: "fake-syncset", Namespace: cd.Namespace},
+			Spec: hivev1.SyncSetSpec{SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
+				Secrets: []hivev1.SecretMapping{{SourceRef: hivev1.SecretReference{Name: "synced-secret"}}},
+			}},
+		},
+		&hivev1.DNSZone{
+			ObjectMeta: v1.ObjectMeta{Name: "fake-cluster-zone", Namespace: cd.Namespace},
+			Status: hivev1.DNSZoneStatus{Conditions: []hivev1.DNSZoneCondition{
+				{Type: hivev1.ZoneAvailableDNSZoneCondition, Status: corev1.ConditionTrue},
+			}},
+		},
+		&awsv1alpha1.AccountClaim{
+			ObjectMeta: v1.ObjectMeta{Name: "fake-account-claim", Namespace: cd.Namespace},
+			Spec:       awsv1alpha1.AccountClaimSpec{AccountLink: "fake-account"},
+			Status:     awsv1alpha1.AccountClaimStatus{State: awsv1alpha1.ClaimStatusReady},
+		},
+		&awsv1alpha1.Account{
+			ObjectMeta: v1.ObjectMeta{Name: "fake-account", Namespace: "aws-account-operator"},
+			Spec:       awsv1alpha1.AccountSpec{IAMUserSecret: "fake-secret"},
+			Status:     awsv1alpha1.AccountStatus{State: "Ready"},
+		},
+	}
+
+	for _, output := range []string{"dot", "mermaid"} {
+		t.Run(output, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			l := ListResources{
+				IOStreams:         genericclioptions.IOStreams{Out: out},
+				Cmd:               &cobra.Command{},
+				ClusterId:         "fake-id",
+				ClusterDeployment: cd,
+				Output:            output,
+				KubeCli:           fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+			}
+			require.NoError(t, l.RunListResources(context.Background()))
+
+			for _, expected := range []string{
+				"installed, DeprovisionLaunchError",
+				"deleting, finalizers: hive.openshift.io/deprovision",
+				"ClusterSync",
+				"uhc-production-fake-id/fake-syncset",
+				"Failure",
+				"uhc-production-fake-id/synced-secret",
+				"DNSZone",
+				"available",
+				"aws-account-operator/fake-account",
+				"aws-account-operator/fake-secret",
+				"uhc-production-fake-id/aws-creds",
+				"SelectorSyncSet",
+				"not found",
+			} {
+				assert.Contains(t, out.String(), expected)
+			}
+		})
+	}
+}
+
+func TestRunListResourcesInvalidOutput(t *testing.T) {
+	l := ListResources{Cmd: &cobra.Command{}, ClusterId: "fake-id", Output: "svg"}
+	assert.Error(t, l.RunListResources(context.Background()))
+
+	l = ListResources{Cmd: &cobra.Command{}, ClusterId: "fake-id", Output: "dot", ExternalResourcesOnly: true}
+	assert.Error(t, l.RunListResources(context.Background()))
+}
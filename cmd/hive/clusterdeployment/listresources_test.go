@@ -1,6 +1,7 @@
 package clusterdeployment_test
 
 import (
+	"context"
 	"testing"
 
 	awsv1alpha1 "github.com/openshift/aws-account-operator/api/v1alpha1"
@@ -122,7 +123,7 @@ func TestListResourcesAwsExternalOnly(t *testing.T) {
 		r.mockPrinter.EXPECT().AddRow([]string{`""`, "v1", "Secret", "aws-account-operator", "fake-secret"}),
 		r.mockPrinter.EXPECT().Flush(),
 	)
-	err := r.l.RunListResources()
+	err := r.l.RunListResources(context.Background())
 
 	g.Expect(err).NotTo(HaveOccurred())
 	r.finish()
@@ -143,7 +144,7 @@ func TestListResourcesAws(t *testing.T) {
 		r.mockPrinter.EXPECT().AddRow([]string{`""`, "v1", "Secret", "aws-account-operator", "fake-secret"}),
 		r.mockPrinter.EXPECT().Flush(),
 	)
-	err := r.l.RunListResources()
+	err := r.l.RunListResources(context.Background())
 
 	g.Expect(err).NotTo(HaveOccurred())
 	r.finish()
@@ -34,6 +34,16 @@ type failingClusterSync struct {
 	Hibernating     bool
 	FailingSyncSets string
 	ErrorMessage    string
+	Remediation     string
+
+	// syncSets are the failing SelectorSyncSets and SyncSets, used to track failures across runs
+	syncSets []syncSetFailure
+}
+
+// syncSetFailure is a single failing SelectorSyncSet or SyncSet of a ClusterSync
+type syncSetFailure struct {
+	Name    string
+	Message string
 }
 
 // clusterSyncFailuresOptions defines the struct for running clustersync command
@@ -46,6 +56,11 @@ type clusterSyncFailuresOptions struct {
 	output                 string
 	sortField              string
 	sortOrder              string
+	history                bool
+	historyFile            string
+
+	// shard is the API server of the hive shard, which separates the history of each shard
+	shard string
 
 	genericclioptions.IOStreams
 	kubeCli client.Client
@@ -59,6 +74,11 @@ const (
   for clusters that are not in limited support or hibernating.
 
   Error messages are include in all output format except the text format.
+  Known error messages are mapped to a suggested remediation.
+
+  With --history, the failures of each run are recorded locally and a report of
+  how long each SyncSet failure has persisted across runs is printed, along with
+  the SyncSets failing on the most clusters across all recorded hive shards.
 `
 	clusterSyncFailuresExample = `
   # List clustersync failures using the short version of the command
@@ -73,6 +93,9 @@ const (
 
   # List failures and error message for a single cluster
   $ osdctl hive csf -C <cluster-id>
+
+  # Record this run's failures and report how long they have persisted
+  $ osdctl hive csf --history
 `
 )
 
@@ -103,6 +126,8 @@ func NewCmdClusterSyncFailures(streams genericclioptions.IOStreams, client clien
 	clusterSyncCmd.Flags().StringVar(&opts.sortField, "sort-by", "timestamp", "Sort the output by a specified field. Options: name, timestamp, failingsyncsets.")
 	clusterSyncCmd.Flags().StringVar(&opts.sortOrder, "order", "asc", "Set the sorting order. Options: asc, desc.")
 	clusterSyncCmd.Flags().StringVarP(&opts.clusterID, "cluster-id", "C", "", "Internal ID to list failing syncsets and relative errors for a specific cluster.")
+	clusterSyncCmd.Flags().BoolVar(&opts.history, "history", false, "Record the failures of this run and report how long they have persisted and which syncsets fail fleet-wide.")
+	clusterSyncCmd.Flags().StringVar(&opts.historyFile, "history-file", "", "File the failure history is recorded in. Defaults to hive/clustersync-failures-history.json in the osdctl cache directory.")
 
 	return clusterSyncCmd
 }
@@ -120,9 +145,27 @@ func (o *clusterSyncFailuresOptions) complete(cmd *cobra.Command, args []string)
 		return cmdutil.UsageErrorf(cmd, "invalid output field")
 	}
 
-	if _, err := config.GetConfig(); err != nil {
+	if o.history {
+		if o.clusterID != "" {
+			return cmdutil.UsageErrorf(cmd, "--history can not be used with --cluster-id")
+		}
+		if o.output == "csv" {
+			return cmdutil.UsageErrorf(cmd, "--history supports the yaml, json and text output formats")
+		}
+		if o.historyFile == "" {
+			path, err := defaultClusterSyncHistoryPath()
+			if err != nil {
+				return err
+			}
+			o.historyFile = path
+		}
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
 		return cmdutil.UsageErrorf(cmd, "could not find KUBECONFIG, please make sure you are logged into an hive shard")
 	}
+	o.shard = cfg.Host
 
 	return nil
 }
@@ -137,6 +180,10 @@ func (o *clusterSyncFailuresOptions) run() error {
 		return err
 	}
 
+	if o.history {
+		return o.runHistory(csList, time.Now())
+	}
+
 	if err = o.sortBy(csList); err != nil {
 		return err
 	}
@@ -207,6 +254,9 @@ func (o *clusterSyncFailuresOptions) printFailingCluster() error {
 
 			selectorSyncSetFailures += fmt.Sprintf("  - Name: %s\n", sss.Name)
 			selectorSyncSetFailures += fmt.Sprintf("    Error:\n      %s\n\n", errorMessage)
+			if hint := remediationHint(sss.FailureMessage); hint != "" {
+				selectorSyncSetFailures += fmt.Sprintf("    Remediation:\n      %s\n\n", hint)
+			}
 		}
 	}
 
@@ -222,6 +272,9 @@ func (o *clusterSyncFailuresOptions) printFailingCluster() error {
 
 			syncSetFailures += fmt.Sprintf("  - Name: %s\n", ss.Name)
 			syncSetFailures += fmt.Sprintf("    Error:\n      %s\n\n", errorMessage)
+			if hint := remediationHint(ss.FailureMessage); hint != "" {
+				syncSetFailures += fmt.Sprintf("    Remediation:\n      %s\n\n", hint)
+			}
 		}
 	}
 
@@ -278,13 +331,19 @@ func (o *clusterSyncFailuresOptions) listFailingClusterSyncs() ([]failingCluster
 			}
 		}
 
-		var failingSyncSets strings.Builder
+		var (
+			failingSyncSets strings.Builder
+			syncSets        []syncSetFailure
+			messages        []string
+		)
 		errorMessage := ""
 		for _, sss := range cs.Status.SelectorSyncSets {
 			if sss.Result == "Failure" {
 				errorMessage += sss.FailureMessage + "\n\n"
 				failingSyncSets.WriteString(sss.Name)
 				failingSyncSets.WriteString(" ")
+				syncSets = append(syncSets, syncSetFailure{Name: sss.Name, Message: sss.FailureMessage})
+				messages = append(messages, sss.FailureMessage)
 			}
 		}
 		for _, ss := range cs.Status.SyncSets {
@@ -292,6 +351,8 @@ func (o *clusterSyncFailuresOptions) listFailingClusterSyncs() ([]failingCluster
 				errorMessage += ss.FailureMessage + "\n\n"
 				failingSyncSets.WriteString(ss.Name)
 				failingSyncSets.WriteString(" ")
+				syncSets = append(syncSets, syncSetFailure{Name: ss.Name, Message: ss.FailureMessage})
+				messages = append(messages, ss.FailureMessage)
 			}
 		}
 
@@ -303,6 +364,8 @@ func (o *clusterSyncFailuresOptions) listFailingClusterSyncs() ([]failingCluster
 			Hibernating:     isHibernating,
 			FailingSyncSets: failingSyncSets.String(),
 			ErrorMessage:    errorMessage,
+			Remediation:     remediationHints(messages),
+			syncSets:        syncSets,
 		}
 
 		fcsList = append(fcsList, fc)
@@ -388,7 +451,7 @@ func (o *clusterSyncFailuresOptions) printYaml(failingClusterSyncList []failingC
 func (o *clusterSyncFailuresOptions) printCsv(failingClusterSyncList []failingClusterSync) error {
 	writer := csv.NewWriter(os.Stdout)
 
-	headers := []string{"NAME", "NAMESPACE", "TIMESTAMP", "LIMITED SUPPORT", "HIBERNATING", "FAILING SYNCSETS", "ERROR MESSAGE"}
+	headers := []string{"NAME", "NAMESPACE", "TIMESTAMP", "LIMITED SUPPORT", "HIBERNATING", "FAILING SYNCSETS", "ERROR MESSAGE", "REMEDIATION"}
 	if err := writer.Write(headers); err != nil {
 		return err
 	}
@@ -410,6 +473,7 @@ func (o *clusterSyncFailuresOptions) printCsv(failingClusterSyncList []failingCl
 			strconv.FormatBool(f.Hibernating),
 			f.FailingSyncSets,
 			f.ErrorMessage,
+			f.Remediation,
 		}
 		if err := writer.Write(row); err != nil {
 			return err
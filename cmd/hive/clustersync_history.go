@@ -0,0 +1,256 @@
+package hive
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// clusterSyncHistoryStaleAfter excludes shards which haven't been checked recently from the fleet-wide report
+const clusterSyncHistoryStaleAfter = 7 * 24 * time.Hour
+
+// clusterSyncHistory is the locally recorded history of ClusterSync failures, per hive shard
+type clusterSyncHistory struct {
+	Shards map[string]*shardClusterSyncHistory `json:"shards"`
+}
+
+// shardClusterSyncHistory holds the SyncSet failures of a hive shard which were still failing in its last run
+type shardClusterSyncHistory struct {
+	LastRun  time.Time              `json:"lastRun"`
+	Failures []syncSetFailureRecord `json:"failures"`
+}
+
+// syncSetFailureRecord tracks a SyncSet failure of a single cluster since it was first seen
+type syncSetFailureRecord struct {
+	Namespace   string    `json:"namespace"`
+	ClusterSync string    `json:"clusterSync"`
+	SyncSet     string    `json:"syncSet"`
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+	// Runs is the number of consecutive runs the failure was seen in
+	Runs    int    `json:"runs"`
+	Message string `json:"message"`
+}
+
+// clusterSyncTrends is the report printed in history mode
+type clusterSyncTrends struct {
+	Failures  []syncSetFailureTrend `json:"failures" yaml:"failures"`
+	FleetWide []fleetSyncSetFailure `json:"fleetWide" yaml:"fleetWide"`
+}
+
+type syncSetFailureTrend struct {
+	Namespace   string    `json:"namespace" yaml:"namespace"`
+	ClusterSync string    `json:"clusterSync" yaml:"clusterSync"`
+	SyncSet     string    `json:"syncSet" yaml:"syncSet"`
+	FirstSeen   time.Time `json:"firstSeen" yaml:"firstSeen"`
+	Persisted   string    `json:"persisted" yaml:"persisted"`
+	Runs        int       `json:"runs" yaml:"runs"`
+	Remediation string    `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+}
+
+// fleetSyncSetFailure is a SyncSet name with the number of clusters it is failing on across all recorded shards
+type fleetSyncSetFailure struct {
+	SyncSet  string `json:"syncSet" yaml:"syncSet"`
+	Clusters int    `json:"clusters" yaml:"clusters"`
+	Shards   int    `json:"shards" yaml:"shards"`
+}
+
+// defaultClusterSyncHistoryPath returns the path of the history file in the osdctl cache directory
+func defaultClusterSyncHistoryPath() (string, error) {
+	cacheDir, err := utils.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "hive", "clustersync-failures-history.json"), nil
+}
+
+// loadClusterSyncHistory reads the history file, returning an empty history if it doesn't exist yet
+func loadClusterSyncHistory(path string) (*clusterSyncHistory, error) {
+	history := &clusterSyncHistory{}
+	if _, err := utils.LoadJSON(path, history); err != nil {
+		return nil, fmt.Errorf("failed to load clustersync history: %w", err)
+	}
+	if history.Shards == nil {
+		history.Shards = map[string]*shardClusterSyncHistory{}
+	}
+	return history, nil
+}
+
+// save writes the history file
+func (h *clusterSyncHistory) save(path string) error {
+	if err := utils.SaveJSONAtomic(path, h); err != nil {
+		return fmt.Errorf("failed to save clustersync history: %w", err)
+	}
+	return nil
+}
+
+// record updates a shard's history with the failures of a run. Failures which are no longer failing are forgotten,
+// so a failure which recurs later is tracked from when it recurred.
+func (h *clusterSyncHistory) record(shard string, failures []failingClusterSync, now time.Time) {
+	previous := map[string]syncSetFailureRecord{}
+	if sh, ok := h.Shards[shard]; ok {
+		for _, r := range sh.Failures {
+			previous[r.key()] = r
+		}
+	}
+
+	var records []syncSetFailureRecord
+	for _, cs := range failures {
+		for _, ss := range cs.syncSets {
+			r := syncSetFailureRecord{
+				Namespace:   cs.Namespace,
+				ClusterSync: cs.Name,
+				SyncSet:     ss.Name,
+				FirstSeen:   now,
+				LastSeen:    now,
+				Runs:        1,
+				Message:     ss.Message,
+			}
+			if prev, ok := previous[r.key()]; ok {
+				r.FirstSeen = prev.FirstSeen
+				r.Runs = prev.Runs + 1
+			}
+			records = append(records, r)
+		}
+	}
+
+	h.Shards[shard] = &shardClusterSyncHistory{LastRun: now, Failures: records}
+}
+
+func (r syncSetFailureRecord) key() string {
+	return r.Namespace + "/" + r.ClusterSync + "/" + r.SyncSet
+}
+
+// trends reports the current failures of a shard by how long they have persisted, and the SyncSets failing on the
+// most clusters across all shards checked within clusterSyncHistoryStaleAfter
+func (h *clusterSyncHistory) trends(shard string, now time.Time) clusterSyncTrends {
+	report := clusterSyncTrends{
+		Failures:  []syncSetFailureTrend{},
+		FleetWide: []fleetSyncSetFailure{},
+	}
+
+	if sh, ok := h.Shards[shard]; ok {
+		for _, r := range sh.Failures {
+			report.Failures = append(report.Failures, syncSetFailureTrend{
+				Namespace:   r.Namespace,
+				ClusterSync: r.ClusterSync,
+				SyncSet:     r.SyncSet,
+				FirstSeen:   r.FirstSeen,
+				Persisted:   duration.HumanDuration(now.Sub(r.FirstSeen)),
+				Runs:        r.Runs,
+				Remediation: remediationHint(r.Message),
+			})
+		}
+	}
+	sort.SliceStable(report.Failures, func(i, j int) bool {
+		if !report.Failures[i].FirstSeen.Equal(report.Failures[j].FirstSeen) {
+			return report.Failures[i].FirstSeen.Before(report.Failures[j].FirstSeen)
+		}
+		return report.Failures[i].Namespace < report.Failures[j].Namespace
+	})
+
+	clusters := map[string]map[string]bool{}
+	shards := map[string]map[string]bool{}
+	for name, sh := range h.Shards {
+		if now.Sub(sh.LastRun) > clusterSyncHistoryStaleAfter {
+			continue
+		}
+		for _, r := range sh.Failures {
+			if clusters[r.SyncSet] == nil {
+				clusters[r.SyncSet] = map[string]bool{}
+				shards[r.SyncSet] = map[string]bool{}
+			}
+			clusters[r.SyncSet][r.Namespace] = true
+			shards[r.SyncSet][name] = true
+		}
+	}
+	for syncSet := range clusters {
+		report.FleetWide = append(report.FleetWide, fleetSyncSetFailure{
+			SyncSet:  syncSet,
+			Clusters: len(clusters[syncSet]),
+			Shards:   len(shards[syncSet]),
+		})
+	}
+	sort.Slice(report.FleetWide, func(i, j int) bool {
+		if report.FleetWide[i].Clusters != report.FleetWide[j].Clusters {
+			return report.FleetWide[i].Clusters > report.FleetWide[j].Clusters
+		}
+		return report.FleetWide[i].SyncSet < report.FleetWide[j].SyncSet
+	})
+
+	return report
+}
+
+// runHistory records the failures of this run and prints the trends across runs
+func (o *clusterSyncFailuresOptions) runHistory(failingClusterSyncList []failingClusterSync, now time.Time) error {
+	var filtered []failingClusterSync
+	for _, cs := range failingClusterSyncList {
+		if !o.includeLimitedSupport && cs.LimitedSupport {
+			continue
+		}
+		if !o.includeHibernating && cs.Hibernating {
+			continue
+		}
+		filtered = append(filtered, cs)
+	}
+
+	history, err := loadClusterSyncHistory(o.historyFile)
+	if err != nil {
+		return err
+	}
+	history.record(o.shard, filtered, now)
+	if err := history.save(o.historyFile); err != nil {
+		return err
+	}
+
+	report := history.trends(o.shard, now)
+	switch o.output {
+	case "json":
+		return json.NewEncoder(o.IOStreams.Out).Encode(report)
+	case "yaml":
+		return yaml.NewEncoder(o.IOStreams.Out).Encode(report)
+	default:
+		return o.printTrends(o.IOStreams.Out, report)
+	}
+}
+
+// printTrends prints the history report in text format
+func (o *clusterSyncFailuresOptions) printTrends(w io.Writer, report clusterSyncTrends) error {
+	if len(report.Failures) == 0 {
+		fmt.Fprintln(w, "No failing syncsets")
+	} else {
+		p := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+		if !o.noHeaders {
+			p.AddRow([]string{"NAMESPACE", "SYNCSET", "FIRST SEEN", "PERSISTED", "RUNS", "REMEDIATION"})
+		}
+		for _, f := range report.Failures {
+			p.AddRow([]string{f.Namespace, f.SyncSet, f.FirstSeen.Format(time.RFC3339), f.Persisted, strconv.Itoa(f.Runs), f.Remediation})
+		}
+		if err := p.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(report.FleetWide) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w, "\nFailing syncsets across recorded shards:")
+	p := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	if !o.noHeaders {
+		p.AddRow([]string{"SYNCSET", "CLUSTERS", "SHARDS"})
+	}
+	for _, f := range report.FleetWide {
+		p.AddRow([]string{f.SyncSet, strconv.Itoa(f.Clusters), strconv.Itoa(f.Shards)})
+	}
+	return p.Flush()
+}
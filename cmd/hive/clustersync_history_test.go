@@ -0,0 +1,127 @@
+package hive
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRemediationHint(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{
+			message:  `admission webhook "validate.example.com" denied the request: not allowed`,
+			expected: "webhook-denied",
+		},
+		{
+			message:  `Internal error occurred: failed calling webhook "validate.example.com": connection refused`,
+			expected: "webhook-unavailable",
+		},
+		{
+			message:  `no matches for kind "ServiceMonitor" in version "monitoring.coreos.com/v1"`,
+			expected: "crd-missing",
+		},
+		{
+			message:  `pods "example" is forbidden: exceeded quota: compute-resources`,
+			expected: "quota-exceeded",
+		},
+		{
+			message:  `namespaces "openshift-example" not found`,
+			expected: "namespace-missing",
+		},
+		{
+			message:  "something unexpected",
+			expected: "",
+		},
+	}
+
+	hints := map[string]string{}
+	for _, rule := range clusterSyncRemediationRules {
+		hints[rule.name] = rule.hint
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, hints[tt.expected], remediationHint(tt.message))
+		})
+	}
+
+	assert.Equal(t, hints["crd-missing"], remediationHints([]string{
+		"something unexpected",
+		`no matches for kind "A"`,
+		`no matches for kind "B"`,
+	}))
+}
+
+func TestClusterSyncHistory(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failure := func(namespace string, syncSets ...string) failingClusterSync {
+		cs := failingClusterSync{Name: "clustersync", Namespace: namespace}
+		for _, ss := range syncSets {
+			cs.syncSets = append(cs.syncSets, syncSetFailure{Name: ss, Message: `no matches for kind "Example"`})
+		}
+		return cs
+	}
+
+	history := &clusterSyncHistory{Shards: map[string]*shardClusterSyncHistory{}}
+	history.record("shard-a", []failingClusterSync{failure("uhc-1", "ss-a", "ss-b"), failure("uhc-2", "ss-a")}, start)
+	history.record("shard-b", []failingClusterSync{failure("uhc-3", "ss-a")}, start)
+	// ss-b recovers on uhc-1, uhc-4 starts failing
+	history.record("shard-a", []failingClusterSync{failure("uhc-1", "ss-a"), failure("uhc-4", "ss-c")}, start.Add(2*time.Hour))
+
+	report := history.trends("shard-a", start.Add(3*time.Hour))
+	require.Len(t, report.Failures, 2)
+	assert.Equal(t, "uhc-1", report.Failures[0].Namespace)
+	assert.Equal(t, "ss-a", report.Failures[0].SyncSet)
+	assert.Equal(t, 2, report.Failures[0].Runs)
+	assert.Equal(t, "3h", report.Failures[0].Persisted)
+	assert.NotEmpty(t, report.Failures[0].Remediation)
+	assert.Equal(t, "uhc-4", report.Failures[1].Namespace)
+	assert.Equal(t, 1, report.Failures[1].Runs)
+	assert.Equal(t, "60m", report.Failures[1].Persisted)
+
+	assert.Equal(t, []fleetSyncSetFailure{
+		{SyncSet: "ss-a", Clusters: 2, Shards: 2},
+		{SyncSet: "ss-c", Clusters: 1, Shards: 1},
+	}, report.FleetWide)
+
+	// Shards which were not checked recently don't count towards fleet-wide failures
+	report = history.trends("shard-a", start.Add(clusterSyncHistoryStaleAfter+3*time.Hour))
+	assert.Equal(t, []fleetSyncSetFailure{}, report.FleetWide)
+}
+
+func TestRunHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failures := []failingClusterSync{
+		{Name: "clustersync", Namespace: "uhc-1", syncSets: []syncSetFailure{{Name: "ss-a", Message: "failed"}}},
+		{Name: "clustersync", Namespace: "uhc-2", Hibernating: true, syncSets: []syncSetFailure{{Name: "ss-a", Message: "failed"}}},
+	}
+
+	stdout := &bytes.Buffer{}
+	o := &clusterSyncFailuresOptions{
+		output:      "text",
+		historyFile: path,
+		shard:       "https://api.hive-shard:6443",
+		IOStreams:   genericclioptions.IOStreams{Out: stdout},
+	}
+	require.NoError(t, o.runHistory(failures, start))
+	require.NoError(t, o.runHistory(failures, start.Add(time.Hour)))
+
+	history, err := loadClusterSyncHistory(path)
+	require.NoError(t, err)
+	require.Len(t, history.Shards[o.shard].Failures, 1, "hibernating clusters should not be recorded")
+	assert.Equal(t, 2, history.Shards[o.shard].Failures[0].Runs)
+	assert.Contains(t, stdout.String(), "Failing syncsets across recorded shards:")
+
+	empty, err := loadClusterSyncHistory(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, empty.Shards)
+}
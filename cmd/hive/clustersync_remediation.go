@@ -0,0 +1,87 @@
+package hive
+
+import (
+	"regexp"
+	"strings"
+)
+
+// remediationRule maps SyncSet failure messages matching a pattern to a suggested remediation
+type remediationRule struct {
+	name    string
+	pattern *regexp.Regexp
+	hint    string
+}
+
+// clusterSyncRemediationRules are evaluated in order, the first matching rule provides the remediation hint
+var clusterSyncRemediationRules = []remediationRule{
+	{
+		name:    "webhook-unavailable",
+		pattern: regexp.MustCompile(`failed calling webhook`),
+		hint:    "An admission webhook on the cluster is unreachable. Check the webhook's service and pods, a broken customer webhook blocks all resources it matches.",
+	},
+	{
+		name:    "webhook-denied",
+		pattern: regexp.MustCompile(`admission webhook "[^"]*" denied the request|denied the request`),
+		hint:    "An admission webhook on the cluster rejected the resource. Check for customer webhooks with 'oc get validatingwebhookconfigurations,mutatingwebhookconfigurations'.",
+	},
+	{
+		name:    "crd-missing",
+		pattern: regexp.MustCompile(`no matches for kind|ensure CRDs are installed first|the server could not find the requested resource`),
+		hint:    "The resource's CRD is not installed on the cluster. Check that the operator providing it is installed and its CSV succeeded.",
+	},
+	{
+		name:    "quota-exceeded",
+		pattern: regexp.MustCompile(`exceeded quota`),
+		hint:    "A ResourceQuota in the target namespace blocks the resource. Review it with 'oc describe resourcequota -n <namespace>', it may have been created by the customer.",
+	},
+	{
+		name:    "namespace-terminating",
+		pattern: regexp.MustCompile(`because it is being terminated`),
+		hint:    "The target namespace is terminating. Check for finalizers blocking its deletion.",
+	},
+	{
+		name:    "namespace-missing",
+		pattern: regexp.MustCompile(`namespaces? "[^"]+" not found`),
+		hint:    "The target namespace doesn't exist. Check whether it was deleted or whether the SyncSet creating it is failing too.",
+	},
+	{
+		name:    "immutable-field",
+		pattern: regexp.MustCompile(`field is immutable`),
+		hint:    "An immutable field differs from the resource on the cluster. The resource has to be deleted on the cluster so hive can recreate it.",
+	},
+	{
+		name:    "api-unreachable",
+		pattern: regexp.MustCompile(`connection refused|i/o timeout|no such host|Unable to connect|context deadline exceeded`),
+		hint:    "Hive could not reach the cluster's API server. Check whether the cluster is hibernating, in limited support or has an unhealthy API.",
+	},
+	{
+		name:    "unauthorized",
+		pattern: regexp.MustCompile(`Unauthorized|certificate has expired|x509:`),
+		hint:    "The cluster rejected hive's credentials. Check the ClusterDeployment's admin kubeconfig secret and the cluster's certificates.",
+	},
+}
+
+// remediationHint returns the remediation of the first rule matching a failure message, or an empty string
+func remediationHint(message string) string {
+	for _, rule := range clusterSyncRemediationRules {
+		if rule.pattern.MatchString(message) {
+			return rule.hint
+		}
+	}
+	return ""
+}
+
+// remediationHints returns the distinct remediations for a set of failure messages, in order
+func remediationHints(messages []string) string {
+	var hints []string
+	seen := map[string]bool{}
+	for _, message := range messages {
+		hint := remediationHint(message)
+		if hint == "" || seen[hint] {
+			continue
+		}
+		seen[hint] = true
+		hints = append(hints, hint)
+	}
+	return strings.Join(hints, " ")
+}
@@ -169,6 +169,10 @@ func TestListFailingClusterSyncs(t *testing.T) {
 					Hibernating:     true,
 					FailingSyncSets: "selectorsyncset1 syncset1 ",
 					ErrorMessage:    "Failed to sync selectorsyncset1\n\nFailed to sync syncset1\n\n",
+					syncSets: []syncSetFailure{
+						{Name: "selectorsyncset1", Message: "Failed to sync selectorsyncset1"},
+						{Name: "syncset1", Message: "Failed to sync syncset1"},
+					},
 				},
 			},
 			isEmpty: false,
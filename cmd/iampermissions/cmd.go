@@ -18,6 +18,14 @@ func NewCmdIamPermissions() *cobra.Command {
 	var iamPermissionsCommand = &cobra.Command{
 		Use:   "iampermissions",
 		Short: "STS/WIF utilities",
+		Long: `STS/WIF utilities
+
+Versions can be given as OpenShift version (e.g. 4.16.0) or release image pullspec, which are extracted
+with 'oc adm release extract'. They can also be given as a local path prefixed with '` + policies.LocalReleasePrefix + `', either to a
+release payload directory (containing release-manifests/) or to an OCI image layout of the release image,
+as directory or tarball (e.g. created with 'oc image mirror' or 'skopeo copy ... oci-archive:release.tar'),
+e.g. ` + policies.LocalReleasePrefix + `./release.tar. Local paths are read without oc or network access, and CredentialsRequests
+extracted from OCI image layouts are cached by the digest of the release image.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			err := cmd.Help()
 			if err != nil {
@@ -31,6 +39,7 @@ func NewCmdIamPermissions() *cobra.Command {
 	iamPermissionsCommand.AddCommand(newCmdGet())
 	iamPermissionsCommand.AddCommand(newCmdDiff())
 	iamPermissionsCommand.AddCommand(newCmdSave())
+	iamPermissionsCommand.AddCommand(newCmdVerify())
 
 	return iamPermissionsCommand
 }
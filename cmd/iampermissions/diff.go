@@ -1,11 +1,13 @@
 package iampermissions
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
+	"strings"
 
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	"github.com/openshift/osdctl/pkg/policies"
 	"github.com/spf13/cobra"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -15,26 +17,40 @@ type diffOptions struct {
 	BaseVersion   string
 	TargetVersion string
 	Cloud         policies.CloudSpec
+	Output        string
 	downloadFunc  func(string, policies.CloudSpec) (string, error)
-	execFunc      func(string, ...string) *exec.Cmd
+	parseFunc     func(string) ([]*cco.CredentialsRequest, error)
 	outputWriter  io.Writer
 }
 
 const (
 	baseVersionFlagName   = "base-version"
 	targetVersionFlagName = "target-version"
+
+	diffOutputText     = "text"
+	diffOutputJSON     = "json"
+	diffOutputMarkdown = "markdown"
 )
 
 func newCmdDiff() *cobra.Command {
 	ops := &diffOptions{
 		downloadFunc: policies.DownloadCredentialRequests,
-		execFunc:     exec.Command,
+		parseFunc:    policies.ParseCredentialsRequestsInDir,
 		outputWriter: os.Stdout,
 	}
 
 	policyCmd := &cobra.Command{
-		Use:               "diff",
-		Short:             "Diff IAM permissions for cluster operators between two versions",
+		Use:   "diff",
+		Short: "Diff IAM permissions for cluster operators between two versions",
+		Long: `Diff IAM permissions for cluster operators between two versions.
+
+Shows per CredentialsRequest which actions were added or removed, which actions are granted with a different
+effect, resource or condition, and which CredentialsRequests are new or were removed in the target version.`,
+		Example: `  # Show the AWS permission changes between two versions
+  osdctl iampermissions diff -c aws -b 4.15.0 -t 4.16.0
+
+  # Write a markdown report to attach to an upgrade readiness review
+  osdctl iampermissions diff -c wif -b 4.15.0 -t 4.16.0 -o markdown > iam-diff.md`,
 		Args:              cobra.ExactArgs(0),
 		DisableAutoGenTag: true,
 		Run: func(cmd *cobra.Command, args []string) {
@@ -45,6 +61,7 @@ func newCmdDiff() *cobra.Command {
 
 	policyCmd.Flags().StringVarP(&ops.BaseVersion, baseVersionFlagName, "b", "", "")
 	policyCmd.Flags().StringVarP(&ops.TargetVersion, targetVersionFlagName, "t", "", "")
+	policyCmd.Flags().StringVarP(&ops.Output, "output", "o", diffOutputText, "Output format: text, json or markdown")
 	_ = policyCmd.MarkFlagRequired(baseVersionFlagName)
 	_ = policyCmd.MarkFlagRequired(targetVersionFlagName)
 
@@ -52,21 +69,133 @@ func newCmdDiff() *cobra.Command {
 }
 
 func (o *diffOptions) run() error {
-	fmt.Fprintf(os.Stderr, "Downloading Credential Requests for %s\n", o.BaseVersion)
-	baseDir, err := o.downloadFunc(o.BaseVersion, o.Cloud)
+	if o.Output != diffOutputText && o.Output != diffOutputJSON && o.Output != diffOutputMarkdown {
+		return fmt.Errorf("invalid output format %q, must be one of %s, %s or %s", o.Output, diffOutputText, diffOutputJSON, diffOutputMarkdown)
+	}
+
+	base, err := o.credentialsRequests(o.BaseVersion)
+	if err != nil {
+		return err
+	}
+
+	target, err := o.credentialsRequests(o.TargetVersion)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(os.Stderr, "Downloading Credential Requests for %s\n", o.TargetVersion)
-	targetDir, err := o.downloadFunc(o.TargetVersion, o.Cloud)
+	diff, err := policies.DiffCredentialsRequests(o.Cloud, base, target)
 	if err != nil {
 		return err
 	}
+	diff.BaseVersion = o.BaseVersion
+	diff.TargetVersion = o.TargetVersion
 
-	cmd := o.execFunc("diff", baseDir, targetDir)
-	output, _ := cmd.CombinedOutput() // #nosec G204
-	fmt.Fprintln(o.outputWriter, string(output))
+	switch o.Output {
+	case diffOutputJSON:
+		out, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("couldn't marshal permissions diff: %w", err)
+		}
+		_, err = fmt.Fprintln(o.outputWriter, string(out))
+		return err
+	case diffOutputMarkdown:
+		return printDiffMarkdown(o.outputWriter, diff)
+	default:
+		return printDiffText(o.outputWriter, diff)
+	}
+}
 
-	return nil
+func (o *diffOptions) credentialsRequests(version string) ([]*cco.CredentialsRequest, error) {
+	fmt.Fprintf(os.Stderr, "Downloading Credential Requests for %s\n", version)
+	dir, err := o.downloadFunc(version, o.Cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	crs, err := o.parseFunc(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Credential Requests for %s: %w", version, err)
+	}
+	return crs, nil
+}
+
+func printDiffText(w io.Writer, diff *policies.PermissionsDiff) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "IAM permission changes for %s from %s to %s\n", diff.Cloud, diff.BaseVersion, diff.TargetVersion)
+	if diff.IsEmpty() {
+		b.WriteString("\nNo permission changes\n")
+	}
+
+	for _, cr := range diff.CredentialsRequests {
+		fmt.Fprintf(&b, "\nCredentialsRequest %s (%s)\n", cr.Name, cr.Status)
+		for _, action := range cr.AddedActions {
+			fmt.Fprintf(&b, "  + %s\n", action)
+		}
+		for _, action := range cr.RemovedActions {
+			fmt.Fprintf(&b, "  - %s\n", action)
+		}
+		for _, change := range cr.ChangedScopes {
+			fmt.Fprintf(&b, "  ~ %s\n", change.Action)
+			for _, scope := range change.Base {
+				fmt.Fprintf(&b, "      - %s\n", scope)
+			}
+			for _, scope := range change.Target {
+				fmt.Fprintf(&b, "      + %s\n", scope)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func printDiffMarkdown(w io.Writer, diff *policies.PermissionsDiff) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# IAM permission changes for %s from %s to %s\n\n", diff.Cloud, diff.BaseVersion, diff.TargetVersion)
+	if diff.IsEmpty() {
+		b.WriteString("No permission changes.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	b.WriteString("| CredentialsRequest | Status | Added actions | Removed actions | Changed scopes |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, cr := range diff.CredentialsRequests {
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %d |\n", cr.Name, cr.Status, len(cr.AddedActions), len(cr.RemovedActions), len(cr.ChangedScopes))
+	}
+
+	for _, cr := range diff.CredentialsRequests {
+		fmt.Fprintf(&b, "\n## %s (%s)\n", cr.Name, cr.Status)
+		if len(cr.AddedActions) > 0 {
+			b.WriteString("\n**Added actions**\n\n")
+			for _, action := range cr.AddedActions {
+				fmt.Fprintf(&b, "- `%s`\n", action)
+			}
+		}
+		if len(cr.RemovedActions) > 0 {
+			b.WriteString("\n**Removed actions**\n\n")
+			for _, action := range cr.RemovedActions {
+				fmt.Fprintf(&b, "- `%s`\n", action)
+			}
+		}
+		if len(cr.ChangedScopes) > 0 {
+			b.WriteString("\n**Changed scopes**\n\n")
+			b.WriteString("| Action | Before | After |\n")
+			b.WriteString("|---|---|---|\n")
+			for _, change := range cr.ChangedScopes {
+				fmt.Fprintf(&b, "| `%s` | %s | %s |\n", change.Action, markdownScopes(change.Base), markdownScopes(change.Target))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownScopes(scopes []string) string {
+	quoted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		quoted = append(quoted, "`"+strings.ReplaceAll(scope, "|", "\\|")+"`")
+	}
+	return strings.Join(quoted, "<br>")
 }
@@ -2,33 +2,90 @@ package iampermissions
 
 import (
 	"bytes"
-	"os/exec"
+	"encoding/json"
 	"testing"
 
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	"github.com/openshift/osdctl/pkg/policies"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func TestRunSuccess(t *testing.T) {
-	mockDownload := func(version string, cloud policies.CloudSpec) (string, error) {
-		return "/mock/path/" + version, nil
+func newTestCredentialsRequest(t *testing.T, name string, actions ...string) *cco.CredentialsRequest {
+	spec, err := cco.Codec.EncodeProviderSpec(&cco.AWSProviderSpec{
+		TypeMeta:         metav1.TypeMeta{APIVersion: "cloudcredential.openshift.io/v1", Kind: "AWSProviderSpec"},
+		StatementEntries: []cco.StatementEntry{{Effect: "Allow", Action: actions, Resource: "*"}},
+	})
+	assert.NoError(t, err)
+	return &cco.CredentialsRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       cco.CredentialsRequestSpec{ProviderSpec: spec},
 	}
+}
 
-	mockExec := func(command string, args ...string) *exec.Cmd {
-		return exec.Command("echo", "mock diff output")
+func newTestDiffOptions(t *testing.T, output string, outputBuffer *bytes.Buffer) *diffOptions {
+	crs := map[string][]*cco.CredentialsRequest{
+		"/mock/path/v1": {newTestCredentialsRequest(t, "openshift-ingress", "route53:ListHostedZones", "route53:ChangeResourceRecordSets")},
+		"/mock/path/v2": {newTestCredentialsRequest(t, "openshift-ingress", "route53:ListHostedZones", "tag:GetResources")},
 	}
 
-	var outputBuffer bytes.Buffer
-
-	o := &diffOptions{
+	return &diffOptions{
 		BaseVersion:   "v1",
 		TargetVersion: "v2",
 		Cloud:         policies.AWS,
-		downloadFunc:  mockDownload,
-		execFunc:      mockExec,
-		outputWriter:  &outputBuffer,
+		Output:        output,
+		downloadFunc: func(version string, cloud policies.CloudSpec) (string, error) {
+			return "/mock/path/" + version, nil
+		},
+		parseFunc: func(dir string) ([]*cco.CredentialsRequest, error) {
+			return crs[dir], nil
+		},
+		outputWriter: outputBuffer,
 	}
+}
+
+func TestRunSuccess(t *testing.T) {
+	var outputBuffer bytes.Buffer
+
+	o := newTestDiffOptions(t, diffOutputText, &outputBuffer)
 	err := o.run()
 	assert.NoError(t, err)
-	assert.Contains(t, outputBuffer.String(), "mock diff output")
+	assert.Contains(t, outputBuffer.String(), "CredentialsRequest openshift-ingress (changed)")
+	assert.Contains(t, outputBuffer.String(), "  + tag:GetResources")
+	assert.Contains(t, outputBuffer.String(), "  - route53:ChangeResourceRecordSets")
+}
+
+func TestRunJSON(t *testing.T) {
+	var outputBuffer bytes.Buffer
+
+	o := newTestDiffOptions(t, diffOutputJSON, &outputBuffer)
+	assert.NoError(t, o.run())
+
+	diff := &policies.PermissionsDiff{}
+	assert.NoError(t, json.Unmarshal(outputBuffer.Bytes(), diff))
+	assert.Equal(t, "v1", diff.BaseVersion)
+	assert.Equal(t, "v2", diff.TargetVersion)
+	assert.Len(t, diff.CredentialsRequests, 1)
+	assert.Equal(t, []string{"tag:GetResources"}, diff.CredentialsRequests[0].AddedActions)
+}
+
+func TestRunMarkdown(t *testing.T) {
+	var outputBuffer bytes.Buffer
+
+	o := newTestDiffOptions(t, diffOutputMarkdown, &outputBuffer)
+	assert.NoError(t, o.run())
+	assert.Contains(t, outputBuffer.String(), "# IAM permission changes for aws from v1 to v2")
+	assert.Contains(t, outputBuffer.String(), "| openshift-ingress | changed | 1 | 1 | 0 |")
+	assert.Contains(t, outputBuffer.String(), "- `tag:GetResources`")
+}
+
+func TestRunInvalidOutput(t *testing.T) {
+	var outputBuffer bytes.Buffer
+
+	o := newTestDiffOptions(t, "yaml", &outputBuffer)
+	assert.Error(t, o.run())
+}
+
+func TestMarkdownScopes(t *testing.T) {
+	assert.Equal(t, "`Allow on *`<br>`Deny on a\\|b`", markdownScopes([]string{"Allow on *", "Deny on a|b"}))
 }
@@ -0,0 +1,310 @@
+package iampermissions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	"github.com/openshift/osdctl/pkg/osdCloud"
+	"github.com/openshift/osdctl/pkg/policies"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/provider/aws"
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+const (
+	roleStatusOK      = "ok"
+	roleStatusMissing = "missing permissions"
+	roleStatusNoRole  = "no operator role"
+)
+
+type verifyOptions struct {
+	ClusterID string
+	Version   string
+	Cloud     policies.CloudSpec
+	Output    string
+
+	// Injected for testability
+	downloadFunc func(string, policies.CloudSpec) (string, error)
+	parseFunc    func(string) ([]*cco.CredentialsRequest, error)
+	outputWriter io.Writer
+}
+
+// operatorRoleResult is the outcome of verifying the operator role of a single CredentialsRequest
+type operatorRoleResult struct {
+	CredentialsRequest string   `json:"credentialsRequest"`
+	Namespace          string   `json:"namespace"`
+	SecretName         string   `json:"secretName"`
+	RoleARN            string   `json:"roleARN,omitempty"`
+	Status             string   `json:"status"`
+	MissingActions     []string `json:"missingActions,omitempty"`
+}
+
+type verifyResult struct {
+	ClusterID string               `json:"clusterID"`
+	Version   string               `json:"version"`
+	Roles     []operatorRoleResult `json:"roles"`
+}
+
+func newCmdVerify() *cobra.Command {
+	ops := &verifyOptions{
+		downloadFunc: policies.DownloadCredentialRequests,
+		parseFunc:    policies.ParseCredentialsRequestsInDir,
+		outputWriter: os.Stdout,
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify a STS cluster's operator roles grant the IAM permissions required by a version",
+		Long: `Verify a STS cluster's operator roles grant the IAM permissions required by a version.
+
+The operator role ARNs are resolved from OCM and the policies attached to, and inlined in, each role are
+fetched from the customer's AWS account. Every action allowed by the version's CredentialsRequests which
+isn't allowed by the role's policies, or which is denied on all resources, is reported as missing.
+Resource scopes and conditions of the role's Allow statements are not evaluated.
+
+By default the cluster's current version is verified. Pass --version to verify an upgrade target.`,
+		Example: `  # Verify the operator roles against the cluster's current version
+  osdctl iampermissions verify -C my-cluster
+
+  # Verify the operator roles before upgrading to 4.16.0
+  osdctl iampermissions verify -C my-cluster --version 4.16.0`,
+		Args:              cobra.ExactArgs(0),
+		DisableAutoGenTag: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			ops.Cloud = *cmd.Flag(cloudFlagName).Value.(*policies.CloudSpec)
+			cmdutil.CheckErr(ops.run(cmd.Context()))
+		},
+	}
+
+	verifyCmd.Flags().StringVarP(&ops.ClusterID, "cluster-id", "C", "", "Cluster ID (internal, external or name) of the STS cluster")
+	verifyCmd.Flags().StringVarP(&ops.Version, "version", "r", "", "OpenShift version or release image to verify against. Defaults to the cluster's current version")
+	verifyCmd.Flags().StringVarP(&ops.Output, "output", "o", "text", "Output format: text or json")
+	_ = verifyCmd.MarkFlagRequired("cluster-id")
+
+	return verifyCmd
+}
+
+func (o *verifyOptions) run(ctx context.Context) error {
+	if o.Cloud != policies.AWS {
+		return fmt.Errorf("verify is only supported for AWS STS clusters")
+	}
+	if o.Output != "text" && o.Output != "json" {
+		return fmt.Errorf("invalid output format %q, must be text or json", o.Output)
+	}
+
+	ocmClient, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	defer ocmClient.Close()
+
+	cluster, err := utils.GetCluster(ocmClient, o.ClusterID)
+	if err != nil {
+		return err
+	}
+	if !cluster.AWS().STS().Enabled() {
+		return fmt.Errorf("cluster %s is not a STS cluster", cluster.ID())
+	}
+
+	version := o.Version
+	if version == "" {
+		version = cluster.Version().RawID()
+	}
+
+	fmt.Fprintf(os.Stderr, "Downloading Credential Requests for %s\n", version)
+	dir, err := o.downloadFunc(version, o.Cloud)
+	if err != nil {
+		return err
+	}
+	crs, err := o.parseFunc(dir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := osdCloud.CreateAWSV2Config(ocmClient, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get AWS credentials for cluster %s: %w", cluster.ID(), err)
+	}
+	awsClient := aws.NewAwsContextClientFromConfig(cfg)
+
+	result, err := verifyOperatorRoles(ctx, awsClient, crs, cluster.AWS().STS().OperatorIAMRoles())
+	if err != nil {
+		return err
+	}
+	result.ClusterID = cluster.ID()
+	result.Version = version
+
+	if o.Output == "json" {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("couldn't marshal verification result: %w", err)
+		}
+		fmt.Fprintln(o.outputWriter, string(out))
+	} else if err := printVerifyResult(o.outputWriter, result); err != nil {
+		return err
+	}
+
+	if failed := result.failedRoles(); failed > 0 {
+		return fmt.Errorf("%d operator role(s) lack permissions required by %s", failed, version)
+	}
+	return nil
+}
+
+// verifyOperatorRoles checks the operator role of every CredentialsRequest for missing permissions
+func verifyOperatorRoles(ctx context.Context, awsClient aws.ContextClient, crs []*cco.CredentialsRequest, operatorRoles []*cmv1.OperatorIAMRole) (*verifyResult, error) {
+	result := &verifyResult{Roles: []operatorRoleResult{}}
+	for _, cr := range crs {
+		roleResult := operatorRoleResult{
+			CredentialsRequest: cr.Name,
+			Namespace:          cr.Spec.SecretRef.Namespace,
+			SecretName:         cr.Spec.SecretRef.Name,
+			Status:             roleStatusNoRole,
+		}
+
+		role := findOperatorRole(operatorRoles, cr.Spec.SecretRef.Namespace, cr.Spec.SecretRef.Name)
+		if role == nil {
+			result.Roles = append(result.Roles, roleResult)
+			continue
+		}
+		roleResult.RoleARN = role.RoleARN()
+
+		required, err := policies.RequiredAWSActions(cr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CredentialsRequest '%s': %w", cr.Name, err)
+		}
+
+		roleName, err := roleNameFromARN(role.RoleARN())
+		if err != nil {
+			return nil, err
+		}
+		docs, err := rolePolicyDocuments(ctx, awsClient, roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		roleResult.MissingActions = policies.MissingActions(required, docs)
+		roleResult.Status = roleStatusOK
+		if len(roleResult.MissingActions) > 0 {
+			roleResult.Status = roleStatusMissing
+		}
+		result.Roles = append(result.Roles, roleResult)
+	}
+	return result, nil
+}
+
+func findOperatorRole(operatorRoles []*cmv1.OperatorIAMRole, namespace, name string) *cmv1.OperatorIAMRole {
+	for _, role := range operatorRoles {
+		if role.Namespace() == namespace && role.Name() == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// roleNameFromARN returns the role name of a role ARN, e.g. arn:aws:iam::123:role/path/name -> name
+func roleNameFromARN(roleARN string) (string, error) {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return "", fmt.Errorf("invalid operator role ARN %s: %w", roleARN, err)
+	}
+	parts := strings.Split(parsed.Resource, "/")
+	return parts[len(parts)-1], nil
+}
+
+// rolePolicyDocuments returns the default versions of the managed policies attached to the role,
+// followed by the role's inline policies
+func rolePolicyDocuments(ctx context.Context, awsClient aws.ContextClient, roleName string) ([]*policies.IAMPolicyDocument, error) {
+	docs := []*policies.IAMPolicyDocument{}
+
+	var marker *string
+	for {
+		attached, err := awsClient.ListAttachedRolePolicies(ctx, &iam.ListAttachedRolePoliciesInput{RoleName: &roleName, Marker: marker})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attached policies of role %s: %w", roleName, err)
+		}
+		for _, attachedPolicy := range attached.AttachedPolicies {
+			policy, err := awsClient.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: attachedPolicy.PolicyArn})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get policy %s: %w", awsSdk.ToString(attachedPolicy.PolicyArn), err)
+			}
+			version, err := awsClient.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{PolicyArn: attachedPolicy.PolicyArn, VersionId: policy.Policy.DefaultVersionId})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get default version of policy %s: %w", awsSdk.ToString(attachedPolicy.PolicyArn), err)
+			}
+			doc, err := policies.ParseIAMPolicyDocument(awsSdk.ToString(version.PolicyVersion.Document))
+			if err != nil {
+				return nil, fmt.Errorf("policy %s: %w", awsSdk.ToString(attachedPolicy.PolicyArn), err)
+			}
+			docs = append(docs, doc)
+		}
+		if !attached.IsTruncated {
+			break
+		}
+		marker = attached.Marker
+	}
+
+	marker = nil
+	for {
+		inline, err := awsClient.ListRolePolicies(ctx, &iam.ListRolePoliciesInput{RoleName: &roleName, Marker: marker})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list inline policies of role %s: %w", roleName, err)
+		}
+		for _, policyName := range inline.PolicyNames {
+			policy, err := awsClient.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: &roleName, PolicyName: awsSdk.String(policyName)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get inline policy %s of role %s: %w", policyName, roleName, err)
+			}
+			doc, err := policies.ParseIAMPolicyDocument(awsSdk.ToString(policy.PolicyDocument))
+			if err != nil {
+				return nil, fmt.Errorf("inline policy %s of role %s: %w", policyName, roleName, err)
+			}
+			docs = append(docs, doc)
+		}
+		if !inline.IsTruncated {
+			break
+		}
+		marker = inline.Marker
+	}
+
+	return docs, nil
+}
+
+func (r *verifyResult) failedRoles() int {
+	failed := 0
+	for _, role := range r.Roles {
+		if role.Status == roleStatusMissing {
+			failed++
+		}
+	}
+	return failed
+}
+
+func printVerifyResult(w io.Writer, result *verifyResult) error {
+	fmt.Fprintf(w, "Operator roles of cluster %s verified against %s\n\n", result.ClusterID, result.Version)
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"Namespace", "Secret", "Role ARN", "Status", "Missing Actions"})
+	for _, role := range result.Roles {
+		missing := "-"
+		if len(role.MissingActions) > 0 {
+			missing = strings.Join(role.MissingActions, ", ")
+		}
+		roleARN := role.RoleARN
+		if roleARN == "" {
+			roleARN = "-"
+		}
+		table.AddRow([]string{role.Namespace, role.SecretName, roleARN, role.Status, missing})
+	}
+	return table.Flush()
+}
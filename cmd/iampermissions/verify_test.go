@@ -0,0 +1,90 @@
+package iampermissions
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	"github.com/openshift/osdctl/pkg/provider/aws/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestOperatorRole(t *testing.T, namespace, name, roleARN string) *cmv1.OperatorIAMRole {
+	role, err := cmv1.NewOperatorIAMRole().Namespace(namespace).Name(name).RoleARN(roleARN).Build()
+	assert.NoError(t, err)
+	return role
+}
+
+func TestVerifyOperatorRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockAWSClient := mock.NewMockContextClient(ctrl)
+
+	ingress := newTestCredentialsRequest(t, "openshift-ingress", "route53:ListHostedZones", "tag:GetResources")
+	ingress.Spec.SecretRef.Namespace = "openshift-ingress-operator"
+	ingress.Spec.SecretRef.Name = "cloud-credentials"
+
+	disabled := newTestCredentialsRequest(t, "openshift-disabled-capability", "s3:GetObject")
+	disabled.Spec.SecretRef.Namespace = "openshift-disabled"
+	disabled.Spec.SecretRef.Name = "cloud-credentials"
+
+	roles := []*cmv1.OperatorIAMRole{
+		newTestOperatorRole(t, "openshift-ingress-operator", "cloud-credentials", "arn:aws:iam::123456789012:role/prefix-openshift-ingress-operator-cloud-credentials"),
+	}
+
+	roleName := "prefix-openshift-ingress-operator-cloud-credentials"
+	policyArn := "arn:aws:iam::123456789012:policy/prefix-openshift-ingress-operator-cloud-credentials"
+	mockAWSClient.EXPECT().ListAttachedRolePolicies(gomock.Any(), &iam.ListAttachedRolePoliciesInput{RoleName: &roleName}).Return(&iam.ListAttachedRolePoliciesOutput{
+		AttachedPolicies: []iamTypes.AttachedPolicy{{PolicyArn: &policyArn}},
+	}, nil)
+	mockAWSClient.EXPECT().GetPolicy(gomock.Any(), gomock.Any()).Return(&iam.GetPolicyOutput{
+		Policy: &iamTypes.Policy{DefaultVersionId: awsSdk.String("v2")},
+	}, nil)
+	mockAWSClient.EXPECT().GetPolicyVersion(gomock.Any(), &iam.GetPolicyVersionInput{PolicyArn: &policyArn, VersionId: awsSdk.String("v2")}).Return(&iam.GetPolicyVersionOutput{
+		PolicyVersion: &iamTypes.PolicyVersion{Document: awsSdk.String(url.QueryEscape(`{"Statement":[{"Effect":"Allow","Action":"route53:List*","Resource":"*"}]}`))},
+	}, nil)
+	mockAWSClient.EXPECT().ListRolePolicies(gomock.Any(), gomock.Any()).Return(&iam.ListRolePoliciesOutput{PolicyNames: []string{"inline"}}, nil)
+	mockAWSClient.EXPECT().GetRolePolicy(gomock.Any(), gomock.Any()).Return(&iam.GetRolePolicyOutput{
+		PolicyDocument: awsSdk.String(url.QueryEscape(`{"Statement":{"Effect":"Allow","Action":"ec2:DescribeInstances","Resource":"*"}}`)),
+	}, nil)
+
+	result, err := verifyOperatorRoles(context.Background(), mockAWSClient, []*cco.CredentialsRequest{ingress, disabled}, roles)
+	assert.NoError(t, err)
+	assert.Equal(t, []operatorRoleResult{
+		{
+			CredentialsRequest: "openshift-ingress",
+			Namespace:          "openshift-ingress-operator",
+			SecretName:         "cloud-credentials",
+			RoleARN:            "arn:aws:iam::123456789012:role/prefix-openshift-ingress-operator-cloud-credentials",
+			Status:             roleStatusMissing,
+			MissingActions:     []string{"tag:GetResources"},
+		},
+		{
+			CredentialsRequest: "openshift-disabled-capability",
+			Namespace:          "openshift-disabled",
+			SecretName:         "cloud-credentials",
+			Status:             roleStatusNoRole,
+		},
+	}, result.Roles)
+	assert.Equal(t, 1, result.failedRoles())
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, printVerifyResult(buf, result))
+	assert.Contains(t, buf.String(), "tag:GetResources")
+	assert.Contains(t, buf.String(), roleStatusNoRole)
+}
+
+func TestRoleNameFromARN(t *testing.T) {
+	name, err := roleNameFromARN("arn:aws:iam::123456789012:role/path/my-role")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-role", name)
+
+	_, err = roleNameFromARN("not-an-arn")
+	assert.Error(t, err)
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/osdctl/pkg/osdCloud"
 	"github.com/openshift/osdctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +22,23 @@ const (
 	awsResourceName     = "red-hat-sre-jumphost"
 	publicSubnetTagKey  = "kubernetes.io/role/elb"
 	privateSubnetTagKey = "kubernetes.io/role/internal-elb"
+	// clusterTagKeyPrefix prefixes the infra ID of a cluster in the tag key of the cluster's AWS resources
+	clusterTagKeyPrefix = "kubernetes.io/cluster/"
+
+	// expiresAtTagKey records when a jumphost's resources expire and may be reaped, in RFC3339
+	expiresAtTagKey = "osdctl.openshift.io/jumphost-expires-at"
+	// accessTagKey records how a jumphost is accessed, either accessSSH or accessSSM
+	accessTagKey = "osdctl.openshift.io/jumphost-access"
+
+	// checkIpURL returns the caller's public IP, which SSH to the jumphost is allowed from
+	checkIpURL     = "https://checkip.amazonaws.com"
+	checkIpTimeout = 10 * time.Second
+
+	accessSSH = "ssh"
+	accessSSM = "ssm"
+
+	// defaultTTL is the lifetime of a jumphost, also assumed for jumphosts created without an expiry tag
+	defaultTTL = 8 * time.Hour
 )
 
 func NewCmdJumphost() *cobra.Command {
@@ -29,6 +50,8 @@ func NewCmdJumphost() *cobra.Command {
 	jumphost.AddCommand(
 		newCmdCreateJumphost(),
 		newCmdDeleteJumphost(),
+		newCmdListJumphost(),
+		newCmdReapJumphost(),
 	)
 
 	return jumphost
@@ -36,12 +59,25 @@ func NewCmdJumphost() *cobra.Command {
 
 type jumphostConfig struct {
 	awsClient jumphostAWSClient
+	iamClient jumphostIAMClient
 	cluster   *cmv1.Cluster
+	region    string
 	subnetId  string
 	tags      []types.Tag
 
+	// regionalClient returns an EC2 client for another region of the same account
+	regionalClient func(region string) jumphostAWSClient
+
+	// ttl is the lifetime of a created jumphost, after which it shuts itself down and may be reaped
+	ttl time.Duration
+	// ssm creates a jumphost accessed with SSM Session Manager instead of SSH
+	ssm bool
+	// ingressCIDR is allowed to SSH to the jumphost, defaulting to the caller's public IP
+	ingressCIDR string
+
 	keyFilepath string
 	ec2PublicIp string
+	instanceId  string
 }
 
 type jumphostAWSClient interface {
@@ -55,6 +91,7 @@ type jumphostAWSClient interface {
 	DescribeSecurityGroups(ctx context.Context, params *ec2.DescribeSecurityGroupsInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error)
 
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeImagesOutput, error)
+	DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeRegionsOutput, error)
 	DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeSubnetsOutput, error)
 	DescribeInstances(ctx context.Context, params *ec2.DescribeInstancesInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeInstancesOutput, error)
 	RunInstances(ctx context.Context, params *ec2.RunInstancesInput, optFns ...func(options *ec2.Options)) (*ec2.RunInstancesOutput, error)
@@ -66,57 +103,68 @@ type jumphostAWSClient interface {
 
 // initJumphostConfig initializes a jumphostConfig struct for use with jumphost commands.
 // Generally, this function should always be used as opposed to initializing the struct by hand.
+// When a cluster ID is provided, AWS credentials for the cluster's account are retrieved from backplane,
+// otherwise the default AWS credentials are used.
 func initJumphostConfig(ctx context.Context, clusterId, subnetId string) (*jumphostConfig, error) {
-	ocm, err := utils.CreateConnection()
-	if err != nil {
-		return nil, err
+	var (
+		cfg     aws.Config
+		cluster *cmv1.Cluster
+		err     error
+	)
+
+	if clusterId != "" {
+		ocm, err := utils.CreateConnection()
+		if err != nil {
+			return nil, err
+		}
+		defer ocm.Close()
+
+		cluster, err = utils.GetClusterAnyStatus(ocm, clusterId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get OCM cluster info for %s: %s", clusterId, err)
+		}
+
+		if err := validateCluster(cluster); err != nil {
+			return nil, fmt.Errorf("cluster not supported yet - %s", err)
+		}
+
+		log.Printf("getting AWS credentials from backplane-api for %s (%s)", cluster.Name(), cluster.ID())
+		cfg, err = osdCloud.CreateAWSV2Config(ocm, cluster)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Region = cluster.Region().ID()
+	} else {
+		cfg, err = config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer ocm.Close()
-
-	//cluster, err := utils.GetClusterAnyStatus(ocm, clusterId)
-	//if err != nil {
-	//	return nil, fmt.Errorf("failed to get OCM cluster info for %s: %s", clusterId, err)
-	//}
-
-	//if err := validateCluster(cluster); err != nil {
-	//	return nil, fmt.Errorf("cluster not supported yet - %s", err)
-	//}
-	//
-	//log.Printf("getting AWS credentials from backplane-api for %s (%s)", cluster.Name(), cluster.ID())
-	//cfg, err := osdCloud.CreateAWSV2Config(ctx, cluster.ID())
-	//if err != nil {
-	//	return nil, err
-	//}
-
-	// TODO: When --cluster-id is supported, only do this as a fallback
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		return nil, err
+
+	tags := jumphostTags()
+	if cluster != nil {
+		// This tag will allow the uninstaller to clean up orphaned resources in worst-case scenarios
+		tags = append(tags, types.Tag{
+			Key:   aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", cluster.InfraID())),
+			Value: aws.String("owned"),
+		})
 	}
 
 	return &jumphostConfig{
 		awsClient: ec2.NewFromConfig(cfg),
+		iamClient: iam.NewFromConfig(cfg),
+		cluster:   cluster,
+		region:    cfg.Region,
 		subnetId:  subnetId,
-		tags: []types.Tag{
-			//{
-			//	// This tag will allow the uninstaller to clean up orphaned resources in worst-case scenarios
-			//	Key:   aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", cluster.InfraID())),
-			//	Value: aws.String("owned"),
-			//},
-			{
-				Key:   aws.String("red-hat-managed"),
-				Value: aws.String("true"),
-			},
-			{
-				Key:   aws.String("Name"),
-				Value: aws.String("red-hat-sre-jumphost"),
-			},
+		tags:      tags,
+		ttl:       defaultTTL,
+		regionalClient: func(region string) jumphostAWSClient {
+			return ec2.NewFromConfig(cfg, func(o *ec2.Options) { o.Region = region })
 		},
 	}, nil
 }
 
-// validateCluster is currently unused as the --cluster-id flag is not supported yet.
-// Eventually, it will gate the usage of the --cluster-id flag based on types of supported clusters.
+// validateCluster gates the usage of the --cluster-id flag based on types of supported clusters.
 func validateCluster(cluster *cmv1.Cluster) error {
 	if cluster != nil {
 		if cluster.CloudProvider().ID() != "aws" {
@@ -133,6 +181,20 @@ func validateCluster(cluster *cmv1.Cluster) error {
 	return errors.New("unexpected error, nil cluster provided")
 }
 
+// jumphostTags returns the tags identifying all jumphost resources in an AWS account
+func jumphostTags() []types.Tag {
+	return []types.Tag{
+		{
+			Key:   aws.String("red-hat-managed"),
+			Value: aws.String("true"),
+		},
+		{
+			Key:   aws.String("Name"),
+			Value: aws.String(awsResourceName),
+		},
+	}
+}
+
 // generateTagFilters converts a slice of expected tags to a slice of corresponding filters to search by.
 func generateTagFilters(tags []types.Tag) []types.Filter {
 	if len(tags) == 0 {
@@ -149,3 +211,39 @@ func generateTagFilters(tags []types.Tag) []types.Filter {
 
 	return filters
 }
+
+// resourceTags returns the tags of created resources, the identifying tags plus the expiry and access method
+func (j *jumphostConfig) resourceTags(now time.Time) []types.Tag {
+	access := accessSSH
+	if j.ssm {
+		access = accessSSM
+	}
+
+	tags := append([]types.Tag{}, j.tags...)
+	return append(tags,
+		types.Tag{Key: aws.String(expiresAtTagKey), Value: aws.String(now.Add(j.ttl).UTC().Format(time.RFC3339))},
+		types.Tag{Key: aws.String(accessTagKey), Value: aws.String(access)},
+	)
+}
+
+// tagValue returns the value of a tag, or an empty string if it is not set
+func tagValue(tags []types.Tag, key string) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+// expiresAt returns the expiry recorded in the tags. Resources without an expiry tag expire defaultTTL after
+// they were created, or are considered expired if their creation time is unknown.
+func expiresAt(tags []types.Tag, created *time.Time) time.Time {
+	if expiry, err := time.Parse(time.RFC3339, tagValue(tags, expiresAtTagKey)); err == nil {
+		return expiry
+	}
+	if created != nil {
+		return created.Add(defaultTTL)
+	}
+	return time.Time{}
+}
@@ -3,6 +3,7 @@ package jumphost
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -147,3 +148,53 @@ func TestValidateCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceTags(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	j := &jumphostConfig{tags: jumphostTags(), ttl: 2 * time.Hour, ssm: true}
+
+	tags := j.resourceTags(now)
+	assert.Len(t, tags, 4)
+	assert.Equal(t, "2024-01-01T14:00:00Z", tagValue(tags, expiresAtTagKey))
+	assert.Equal(t, accessSSM, tagValue(tags, accessTagKey))
+	assert.Len(t, j.tags, 2, "identifying tags should not be modified")
+}
+
+func TestExpiresAt(t *testing.T) {
+	launched := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		tags     []types.Tag
+		created  *time.Time
+		expected time.Time
+	}{
+		{
+			name:     "expiry_tag",
+			tags:     []types.Tag{{Key: aws.String(expiresAtTagKey), Value: aws.String("2024-01-01T13:00:00Z")}},
+			created:  &launched,
+			expected: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "no_expiry_tag_defaults_to_ttl_after_creation",
+			created:  &launched,
+			expected: launched.Add(defaultTTL),
+		},
+		{
+			name:     "invalid_expiry_tag",
+			tags:     []types.Tag{{Key: aws.String(expiresAtTagKey), Value: aws.String("tomorrow")}},
+			created:  &launched,
+			expected: launched.Add(defaultTTL),
+		},
+		{
+			name:     "unknown_creation_time",
+			expected: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(expiresAt(tt.tags, tt.created)))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package jumphost
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -9,19 +10,24 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/spf13/cobra"
 )
 
 func newCmdCreateJumphost() *cobra.Command {
 	var (
-		clusterId string
-		subnetId  string
+		clusterId   string
+		subnetId    string
+		ttl         time.Duration
+		ssm         bool
+		ingressCIDR string
 	)
 
 	create := &cobra.Command{
@@ -32,9 +38,22 @@ func newCmdCreateJumphost() *cobra.Command {
 
   This command automates the process of creating a jumphost in order to gain SSH
   access to a cluster's EC2 instances and should generally only be used as a last
-  resort when the cluster's API server is otherwise inaccessible. It requires valid
-  AWS credentials to be already set and a subnet ID in the associated AWS account.
-  The provided subnet ID must be a public subnet.
+  resort when the cluster's API server is otherwise inaccessible.
+
+  With --cluster-id, AWS credentials for the cluster's account are retrieved from
+  backplane and a public subnet of the cluster is discovered automatically unless
+  --subnet-id is provided. Otherwise, it requires valid AWS credentials to be
+  already set and a public subnet ID in the associated AWS account.
+
+  The jumphost's resources are tagged with the cluster, which is taken from the
+  subnet's tags when only --subnet-id is provided. This allows finding them with
+  --cluster-id and cleaning them up when the cluster is uninstalled.
+
+  The jumphost shuts itself down and is terminated once its --ttl has passed. Its
+  key pair and security group are tagged with the same expiry and can be cleaned up
+  with "osdctl jumphost reap". SSH is only allowed from the caller's public IP unless
+  --ingress-cidr is provided. With --ssm, no key pair or SSH ingress is created and
+  the jumphost is accessed with SSM Session Manager instead.
 
   When the cluster's API server is accessible, prefer "oc debug node".
 
@@ -53,6 +72,7 @@ func newCmdCreateJumphost() *cobra.Command {
           "ec2:DescribeImages",
           "ec2:DescribeInstances",
           "ec2:DescribeKeyPairs",
+          "ec2:DescribeRegions",
           "ec2:DescribeSecurityGroups",
           "ec2:DescribeSubnets",
           "ec2:RunInstances",
@@ -60,33 +80,89 @@ func newCmdCreateJumphost() *cobra.Command {
         ],
         "Effect": "Allow",
         "Resource": "*"
+      },
+      {
+        "Action": [
+          "iam:AddRoleToInstanceProfile",
+          "iam:AttachRolePolicy",
+          "iam:CreateInstanceProfile",
+          "iam:CreateRole",
+          "iam:DeleteInstanceProfile",
+          "iam:DeleteRole",
+          "iam:DetachRolePolicy",
+          "iam:GetInstanceProfile",
+          "iam:PassRole",
+          "iam:RemoveRoleFromInstanceProfile",
+          "iam:TagInstanceProfile",
+          "iam:TagRole"
+        ],
+        "Effect": "Allow",
+        "Resource": "*"
       }
     ]
-  }`,
+  }
+
+  The IAM permissions are only required with --ssm.`,
 		Example: `
   # Create and delete a jumphost
   osdctl jumphost create --subnet-id public-subnet-id
-  osdctl jumphost delete --subnet-id public-subnet-id`,
+  osdctl jumphost delete --subnet-id public-subnet-id
+
+  # Create a jumphost in a public subnet of a cluster, accessed with SSM Session Manager for 2 hours
+  osdctl jumphost create --cluster-id ${CLUSTER_ID} --ssm --ttl 2h`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			j, err := initJumphostConfig(context.TODO(), clusterId, subnetId)
+			if ttl < time.Minute {
+				return fmt.Errorf("--ttl must be at least 1m, got %s", ttl)
+			}
+			if ingressCIDR != "" {
+				if ssm {
+					return errors.New("--ingress-cidr can not be used with --ssm, no SSH ingress is created")
+				}
+				if _, _, err := net.ParseCIDR(ingressCIDR); err != nil {
+					return fmt.Errorf("invalid --ingress-cidr: %w", err)
+				}
+			}
+
+			j, err := initJumphostConfig(cmd.Context(), clusterId, subnetId)
 			if err != nil {
 				return err
 			}
+			j.ttl = ttl
+			j.ssm = ssm
+			j.ingressCIDR = ingressCIDR
 
-			return j.runCreate(context.TODO())
+			return j.runCreate(cmd.Context())
 		},
 	}
 
+	create.Flags().StringVarP(&clusterId, "cluster-id", "C", "", "cluster to create a jumphost for, discovering a public subnet and using its AWS credentials from backplane")
 	create.Flags().StringVar(&subnetId, "subnet-id", "", "public subnet id to create a jumphost in")
+	create.Flags().DurationVar(&ttl, "ttl", defaultTTL, "lifetime of the jumphost, after which it terminates itself and its resources can be reaped")
+	create.Flags().BoolVar(&ssm, "ssm", false, "access the jumphost with SSM Session Manager instead of opening port 22")
+	create.Flags().StringVar(&ingressCIDR, "ingress-cidr", "", "CIDR allowed to SSH to the jumphost, defaults to the caller's public IP")
 
-	_ = create.MarkFlagRequired("subnet-id")
+	create.MarkFlagsOneRequired("cluster-id", "subnet-id")
 
 	return create
 }
 
 func (j *jumphostConfig) runCreate(ctx context.Context) error {
-	if err := j.createKeyPair(ctx); err != nil {
+	if j.subnetId == "" {
+		subnetId, err := j.findPublicSubnet(ctx)
+		if err != nil {
+			return err
+		}
+		j.subnetId = subnetId
+	} else if err := j.tagClusterOfSubnet(ctx); err != nil {
+		return err
+	}
+
+	if j.ssm {
+		if err := j.createSSMInstanceProfile(ctx); err != nil {
+			return err
+		}
+	} else if err := j.createKeyPair(ctx); err != nil {
 		return err
 	}
 
@@ -100,6 +176,7 @@ func (j *jumphostConfig) runCreate(ctx context.Context) error {
 	}
 
 	log.Println(j.assembleNextSteps())
+	log.Printf("the jumphost will terminate itself at %s, run \"osdctl jumphost delete\" to clean up earlier", time.Now().Add(j.ttl).Format(time.RFC3339))
 	return nil
 }
 
@@ -114,7 +191,7 @@ func (j *jumphostConfig) createKeyPair(ctx context.Context) error {
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeKeyPair,
-				Tags:         j.tags,
+				Tags:         j.resourceTags(time.Now()),
 			},
 		},
 	})
@@ -147,7 +224,8 @@ func (j *jumphostConfig) createKeyPair(ctx context.Context) error {
 	return nil
 }
 
-// createSecurityGroup creates a security group and creates a single inbound rule to allow the user's public IP to SSH.
+// createSecurityGroup creates a security group and, unless the jumphost is accessed with SSM, creates a single inbound
+// rule to allow the user's public IP to SSH.
 func (j *jumphostConfig) createSecurityGroup(ctx context.Context) (string, error) {
 	vpcId, err := j.findVpcId(ctx)
 	if err != nil {
@@ -160,7 +238,7 @@ func (j *jumphostConfig) createSecurityGroup(ctx context.Context) (string, error
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeSecurityGroup,
-				Tags:         j.tags,
+				Tags:         j.resourceTags(time.Now()),
 			},
 		},
 		VpcId: aws.String(vpcId),
@@ -176,6 +254,11 @@ func (j *jumphostConfig) createSecurityGroup(ctx context.Context) (string, error
 	}
 	log.Printf("created security group: %s", *resp.GroupId)
 
+	// SSM Session Manager only requires outbound access, which is allowed by default
+	if j.ssm {
+		return *resp.GroupId, nil
+	}
+
 	if err := j.allowJumphostSshFromIp(ctx, *resp.GroupId); err != nil {
 		return *resp.GroupId, fmt.Errorf("failed to allow SSH to jumphost: %w", err)
 	}
@@ -194,7 +277,7 @@ func (j *jumphostConfig) createEc2Jumphost(ctx context.Context, securityGroupId
 		return err
 	}
 
-	resp, err := j.awsClient.RunInstances(ctx, &ec2.RunInstancesInput{
+	input := &ec2.RunInstancesInput{
 		MaxCount: aws.Int32(1),
 		MinCount: aws.Int32(1),
 		BlockDeviceMappings: []types.BlockDeviceMapping{
@@ -209,7 +292,6 @@ func (j *jumphostConfig) createEc2Jumphost(ctx context.Context, securityGroupId
 		ImageId:                           aws.String(ami),
 		InstanceInitiatedShutdownBehavior: types.ShutdownBehaviorTerminate,
 		InstanceType:                      types.InstanceTypeT3Micro,
-		KeyName:                           aws.String(awsResourceName),
 		NetworkInterfaces: []types.InstanceNetworkInterfaceSpecification{
 			{
 				AssociatePublicIpAddress: aws.Bool(true),
@@ -222,16 +304,23 @@ func (j *jumphostConfig) createEc2Jumphost(ctx context.Context, securityGroupId
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeInstance,
-				Tags:         j.tags,
+				Tags:         j.resourceTags(time.Now()),
 			},
 		},
-		// TODO: It would be nice to add user data which shut down the EC2 instance after 8 hours.
-		// This would automatically terminate the EC2 instance in case we forget to clean up.
-		UserData: nil,
-	})
+		// The instance is terminated on shutdown, so it cleans itself up in case we forget to
+		UserData: aws.String(shutdownUserData(j.ttl)),
+	}
+	if j.ssm {
+		input.IamInstanceProfile = &types.IamInstanceProfileSpecification{Name: aws.String(awsResourceName)}
+	} else {
+		input.KeyName = aws.String(awsResourceName)
+	}
+
+	resp, err := j.runInstances(ctx, input)
 	if err != nil {
 		return fmt.Errorf("failed to create jumphost EC2 instace: %w", err)
 	}
+	j.instanceId = *resp.Instances[0].InstanceId
 
 	// Wait up to 5 minutes for the instance to be running
 	// If it fails to come up in time, terminate it - we can always try again later
@@ -249,14 +338,55 @@ func (j *jumphostConfig) createEc2Jumphost(ctx context.Context, securityGroupId
 	describeInstancesResp, err := j.awsClient.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
 		InstanceIds: []string{*resp.Instances[0].InstanceId},
 	})
+	if err != nil {
+		return err
+	}
 
 	log.Printf("created EC2 jumphost: %s with public ip: %s", *describeInstancesResp.Reservations[0].Instances[0].InstanceId, *describeInstancesResp.Reservations[0].Instances[0].PublicIpAddress)
 	j.ec2PublicIp = *describeInstancesResp.Reservations[0].Instances[0].PublicIpAddress
 	return nil
 }
 
+// runInstances runs an EC2 instance, retrying while a newly created instance profile is not yet usable by EC2
+func (j *jumphostConfig) runInstances(ctx context.Context, input *ec2.RunInstancesInput) (*ec2.RunInstancesOutput, error) {
+	const attempts = 6
+	for i := 1; ; i++ {
+		resp, err := j.awsClient.RunInstances(ctx, input)
+		if err == nil || input.IamInstanceProfile == nil || i == attempts {
+			return resp, err
+		}
+
+		var apiErr smithy.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "InvalidParameterValue" || !strings.Contains(apiErr.ErrorMessage(), "iamInstanceProfile") {
+			return resp, err
+		}
+		log.Println("waiting for the instance profile to propagate")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// shutdownUserData returns base64 encoded user data shutting the instance down once the ttl has passed
+func shutdownUserData(ttl time.Duration) string {
+	minutes := int(ttl.Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("#!/bin/bash\nshutdown -h +%d\n", minutes)))
+}
+
 // assembleNextSteps returns a string with helpful next steps for connecting to the created jumphost
 func (j *jumphostConfig) assembleNextSteps() string {
+	if j.ssm {
+		if j.instanceId == "" {
+			return "could not determine EC2 instance id - please verify, but something likely went wrong"
+		}
+		return fmt.Sprintf("aws ssm start-session --target %s --region %s", j.instanceId, j.region)
+	}
+
 	if j.ec2PublicIp == "" {
 		return fmt.Sprintf("could not determine EC2 public ip - please verify, but something likely went wrong")
 	}
@@ -268,6 +398,90 @@ func (j *jumphostConfig) assembleNextSteps() string {
 	return fmt.Sprintf("ssh -i ${private_key} ec2-user@%s", j.ec2PublicIp)
 }
 
+// findPublicSubnet returns the first public subnet of the cluster, those tagged for public load balancers or
+// assigning public IPs on launch. BYO VPC clusters are searched by their subnet IDs, otherwise by the cluster's tag.
+func (j *jumphostConfig) findPublicSubnet(ctx context.Context) (string, error) {
+	if j.cluster == nil {
+		return "", errors.New("could not discover a public subnet; either --cluster-id or --subnet-id must be provided")
+	}
+
+	input := &ec2.DescribeSubnetsInput{}
+	if subnetIds := j.cluster.AWS().SubnetIDs(); len(subnetIds) > 0 {
+		input.SubnetIds = subnetIds
+	} else {
+		input.Filters = []types.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{fmt.Sprintf("kubernetes.io/cluster/%s", j.cluster.InfraID())},
+			},
+		}
+	}
+
+	log.Printf("searching for public subnets of %s", j.cluster.ID())
+	var publicSubnets []string
+	paginator := ec2.NewDescribeSubnetsPaginator(j.awsClient, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to describe subnets: %w", err)
+		}
+
+		for _, subnet := range page.Subnets {
+			if tagValue(subnet.Tags, publicSubnetTagKey) != "" || aws.ToBool(subnet.MapPublicIpOnLaunch) {
+				publicSubnets = append(publicSubnets, *subnet.SubnetId)
+			}
+		}
+	}
+
+	if len(publicSubnets) == 0 {
+		return "", fmt.Errorf("found no public subnets for %s, provide one with --subnet-id", j.cluster.ID())
+	}
+
+	sort.Strings(publicSubnets)
+	log.Printf("found public subnet: %s", publicSubnets[0])
+	return publicSubnets[0], nil
+}
+
+// tagClusterOfSubnet adds the cluster tag of the provided subnet to the tags of the jumphost's resources when no
+// cluster was provided, so jumphosts in a cluster's subnet can always be found with --cluster-id and are cleaned up
+// by the uninstaller
+func (j *jumphostConfig) tagClusterOfSubnet(ctx context.Context) error {
+	if j.cluster != nil {
+		return nil
+	}
+
+	resp, err := j.awsClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: []string{j.subnetId},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe subnet %s: %w", j.subnetId, err)
+	}
+	if len(resp.Subnets) == 0 {
+		return fmt.Errorf("found 0 subnets matching %s", j.subnetId)
+	}
+
+	var clusterTagKeys []string
+	for _, tag := range resp.Subnets[0].Tags {
+		if strings.HasPrefix(aws.ToString(tag.Key), clusterTagKeyPrefix) {
+			clusterTagKeys = append(clusterTagKeys, aws.ToString(tag.Key))
+		}
+	}
+
+	switch len(clusterTagKeys) {
+	case 0:
+		log.Printf("subnet %s isn't tagged with a cluster, the jumphost won't be tagged with one", j.subnetId)
+	case 1:
+		log.Printf("tagging the jumphost with the cluster of subnet %s: %s", j.subnetId, clusterTagKeys[0])
+		j.tags = append(j.tags, types.Tag{
+			Key:   aws.String(clusterTagKeys[0]),
+			Value: aws.String("owned"),
+		})
+	default:
+		return fmt.Errorf("subnet %s is shared by multiple clusters, provide the cluster to create the jumphost for with --cluster-id", j.subnetId)
+	}
+	return nil
+}
+
 // findVpcId returns the AWS VPC ID of a provided jumphostConfig.
 // Currently, requires that subnetId be defined.
 func (j *jumphostConfig) findVpcId(ctx context.Context) (string, error) {
@@ -291,37 +505,47 @@ func (j *jumphostConfig) findVpcId(ctx context.Context) (string, error) {
 }
 
 // allowJumphostSshFromIp uses ec2:AuthorizeSecurityGroupIngress to create an inbound rule to allow
-// TCP traffic on port 22 from the user's public IP.
+// TCP traffic on port 22 from the configured ingress CIDR, or the user's public IP by default.
 func (j *jumphostConfig) allowJumphostSshFromIp(ctx context.Context, groupId string) error {
-	ip, err := determinePublicIp()
-	if err != nil {
-		log.Printf("skipping modifying security group rule - failed to determine public ip: %s", err)
-		return nil
+	cidr := j.ingressCIDR
+	if cidr == "" {
+		ip, err := determinePublicIp(ctx, checkIpURL)
+		if err != nil {
+			return fmt.Errorf("failed to determine public ip, provide the CIDR to allow SSH from with --ingress-cidr: %w", err)
+		}
+		cidr = fmt.Sprintf("%s/32", ip)
 	}
 
 	if _, err := j.awsClient.AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
-		CidrIp:     aws.String(fmt.Sprintf("%s/32", ip)),
+		CidrIp:     aws.String(cidr),
 		FromPort:   aws.Int32(22),
 		GroupId:    aws.String(groupId),
 		IpProtocol: aws.String("tcp"),
 		TagSpecifications: []types.TagSpecification{
 			{
 				ResourceType: types.ResourceTypeSecurityGroupRule,
-				Tags:         j.tags,
+				Tags:         j.resourceTags(time.Now()),
 			},
 		},
 		ToPort: aws.Int32(22),
 	}); err != nil {
 		return err
 	}
-	log.Printf("authorized security group ingress for %s", ip)
+	log.Printf("authorized security group ingress for %s", cidr)
 
 	return nil
 }
 
-// determinePublicIp returns the public IP determined by a GET request to https://checkip.amazonaws.com
-func determinePublicIp() (string, error) {
-	resp, err := http.Get("https://checkip.amazonaws.com")
+// determinePublicIp returns the public IP determined by a GET request to url, e.g. checkIpURL
+func determinePublicIp(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	client := http.Client{
+		Timeout: checkIpTimeout,
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -408,6 +632,10 @@ func (j *jumphostConfig) findLatestJumphostAMI(ctx context.Context) (string, err
 		}
 	}
 
+	if latestAmi == "" {
+		return "", errors.New("failed to find an Amazon Linux 2023 AMI to launch an EC2 jumphost")
+	}
+
 	log.Printf("found AMI: %s", latestAmi)
 	return latestAmi, nil
 }
@@ -2,14 +2,19 @@ package jumphost
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -53,6 +58,7 @@ func TestFindLatestJumphostAMI(t *testing.T) {
 	}
 	tests := []struct {
 		name          string
+		images        *ec2.DescribeImagesOutput
 		mockError     error
 		expectedAMI   string
 		expectError   bool
@@ -71,12 +77,23 @@ func TestFindLatestJumphostAMI(t *testing.T) {
 			expectError:   true,
 			errorContains: "failed to describe images in order to launch an EC2 jumphost: errorDescribeImages",
 		},
+		{
+			name:          "error_case_no_AMI_found",
+			images:        &ec2.DescribeImagesOutput{},
+			expectedAMI:   "",
+			expectError:   true,
+			errorContains: "failed to find an Amazon Linux 2023 AMI to launch an EC2 jumphost",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			images := mockImages
+			if tt.images != nil {
+				images = tt.images
+			}
 			mockClient.On("DescribeImages", ctx, mock.Anything).
-				Return(mockImages, tt.mockError).Once()
+				Return(images, tt.mockError).Once()
 			ami, err := jumphost.findLatestJumphostAMI(ctx)
 			if tt.expectError {
 				assert.Error(t, err)
@@ -92,9 +109,38 @@ func TestFindLatestJumphostAMI(t *testing.T) {
 	}
 }
 
+func TestDeterminePublicIp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/invalid":
+			_, _ = w.Write([]byte("not an ip\n"))
+		case "/error":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("192.0.2.1\n"))
+		}
+	}))
+	defer server.Close()
+
+	ip, err := determinePublicIp(context.Background(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", ip)
+
+	_, err = determinePublicIp(context.Background(), server.URL+"/invalid")
+	assert.Error(t, err)
+
+	_, err = determinePublicIp(context.Background(), server.URL+"/error")
+	assert.ErrorContains(t, err, "503")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = determinePublicIp(ctx, server.URL)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestAllowJumphostSshFromIp(t *testing.T) {
 	mockClient := new(mockJumphostAWSClient)
-	jumphost := &jumphostConfig{awsClient: mockClient}
+	jumphost := &jumphostConfig{awsClient: mockClient, ingressCIDR: "192.0.2.1/32"}
 	ctx := context.TODO()
 	groupId := "Test12345"
 	mockResponse := &ec2.AuthorizeSecurityGroupIngressOutput{}
@@ -119,8 +165,9 @@ func TestAllowJumphostSshFromIp(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient.On("AuthorizeSecurityGroupIngress", ctx, mock.Anything).
-				Return(mockResponse, tt.mockError).Once()
+			mockClient.On("AuthorizeSecurityGroupIngress", ctx, mock.MatchedBy(func(input *ec2.AuthorizeSecurityGroupIngressInput) bool {
+				return *input.CidrIp == "192.0.2.1/32"
+			})).Return(mockResponse, tt.mockError).Once()
 
 			err := jumphost.allowJumphostSshFromIp(ctx, groupId)
 
@@ -243,6 +290,15 @@ func TestAssembleNextSteps(t *testing.T) {
 			jumphost: &jumphostConfig{},
 			expected: "could not determine EC2 public ip - please verify, but something likely went wrong",
 		},
+		{
+			name: "ssm",
+			jumphost: &jumphostConfig{
+				ssm:        true,
+				instanceId: "i-12345",
+				region:     "us-east-1",
+			},
+			expected: "aws ssm start-session --target i-12345 --region us-east-1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -309,3 +365,129 @@ func TestCreateKeyPair(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPublicSubnet(t *testing.T) {
+	ctx := context.TODO()
+	subnets := &ec2.DescribeSubnetsOutput{
+		Subnets: []types.Subnet{
+			{SubnetId: aws.String("subnet-private")},
+			{SubnetId: aws.String("subnet-public-b"), Tags: []types.Tag{{Key: aws.String(publicSubnetTagKey), Value: aws.String("1")}}},
+			{SubnetId: aws.String("subnet-public-a"), MapPublicIpOnLaunch: aws.Bool(true)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		aws            *cmv1.AWSBuilder
+		response       *ec2.DescribeSubnetsOutput
+		matchInput     func(*ec2.DescribeSubnetsInput) bool
+		expectedSubnet string
+		errorContains  string
+	}{
+		{
+			name:     "byo_vpc_searches_by_subnet_ids",
+			aws:      cmv1.NewAWS().SubnetIDs("subnet-private", "subnet-public-a", "subnet-public-b"),
+			response: subnets,
+			matchInput: func(input *ec2.DescribeSubnetsInput) bool {
+				return len(input.SubnetIds) == 3 && len(input.Filters) == 0
+			},
+			expectedSubnet: "subnet-public-a",
+		},
+		{
+			name:     "installer_vpc_searches_by_cluster_tag",
+			aws:      cmv1.NewAWS(),
+			response: subnets,
+			matchInput: func(input *ec2.DescribeSubnetsInput) bool {
+				return len(input.Filters) == 1 && input.Filters[0].Values[0] == "kubernetes.io/cluster/infra-id"
+			},
+			expectedSubnet: "subnet-public-a",
+		},
+		{
+			name:     "no_public_subnets",
+			aws:      cmv1.NewAWS(),
+			response: &ec2.DescribeSubnetsOutput{Subnets: []types.Subnet{{SubnetId: aws.String("subnet-private")}}},
+			matchInput: func(input *ec2.DescribeSubnetsInput) bool {
+				return true
+			},
+			errorContains: "found no public subnets for cluster-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster, err := cmv1.NewCluster().ID("cluster-id").InfraID("infra-id").AWS(tt.aws).Build()
+			assert.NoError(t, err)
+
+			mockClient := new(mockJumphostAWSClient)
+			mockClient.On("DescribeSubnets", ctx, mock.MatchedBy(tt.matchInput)).Return(tt.response, nil).Once()
+
+			j := &jumphostConfig{awsClient: mockClient, cluster: cluster}
+			subnetId, err := j.findPublicSubnet(ctx)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSubnet, subnetId)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+
+	_, err := (&jumphostConfig{}).findPublicSubnet(ctx)
+	assert.Error(t, err)
+}
+
+func TestShutdownUserData(t *testing.T) {
+	userData, err := base64.StdEncoding.DecodeString(shutdownUserData(90 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "#!/bin/bash\nshutdown -h +90\n", string(userData))
+}
+
+func TestTagClusterOfSubnet(t *testing.T) {
+	ctx := context.TODO()
+	clusterTag := types.Tag{Key: aws.String("kubernetes.io/cluster/infra-id"), Value: aws.String("shared")}
+
+	tests := []struct {
+		name          string
+		subnetTags    []types.Tag
+		expectedTags  []types.Tag
+		errorContains string
+	}{
+		{
+			name:         "cluster_subnet",
+			subnetTags:   []types.Tag{clusterTag, {Key: aws.String(publicSubnetTagKey), Value: aws.String("1")}},
+			expectedTags: append(jumphostTags(), types.Tag{Key: aws.String("kubernetes.io/cluster/infra-id"), Value: aws.String("owned")}),
+		},
+		{
+			name:         "subnet_without_cluster",
+			expectedTags: jumphostTags(),
+		},
+		{
+			name:          "subnet_shared_by_clusters",
+			subnetTags:    []types.Tag{clusterTag, {Key: aws.String("kubernetes.io/cluster/other-infra-id"), Value: aws.String("shared")}},
+			errorContains: "provide the cluster to create the jumphost for with --cluster-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mockJumphostAWSClient)
+			mockClient.On("DescribeSubnets", ctx, mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []types.Subnet{{SubnetId: aws.String("subnet-12345"), Tags: tt.subnetTags}},
+			}, nil).Once()
+
+			j := &jumphostConfig{awsClient: mockClient, subnetId: "subnet-12345", tags: jumphostTags()}
+			err := j.tagClusterOfSubnet(ctx)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTags, j.tags)
+			mockClient.AssertExpectations(t)
+		})
+	}
+
+	// The cluster's tag is already set with --cluster-id
+	assert.NoError(t, (&jumphostConfig{cluster: &cmv1.Cluster{}}).tagClusterOfSubnet(ctx))
+}
@@ -28,6 +28,11 @@ func newCmdDeleteJumphost() *cobra.Command {
   fails the customer should be notified as there will be leftover AWS resources
   in their account. This command is idempotent and safe to run over and over.
 
+  With --cluster-id, AWS credentials for the cluster's account are retrieved from
+  backplane and the jumphost is searched for in the cluster's public subnet unless
+  --subnet-id is provided. The IAM role and instance profile of a jumphost created
+  with --ssm are deleted once no other SSM jumphosts remain in the account.
+
   Requires these permissions:
   {
     "Version": "2012-10-17",
@@ -43,6 +48,7 @@ func newCmdDeleteJumphost() *cobra.Command {
           "ec2:DescribeImages",
           "ec2:DescribeInstances",
           "ec2:DescribeKeyPairs",
+          "ec2:DescribeRegions",
           "ec2:DescribeSecurityGroups",
           "ec2:DescribeSubnets",
           "ec2:RunInstances",
@@ -50,32 +56,56 @@ func newCmdDeleteJumphost() *cobra.Command {
         ],
         "Effect": "Allow",
         "Resource": "*"
+      },
+      {
+        "Action": [
+          "iam:DeleteInstanceProfile",
+          "iam:DeleteRole",
+          "iam:DetachRolePolicy",
+          "iam:RemoveRoleFromInstanceProfile"
+        ],
+        "Effect": "Allow",
+        "Resource": "*"
       }
     ]
-  }`,
+  }
+
+  The IAM permissions are only required for jumphosts created with --ssm.`,
 		Example: `
   # Create and delete a jumphost
   osdctl jumphost create --subnet-id public-subnet-id
-  osdctl jumphost delete --subnet-id public-subnet-id`,
+  osdctl jumphost delete --subnet-id public-subnet-id
+
+  # Delete the jumphost of a cluster
+  osdctl jumphost delete --cluster-id ${CLUSTER_ID}`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			j, err := initJumphostConfig(context.TODO(), clusterId, subnetId)
+			j, err := initJumphostConfig(cmd.Context(), clusterId, subnetId)
 			if err != nil {
 				return err
 			}
 
-			return j.runDelete(context.TODO())
+			return j.runDelete(cmd.Context())
 		},
 	}
 
+	create.Flags().StringVarP(&clusterId, "cluster-id", "C", "", "cluster to delete the jumphost of, using its AWS credentials from backplane")
 	create.Flags().StringVar(&subnetId, "subnet-id", "", "subnet id to search for and delete a jumphost in")
 
-	_ = create.MarkFlagRequired("subnet-id")
+	create.MarkFlagsOneRequired("cluster-id", "subnet-id")
 
 	return create
 }
 
 func (j *jumphostConfig) runDelete(ctx context.Context) error {
+	if j.subnetId == "" {
+		subnetId, err := j.findPublicSubnet(ctx)
+		if err != nil {
+			return err
+		}
+		j.subnetId = subnetId
+	}
+
 	if err := j.deleteEc2Jumphost(ctx); err != nil {
 		return err
 	}
@@ -88,6 +118,19 @@ func (j *jumphostConfig) runDelete(ctx context.Context) error {
 		return err
 	}
 
+	if j.ssm {
+		// The instance profile is shared by all SSM jumphosts in the account
+		active, err := j.activeSSMJumphosts(ctx)
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			log.Printf("skipping deleting instance profile - %d other SSM jumphosts are still active", active)
+			return nil
+		}
+		return j.deleteSSMInstanceProfile(ctx)
+	}
+
 	return nil
 }
 
@@ -157,7 +200,7 @@ func (j *jumphostConfig) deleteSecurityGroup(ctx context.Context) error {
 }
 
 // deleteEc2Jumphost searches for EC2 instances by the expected tag filter within the provided subnet's VPC and
-// terminates the first matching EC2 instance
+// terminates the first matching EC2 instance, recording whether it was accessed with SSM
 func (j *jumphostConfig) deleteEc2Jumphost(ctx context.Context) error {
 	vpcId, err := j.findVpcId(ctx)
 	if err != nil {
@@ -170,6 +213,10 @@ func (j *jumphostConfig) deleteEc2Jumphost(ctx context.Context) error {
 				Name:   aws.String("vpc-id"),
 				Values: []string{vpcId},
 			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: activeInstanceStates,
+			},
 		}...),
 	})
 	if err != nil {
@@ -181,6 +228,8 @@ func (j *jumphostConfig) deleteEc2Jumphost(ctx context.Context) error {
 		return nil
 	}
 
+	j.ssm = tagValue(describeInstancesResp.Reservations[0].Instances[0].Tags, accessTagKey) == accessSSM
+
 	log.Printf("terminating EC2 instance: %s", *describeInstancesResp.Reservations[0].Instances[0].InstanceId)
 	_, err = j.awsClient.TerminateInstances(ctx, &ec2.TerminateInstancesInput{
 		InstanceIds: []string{*describeInstancesResp.Reservations[0].Instances[0].InstanceId},
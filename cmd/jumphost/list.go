@@ -0,0 +1,233 @@
+package jumphost
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// activeInstanceStates are the states of jumphost EC2 instances which have not been terminated
+var activeInstanceStates = []string{
+	string(types.InstanceStateNamePending),
+	string(types.InstanceStateNameRunning),
+	string(types.InstanceStateNameStopping),
+	string(types.InstanceStateNameStopped),
+}
+
+// jumphostInstance is a jumphost EC2 instance found in an AWS account
+type jumphostInstance struct {
+	Region     string
+	InstanceID string
+	State      string
+	VpcID      string
+	SubnetID   string
+	PublicIP   string
+	Access     string
+	LaunchTime time.Time
+	ExpiresAt  time.Time
+}
+
+func (i jumphostInstance) expired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+func newCmdListJumphost() *cobra.Command {
+	var (
+		clusterId string
+		region    string
+	)
+
+	list := &cobra.Command{
+		Use:          "list",
+		SilenceUsage: true,
+		Short:        "List jumphosts created by `osdctl jumphost create` across an AWS account",
+		Long: `List jumphosts created by "osdctl jumphost create" across an AWS account
+
+  Searches all enabled regions of the AWS account, or only --region, for jumphost
+  EC2 instances which have not been terminated and prints when they expire.
+  Expired jumphosts can be cleaned up with "osdctl jumphost reap".
+
+  With --cluster-id, AWS credentials for the cluster's account are retrieved from
+  backplane and only the cluster's jumphosts are listed. Otherwise, it requires valid
+  AWS credentials to be already set.
+
+  Requires these permissions:
+  {
+    "Version": "2012-10-17",
+    "Statement": [
+      {
+        "Action": [
+          "ec2:DescribeInstances",
+          "ec2:DescribeRegions"
+        ],
+        "Effect": "Allow",
+        "Resource": "*"
+      }
+    ]
+  }`,
+		Example: `
+  # List jumphosts across the AWS account of the current credentials
+  osdctl jumphost list
+
+  # List the jumphosts of a cluster
+  osdctl jumphost list --cluster-id ${CLUSTER_ID}`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := initJumphostConfig(cmd.Context(), clusterId, "")
+			if err != nil {
+				return err
+			}
+
+			return j.runList(cmd.Context(), os.Stdout, region)
+		},
+	}
+
+	list.Flags().StringVarP(&clusterId, "cluster-id", "C", "", "only list the jumphosts of a cluster, using its AWS credentials from backplane")
+	list.Flags().StringVar(&region, "region", "", "only list jumphosts in this region instead of all enabled regions")
+
+	return list
+}
+
+func (j *jumphostConfig) runList(ctx context.Context, w io.Writer, region string) error {
+	regions, err := j.listRegions(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	var instances []jumphostInstance
+	for _, r := range regions {
+		regionInstances, err := listJumphostInstances(ctx, j.regionalClient(r), r, j.tags)
+		if err != nil {
+			return err
+		}
+		instances = append(instances, regionInstances...)
+	}
+
+	return printJumphostInstances(w, instances, time.Now())
+}
+
+// listRegions returns the given region, or all regions enabled in the AWS account if it is empty
+func (j *jumphostConfig) listRegions(ctx context.Context, region string) ([]string, error) {
+	if region != "" {
+		return []string{region}, nil
+	}
+
+	resp, err := j.awsClient.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	regions := make([]string, 0, len(resp.Regions))
+	for _, r := range resp.Regions {
+		regions = append(regions, aws.ToString(r.RegionName))
+	}
+	sort.Strings(regions)
+
+	return regions, nil
+}
+
+// listJumphostInstances returns the jumphost EC2 instances matching the tags which have not been terminated
+func listJumphostInstances(ctx context.Context, client jumphostAWSClient, region string, tags []types.Tag) ([]jumphostInstance, error) {
+	var instances []jumphostInstance
+
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
+		Filters: append(generateTagFilters(tags), types.Filter{
+			Name:   aws.String("instance-state-name"),
+			Values: activeInstanceStates,
+		}),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances in %s: %w", region, err)
+		}
+
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				access := tagValue(instance.Tags, accessTagKey)
+				if access == "" {
+					access = accessSSH
+				}
+
+				var state string
+				if instance.State != nil {
+					state = string(instance.State.Name)
+				}
+
+				instances = append(instances, jumphostInstance{
+					Region:     region,
+					InstanceID: aws.ToString(instance.InstanceId),
+					State:      state,
+					VpcID:      aws.ToString(instance.VpcId),
+					SubnetID:   aws.ToString(instance.SubnetId),
+					PublicIP:   aws.ToString(instance.PublicIpAddress),
+					Access:     access,
+					LaunchTime: aws.ToTime(instance.LaunchTime),
+					ExpiresAt:  expiresAt(instance.Tags, instance.LaunchTime),
+				})
+			}
+		}
+	}
+
+	return instances, nil
+}
+
+// activeSSMJumphosts returns the number of jumphosts accessed with SSM in all regions of the AWS account
+func (j *jumphostConfig) activeSSMJumphosts(ctx context.Context) (int, error) {
+	regions, err := j.listRegions(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	active := 0
+	for _, r := range regions {
+		instances, err := listJumphostInstances(ctx, j.regionalClient(r), r, jumphostTags())
+		if err != nil {
+			return 0, err
+		}
+		for _, instance := range instances {
+			if instance.Access == accessSSM {
+				active++
+			}
+		}
+	}
+
+	return active, nil
+}
+
+func printJumphostInstances(w io.Writer, instances []jumphostInstance, now time.Time) error {
+	if len(instances) == 0 {
+		_, err := fmt.Fprintln(w, "no jumphosts found")
+		return err
+	}
+
+	table := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	table.AddRow([]string{"REGION", "INSTANCE ID", "STATE", "ACCESS", "SUBNET", "PUBLIC IP", "LAUNCHED", "EXPIRES"})
+	for _, instance := range instances {
+		expires := instance.ExpiresAt.Format(time.RFC3339)
+		if instance.expired(now) {
+			expires = "expired"
+		}
+		table.AddRow([]string{
+			instance.Region,
+			instance.InstanceID,
+			instance.State,
+			instance.Access,
+			instance.SubnetID,
+			instance.PublicIP,
+			instance.LaunchTime.Format(time.RFC3339),
+			expires,
+		})
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,89 @@
+package jumphost
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *mockAWSClient) DescribeRegions(ctx context.Context, params *ec2.DescribeRegionsInput, optFns ...func(options *ec2.Options)) (*ec2.DescribeRegionsOutput, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(*ec2.DescribeRegionsOutput), args.Error(1)
+}
+
+func newTestInstance(id, vpcId string, launched time.Time, tags ...types.Tag) types.Instance {
+	return types.Instance{
+		InstanceId: aws.String(id),
+		VpcId:      aws.String(vpcId),
+		SubnetId:   aws.String("subnet-" + vpcId),
+		LaunchTime: aws.Time(launched),
+		State:      &types.InstanceState{Name: types.InstanceStateNameRunning},
+		Tags:       tags,
+	}
+}
+
+func TestListRegions(t *testing.T) {
+	ctx := context.TODO()
+	mockClient := new(mockAWSClient)
+	mockClient.On("DescribeRegions", ctx, mock.Anything).Return(&ec2.DescribeRegionsOutput{
+		Regions: []types.Region{{RegionName: aws.String("us-west-2")}, {RegionName: aws.String("eu-west-1")}},
+	}, nil).Once()
+	j := &jumphostConfig{awsClient: mockClient}
+
+	regions, err := j.listRegions(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"eu-west-1", "us-west-2"}, regions)
+
+	regions, err = j.listRegions(ctx, "us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-east-1"}, regions)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestListJumphostInstances(t *testing.T) {
+	ctx := context.TODO()
+	launched := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockClient := new(mockAWSClient)
+	mockClient.On("DescribeInstances", ctx, mock.MatchedBy(func(input *ec2.DescribeInstancesInput) bool {
+		last := input.Filters[len(input.Filters)-1]
+		return len(input.Filters) == 3 && *last.Name == "instance-state-name"
+	})).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{{Instances: []types.Instance{
+			newTestInstance("i-legacy", "vpc-1", launched),
+			newTestInstance("i-ssm", "vpc-2", launched,
+				types.Tag{Key: aws.String(accessTagKey), Value: aws.String(accessSSM)},
+				types.Tag{Key: aws.String(expiresAtTagKey), Value: aws.String("2024-01-01T13:00:00Z")},
+			),
+		}}},
+	}, nil).Once()
+
+	instances, err := listJumphostInstances(ctx, mockClient, "us-east-1", jumphostTags())
+	assert.NoError(t, err)
+	assert.Len(t, instances, 2)
+
+	assert.Equal(t, "us-east-1", instances[0].Region)
+	assert.Equal(t, accessSSH, instances[0].Access)
+	assert.Equal(t, "running", instances[0].State)
+	assert.True(t, launched.Add(defaultTTL).Equal(instances[0].ExpiresAt))
+
+	assert.Equal(t, accessSSM, instances[1].Access)
+	assert.True(t, instances[1].expired(launched.Add(time.Hour)))
+	assert.False(t, instances[1].expired(launched.Add(59*time.Minute)))
+
+	var out bytes.Buffer
+	assert.NoError(t, printJumphostInstances(&out, instances, launched.Add(2*time.Hour)))
+	assert.Contains(t, out.String(), "i-legacy")
+	assert.Contains(t, out.String(), "2024-01-01T20:00:00Z")
+	assert.Contains(t, out.String(), "expired")
+
+	mockClient.AssertExpectations(t)
+}
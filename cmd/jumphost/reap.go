@@ -0,0 +1,236 @@
+package jumphost
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+)
+
+type reapOptions struct {
+	region string
+	dryRun bool
+}
+
+func newCmdReapJumphost() *cobra.Command {
+	var (
+		clusterId string
+		ops       reapOptions
+	)
+
+	reap := &cobra.Command{
+		Use:          "reap",
+		SilenceUsage: true,
+		Short:        "Delete expired jumphosts created by `osdctl jumphost create` across an AWS account",
+		Long: `Delete expired jumphosts created by "osdctl jumphost create" across an AWS account
+
+  Searches all enabled regions of the AWS account, or only --region, and deletes
+  jumphosts whose TTL has passed, along with their leftover security groups and key
+  pairs. Jumphosts created before TTLs were recorded are assumed to expire 8 hours
+  after they were launched. Once no SSM jumphosts remain in the account, the IAM role
+  and instance profile used by "osdctl jumphost create --ssm" are deleted as well.
+
+  With --cluster-id, AWS credentials for the cluster's account are retrieved from
+  backplane and only the cluster's jumphosts are reaped. Otherwise, it requires valid
+  AWS credentials to be already set.
+
+  Requires these permissions:
+  {
+    "Version": "2012-10-17",
+    "Statement": [
+      {
+        "Action": [
+          "ec2:DeleteKeyPair",
+          "ec2:DeleteSecurityGroup",
+          "ec2:DescribeInstances",
+          "ec2:DescribeKeyPairs",
+          "ec2:DescribeRegions",
+          "ec2:DescribeSecurityGroups",
+          "ec2:TerminateInstances",
+          "iam:DeleteInstanceProfile",
+          "iam:DeleteRole",
+          "iam:DetachRolePolicy",
+          "iam:RemoveRoleFromInstanceProfile"
+        ],
+        "Effect": "Allow",
+        "Resource": "*"
+      }
+    ]
+  }`,
+		Example: `
+  # Show what would be reaped across the AWS account of the current credentials
+  osdctl jumphost reap --dry-run
+
+  # Reap the expired jumphosts of a cluster
+  osdctl jumphost reap --cluster-id ${CLUSTER_ID}`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := initJumphostConfig(cmd.Context(), clusterId, "")
+			if err != nil {
+				return err
+			}
+
+			return j.runReap(cmd.Context(), ops)
+		},
+	}
+
+	reap.Flags().StringVarP(&clusterId, "cluster-id", "C", "", "only reap the jumphosts of a cluster, using its AWS credentials from backplane")
+	reap.Flags().StringVar(&ops.region, "region", "", "only reap jumphosts in this region instead of all enabled regions")
+	reap.Flags().BoolVar(&ops.dryRun, "dry-run", false, "only print the resources which would be deleted")
+
+	return reap
+}
+
+func (j *jumphostConfig) runReap(ctx context.Context, ops reapOptions) error {
+	regions, err := j.listRegions(ctx, ops.region)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	activeSSM := 0
+	for _, region := range regions {
+		remaining, err := j.reapRegion(ctx, j.regionalClient(region), region, now, ops.dryRun)
+		if err != nil {
+			return err
+		}
+		for _, instance := range remaining {
+			if instance.Access == accessSSM {
+				activeSSM++
+			}
+		}
+	}
+
+	// The instance profile is global and shared by all SSM jumphosts, so it can only be reaped after checking
+	// every region for jumphosts of any cluster
+	if ops.region != "" || j.cluster != nil || activeSSM > 0 {
+		return nil
+	}
+	if ops.dryRun {
+		log.Printf("would delete instance profile and IAM role %s if they exist", awsResourceName)
+		return nil
+	}
+	if err := j.deleteSSMInstanceProfile(ctx); err != nil {
+		log.Printf("failed to clean up the SSM instance profile: %s", err)
+	}
+
+	return nil
+}
+
+// reapRegion terminates expired jumphosts in a region, then deletes expired security groups in VPCs and key pairs in
+// regions without remaining jumphosts. It returns the jumphosts which have not expired.
+func (j *jumphostConfig) reapRegion(ctx context.Context, client jumphostAWSClient, region string, now time.Time, dryRun bool) ([]jumphostInstance, error) {
+	instances, err := listJumphostInstances(ctx, client, region, j.tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		expired   []string
+		remaining []jumphostInstance
+	)
+	activeVpcs := map[string]bool{}
+	for _, instance := range instances {
+		if instance.expired(now) {
+			expired = append(expired, instance.InstanceID)
+			continue
+		}
+		remaining = append(remaining, instance)
+		activeVpcs[instance.VpcID] = true
+	}
+
+	if len(expired) > 0 {
+		if dryRun {
+			log.Printf("would terminate expired EC2 instances in %s: %v", region, expired)
+		} else {
+			log.Printf("terminating expired EC2 instances in %s: %v", region, expired)
+			if _, err := client.TerminateInstances(ctx, &ec2.TerminateInstancesInput{InstanceIds: expired}); err != nil {
+				return nil, fmt.Errorf("failed to terminate instances in %s: %w", region, err)
+			}
+
+			// Security groups can't be deleted while they're still attached to an instance
+			log.Println("waiting for the EC2 instances to be in a terminated state")
+			waiter := ec2.NewInstanceTerminatedWaiter(client)
+			if err := waiter.Wait(ctx, &ec2.DescribeInstancesInput{InstanceIds: expired}, 5*time.Minute); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := j.reapSecurityGroups(ctx, client, region, activeVpcs, now, dryRun); err != nil {
+		return nil, err
+	}
+
+	if len(remaining) == 0 {
+		if err := j.reapKeyPairs(ctx, client, region, now, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	return remaining, nil
+}
+
+// reapSecurityGroups deletes expired jumphost security groups in VPCs without remaining jumphosts
+func (j *jumphostConfig) reapSecurityGroups(ctx context.Context, client jumphostAWSClient, region string, activeVpcs map[string]bool, now time.Time, dryRun bool) error {
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(client, &ec2.DescribeSecurityGroupsInput{
+		Filters: append(generateTagFilters(j.tags), types.Filter{
+			Name:   aws.String("group-name"),
+			Values: []string{awsResourceName},
+		}),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe security groups in %s: %w", region, err)
+		}
+
+		for _, sg := range page.SecurityGroups {
+			if activeVpcs[aws.ToString(sg.VpcId)] || now.Before(expiresAt(sg.Tags, nil)) {
+				continue
+			}
+
+			if dryRun {
+				log.Printf("would delete security group in %s: %s", region, aws.ToString(sg.GroupId))
+				continue
+			}
+			log.Printf("deleting security group in %s: %s", region, aws.ToString(sg.GroupId))
+			if _, err := client.DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{GroupId: sg.GroupId}); err != nil {
+				return fmt.Errorf("failed to delete security group: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// reapKeyPairs deletes expired jumphost key pairs
+func (j *jumphostConfig) reapKeyPairs(ctx context.Context, client jumphostAWSClient, region string, now time.Time, dryRun bool) error {
+	resp, err := client.DescribeKeyPairs(ctx, &ec2.DescribeKeyPairsInput{
+		Filters: generateTagFilters(j.tags),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe key pairs in %s: %w", region, err)
+	}
+
+	for _, kp := range resp.KeyPairs {
+		if now.Before(expiresAt(kp.Tags, kp.CreateTime)) {
+			continue
+		}
+
+		if dryRun {
+			log.Printf("would delete key pair in %s: %s", region, aws.ToString(kp.KeyPairId))
+			continue
+		}
+		log.Printf("deleting key pair in %s: %s", region, aws.ToString(kp.KeyPairId))
+		if _, err := client.DeleteKeyPair(ctx, &ec2.DeleteKeyPairInput{KeyPairId: kp.KeyPairId}); err != nil {
+			return fmt.Errorf("failed to delete key pair: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package jumphost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReapRegion(t *testing.T) {
+	ctx := context.TODO()
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	expired := types.Tag{Key: aws.String(expiresAtTagKey), Value: aws.String("2024-01-01T19:00:00Z")}
+	active := types.Tag{Key: aws.String(expiresAtTagKey), Value: aws.String("2024-01-01T21:00:00Z")}
+
+	isStateFilter := func(input *ec2.DescribeInstancesInput) bool {
+		return len(input.InstanceIds) == 0
+	}
+	isWaiter := func(input *ec2.DescribeInstancesInput) bool {
+		return len(input.InstanceIds) == 1 && input.InstanceIds[0] == "i-expired"
+	}
+
+	tests := []struct {
+		name              string
+		instances         []types.Instance
+		dryRun            bool
+		setupMocks        func(m *mockAWSClient)
+		expectedRemaining int
+	}{
+		{
+			name: "terminates expired instances and deletes their resources",
+			instances: []types.Instance{
+				newTestInstance("i-expired", "vpc-1", now.Add(-10*time.Hour), expired),
+			},
+			setupMocks: func(m *mockAWSClient) {
+				m.On("TerminateInstances", ctx, &ec2.TerminateInstancesInput{InstanceIds: []string{"i-expired"}}).
+					Return(&ec2.TerminateInstancesOutput{}, nil).Once()
+				m.On("DescribeInstances", mock.Anything, mock.MatchedBy(isWaiter)).Return(&ec2.DescribeInstancesOutput{
+					Reservations: []types.Reservation{{Instances: []types.Instance{
+						{InstanceId: aws.String("i-expired"), State: &types.InstanceState{Name: types.InstanceStateNameTerminated}},
+					}}},
+				}, nil).Once()
+				m.On("DescribeSecurityGroups", ctx, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []types.SecurityGroup{
+						{GroupId: aws.String("sg-expired"), VpcId: aws.String("vpc-1"), Tags: []types.Tag{expired}},
+						{GroupId: aws.String("sg-active"), VpcId: aws.String("vpc-2"), Tags: []types.Tag{active}},
+					},
+				}, nil).Once()
+				m.On("DeleteSecurityGroup", ctx, &ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-expired")}).
+					Return(&ec2.DeleteSecurityGroupOutput{}, nil).Once()
+				m.On("DescribeKeyPairs", ctx, mock.Anything).Return(&ec2.DescribeKeyPairsOutput{
+					KeyPairs: []types.KeyPairInfo{{KeyPairId: aws.String("key-legacy"), CreateTime: aws.Time(now.Add(-9 * time.Hour))}},
+				}, nil).Once()
+				m.On("DeleteKeyPair", ctx, &ec2.DeleteKeyPairInput{KeyPairId: aws.String("key-legacy")}).
+					Return(&ec2.DeleteKeyPairOutput{}, nil).Once()
+			},
+		},
+		{
+			name: "keeps resources of active instances",
+			instances: []types.Instance{
+				newTestInstance("i-active", "vpc-1", now.Add(-time.Hour), active),
+			},
+			setupMocks: func(m *mockAWSClient) {
+				m.On("DescribeSecurityGroups", ctx, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []types.SecurityGroup{
+						{GroupId: aws.String("sg-legacy"), VpcId: aws.String("vpc-1")},
+					},
+				}, nil).Once()
+			},
+			expectedRemaining: 1,
+		},
+		{
+			name: "dry run deletes nothing",
+			instances: []types.Instance{
+				newTestInstance("i-expired", "vpc-1", now.Add(-10*time.Hour), expired),
+			},
+			dryRun: true,
+			setupMocks: func(m *mockAWSClient) {
+				m.On("DescribeSecurityGroups", ctx, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []types.SecurityGroup{
+						{GroupId: aws.String("sg-expired"), VpcId: aws.String("vpc-1"), Tags: []types.Tag{expired}},
+					},
+				}, nil).Once()
+				m.On("DescribeKeyPairs", ctx, mock.Anything).Return(&ec2.DescribeKeyPairsOutput{
+					KeyPairs: []types.KeyPairInfo{{KeyPairId: aws.String("key-expired"), Tags: []types.Tag{expired}}},
+				}, nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mockAWSClient)
+			mockClient.On("DescribeInstances", ctx, mock.MatchedBy(isStateFilter)).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []types.Reservation{{Instances: tt.instances}},
+			}, nil).Once()
+			tt.setupMocks(mockClient)
+
+			j := &jumphostConfig{tags: jumphostTags()}
+			remaining, err := j.reapRegion(ctx, mockClient, "us-east-1", now, tt.dryRun)
+			assert.NoError(t, err)
+			assert.Len(t, remaining, tt.expectedRemaining)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package jumphost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+const (
+	ssmManagedPolicyArn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+
+	ec2AssumeRolePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "ec2.amazonaws.com"},
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}`
+)
+
+// jumphostIAMClient creates the instance profile allowing a jumphost to register with SSM Session Manager
+type jumphostIAMClient interface {
+	CreateRole(ctx context.Context, params *iam.CreateRoleInput, optFns ...func(*iam.Options)) (*iam.CreateRoleOutput, error)
+	DeleteRole(ctx context.Context, params *iam.DeleteRoleInput, optFns ...func(*iam.Options)) (*iam.DeleteRoleOutput, error)
+	AttachRolePolicy(ctx context.Context, params *iam.AttachRolePolicyInput, optFns ...func(*iam.Options)) (*iam.AttachRolePolicyOutput, error)
+	DetachRolePolicy(ctx context.Context, params *iam.DetachRolePolicyInput, optFns ...func(*iam.Options)) (*iam.DetachRolePolicyOutput, error)
+
+	CreateInstanceProfile(ctx context.Context, params *iam.CreateInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.CreateInstanceProfileOutput, error)
+	DeleteInstanceProfile(ctx context.Context, params *iam.DeleteInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.DeleteInstanceProfileOutput, error)
+	GetInstanceProfile(ctx context.Context, params *iam.GetInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.GetInstanceProfileOutput, error)
+	AddRoleToInstanceProfile(ctx context.Context, params *iam.AddRoleToInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.AddRoleToInstanceProfileOutput, error)
+	RemoveRoleFromInstanceProfile(ctx context.Context, params *iam.RemoveRoleFromInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.RemoveRoleFromInstanceProfileOutput, error)
+}
+
+// createSSMInstanceProfile creates an IAM role with the AmazonSSMManagedInstanceCore policy and an instance profile
+// for it, both named after the jumphost. Existing resources from a previous jumphost are reused.
+func (j *jumphostConfig) createSSMInstanceProfile(ctx context.Context) error {
+	iamTags := make([]iamtypes.Tag, 0, len(j.tags))
+	for _, tag := range j.tags {
+		iamTags = append(iamTags, iamtypes.Tag{Key: tag.Key, Value: tag.Value})
+	}
+
+	if _, err := j.iamClient.CreateRole(ctx, &iam.CreateRoleInput{
+		RoleName:                 aws.String(awsResourceName),
+		AssumeRolePolicyDocument: aws.String(ec2AssumeRolePolicy),
+		Description:              aws.String("Allows osdctl jumphosts to be accessed with SSM Session Manager"),
+		Tags:                     iamTags,
+	}); err != nil && !isIAMError[*iamtypes.EntityAlreadyExistsException](err) {
+		return fmt.Errorf("failed to create IAM role: %w", err)
+	}
+
+	if _, err := j.iamClient.AttachRolePolicy(ctx, &iam.AttachRolePolicyInput{
+		RoleName:  aws.String(awsResourceName),
+		PolicyArn: aws.String(ssmManagedPolicyArn),
+	}); err != nil {
+		return fmt.Errorf("failed to attach %s to IAM role: %w", ssmManagedPolicyArn, err)
+	}
+
+	if _, err := j.iamClient.CreateInstanceProfile(ctx, &iam.CreateInstanceProfileInput{
+		InstanceProfileName: aws.String(awsResourceName),
+		Tags:                iamTags,
+	}); err != nil && !isIAMError[*iamtypes.EntityAlreadyExistsException](err) {
+		return fmt.Errorf("failed to create instance profile: %w", err)
+	}
+
+	// An instance profile holds a single role, so the limit is exceeded if the role was already added
+	if _, err := j.iamClient.AddRoleToInstanceProfile(ctx, &iam.AddRoleToInstanceProfileInput{
+		InstanceProfileName: aws.String(awsResourceName),
+		RoleName:            aws.String(awsResourceName),
+	}); err != nil && !isIAMError[*iamtypes.LimitExceededException](err) {
+		return fmt.Errorf("failed to add IAM role to instance profile: %w", err)
+	}
+
+	waiter := iam.NewInstanceProfileExistsWaiter(j.iamClient)
+	if err := waiter.Wait(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(awsResourceName)}, 1*time.Minute); err != nil {
+		return fmt.Errorf("timed out waiting for instance profile to exist: %s", awsResourceName)
+	}
+	log.Printf("created instance profile: %s", awsResourceName)
+
+	return nil
+}
+
+// deleteSSMInstanceProfile deletes the instance profile and IAM role created by createSSMInstanceProfile.
+// It is idempotent, resources which don't exist are skipped.
+func (j *jumphostConfig) deleteSSMInstanceProfile(ctx context.Context) error {
+	if _, err := j.iamClient.RemoveRoleFromInstanceProfile(ctx, &iam.RemoveRoleFromInstanceProfileInput{
+		InstanceProfileName: aws.String(awsResourceName),
+		RoleName:            aws.String(awsResourceName),
+	}); err != nil && !isIAMError[*iamtypes.NoSuchEntityException](err) {
+		return fmt.Errorf("failed to remove IAM role from instance profile: %w", err)
+	}
+
+	if _, err := j.iamClient.DeleteInstanceProfile(ctx, &iam.DeleteInstanceProfileInput{
+		InstanceProfileName: aws.String(awsResourceName),
+	}); err != nil && !isIAMError[*iamtypes.NoSuchEntityException](err) {
+		return fmt.Errorf("failed to delete instance profile: %w", err)
+	}
+
+	if _, err := j.iamClient.DetachRolePolicy(ctx, &iam.DetachRolePolicyInput{
+		RoleName:  aws.String(awsResourceName),
+		PolicyArn: aws.String(ssmManagedPolicyArn),
+	}); err != nil && !isIAMError[*iamtypes.NoSuchEntityException](err) {
+		return fmt.Errorf("failed to detach %s from IAM role: %w", ssmManagedPolicyArn, err)
+	}
+
+	if _, err := j.iamClient.DeleteRole(ctx, &iam.DeleteRoleInput{
+		RoleName: aws.String(awsResourceName),
+	}); err != nil && !isIAMError[*iamtypes.NoSuchEntityException](err) {
+		return fmt.Errorf("failed to delete IAM role: %w", err)
+	}
+
+	log.Printf("deleted instance profile and IAM role: %s", awsResourceName)
+	return nil
+}
+
+func isIAMError[T error](err error) bool {
+	var target T
+	return errors.As(err, &target)
+}
@@ -0,0 +1,364 @@
+package mc
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/osdctl/pkg/k8s"
+	"github.com/openshift/osdctl/pkg/printer"
+	"github.com/openshift/osdctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultWarnPercent = 80
+	defaultConcurrency = 10
+)
+
+type capacity struct {
+	outputFormat string
+	sector       string
+	maxHCPs      int
+	warnPercent  int
+	noHeaders    bool
+	concurrency  int
+
+	// newClient creates a client for a management cluster, it is replaced in tests
+	newClient func(clusterID string) (client.Client, error)
+}
+
+type managementClusterCapacity struct {
+	Name                     string  `json:"name"`
+	ID                       string  `json:"id"`
+	Sector                   string  `json:"sector"`
+	Region                   string  `json:"region"`
+	Status                   string  `json:"status"`
+	ProvisionShardID         string  `json:"provision_shard_id"`
+	ShardStatus              string  `json:"shard_status"`
+	HostedClusters           int     `json:"hosted_clusters"`
+	MaxHostedClusters        int     `json:"max_hosted_clusters"`
+	Utilization              float64 `json:"utilization_percent"`
+	Nodes                    int     `json:"nodes"`
+	RequestServingNodes      int     `json:"request_serving_nodes"`
+	RequestServingNodesInUse int     `json:"request_serving_nodes_in_use"`
+	NearLimit                bool    `json:"near_limit"`
+	Error                    string  `json:"error,omitempty"`
+}
+
+func newCmdCapacity() *cobra.Command {
+	c := &capacity{}
+	capacityCmd := &cobra.Command{
+		Use:   "capacity",
+		Short: "Show the hosted control plane capacity of ROSA HCP Management Clusters",
+		Long: `Show the hosted control plane capacity of ROSA HCP Management Clusters.
+
+For each management cluster, counts the hosted control planes, the nodes and how many of the
+request-serving nodes are dedicated to a hosted cluster, along with the status of its provision
+shard. Management clusters with at least --warn-percent of --max-hcps hosted control planes are
+flagged as near their limit. The management clusters don't expose a hosted control plane limit, so
+--max-hcps is required. Requires backplane access to each management cluster, the command fails
+after printing the capacity if any management cluster couldn't be checked.`,
+		Example: `
+  # Show the capacity of all management clusters
+  osdctl mc capacity --max-hcps 80
+
+  # Show the capacity of the management clusters of a sector as CSV
+  osdctl mc capacity --max-hcps 80 --sector main --output csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run(cmd.Context())
+		},
+	}
+
+	flagSet := capacityCmd.Flags()
+	flagSet.StringVar(&c.outputFormat, "output", "table", "Output format. Supported output formats include: table, json, csv")
+	flagSet.StringVar(&c.sector, "sector", "", "Only show management clusters of this sector")
+	flagSet.IntVar(&c.maxHCPs, "max-hcps", 0, "The maximum number of hosted control planes placed on a management cluster")
+	flagSet.IntVar(&c.warnPercent, "warn-percent", defaultWarnPercent, "Flag management clusters with at least this percentage of --max-hcps hosted control planes")
+	flagSet.BoolVar(&c.noHeaders, "no-headers", false, "Skip headers in table and csv output")
+	flagSet.IntVar(&c.concurrency, "concurrency", defaultConcurrency, "Number of management clusters to check in parallel")
+	_ = capacityCmd.MarkFlagRequired("max-hcps")
+
+	return capacityCmd
+}
+
+func (c *capacity) validate() error {
+	switch c.outputFormat {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("unsupported output format: %s, must be one of: table, json, csv", c.outputFormat)
+	}
+	if c.maxHCPs <= 0 {
+		return fmt.Errorf("--max-hcps must be greater than 0")
+	}
+	if c.warnPercent <= 0 || c.warnPercent > 100 {
+		return fmt.Errorf("--warn-percent must be between 1 and 100")
+	}
+	if c.concurrency <= 0 {
+		return fmt.Errorf("--concurrency must be greater than 0")
+	}
+	return nil
+}
+
+func (c *capacity) Run(ctx context.Context) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	ocm, err := utils.CreateConnection()
+	if err != nil {
+		return err
+	}
+	defer ocm.Close()
+
+	managementClusters, err := ocm.OSDFleetMgmt().V1().ManagementClusters().List().Send()
+	if err != nil {
+		return fmt.Errorf("failed to list management clusters: %v", err)
+	}
+
+	provisionShards, err := getProvisionShards(ocm)
+	if err != nil {
+		log.Printf("Warning: %s", err)
+	}
+
+	if c.newClient == nil {
+		scheme := runtime.NewScheme()
+		if err := hypershiftv1beta1.AddToScheme(scheme); err != nil {
+			return fmt.Errorf("failed to add hypershift scheme: %v", err)
+		}
+		if err := corev1.AddToScheme(scheme); err != nil {
+			return fmt.Errorf("failed to add core v1 scheme: %v", err)
+		}
+		c.newClient = func(clusterID string) (client.Client, error) {
+			return k8s.NewWithConn(clusterID, client.Options{Scheme: scheme}, ocm)
+		}
+	}
+
+	var output []managementClusterCapacity
+	for _, mc := range managementClusters.Items().Slice() {
+		if c.sector != "" && mc.Sector() != c.sector {
+			continue
+		}
+
+		mcCapacity := managementClusterCapacity{
+			Name:   mc.Name(),
+			ID:     mc.ClusterManagementReference().ClusterId(),
+			Sector: mc.Sector(),
+			Region: mc.Region(),
+			Status: mc.Status(),
+		}
+		setShard(&mcCapacity, provisionShards, mc.Parent().Name())
+		output = append(output, mcCapacity)
+	}
+
+	collectErr := c.collectAll(ctx, output)
+	sortByUtilization(output)
+
+	var printErr error
+	switch c.outputFormat {
+	case "json":
+		printErr = c.printJSON(os.Stdout, output)
+	case "csv":
+		printErr = c.printCSV(os.Stdout, output)
+	default:
+		printErr = c.printTable(os.Stdout, output)
+	}
+	return errors.Join(printErr, collectErr)
+}
+
+// collectAll collects the capacity of the management clusters in parallel. The error of a management cluster is
+// recorded in its row and returned together with those of the others once all management clusters were checked.
+func (c *capacity) collectAll(ctx context.Context, output []managementClusterCapacity) error {
+	errs := make([]error, len(output))
+
+	eg := errgroup.Group{}
+	eg.SetLimit(c.concurrency)
+	for i := range output {
+		eg.Go(func() error {
+			if err := c.collect(ctx, &output[i]); err != nil {
+				output[i].Error = err.Error()
+				errs[i] = fmt.Errorf("failed to get the capacity of %s: %w", output[i].Name, err)
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// setShard sets the provision shard of a management cluster, which is matched by the name of its service cluster
+func setShard(mcCapacity *managementClusterCapacity, provisionShards map[string]*cmv1.ProvisionShard, serviceClusterName string) {
+	mcCapacity.ProvisionShardID = "N/A"
+	mcCapacity.ShardStatus = "N/A"
+	if ps, ok := provisionShards[serviceClusterName]; ok {
+		mcCapacity.ProvisionShardID = ps.ID()
+		mcCapacity.ShardStatus = ps.Status()
+	}
+}
+
+// collect fills in the hosted control plane and node counts of a management cluster
+func (c *capacity) collect(ctx context.Context, mcCapacity *managementClusterCapacity) error {
+	kubeClient, err := c.newClient(mcCapacity.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create management cluster client: %v", err)
+	}
+
+	hostedClusters := &hypershiftv1beta1.HostedClusterList{}
+	if err := kubeClient.List(ctx, hostedClusters); err != nil {
+		return fmt.Errorf("failed to list hosted clusters: %v", err)
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := kubeClient.List(ctx, nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	mcCapacity.HostedClusters = len(hostedClusters.Items)
+	mcCapacity.Nodes = len(nodes.Items)
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[hypershiftv1beta1.RequestServingComponentLabel]; !ok {
+			continue
+		}
+		mcCapacity.RequestServingNodes++
+		// Request-serving nodes are labelled with the hosted cluster they are dedicated to once it is scheduled
+		if node.Labels[hypershiftv1beta1.HostedClusterLabel] != "" {
+			mcCapacity.RequestServingNodesInUse++
+		}
+	}
+
+	c.setUtilization(mcCapacity)
+	return nil
+}
+
+// setUtilization sets the percentage of the hosted control plane limit in use and flags the management cluster
+// if it reaches the warning threshold
+func (c *capacity) setUtilization(mcCapacity *managementClusterCapacity) {
+	mcCapacity.MaxHostedClusters = c.maxHCPs
+	mcCapacity.Utilization = float64(mcCapacity.HostedClusters) * 100 / float64(c.maxHCPs)
+	mcCapacity.NearLimit = mcCapacity.Utilization >= float64(c.warnPercent)
+}
+
+// sortByUtilization sorts management clusters with the least headroom first
+func sortByUtilization(output []managementClusterCapacity) {
+	sort.SliceStable(output, func(i, j int) bool {
+		if output[i].Utilization != output[j].Utilization {
+			return output[i].Utilization > output[j].Utilization
+		}
+		return output[i].Name < output[j].Name
+	})
+}
+
+func (c *capacity) printJSON(w io.Writer, output []managementClusterCapacity) error {
+	if output == nil {
+		output = []managementClusterCapacity{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func (c *capacity) printCSV(w io.Writer, output []managementClusterCapacity) error {
+	cw := csv.NewWriter(w)
+	if !c.noHeaders {
+		if err := cw.Write([]string{
+			"name",
+			"id",
+			"sector",
+			"region",
+			"status",
+			"provision_shard_id",
+			"shard_status",
+			"hosted_clusters",
+			"max_hosted_clusters",
+			"utilization_percent",
+			"nodes",
+			"request_serving_nodes",
+			"request_serving_nodes_in_use",
+			"near_limit",
+			"error",
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV header: %v", err)
+		}
+	}
+
+	for _, mc := range output {
+		if err := cw.Write([]string{
+			mc.Name,
+			mc.ID,
+			mc.Sector,
+			mc.Region,
+			mc.Status,
+			mc.ProvisionShardID,
+			mc.ShardStatus,
+			strconv.Itoa(mc.HostedClusters),
+			strconv.Itoa(mc.MaxHostedClusters),
+			strconv.FormatFloat(mc.Utilization, 'f', 1, 64),
+			strconv.Itoa(mc.Nodes),
+			strconv.Itoa(mc.RequestServingNodes),
+			strconv.Itoa(mc.RequestServingNodesInUse),
+			strconv.FormatBool(mc.NearLimit),
+			mc.Error,
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %v", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c *capacity) printTable(w io.Writer, output []managementClusterCapacity) error {
+	p := printer.NewTablePrinter(w, 20, 1, 3, ' ')
+	if !c.noHeaders {
+		p.AddRow([]string{"NAME", "SECTOR", "REGION", "STATUS", "SHARD STATUS", "HCPS", "UTILIZATION", "NODES", "REQUEST SERVING NODES", "NEAR LIMIT"})
+	}
+
+	nearLimit := 0
+	for _, mc := range output {
+		if mc.Error != "" {
+			p.AddRow([]string{mc.Name, mc.Sector, mc.Region, mc.Status, mc.ShardStatus, "?", "?", "?", "?", "?"})
+			continue
+		}
+
+		flag := ""
+		if mc.NearLimit {
+			flag = "⚠️"
+			nearLimit++
+		}
+		p.AddRow([]string{
+			mc.Name,
+			mc.Sector,
+			mc.Region,
+			mc.Status,
+			mc.ShardStatus,
+			fmt.Sprintf("%d/%d", mc.HostedClusters, mc.MaxHostedClusters),
+			fmt.Sprintf("%.0f%%", mc.Utilization),
+			strconv.Itoa(mc.Nodes),
+			fmt.Sprintf("%d/%d", mc.RequestServingNodesInUse, mc.RequestServingNodes),
+			flag,
+		})
+	}
+	if err := p.Flush(); err != nil {
+		return err
+	}
+
+	if nearLimit > 0 {
+		fmt.Fprintf(w, "\n%d management cluster(s) have at least %d%% of %d hosted control planes\n", nearLimit, c.warnPercent, c.maxHCPs)
+	}
+	return nil
+}
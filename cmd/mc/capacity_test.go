@@ -0,0 +1,210 @@
+package mc
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newHostedCluster(namespace, name string) *hypershiftv1beta1.HostedCluster {
+	return &hypershiftv1beta1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func TestCapacityCollect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, hypershiftv1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newHostedCluster("ocm-production-a", "a"),
+		newHostedCluster("ocm-production-b", "b"),
+		newHostedCluster("ocm-production-c", "c"),
+		newHostedCluster("ocm-production-d", "d"),
+		newNode("worker-1", nil),
+		newNode("serving-1", map[string]string{
+			hypershiftv1beta1.RequestServingComponentLabel: "true",
+			hypershiftv1beta1.HostedClusterLabel:           "ocm-production-a-a",
+		}),
+		newNode("serving-2", map[string]string{
+			hypershiftv1beta1.RequestServingComponentLabel: "true",
+		}),
+	).Build()
+
+	c := &capacity{
+		maxHCPs:     5,
+		warnPercent: 80,
+		newClient: func(clusterID string) (client.Client, error) {
+			assert.Equal(t, "mc-id", clusterID)
+			return kubeClient, nil
+		},
+	}
+
+	mcCapacity := managementClusterCapacity{ID: "mc-id"}
+	require.NoError(t, c.collect(context.Background(), &mcCapacity))
+
+	assert.Equal(t, managementClusterCapacity{
+		ID:                       "mc-id",
+		HostedClusters:           4,
+		MaxHostedClusters:        5,
+		Utilization:              80,
+		Nodes:                    3,
+		RequestServingNodes:      2,
+		RequestServingNodesInUse: 1,
+		NearLimit:                true,
+	}, mcCapacity)
+}
+
+func TestCapacityCollectClientError(t *testing.T) {
+	c := &capacity{
+		maxHCPs:     5,
+		warnPercent: 80,
+		newClient: func(string) (client.Client, error) {
+			return nil, errors.New("no backplane access")
+		},
+	}
+
+	err := c.collect(context.Background(), &managementClusterCapacity{ID: "mc-id"})
+	assert.ErrorContains(t, err, "no backplane access")
+}
+
+func TestSetUtilization(t *testing.T) {
+	tests := []struct {
+		name           string
+		hostedClusters int
+		warnPercent    int
+		expectedNear   bool
+	}{
+		{name: "below threshold", hostedClusters: 50, warnPercent: 80, expectedNear: false},
+		{name: "at threshold", hostedClusters: 64, warnPercent: 80, expectedNear: true},
+		{name: "above limit", hostedClusters: 90, warnPercent: 80, expectedNear: true},
+		{name: "custom threshold", hostedClusters: 50, warnPercent: 50, expectedNear: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &capacity{maxHCPs: 80, warnPercent: tt.warnPercent}
+			mcCapacity := managementClusterCapacity{HostedClusters: tt.hostedClusters}
+			c.setUtilization(&mcCapacity)
+			assert.Equal(t, tt.expectedNear, mcCapacity.NearLimit)
+			assert.Equal(t, 80, mcCapacity.MaxHostedClusters)
+		})
+	}
+}
+
+func TestSetShard(t *testing.T) {
+	ps, err := cmv1.NewProvisionShard().ID("shard-1").Status("active").Build()
+	require.NoError(t, err)
+	provisionShards := map[string]*cmv1.ProvisionShard{"hs-sc-1": ps}
+
+	mcCapacity := managementClusterCapacity{}
+	setShard(&mcCapacity, provisionShards, "hs-sc-1")
+	assert.Equal(t, "shard-1", mcCapacity.ProvisionShardID)
+	assert.Equal(t, "active", mcCapacity.ShardStatus)
+
+	mcCapacity = managementClusterCapacity{}
+	setShard(&mcCapacity, provisionShards, "hs-sc-2")
+	assert.Equal(t, "N/A", mcCapacity.ProvisionShardID)
+	assert.Equal(t, "N/A", mcCapacity.ShardStatus)
+}
+
+func TestSortByUtilization(t *testing.T) {
+	output := []managementClusterCapacity{
+		{Name: "c", Utilization: 10},
+		{Name: "b", Utilization: 90},
+		{Name: "a", Utilization: 10},
+	}
+	sortByUtilization(output)
+
+	var names []string
+	for _, mc := range output {
+		names = append(names, mc.Name)
+	}
+	assert.Equal(t, []string{"b", "a", "c"}, names)
+}
+
+func TestCapacityCollectAll(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, hypershiftv1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newHostedCluster("ocm-production-a", "a")).Build()
+
+	c := &capacity{
+		maxHCPs:     5,
+		warnPercent: 80,
+		concurrency: 2,
+		newClient: func(clusterID string) (client.Client, error) {
+			if clusterID == "unreachable-id" {
+				return nil, errors.New("no backplane access")
+			}
+			return kubeClient, nil
+		},
+	}
+
+	output := []managementClusterCapacity{
+		{Name: "mc-1", ID: "id-1"},
+		{Name: "mc-2", ID: "unreachable-id"},
+		{Name: "mc-3", ID: "id-3"},
+	}
+	err := c.collectAll(context.Background(), output)
+	assert.ErrorContains(t, err, "failed to get the capacity of mc-2")
+	assert.Equal(t, 1, output[0].HostedClusters)
+	assert.Contains(t, output[1].Error, "no backplane access")
+	assert.Equal(t, 1, output[2].HostedClusters)
+	assert.Empty(t, output[2].Error)
+}
+
+func TestCapacityValidate(t *testing.T) {
+	assert.NoError(t, (&capacity{outputFormat: "csv", maxHCPs: 80, warnPercent: 80, concurrency: 10}).validate())
+	assert.Error(t, (&capacity{outputFormat: "yaml", maxHCPs: 80, warnPercent: 80, concurrency: 10}).validate())
+	assert.Error(t, (&capacity{outputFormat: "table", maxHCPs: 0, warnPercent: 80, concurrency: 10}).validate())
+	assert.Error(t, (&capacity{outputFormat: "table", maxHCPs: 80, warnPercent: 101, concurrency: 10}).validate())
+	assert.Error(t, (&capacity{outputFormat: "table", maxHCPs: 80, warnPercent: 80, concurrency: 0}).validate())
+}
+
+func TestCapacityPrintCSV(t *testing.T) {
+	c := &capacity{}
+	output := []managementClusterCapacity{
+		{Name: "mc-1", ID: "id-1", HostedClusters: 70, MaxHostedClusters: 80, Utilization: 87.5, NearLimit: true},
+		{Name: "mc-2", ID: "id-2", Error: "unreachable"},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.printCSV(buf, output))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "name", records[0][0])
+	assert.Equal(t, []string{"mc-1", "id-1", "", "", "", "", "", "70", "80", "87.5", "0", "0", "0", "true", ""}, records[1])
+	assert.Equal(t, "unreachable", records[2][14])
+}
+
+func TestCapacityPrintTable(t *testing.T) {
+	c := &capacity{maxHCPs: 80, warnPercent: 80}
+	output := []managementClusterCapacity{
+		{Name: "mc-1", HostedClusters: 70, MaxHostedClusters: 80, Utilization: 87.5, RequestServingNodes: 4, RequestServingNodesInUse: 3, NearLimit: true},
+		{Name: "mc-2", Error: "unreachable"},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.printTable(buf, output))
+	assert.Contains(t, buf.String(), "70/80")
+	assert.Contains(t, buf.String(), "3/4")
+	assert.Contains(t, buf.String(), "1 management cluster(s) have at least 80% of 80 hosted control planes")
+}
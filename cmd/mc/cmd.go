@@ -9,6 +9,7 @@ func NewCmdMC() *cobra.Command {
 	}
 
 	mc.AddCommand(newCmdList())
+	mc.AddCommand(newCmdCapacity())
 
 	return mc
 }
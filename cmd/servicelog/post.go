@@ -14,14 +14,13 @@ import (
 	"strings"
 	"time"
 
-	"k8s.io/utils/strings/slices"
-
 	"github.com/openshift-online/ocm-cli/pkg/arguments"
 	"github.com/openshift-online/ocm-cli/pkg/dump"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/osdctl/internal/io"
 	"github.com/openshift/osdctl/internal/servicelog"
+	"github.com/openshift/osdctl/internal/templates"
 	"github.com/openshift/osdctl/internal/utils"
 	"github.com/openshift/osdctl/pkg/link_validator"
 	"github.com/openshift/osdctl/pkg/printer"
@@ -46,6 +45,9 @@ type PostCmdOptions struct {
 	InternalOnly    bool
 	ClusterId       string
 	SkipLinkCheck   bool
+	Params          templates.Params
+	listParams      bool
+	lint            bool
 
 	// Messaged clusters
 	successfulClusters map[string]string
@@ -54,6 +56,9 @@ type PostCmdOptions struct {
 
 const documentationBaseURL = "https://docs.openshift.com"
 
+// clusterPlaceholders are replaced for each cluster a service log is sent to, rather than by '-p' flags
+var clusterPlaceholders = []string{"${CLUSTER_UUID}"}
+
 func newPostCmd() *cobra.Command {
 	var opts = PostCmdOptions{}
 	postCmd := &cobra.Command{
@@ -75,6 +80,10 @@ func newPostCmd() *cobra.Command {
   # Post a short external message
   osdctl servicelog post --cluster-id ${CLUSTER_ID} -r "summary=External Message" -r "description=This is an external message" -r internal_only=False
 
+  # Show the parameters a template needs, or check that it declares all of them
+  osdctl servicelog post -t file.json --list-params
+  osdctl servicelog post -t file.json --lint
+
   # Post a service log to a group of clusters, determined by an OCM query
   ocm list cluster -p search="cloud_provider.id is 'gcp' and managed='true' and state is 'ready'"
   osdctl servicelog post -q "cloud_provider.id is 'gcp' and managed='true' and state is 'ready'" -t file.json
@@ -97,6 +106,8 @@ func newPostCmd() *cobra.Command {
 	postCmd.Flags().StringVarP(&opts.clustersFile, "clusters-file", "c", "", `Read a list of clusters to post the servicelog to. the format of the file is: {"clusters":["$CLUSTERID"]}`)
 	postCmd.Flags().BoolVarP(&opts.InternalOnly, "internal", "i", false, "Internal only service log. Use MESSAGE for template parameter (eg. -p MESSAGE='My super secret message').")
 	postCmd.Flags().BoolVar(&opts.SkipLinkCheck, "skip-link-check", false, "Skip validating if links in Service Log are valid")
+	postCmd.Flags().BoolVar(&opts.listParams, "list-params", false, "List the parameters used by the template, with the types, defaults and descriptions it declares, then exit")
+	postCmd.Flags().BoolVar(&opts.lint, "lint", false, "Check that every placeholder used by the template is declared in its \"parameters\", then exit")
 
 	return postCmd
 }
@@ -110,6 +121,9 @@ func (o *PostCmdOptions) Init() error {
 }
 
 func (o *PostCmdOptions) Validate() error {
+	if o.listParams || o.lint {
+		return nil
+	}
 	if o.ClusterId == "" && len(o.filterParams) == 0 && o.clustersFile == "" && len(o.filterFiles) == 0 {
 		return fmt.Errorf("no cluster identifier has been found, please specify --cluster-id, -q, -c or -f")
 	}
@@ -150,6 +164,18 @@ func (o *PostCmdOptions) Run() error {
 	o.readFilterFile() // parse the ocm filters in file provided via '-f' flag
 	o.readTemplate()   // parse the given JSON template provided via '-t' flag
 
+	if o.listParams {
+		return o.Params.Print(os.Stdout, o.usedParams(), clusterPlaceholders)
+	}
+	if o.lint {
+		return o.lintTemplate()
+	}
+
+	// Fill in the declared defaults of parameters which weren't set with '-p', and check the values against their declared types
+	if err := o.applyParamDefaults(); err != nil {
+		return err
+	}
+
 	// For every '-p' flag, replace its related placeholder in the template & filterFiles
 	for k := range userParameterNames {
 		o.replaceFlags(userParameterNames[k], userParameterValues[k])
@@ -165,7 +191,7 @@ func (o *PostCmdOptions) Run() error {
 
 	// Check if there are any remaining placeholders in the template that are not replaced by a parameter,
 	// excluding '${CLUSTER_UUID}' which will be replaced for each cluster later
-	o.checkLeftovers([]string{"${CLUSTER_UUID}"})
+	o.checkLeftovers(clusterPlaceholders)
 
 	// Create an OCM client to talk to the cluster API
 	// the user has to be logged in (e.g. 'ocm login')
@@ -357,19 +383,37 @@ func (o *PostCmdOptions) check(response *sdk.Response, clusterMessage servicelog
 
 // parseUserParameters parse all the '-p FOO=BAR' parameters and checks for syntax errors
 func (o *PostCmdOptions) parseUserParameters() {
-	for _, v := range o.TemplateParams {
-		if !strings.Contains(v, "=") {
-			log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
-		}
+	names, values, err := templates.ParseAssignments(o.TemplateParams)
+	if err != nil {
+		log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
+	}
+	userParameterNames = append(userParameterNames, names...)
+	userParameterValues = append(userParameterValues, values...)
+}
 
-		param := strings.SplitN(v, "=", 2)
-		if param[0] == "" || param[1] == "" {
-			log.Fatalf("Wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
-		}
+// usedParams returns the names of the parameters used by the template and the filter files
+func (o *PostCmdOptions) usedParams() []string {
+	return templates.UsedParams(append(o.Message.Texts(), o.filtersFromFile)...)
+}
 
-		userParameterNames = append(userParameterNames, fmt.Sprintf("${%v}", param[0]))
-		userParameterValues = append(userParameterValues, param[1])
+// applyParamDefaults adds the default value of every declared parameter not set with '-p', then validates all values
+func (o *PostCmdOptions) applyParamDefaults() error {
+	var err error
+	userParameterNames, userParameterValues, err = o.Params.WithDefaults(o.usedParams(), userParameterNames, userParameterValues)
+	return err
+}
+
+// lintTemplate fails if the template uses placeholders which it doesn't declare
+func (o *PostCmdOptions) lintTemplate() error {
+	problems := o.Params.Lint(o.usedParams(), clusterPlaceholders)
+	for _, problem := range problems {
+		log.Error(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("template %s has %d problem(s)", o.Template, len(problems))
 	}
+	log.Infof("template %s declares all of its parameters", o.Template)
+	return nil
 }
 
 // parseOverides parses all the '-o FOO=BAR' overrides which replace items in the final JSON document
@@ -481,6 +525,7 @@ func (o *PostCmdOptions) readTemplate() {
 		if err := o.parseTemplate(messageTemplate); err != nil {
 			log.Fatalf("Cannot not parse the JSON internal message template.\nError: %q\n", err)
 		}
+		o.Params = templates.Params{"MESSAGE": {Type: templates.TypeString, Description: "Message of the internal service log"}}
 		return
 	}
 
@@ -511,6 +556,10 @@ func (o *PostCmdOptions) readTemplate() {
 	if err = o.parseTemplate(file); err != nil {
 		log.Fatalf("Cannot not parse the JSON template.\nError: %q\n", err)
 	}
+
+	if o.Params, err = templates.ParseParams(file); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func (o *PostCmdOptions) readFilterFile() {
@@ -534,50 +583,18 @@ func (o *PostCmdOptions) readFilterFile() {
 }
 
 func (o *PostCmdOptions) FindLeftovers(s string) (matches []string) {
-	r := regexp.MustCompile(`\${[^{}]*}`)
-	matches = r.FindAllString(s, -1)
-	return matches
+	return templates.FindPlaceholders(s)
 }
 
 func (o *PostCmdOptions) checkLeftovers(excludes []string) {
-	unusedParameters, _ := o.Message.FindLeftovers()
-	unusedParameters = append(unusedParameters, o.FindLeftovers(o.filtersFromFile)...)
-
-	var numberOfMissingParameters int
-	for _, v := range unusedParameters {
-		// Ignore parameters in the exclude list, ie ${CLUSTER_UUID}, which will be replaced later for each cluster a servicelog is sent to
-
-		if !slices.Contains(excludes, v) {
-			numberOfMissingParameters++
-			regex := strings.NewReplacer("${", "", "}", "")
-			log.Errorf("The one of the template files is using '%s' parameter, but '--param' flag is not set for this one. Use '-p %v=\"FOOBAR\"' to fix this.", v, regex.Replace(v))
-		}
-	}
-	if numberOfMissingParameters == 1 {
-		log.Fatal("Please define this missing parameter properly.")
-	} else if numberOfMissingParameters > 1 {
-		log.Fatalf("Please define all %v missing parameters properly.", numberOfMissingParameters)
+	if err := templates.CheckLeftovers(excludes, append(o.Message.Texts(), o.filtersFromFile)...); err != nil {
+		log.Fatal(err)
 	}
 }
 
 func (o *PostCmdOptions) replaceFlags(flagName string, flagValue string) {
-	if flagValue == "" {
-		log.Fatalf("The selected template is using '%[1]s' parameter, but '%[1]s' flag was not set. Use '-p %[1]s=\"FOOBAR\"' to fix this.", flagName)
-	}
-
-	found := false
-	if o.Message.SearchFlag(flagName) {
-		found = true
-		o.Message.ReplaceWithFlag(flagName, flagValue)
-	}
-
-	if strings.Contains(o.filtersFromFile, flagName) {
-		found = true
-		o.filtersFromFile = strings.ReplaceAll(o.filtersFromFile, flagName, flagValue)
-	}
-
-	if !found {
-		log.Fatalf("The selected template is not using '%s' parameter, but '--param' flag was set. Do not use '-p %s=%s' to fix this.", flagName, flagName, flagValue)
+	if err := templates.Replace(flagName, flagValue, append(o.Message.TextFields(), &o.filtersFromFile)...); err != nil {
+		log.Fatal(err)
 	}
 }
 
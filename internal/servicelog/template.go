@@ -98,6 +98,16 @@ func (m *Message) SearchFlag(placeholder string) (found bool) {
 	return false
 }
 
+// Texts returns every field of the message which may contain placeholders
+func (m *Message) Texts() []string {
+	return []string{m.Severity, m.ServiceName, m.ClusterUUID, m.ClusterID, m.Summary, m.Description, m.EventStreamID, m.SubscriptionID}
+}
+
+// TextFields returns a pointer to every field of the message which may contain placeholders, to replace them
+func (m *Message) TextFields() []*string {
+	return []*string{&m.Severity, &m.ServiceName, &m.ClusterUUID, &m.ClusterID, &m.Summary, &m.Description, &m.EventStreamID, &m.SubscriptionID}
+}
+
 func (m *Message) FindLeftovers() (matches []string, found bool) {
 	r := regexp.MustCompile(`\${[^{}]*}`)
 	str := m.Severity + m.ServiceName + m.ClusterUUID + m.Summary + m.Description + m.EventStreamID
@@ -0,0 +1,228 @@
+// Package templates implements the ${...} placeholder templating shared by service log and
+// limited support templates.
+//
+// Templates may declare their parameters in a top-level "parameters" object, which is
+// ignored when the template itself is sent to OCM:
+//
+//	{
+//	  "summary": "Action required: ${ACTION}",
+//	  "description": "... ${ACTION} before ${DEADLINE} ...",
+//	  "parameters": {
+//	    "ACTION":   {"description": "What the customer needs to do"},
+//	    "DEADLINE": {"type": "string", "default": "the next maintenance window"}
+//	  }
+//	}
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/osdctl/pkg/printer"
+)
+
+// ParamType is the type of value a template parameter accepts
+type ParamType string
+
+const (
+	TypeString ParamType = "string"
+	TypeInt    ParamType = "int"
+	TypeBool   ParamType = "bool"
+	TypeURL    ParamType = "url"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\${[^{}]*}`)
+
+// Param is a parameter declared by a template
+type Param struct {
+	Type        ParamType `json:"type,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Default     *string   `json:"default,omitempty"`
+}
+
+// Params are the parameters declared by a template, by name
+type Params map[string]Param
+
+type declaration struct {
+	Parameters Params `json:"parameters"`
+}
+
+// ParseParams reads the parameters declared in the "parameters" key of a JSON template
+func ParseParams(template []byte) (Params, error) {
+	var decl declaration
+	if err := json.Unmarshal(template, &decl); err != nil {
+		return nil, fmt.Errorf("cannot parse template parameters: %w", err)
+	}
+	if decl.Parameters == nil {
+		return Params{}, nil
+	}
+
+	for name, param := range decl.Parameters {
+		if param.Type == "" {
+			param.Type = TypeString
+			decl.Parameters[name] = param
+		}
+		switch param.Type {
+		case TypeString, TypeInt, TypeBool, TypeURL:
+		default:
+			return nil, fmt.Errorf("parameter %s has unsupported type %q, must be one of %s, %s, %s or %s", name, param.Type, TypeString, TypeInt, TypeBool, TypeURL)
+		}
+		if param.Default != nil {
+			if err := param.Validate(*param.Default); err != nil {
+				return nil, fmt.Errorf("default value of parameter %s is invalid: %w", name, err)
+			}
+		}
+	}
+
+	return decl.Parameters, nil
+}
+
+// Validate checks that value is valid for the parameter's type
+func (p Param) Validate(value string) error {
+	switch p.Type {
+	case TypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+	case TypeURL:
+		u, err := url.ParseRequestURI(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute URL", value)
+		}
+	}
+	return nil
+}
+
+// Placeholder returns the placeholder for the parameter name, e.g. FOO -> ${FOO}
+func Placeholder(name string) string {
+	return "${" + name + "}"
+}
+
+// PlaceholderName returns the parameter name of the placeholder, e.g. ${FOO} -> FOO
+func PlaceholderName(placeholder string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(placeholder, "${"), "}")
+}
+
+// FindPlaceholders returns every placeholder found in s, in order of appearance
+func FindPlaceholders(s string) []string {
+	return placeholderRegexp.FindAllString(s, -1)
+}
+
+// UsedParams returns the sorted, unique names of the parameters used by texts
+func UsedParams(texts ...string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, text := range texts {
+		for _, placeholder := range FindPlaceholders(text) {
+			name := PlaceholderName(placeholder)
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseAssignment parses a NAME=VALUE parameter as given with '-p'
+func ParseAssignment(assignment string) (name string, value string, err error) {
+	name, value, found := strings.Cut(assignment, "=")
+	if !found || name == "" || value == "" {
+		return "", "", fmt.Errorf("wrong syntax of '-p' flag. Please use it like this: '-p FOO=BAR'")
+	}
+	return name, value, nil
+}
+
+// Defaults returns the default values of the declared parameters which are used by the template but weren't set
+func (p Params) Defaults(used []string, set map[string]bool) map[string]string {
+	defaults := map[string]string{}
+	for _, name := range used {
+		param, ok := p[name]
+		if !ok || param.Default == nil || set[name] {
+			continue
+		}
+		defaults[name] = *param.Default
+	}
+	return defaults
+}
+
+// ValidateValues checks the values given for declared parameters against their type
+func (p Params) ValidateValues(values map[string]string) error {
+	var errs []string
+	for _, name := range sortedKeys(values) {
+		param, ok := p[name]
+		if !ok {
+			continue
+		}
+		if err := param.Validate(values[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("parameter %s: %v", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid template parameters:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// Lint returns a problem for every placeholder used without being declared, and for every
+// declared parameter which isn't used. Placeholders in excludes are filled in by osdctl itself.
+func (p Params) Lint(used []string, excludes []string) []string {
+	var problems []string
+	isUsed := map[string]bool{}
+	for _, name := range used {
+		isUsed[name] = true
+		if _, ok := p[name]; ok || slices.Contains(excludes, Placeholder(name)) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("placeholder %s is used but not declared in \"parameters\"", Placeholder(name)))
+	}
+	for _, name := range sortedKeys(p) {
+		if !isUsed[name] {
+			problems = append(problems, fmt.Sprintf("parameter %s is declared but never used", name))
+		}
+	}
+	return problems
+}
+
+// Print writes a table of the parameters used by the template, including undeclared ones
+func (p Params) Print(w io.Writer, used []string, excludes []string) error {
+	table := printer.NewTablePrinter(w, 10, 1, 3, ' ')
+	table.AddRow([]string{"Parameter", "Type", "Required", "Default", "Description"})
+	for _, name := range used {
+		if slices.Contains(excludes, Placeholder(name)) {
+			continue
+		}
+		param, declared := p[name]
+		if !declared {
+			table.AddRow([]string{name, string(TypeString), "true", "", "(not declared by the template)"})
+			continue
+		}
+		defaultValue := ""
+		if param.Default != nil {
+			defaultValue = *param.Default
+		}
+		table.AddRow([]string{name, string(param.Type), strconv.FormatBool(param.Default == nil), defaultValue, param.Description})
+	}
+	return table.Flush()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
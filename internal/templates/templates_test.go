@@ -0,0 +1,146 @@
+package templates
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTemplate = `{
+	"summary": "Action required: ${ACTION}",
+	"details": "Please ${ACTION} within ${DAYS} days, see ${DOC_URL}. Cluster: ${CLUSTER_UUID}",
+	"parameters": {
+		"ACTION": {"description": "What the customer needs to do"},
+		"DAYS": {"type": "int", "default": "7", "description": "Days before the deadline"},
+		"DOC_URL": {"type": "url"},
+		"UNUSED": {}
+	}
+}`
+
+func TestParseParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		expectErr bool
+		expected  int
+	}{
+		{
+			name:     "declared parameters",
+			template: testTemplate,
+			expected: 4,
+		},
+		{
+			name:     "no parameters",
+			template: `{"summary": "${FOO}"}`,
+			expected: 0,
+		},
+		{
+			name:      "unsupported type",
+			template:  `{"parameters": {"FOO": {"type": "float"}}}`,
+			expectErr: true,
+		},
+		{
+			name:      "default of the wrong type",
+			template:  `{"parameters": {"FOO": {"type": "bool", "default": "maybe"}}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseParams([]byte(tt.template))
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, params, tt.expected)
+			for _, param := range params {
+				assert.NotEmpty(t, param.Type)
+			}
+		})
+	}
+}
+
+func TestParamValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		param     Param
+		value     string
+		expectErr bool
+	}{
+		{name: "string", param: Param{Type: TypeString}, value: "anything"},
+		{name: "int", param: Param{Type: TypeInt}, value: "42"},
+		{name: "not an int", param: Param{Type: TypeInt}, value: "forty-two", expectErr: true},
+		{name: "bool", param: Param{Type: TypeBool}, value: "true"},
+		{name: "not a bool", param: Param{Type: TypeBool}, value: "yes please", expectErr: true},
+		{name: "url", param: Param{Type: TypeURL}, value: "https://docs.openshift.com/rosa"},
+		{name: "relative url", param: Param{Type: TypeURL}, value: "/rosa", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.param.Validate(tt.value)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUsedParams(t *testing.T) {
+	assert.Equal(t, []string{"A", "B"}, UsedParams("${B} and ${A}", "${A} again"))
+	assert.Nil(t, UsedParams("no placeholders"))
+}
+
+func TestParseAssignment(t *testing.T) {
+	name, value, err := ParseAssignment("FOO=BAR=BAZ")
+	assert.NoError(t, err)
+	assert.Equal(t, "FOO", name)
+	assert.Equal(t, "BAR=BAZ", value)
+
+	for _, invalid := range []string{"FOO", "=BAR", "FOO="} {
+		_, _, err := ParseAssignment(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDefaultsAndValidateValues(t *testing.T) {
+	params, err := ParseParams([]byte(testTemplate))
+	assert.NoError(t, err)
+
+	used := []string{"ACTION", "DAYS", "DOC_URL"}
+	assert.Equal(t, map[string]string{"DAYS": "7"}, params.Defaults(used, map[string]bool{"ACTION": true}))
+	assert.Empty(t, params.Defaults(used, map[string]bool{"DAYS": true}))
+
+	assert.NoError(t, params.ValidateValues(map[string]string{"DAYS": "3", "DOC_URL": "https://example.com", "OTHER": "x"}))
+	assert.Error(t, params.ValidateValues(map[string]string{"DAYS": "three"}))
+}
+
+func TestLint(t *testing.T) {
+	params, err := ParseParams([]byte(testTemplate))
+	assert.NoError(t, err)
+
+	used := []string{"ACTION", "CLUSTER_UUID", "DAYS", "DOC_URL", "UNDECLARED"}
+	problems := params.Lint(used, []string{"${CLUSTER_UUID}"})
+	assert.Equal(t, []string{
+		`placeholder ${UNDECLARED} is used but not declared in "parameters"`,
+		"parameter UNUSED is declared but never used",
+	}, problems)
+}
+
+func TestPrint(t *testing.T) {
+	params, err := ParseParams([]byte(testTemplate))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, params.Print(buf, []string{"ACTION", "CLUSTER_UUID", "DAYS", "UNDECLARED"}, []string{"${CLUSTER_UUID}"}))
+
+	output := buf.String()
+	assert.Contains(t, output, "Days before the deadline")
+	assert.Contains(t, output, "(not declared by the template)")
+	assert.NotContains(t, output, "CLUSTER_UUID")
+}
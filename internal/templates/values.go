@@ -0,0 +1,89 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParseAssignments parses the '-p NAME=VALUE' parameters into the placeholders to replace and their values, in the
+// order they were given
+func ParseAssignments(assignments []string) (placeholders []string, values []string, err error) {
+	for _, assignment := range assignments {
+		name, value, err := ParseAssignment(assignment)
+		if err != nil {
+			return nil, nil, err
+		}
+		placeholders = append(placeholders, Placeholder(name))
+		values = append(values, value)
+	}
+	return placeholders, values, nil
+}
+
+// WithDefaults appends the declared default of every parameter used by the template which wasn't set to the
+// placeholders and values, then validates all values against the declared types
+func (p Params) WithDefaults(used []string, placeholders []string, values []string) ([]string, []string, error) {
+	set := map[string]bool{}
+	byName := map[string]string{}
+	for i, placeholder := range placeholders {
+		name := PlaceholderName(placeholder)
+		set[name] = true
+		byName[name] = values[i]
+	}
+
+	defaults := p.Defaults(used, set)
+	for _, name := range used {
+		if value, ok := defaults[name]; ok {
+			placeholders = append(placeholders, Placeholder(name))
+			values = append(values, value)
+			byName[name] = value
+		}
+	}
+
+	return placeholders, values, p.ValidateValues(byName)
+}
+
+// Replace replaces every occurrence of placeholder in texts with value. It fails if the value is empty or if none of
+// the texts use the placeholder, as the parameter was most likely misspelled.
+func Replace(placeholder string, value string, texts ...*string) error {
+	if value == "" {
+		return fmt.Errorf("the selected template is using '%[1]s' parameter, but '%[1]s' flag was not set. Use '-p %[1]s=\"FOOBAR\"' to fix this", placeholder)
+	}
+
+	found := false
+	for _, text := range texts {
+		if strings.Contains(*text, placeholder) {
+			found = true
+			*text = strings.ReplaceAll(*text, placeholder, value)
+		}
+	}
+	if !found {
+		return fmt.Errorf("the selected template is not using '%[1]s' parameter, but '--param' flag was set. Do not use '-p %[1]s=%[2]s' to fix this", placeholder, value)
+	}
+	return nil
+}
+
+// CheckLeftovers fails if texts still contain placeholders once all parameters were replaced, ignoring the
+// placeholders in excludes which are filled in later, e.g. ${CLUSTER_UUID} for each cluster a service log is sent to
+func CheckLeftovers(excludes []string, texts ...string) error {
+	var problems []string
+	for _, text := range texts {
+		for _, placeholder := range FindPlaceholders(text) {
+			if slices.Contains(excludes, placeholder) {
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("The one of the template files is using '%s' parameter, but '--param' flag is not set for this one. Use '-p %s=\"FOOBAR\"' to fix this.", placeholder, PlaceholderName(placeholder)))
+		}
+	}
+
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		problems = append(problems, "Please define this missing parameter properly.")
+	default:
+		problems = append(problems, fmt.Sprintf("Please define all %v missing parameters properly.", len(problems)))
+	}
+	return errors.New(strings.Join(problems, "\n"))
+}
@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssignments(t *testing.T) {
+	placeholders, values, err := ParseAssignments([]string{"FOO=BAR", "URL=https://example.com/?a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"${FOO}", "${URL}"}, placeholders)
+	assert.Equal(t, []string{"BAR", "https://example.com/?a=b"}, values)
+
+	_, _, err = ParseAssignments([]string{"FOO=BAR", "BAZ"})
+	assert.Error(t, err)
+}
+
+func TestWithDefaults(t *testing.T) {
+	params, err := ParseParams([]byte(testTemplate))
+	require.NoError(t, err)
+	used := []string{"ACTION", "CLUSTER_UUID", "DAYS", "DOC_URL"}
+
+	placeholders, values, err := params.WithDefaults(used, []string{"${ACTION}", "${DOC_URL}"}, []string{"upgrade", "https://docs.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"${ACTION}", "${DOC_URL}", "${DAYS}"}, placeholders)
+	assert.Equal(t, []string{"upgrade", "https://docs.example.com", "7"}, values)
+
+	_, _, err = params.WithDefaults(used, []string{"${DAYS}"}, []string{"soon"})
+	assert.ErrorContains(t, err, "parameter DAYS")
+}
+
+func TestReplace(t *testing.T) {
+	summary, details := "Action required: ${ACTION}", "Please ${ACTION} soon"
+
+	require.NoError(t, Replace("${ACTION}", "upgrade", &summary, &details))
+	assert.Equal(t, "Action required: upgrade", summary)
+	assert.Equal(t, "Please upgrade soon", details)
+
+	assert.ErrorContains(t, Replace("${OTHER}", "value", &summary, &details), "is not using '${OTHER}' parameter")
+	assert.ErrorContains(t, Replace("${ACTION}", "", &summary), "flag was not set")
+}
+
+func TestCheckLeftovers(t *testing.T) {
+	assert.NoError(t, CheckLeftovers(nil, "no placeholders"))
+	assert.NoError(t, CheckLeftovers([]string{"${CLUSTER_UUID}"}, "cluster ${CLUSTER_UUID}"))
+
+	err := CheckLeftovers([]string{"${CLUSTER_UUID}"}, "${FOO} on ${CLUSTER_UUID}", "${BAR}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Use '-p FOO=\"FOOBAR\"' to fix this.")
+	assert.Contains(t, err.Error(), "Use '-p BAR=\"FOOBAR\"' to fix this.")
+	assert.Contains(t, err.Error(), "Please define all 2 missing parameters properly.")
+	assert.NotContains(t, err.Error(), "CLUSTER_UUID")
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/openshift/osdctl/cmd"
 	"github.com/openshift/osdctl/pkg/osdctlConfig"
@@ -20,11 +23,16 @@ func main() {
 
 	command := cmd.NewCmdRoot(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
 
-	if err := command.Execute(); err != nil {
+	// Cancel the command context on Ctrl-C so that in-flight API calls are aborted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := command.ExecuteContext(ctx); err != nil {
 		_, err := fmt.Fprintf(os.Stderr, "%v\n", err)
 		if err != nil {
 			fmt.Println("Error while printing to stderr: ", err.Error())
 		}
+		stop()
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,196 @@
+package graphviz
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Graph is a directed graph rendered in the order its nodes and edges were added
+type Graph struct {
+	nodes []GraphNode
+	edges []Edge
+	index map[string]int
+}
+
+// GraphNode is a node of a Graph. Label lines are separated by "\n".
+type GraphNode struct {
+	Id    string
+	Label string
+	// Highlight marks the node, e.g. as failing or blocking
+	Highlight bool
+	// Group places the node in a named subgraph, e.g. the account it belongs to
+	Group string
+	// Details are shown when the node is selected in the HTML rendering
+	Details string
+}
+
+// Edge is a directed edge between the ids of two nodes
+type Edge struct {
+	From  string
+	To    string
+	Label string
+}
+
+func NewGraph() *Graph {
+	return &Graph{index: map[string]int{}}
+}
+
+// AddNode adds a node, a node added again with the same id replaces the previous one
+func (g *Graph) AddNode(node GraphNode) {
+	if i, ok := g.index[node.Id]; ok {
+		g.nodes[i] = node
+		return
+	}
+	g.index[node.Id] = len(g.nodes)
+	g.nodes = append(g.nodes, node)
+}
+
+// AddEdge adds an edge, duplicate edges are ignored
+func (g *Graph) AddEdge(edge Edge) {
+	for _, e := range g.edges {
+		if e == edge {
+			return
+		}
+	}
+	g.edges = append(g.edges, edge)
+}
+
+// HasNode returns whether a node with the id was added
+func (g *Graph) HasNode(id string) bool {
+	_, ok := g.index[id]
+	return ok
+}
+
+// RenderDot writes the graph in the graphviz dot language
+func (g *Graph) RenderDot(w io.Writer) error {
+	sb := strings.Builder{}
+	sb.WriteString("digraph {\n")
+	sb.WriteString("  rankdir=LR\n")
+	sb.WriteString("  node [shape=box]\n")
+	writeNode := func(indent string, n GraphNode) {
+		attrs := fmt.Sprintf("label=%s", dotQuote(n.Label))
+		if n.Highlight {
+			attrs += " color=red penwidth=2"
+		}
+		sb.WriteString(fmt.Sprintf("%s%s [%s]\n", indent, dotQuote(n.Id), attrs))
+	}
+	groups := g.groups()
+	for _, n := range groups[""] {
+		writeNode("  ", n)
+	}
+	for i, group := range g.groupNames() {
+		sb.WriteString(fmt.Sprintf("  subgraph \"cluster_%d\" {\n", i))
+		sb.WriteString(fmt.Sprintf("    label=%s\n", dotQuote(group)))
+		for _, n := range groups[group] {
+			writeNode("    ", n)
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range g.edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s", dotQuote(e.From), dotQuote(e.To)))
+		if e.Label != "" {
+			sb.WriteString(fmt.Sprintf(" [label=%s]", dotQuote(e.Label)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// RenderMermaid writes the graph as a mermaid flowchart
+func (g *Graph) RenderMermaid(w io.Writer) error {
+	flowchart, err := g.mermaid()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, flowchart)
+	return err
+}
+
+// mermaid returns the graph as a mermaid flowchart, node n<i> is the i-th node added
+func (g *Graph) mermaid() (string, error) {
+	sb := strings.Builder{}
+	sb.WriteString("graph LR\n")
+	sb.WriteString("  classDef highlight stroke:#d00,stroke-width:3px\n")
+	writeNode := func(indent string, n GraphNode) {
+		i := g.index[n.Id]
+		sb.WriteString(fmt.Sprintf("%sn%d[\"%s\"]\n", indent, i, mermaidEscape(n.Label)))
+		if n.Highlight {
+			sb.WriteString(fmt.Sprintf("%sclass n%d highlight\n", indent, i))
+		}
+	}
+	groups := g.groups()
+	for _, n := range groups[""] {
+		writeNode("  ", n)
+	}
+	for i, group := range g.groupNames() {
+		sb.WriteString(fmt.Sprintf("  subgraph g%d[\"%s\"]\n", i, mermaidEscape(group)))
+		for _, n := range groups[group] {
+			writeNode("    ", n)
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, e := range g.edges {
+		from, ok := g.index[e.From]
+		if !ok {
+			return "", fmt.Errorf("edge from unknown node %q", e.From)
+		}
+		to, ok := g.index[e.To]
+		if !ok {
+			return "", fmt.Errorf("edge to unknown node %q", e.To)
+		}
+		if e.Label != "" {
+			sb.WriteString(fmt.Sprintf("  n%d -->|\"%s\"| n%d\n", from, mermaidEscape(e.Label), to))
+		} else {
+			sb.WriteString(fmt.Sprintf("  n%d --> n%d\n", from, to))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// groups returns the nodes of each group in the order they were added, ungrouped nodes are in the "" group
+func (g *Graph) groups() map[string][]GraphNode {
+	groups := map[string][]GraphNode{}
+	for _, n := range g.nodes {
+		groups[n.Group] = append(groups[n.Group], n)
+	}
+	return groups
+}
+
+// groupNames returns the names of the groups in the order their first node was added
+func (g *Graph) groupNames() []string {
+	var names []string
+	for _, n := range g.nodes {
+		if n.Group != "" && !slices.Contains(names, n.Group) {
+			names = append(names, n.Group)
+		}
+	}
+	return names
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;",
+	"&", "#amp;",
+	"<", "#lt;",
+	">", "#gt;",
+	`"`, "#quot;",
+	"'", "#39;",
+	"\n", "<br/>",
+)
+
+// mermaidEscape writes the characters mermaid or the browser would interpret as markup as mermaid entity codes, so
+// that labels taken from cluster resources can't inject HTML in the rendered page
+func mermaidEscape(s string) string {
+	return mermaidEscaper.Replace(s)
+}
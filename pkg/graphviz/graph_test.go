@@ -0,0 +1,160 @@
+package graphviz
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGraph() *Graph {
+	g := NewGraph()
+	g.AddNode(GraphNode{Id: "a", Label: "ClusterDeployment\nns/a"})
+	g.AddNode(GraphNode{Id: "b", Label: `Secret "b"`, Highlight: true})
+	g.AddEdge(Edge{From: "a", To: "b", Label: "references"})
+	g.AddEdge(Edge{From: "a", To: "b", Label: "references"})
+	return g
+}
+
+func TestRenderDot(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestGraph().RenderDot(&out))
+	assert.Equal(t, `digraph {
+  rankdir=LR
+  node [shape=box]
+  "a" [label="ClusterDeployment\nns/a"]
+  "b" [label="Secret \"b\"" color=red penwidth=2]
+  "a" -> "b" [label="references"]
+}
+`, out.String())
+}
+
+func TestRenderMermaid(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestGraph().RenderMermaid(&out))
+	assert.Equal(t, `graph LR
+  classDef highlight stroke:#d00,stroke-width:3px
+  n0["ClusterDeployment<br/>ns/a"]
+  n1["Secret #quot;b#quot;"]
+  class n1 highlight
+  n0 -->|"references"| n1
+`, out.String())
+
+	g := newTestGraph()
+	g.AddEdge(Edge{From: "a", To: "missing"})
+	assert.Error(t, g.RenderMermaid(&out))
+}
+
+func TestAddNodeReplaces(t *testing.T) {
+	g := newTestGraph()
+	g.AddNode(GraphNode{Id: "a", Label: "replaced"})
+	assert.True(t, g.HasNode("a"))
+	assert.False(t, g.HasNode("c"))
+	assert.Len(t, g.nodes, 2)
+	assert.Equal(t, "replaced", g.nodes[0].Label)
+}
+
+func newTestGroupedGraph() *Graph {
+	g := NewGraph()
+	g.AddNode(GraphNode{Id: "vpce", Label: "VPC Endpoint", Group: "customer", Details: "state: pending", Highlight: true})
+	g.AddNode(GraphNode{Id: "svc", Label: "Endpoint Service", Group: "management"})
+	g.AddNode(GraphNode{Id: "note", Label: "Note"})
+	g.AddEdge(Edge{From: "vpce", To: "svc"})
+	return g
+}
+
+func TestRenderGroups(t *testing.T) {
+	var dot bytes.Buffer
+	require.NoError(t, newTestGroupedGraph().RenderDot(&dot))
+	assert.Equal(t, `digraph {
+  rankdir=LR
+  node [shape=box]
+  "note" [label="Note"]
+  subgraph "cluster_0" {
+    label="customer"
+    "vpce" [label="VPC Endpoint" color=red penwidth=2]
+  }
+  subgraph "cluster_1" {
+    label="management"
+    "svc" [label="Endpoint Service"]
+  }
+  "vpce" -> "svc"
+}
+`, dot.String())
+
+	var mermaid bytes.Buffer
+	require.NoError(t, newTestGroupedGraph().RenderMermaid(&mermaid))
+	assert.Equal(t, `graph LR
+  classDef highlight stroke:#d00,stroke-width:3px
+  n2["Note"]
+  subgraph g0["customer"]
+    n0["VPC Endpoint"]
+    class n0 highlight
+  end
+  subgraph g1["management"]
+    n1["Endpoint Service"]
+  end
+  n0 --> n1
+`, mermaid.String())
+}
+
+func TestRenderHTML(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestGroupedGraph().RenderHTML(&out, "Topology <test>", []string{"endpoint is pending"}))
+
+	html := out.String()
+	assert.Contains(t, html, "<title>Topology &lt;test&gt;</title>")
+	assert.Contains(t, html, "<li>endpoint is pending</li>")
+	assert.Contains(t, html, `"n0":"state: pending"`)
+	assert.Contains(t, html, `<script src="`+mermaidScript+`"`)
+	assert.NotContains(t, html, `securityLevel: "loose"`)
+
+	out.Reset()
+	g := NewGraph()
+	g.AddNode(GraphNode{Id: "a", Label: `<img src=x onerror="alert(1)"> #1 & 'b'`})
+	require.NoError(t, g.RenderHTML(&out, "Injection", nil))
+	assert.Contains(t, out.String(), `#lt;img src=x onerror=#quot;alert(1)#quot;#gt; #35;1 #amp; #39;b#39;`)
+	assert.NotContains(t, out.String(), "<img")
+
+	out.Reset()
+	require.NoError(t, NewGraph().RenderHTML(&out, "Empty", nil))
+	assert.Contains(t, out.String(), "No problems found")
+}
+
+func TestRenderHTMLDetailsWiring(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, newTestGroupedGraph().RenderHTML(&out, "Topology", nil))
+	html := out.String()
+
+	// mermaid only binds click directives with securityLevel "loose", the page attaches its own listeners instead
+	assert.NotContains(t, html, "click n")
+	assert.Contains(t, html, `securityLevel: "antiscript"`)
+	assert.Contains(t, html, "startOnLoad: false")
+
+	// the listeners are attached once mermaid rendered the nodes
+	run := strings.Index(html, "mermaid.run(")
+	listen := strings.Index(html, `addEventListener("click"`)
+	require.NotEqual(t, -1, run)
+	require.NotEqual(t, -1, listen)
+	assert.Less(t, run, listen)
+	assert.Contains(t, html[run:listen], ".then(")
+
+	// every node with details is a node of the flowchart
+	match := regexp.MustCompile(`const details = (\{.*\});`).FindStringSubmatch(html)
+	require.Len(t, match, 2)
+	details := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(match[1]), &details))
+	assert.Equal(t, map[string]string{"n0": "state: pending"}, details)
+	flowchart, err := newTestGroupedGraph().mermaid()
+	require.NoError(t, err)
+	for id := range details {
+		assert.Contains(t, flowchart, "\n    "+id+"[")
+	}
+
+	// the mermaid release is pinned
+	assert.Regexp(t, `/mermaid@\d+\.\d+\.\d+/`, mermaidScript)
+}
@@ -0,0 +1,106 @@
+package graphviz
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+const (
+	// mermaidScript is the mermaid release the HTML rendering loads from its CDN
+	mermaidScript = "https://cdn.jsdelivr.net/npm/mermaid@11.4.1/dist/mermaid.min.js"
+	// mermaidIntegrity is the subresource integrity hash of mermaidScript, the browser refuses to run it if the CDN
+	// serves anything else. It must be updated with mermaidScript:
+	//   curl -s <mermaidScript> | openssl dgst -sha384 -binary | openssl base64 -A
+	mermaidIntegrity = ""
+)
+
+var htmlTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: sans-serif; margin: 1em 2em; }
+  .notes li { color: #d00; }
+  .ok { color: #080; }
+  #layout { display: flex; gap: 2em; align-items: flex-start; }
+  #graph { flex: 1; overflow: auto; }
+  #details { width: 30em; white-space: pre-wrap; font-family: monospace; border: 1px solid #ccc; padding: 1em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Notes}}
+<ul class="notes">
+{{- range .Notes}}
+  <li>{{.}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p class="ok">No problems found</p>
+{{- end}}
+<div id="layout">
+<pre id="graph" class="mermaid">
+{{.Flowchart}}</pre>
+<div id="details">Select a node to show its details</div>
+</div>
+<script src="{{.MermaidScript}}"{{if .MermaidIntegrity}} integrity="{{.MermaidIntegrity}}"{{end}} crossorigin="anonymous"></script>
+<script>
+  const details = {{.Details}};
+  const nodeId = (el) => {
+    if (el.dataset.id) {
+      return el.dataset.id;
+    }
+    const match = /flowchart-(n\d+)-\d+$/.exec(el.id);
+    return match ? match[1] : "";
+  };
+  mermaid.initialize({ startOnLoad: false, securityLevel: "antiscript", maxTextSize: 1000000 });
+  mermaid.run({ querySelector: "#graph" }).then(() => {
+    document.querySelectorAll("#graph .node").forEach((el) => {
+      const id = nodeId(el);
+      if (!(id in details)) {
+        return;
+      }
+      el.style.cursor = "pointer";
+      el.addEventListener("click", () => {
+        document.getElementById("details").textContent = details[id];
+      });
+    });
+  });
+</script>
+</body>
+</html>
+`))
+
+// RenderHTML writes the graph as a standalone HTML page rendering it with mermaid, which is loaded from its CDN.
+// Clicking a node shows its details, the notes are listed above the graph, e.g. the problems found in it.
+func (g *Graph) RenderHTML(w io.Writer, title string, notes []string) error {
+	flowchart, err := g.mermaid()
+	if err != nil {
+		return err
+	}
+
+	details := map[string]string{}
+	for i, n := range g.nodes {
+		if n.Details != "" {
+			details[fmt.Sprintf("n%d", i)] = n.Details
+		}
+	}
+
+	return htmlTemplate.Execute(w, struct {
+		Title            string
+		Notes            []string
+		Flowchart        string
+		Details          map[string]string
+		MermaidScript    string
+		MermaidIntegrity string
+	}{
+		Title:            title,
+		Notes:            notes,
+		Flowchart:        flowchart,
+		Details:          details,
+		MermaidScript:    mermaidScript,
+		MermaidIntegrity: mermaidIntegrity,
+	})
+}
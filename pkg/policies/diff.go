@@ -0,0 +1,194 @@
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+)
+
+const (
+	CredentialsRequestAdded   = "added"
+	CredentialsRequestRemoved = "removed"
+	CredentialsRequestChanged = "changed"
+)
+
+// PermissionsDiff is the difference in the permissions requested by the CredentialsRequests
+// of two OpenShift versions
+type PermissionsDiff struct {
+	Cloud               string                   `json:"cloud"`
+	BaseVersion         string                   `json:"baseVersion"`
+	TargetVersion       string                   `json:"targetVersion"`
+	CredentialsRequests []CredentialsRequestDiff `json:"credentialsRequests"`
+}
+
+// CredentialsRequestDiff is the difference in the permissions of a single CredentialsRequest.
+// For added or removed CredentialsRequests, all of their actions are added or removed.
+type CredentialsRequestDiff struct {
+	Name           string        `json:"name"`
+	Status         string        `json:"status"`
+	AddedActions   []string      `json:"addedActions,omitempty"`
+	RemovedActions []string      `json:"removedActions,omitempty"`
+	ChangedScopes  []ScopeChange `json:"changedScopes,omitempty"`
+}
+
+// ScopeChange is an action which is requested in both versions, but with different
+// effects, resources or conditions
+type ScopeChange struct {
+	Action string   `json:"action"`
+	Base   []string `json:"base"`
+	Target []string `json:"target"`
+}
+
+// IsEmpty returns true if the permissions didn't change between the versions
+func (d *PermissionsDiff) IsEmpty() bool {
+	return len(d.CredentialsRequests) == 0
+}
+
+// DiffCredentialsRequests compares the permissions requested by the base and target CredentialsRequests
+func DiffCredentialsRequests(cloud CloudSpec, base, target []*cco.CredentialsRequest) (*PermissionsDiff, error) {
+	basePermissions, err := credentialsRequestPermissions(cloud, base)
+	if err != nil {
+		return nil, err
+	}
+	targetPermissions, err := credentialsRequestPermissions(cloud, target)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range basePermissions {
+		names = append(names, name)
+	}
+	for name := range targetPermissions {
+		if _, ok := basePermissions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := &PermissionsDiff{Cloud: cloud.String(), CredentialsRequests: []CredentialsRequestDiff{}}
+	for _, name := range names {
+		basePerms, inBase := basePermissions[name]
+		targetPerms, inTarget := targetPermissions[name]
+
+		crDiff := diffPermissions(basePerms, targetPerms)
+		crDiff.Name = name
+		switch {
+		case !inBase:
+			crDiff.Status = CredentialsRequestAdded
+		case !inTarget:
+			crDiff.Status = CredentialsRequestRemoved
+		case len(crDiff.AddedActions) == 0 && len(crDiff.RemovedActions) == 0 && len(crDiff.ChangedScopes) == 0:
+			continue
+		default:
+			crDiff.Status = CredentialsRequestChanged
+		}
+		diff.CredentialsRequests = append(diff.CredentialsRequests, crDiff)
+	}
+
+	return diff, nil
+}
+
+// permissions maps every action of a CredentialsRequest to the sorted scopes it is granted with
+type permissions map[string][]string
+
+func credentialsRequestPermissions(cloud CloudSpec, credReqs []*cco.CredentialsRequest) (map[string]permissions, error) {
+	result := map[string]permissions{}
+	for _, credReq := range credReqs {
+		var perms permissions
+		var err error
+		switch cloud {
+		case AWS:
+			perms, err = awsPermissions(credReq)
+		case GCP:
+			perms, err = gcpPermissions(credReq)
+		default:
+			return nil, fmt.Errorf("unsupported cloud %s", cloud.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CredentialsRequest '%s': %w", credReq.Name, err)
+		}
+		result[credReq.Name] = perms
+	}
+	return result, nil
+}
+
+// awsPermissions returns the scope of every action in the CredentialsRequest's policy,
+// e.g. "Allow on arn:aws:s3:::* when {"StringEquals":{...}}"
+func awsPermissions(credReq *cco.CredentialsRequest) (permissions, error) {
+	doc, err := AWSCredentialsRequestToPolicyDocument(credReq)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := permissions{}
+	for _, statement := range doc.Statement {
+		scope := fmt.Sprintf("%s on %s", statement.Effect, statement.Resource)
+		if len(statement.PolicyCondition) > 0 {
+			condition, err := json.Marshal(statement.PolicyCondition)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't marshal policy condition: %w", err)
+			}
+			scope += " when " + string(condition)
+		}
+		for _, action := range statement.Action {
+			if !slices.Contains(perms[action], scope) {
+				perms[action] = append(perms[action], scope)
+			}
+		}
+	}
+	for action := range perms {
+		sort.Strings(perms[action])
+	}
+	return perms, nil
+}
+
+// gcpPermissions returns the permissions and predefined roles of the CredentialsRequest.
+// GCP permissions are not scoped, so their scope never changes.
+func gcpPermissions(credReq *cco.CredentialsRequest) (permissions, error) {
+	sa, err := CredentialsRequestToWifServiceAccount(credReq)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := permissions{}
+	for _, role := range sa.Roles {
+		if role.Predefined {
+			perms[GCPRoleIDPrefix+role.Id] = nil
+			continue
+		}
+		for _, permission := range role.Permissions {
+			perms[permission] = nil
+		}
+	}
+	return perms, nil
+}
+
+func diffPermissions(base, target permissions) CredentialsRequestDiff {
+	crDiff := CredentialsRequestDiff{}
+	for action, targetScopes := range target {
+		baseScopes, ok := base[action]
+		if !ok {
+			crDiff.AddedActions = append(crDiff.AddedActions, action)
+			continue
+		}
+		if !slices.Equal(baseScopes, targetScopes) {
+			crDiff.ChangedScopes = append(crDiff.ChangedScopes, ScopeChange{Action: action, Base: baseScopes, Target: targetScopes})
+		}
+	}
+	for action := range base {
+		if _, ok := target[action]; !ok {
+			crDiff.RemovedActions = append(crDiff.RemovedActions, action)
+		}
+	}
+
+	sort.Strings(crDiff.AddedActions)
+	sort.Strings(crDiff.RemovedActions)
+	sort.Slice(crDiff.ChangedScopes, func(i, j int) bool {
+		return crDiff.ChangedScopes[i].Action < crDiff.ChangedScopes[j].Action
+	})
+	return crDiff
+}
@@ -0,0 +1,133 @@
+package policies
+
+import (
+	"testing"
+
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newAWSCredentialsRequest(t *testing.T, name string, statements ...cco.StatementEntry) *cco.CredentialsRequest {
+	spec, err := cco.Codec.EncodeProviderSpec(&cco.AWSProviderSpec{
+		TypeMeta:         metav1.TypeMeta{APIVersion: "cloudcredential.openshift.io/v1", Kind: "AWSProviderSpec"},
+		StatementEntries: statements,
+	})
+	assert.NoError(t, err)
+	return &cco.CredentialsRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       cco.CredentialsRequestSpec{ProviderSpec: spec},
+	}
+}
+
+func newGCPCredentialsRequest(t *testing.T, name string, roles []string, permissions []string) *cco.CredentialsRequest {
+	spec, err := cco.Codec.EncodeProviderSpec(&cco.GCPProviderSpec{
+		TypeMeta:        metav1.TypeMeta{APIVersion: "cloudcredential.openshift.io/v1", Kind: "GCPProviderSpec"},
+		PredefinedRoles: roles,
+		Permissions:     permissions,
+	})
+	assert.NoError(t, err)
+	return &cco.CredentialsRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       cco.CredentialsRequestSpec{ProviderSpec: spec},
+	}
+}
+
+func TestDiffCredentialsRequestsAWS(t *testing.T) {
+	condition := cco.IAMPolicyCondition{"StringEquals": cco.IAMPolicyConditionKeyValue{"aws:ResourceTag/red-hat-managed": "true"}}
+
+	base := []*cco.CredentialsRequest{
+		newAWSCredentialsRequest(t, "openshift-ingress",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"route53:ListHostedZones", "route53:ChangeResourceRecordSets"}, Resource: "*"},
+		),
+		newAWSCredentialsRequest(t, "openshift-machine-api",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"ec2:RunInstances", "ec2:TerminateInstances"}, Resource: "*"},
+		),
+		newAWSCredentialsRequest(t, "openshift-removed",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"s3:GetObject"}, Resource: "*"},
+		),
+		newAWSCredentialsRequest(t, "openshift-unchanged",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"iam:GetUser"}, Resource: "*"},
+		),
+	}
+	target := []*cco.CredentialsRequest{
+		newAWSCredentialsRequest(t, "openshift-ingress",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"route53:ListHostedZones", "tag:GetResources"}, Resource: "*"},
+		),
+		newAWSCredentialsRequest(t, "openshift-machine-api",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"ec2:RunInstances"}, Resource: "*"},
+			cco.StatementEntry{Effect: "Allow", Action: []string{"ec2:TerminateInstances"}, Resource: "*", PolicyCondition: condition},
+		),
+		newAWSCredentialsRequest(t, "openshift-new",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"kms:Decrypt"}, Resource: "*"},
+		),
+		newAWSCredentialsRequest(t, "openshift-unchanged",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"iam:GetUser"}, Resource: "*"},
+		),
+	}
+
+	diff, err := DiffCredentialsRequests(AWS, base, target)
+	assert.NoError(t, err)
+	assert.Equal(t, "aws", diff.Cloud)
+	assert.Equal(t, []CredentialsRequestDiff{
+		{
+			Name:           "openshift-ingress",
+			Status:         CredentialsRequestChanged,
+			AddedActions:   []string{"tag:GetResources"},
+			RemovedActions: []string{"route53:ChangeResourceRecordSets"},
+		},
+		{
+			Name:   "openshift-machine-api",
+			Status: CredentialsRequestChanged,
+			ChangedScopes: []ScopeChange{
+				{
+					Action: "ec2:TerminateInstances",
+					Base:   []string{"Allow on *"},
+					Target: []string{`Allow on * when {"StringEquals":{"aws:ResourceTag/red-hat-managed":"true"}}`},
+				},
+			},
+		},
+		{
+			Name:         "openshift-new",
+			Status:       CredentialsRequestAdded,
+			AddedActions: []string{"kms:Decrypt"},
+		},
+		{
+			Name:           "openshift-removed",
+			Status:         CredentialsRequestRemoved,
+			RemovedActions: []string{"s3:GetObject"},
+		},
+	}, diff.CredentialsRequests)
+}
+
+func TestDiffCredentialsRequestsGCP(t *testing.T) {
+	base := []*cco.CredentialsRequest{
+		newGCPCredentialsRequest(t, "openshift-gcp-ccm", []string{"roles/compute.viewer"}, []string{"compute.instances.get"}),
+	}
+	target := []*cco.CredentialsRequest{
+		newGCPCredentialsRequest(t, "openshift-gcp-ccm", nil, []string{"compute.instances.get", "compute.instances.list"}),
+	}
+
+	diff, err := DiffCredentialsRequests(GCP, base, target)
+	assert.NoError(t, err)
+	assert.Equal(t, []CredentialsRequestDiff{
+		{
+			Name:           "openshift-gcp-ccm",
+			Status:         CredentialsRequestChanged,
+			AddedActions:   []string{"compute.instances.list"},
+			RemovedActions: []string{"roles/compute.viewer"},
+		},
+	}, diff.CredentialsRequests)
+}
+
+func TestDiffCredentialsRequestsNoChanges(t *testing.T) {
+	crs := []*cco.CredentialsRequest{
+		newAWSCredentialsRequest(t, "openshift-ingress",
+			cco.StatementEntry{Effect: "Allow", Action: []string{"route53:ListHostedZones"}, Resource: "*"},
+		),
+	}
+
+	diff, err := DiffCredentialsRequests(AWS, crs, crs)
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+}
@@ -0,0 +1,424 @@
+package policies
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// releaseManifestsDir is the directory of a release payload holding its manifests
+	releaseManifestsDir = "release-manifests"
+
+	ociLayoutFile = "oci-layout"
+	ociIndexFile  = "index.json"
+
+	// cacheCompleteMarker is written once all CredentialsRequests of a release were extracted to the cache
+	cacheCompleteMarker = ".complete"
+)
+
+// ociDescriptor is a content descriptor of an OCI image index or manifest
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+// ociManifest holds the fields shared by OCI image indexes and image manifests
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociLayout gives access to the files of an OCI image layout, stored either in a directory or a tarball
+type ociLayout interface {
+	open(name string) (io.ReadCloser, error)
+}
+
+// ExtractCredentialRequests extracts the CredentialsRequests for the cloud from a local release,
+// without requiring oc or network access. The source can be
+//   - a release payload directory, i.e. a directory containing release-manifests/, or release-manifests/ itself
+//   - an OCI image layout of the release image, either as directory or as tarball
+//
+// CredentialsRequests extracted from OCI image layouts are cached by the digest of the release image.
+// The returned directory contains one CredentialsRequest per file.
+func ExtractCredentialRequests(source string, cloud CloudSpec) (string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", err
+	}
+
+	var layout ociLayout
+	switch {
+	case !info.IsDir():
+		layout = ociTarball(source)
+	case fileExists(filepath.Join(source, ociLayoutFile)):
+		layout = ociDirectory(source)
+	default:
+		manifests := source
+		if fileExists(filepath.Join(source, releaseManifestsDir)) {
+			manifests = filepath.Join(source, releaseManifestsDir)
+		}
+		return extractFromPayloadDir(manifests, cloud)
+	}
+
+	digest, layers, err := resolveOCIImage(layout)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OCI image layout %s: %w", source, err)
+	}
+
+	cacheDir, err := credentialsRequestsCacheDir(digest, cloud)
+	if err != nil {
+		return "", err
+	}
+	if fileExists(filepath.Join(cacheDir, cacheCompleteMarker)) {
+		return cacheDir, nil
+	}
+
+	files, err := readReleaseManifestsFromLayers(layout, layers)
+	if err != nil {
+		return "", fmt.Errorf("failed to read release manifests from %s: %w", source, err)
+	}
+
+	return cacheCredentialsRequests(cacheDir, files, cloud)
+}
+
+// extractFromPayloadDir copies the CredentialsRequests found in the release manifests directory to a temp directory
+func extractFromPayloadDir(manifestsDir string, cloud CloudSpec) (string, error) {
+	entries, err := os.ReadDir(manifestsDir)
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isManifestFile(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(manifestsDir, entry.Name())) //#nosec G304 -- manifestsDir is provided by the user
+		if err != nil {
+			return "", err
+		}
+		files[entry.Name()] = content
+	}
+
+	directory, err := os.MkdirTemp("", "osdctl-crs-")
+	if err != nil {
+		return "", err
+	}
+	if err := writeCredentialsRequests(directory, files, cloud); err != nil {
+		return "", err
+	}
+	return directory, nil
+}
+
+// cacheCredentialsRequests writes the CredentialsRequests for the cloud found in the manifest files to the cache
+// directory and marks it complete
+func cacheCredentialsRequests(dir string, files map[string][]byte, cloud CloudSpec) (string, error) {
+	// Write to a temporary sibling first, so an interrupted extraction never leaves a partial cache entry
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := writeCredentialsRequests(tmpDir, files, cloud); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, cacheCompleteMarker), nil, 0600); err != nil {
+		return "", err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeCredentialsRequests writes every CredentialsRequest for the cloud found in the manifest files to dir
+func writeCredentialsRequests(dir string, files map[string][]byte, cloud CloudSpec) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	found := 0
+	for _, name := range names {
+		docs, err := credentialsRequestDocuments(files[name], cloud)
+		if err != nil {
+			return fmt.Errorf("failed to parse manifest %s: %w", name, err)
+		}
+		for i, doc := range docs {
+			out := fmt.Sprintf("%s-%d.yaml", strings.TrimSuffix(strings.TrimSuffix(name, ".yaml"), ".yml"), i)
+			if err := os.WriteFile(filepath.Join(dir, out), doc, 0600); err != nil {
+				return err
+			}
+			found++
+		}
+	}
+	if found == 0 {
+		return fmt.Errorf("no CredentialsRequests for %s found in release manifests", cloud.String())
+	}
+	return nil
+}
+
+// credentialsRequestDocuments returns the YAML documents of a manifest file which are
+// CredentialsRequests for the cloud, the same selection 'oc adm release extract --cloud' makes
+func credentialsRequestDocuments(content []byte, cloud CloudSpec) ([][]byte, error) {
+	var providerKind string
+	switch cloud {
+	case AWS:
+		providerKind = "AWSProviderSpec"
+	case GCP:
+		providerKind = "GCPProviderSpec"
+	default:
+		return nil, fmt.Errorf("unsupported cloud %s", cloud.String())
+	}
+
+	var docs [][]byte
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		manifest := struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				ProviderSpec struct {
+					Kind string `json:"kind"`
+				} `json:"providerSpec"`
+			} `json:"spec"`
+		}{}
+		if err := k8syaml.Unmarshal(doc, &manifest); err != nil {
+			return nil, err
+		}
+		if manifest.Kind == "CredentialsRequest" && manifest.Spec.ProviderSpec.Kind == providerKind {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// resolveOCIImage returns the digest of the image manifest and its layers. Image indexes are
+// resolved to their linux/amd64 manifest, the architecture 'oc adm release extract' defaults to.
+func resolveOCIImage(layout ociLayout) (string, []ociDescriptor, error) {
+	content, err := readOCIFile(layout, ociIndexFile)
+	if err != nil {
+		return "", nil, err
+	}
+
+	digest := ""
+	for {
+		manifest := ociManifest{}
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			return "", nil, fmt.Errorf("failed to parse manifest %s: %w", digest, err)
+		}
+
+		if len(manifest.Manifests) == 0 {
+			if digest == "" {
+				return "", nil, fmt.Errorf("%s doesn't reference any manifests", ociIndexFile)
+			}
+			return digest, manifest.Layers, nil
+		}
+
+		descriptor := selectManifest(manifest.Manifests)
+		if content, err = readOCIFile(layout, blobPath(descriptor.Digest)); err != nil {
+			return "", nil, err
+		}
+		if err := verifyDigest(descriptor.Digest, content); err != nil {
+			return "", nil, err
+		}
+		digest = descriptor.Digest
+	}
+}
+
+func selectManifest(manifests []ociDescriptor) ociDescriptor {
+	for _, m := range manifests {
+		if m.Platform != nil && m.Platform.OS == "linux" && m.Platform.Architecture == "amd64" {
+			return m
+		}
+	}
+	return manifests[0]
+}
+
+// readReleaseManifestsFromLayers returns the release manifest files of the image, applying the layers in order
+func readReleaseManifestsFromLayers(layout ociLayout, layers []ociDescriptor) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, layer := range layers {
+		if err := readLayer(layout, layer, files); err != nil {
+			return nil, fmt.Errorf("layer %s: %w", layer.Digest, err)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("image doesn't contain a %s directory, is it a release image?", releaseManifestsDir)
+	}
+	return files, nil
+}
+
+func readLayer(layout ociLayout, layer ociDescriptor, files map[string][]byte) error {
+	blob, err := layout.open(blobPath(layer.Digest))
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	var reader io.Reader = blob
+	switch {
+	case strings.HasSuffix(layer.MediaType, "gzip"):
+		gz, err := gzip.NewReader(blob)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	case strings.HasSuffix(layer.MediaType, "zstd"):
+		return fmt.Errorf("zstd compressed layers are not supported")
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		dir, name := path.Split(path.Clean(strings.TrimPrefix(header.Name, "./")))
+		if path.Clean(dir) != releaseManifestsDir {
+			continue
+		}
+		// Whiteouts of later layers remove files added by earlier ones
+		if strings.HasPrefix(name, ".wh.") {
+			delete(files, strings.TrimPrefix(name, ".wh."))
+			continue
+		}
+		if header.Typeflag != tar.TypeReg || !isManifestFile(name) {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		files[name] = content
+	}
+}
+
+func readOCIFile(layout ociLayout, name string) ([]byte, error) {
+	f, err := layout.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func blobPath(digest string) string {
+	algorithm, hash, _ := strings.Cut(digest, ":")
+	return path.Join("blobs", algorithm, hash)
+}
+
+func verifyDigest(digest string, content []byte) error {
+	algorithm, expected, _ := strings.Cut(digest, ":")
+	if algorithm != "sha256" {
+		return nil
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != expected {
+		return fmt.Errorf("content of %s doesn't match its digest", digest)
+	}
+	return nil
+}
+
+// credentialsRequestsCacheDir returns the cache directory for the CredentialsRequests of a release image
+func credentialsRequestsCacheDir(digest string, cloud CloudSpec) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	_, hash, _ := strings.Cut(digest, ":")
+	dir := filepath.Join(cacheDir, "osdctl", "credentialsrequests", hash, cloud.String())
+	if err := os.MkdirAll(filepath.Dir(dir), 0750); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func isManifestFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+type ociDirectory string
+
+func (d ociDirectory) open(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(string(d), filepath.FromSlash(name))) //#nosec G304 -- the layout directory is provided by the user
+}
+
+type ociTarball string
+
+// open returns the content of the tarball entry. The tarball is scanned for every entry, which is
+// cheap as tar skips over the content of other entries by seeking.
+func (t ociTarball) open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(string(t))
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%s not found in %s", name, string(t))
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		if path.Clean(strings.TrimPrefix(header.Name, "./")) == name {
+			return tarEntry{Reader: tr, file: f}, nil
+		}
+	}
+}
+
+type tarEntry struct {
+	io.Reader
+	file *os.File
+}
+
+func (e tarEntry) Close() error {
+	return e.file.Close()
+}
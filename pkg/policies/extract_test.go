@@ -0,0 +1,176 @@
+package policies
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReleaseManifest = `apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-ingress
+  namespace: openshift-cloud-credential-operator
+spec:
+  providerSpec:
+    apiVersion: cloudcredential.openshift.io/v1
+    kind: AWSProviderSpec
+    statementEntries:
+    - action:
+      - route53:ListHostedZones
+      effect: Allow
+      resource: "*"
+  secretRef:
+    name: cloud-credentials
+    namespace: openshift-ingress-operator
+---
+apiVersion: cloudcredential.openshift.io/v1
+kind: CredentialsRequest
+metadata:
+  name: openshift-gcp-ccm
+  namespace: openshift-cloud-credential-operator
+spec:
+  providerSpec:
+    apiVersion: cloudcredential.openshift.io/v1
+    kind: GCPProviderSpec
+    predefinedRoles:
+    - roles/compute.viewer
+  secretRef:
+    name: gcp-ccm-cloud-credentials
+    namespace: openshift-cloud-controller-manager
+`
+
+const testOtherManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+`
+
+func writeTarEntry(t *testing.T, tw *tar.Writer, name string, content []byte) {
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(content)
+	assert.NoError(t, err)
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// newTestOCITarball writes an OCI image layout tarball of a release image with a single gzipped layer
+func newTestOCITarball(t *testing.T) (string, string) {
+	layer := &bytes.Buffer{}
+	gz := gzip.NewWriter(layer)
+	layerTar := tar.NewWriter(gz)
+	writeTarEntry(t, layerTar, "release-manifests/0000_50_credentialsrequests.yaml", []byte(testReleaseManifest))
+	writeTarEntry(t, layerTar, "release-manifests/0000_50_configmap.yaml", []byte(testOtherManifest))
+	writeTarEntry(t, layerTar, "usr/bin/cluster-version-operator", []byte("binary"))
+	assert.NoError(t, layerTar.Close())
+	assert.NoError(t, gz.Close())
+
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"layers": []map[string]interface{}{
+			{"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": sha256Digest(layer.Bytes())},
+		},
+	})
+	assert.NoError(t, err)
+
+	index, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"manifests": []map[string]interface{}{
+			{"mediaType": "application/vnd.oci.image.manifest.v1+json", "digest": sha256Digest(manifest)},
+		},
+	})
+	assert.NoError(t, err)
+
+	tarball := filepath.Join(t.TempDir(), "release.tar")
+	f, err := os.Create(tarball)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	writeTarEntry(t, tw, "oci-layout", []byte(`{"imageLayoutVersion":"1.0.0"}`))
+	writeTarEntry(t, tw, "index.json", index)
+	writeTarEntry(t, tw, blobPath(sha256Digest(manifest)), manifest)
+	writeTarEntry(t, tw, blobPath(sha256Digest(layer.Bytes())), layer.Bytes())
+	assert.NoError(t, tw.Close())
+
+	return tarball, sha256Digest(manifest)
+}
+
+func TestExtractCredentialRequestsFromPayloadDir(t *testing.T) {
+	payload := t.TempDir()
+	manifests := filepath.Join(payload, releaseManifestsDir)
+	assert.NoError(t, os.MkdirAll(manifests, 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(manifests, "0000_50_credentialsrequests.yaml"), []byte(testReleaseManifest), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(manifests, "0000_50_configmap.yaml"), []byte(testOtherManifest), 0600))
+
+	for _, source := range []string{payload, manifests} {
+		dir, err := ExtractCredentialRequests(source, AWS)
+		assert.NoError(t, err)
+
+		crs, err := ParseCredentialsRequestsInDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, crs, 1)
+		assert.Equal(t, "openshift-ingress", crs[0].Name)
+	}
+
+	// Only cache entries are marked complete, neither the payload nor the extracted directory
+	dir, err := DownloadCredentialRequests(LocalReleasePrefix+payload, GCP)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, cacheCompleteMarker))
+	assert.NoFileExists(t, filepath.Join(payload, cacheCompleteMarker))
+	assert.NoFileExists(t, filepath.Join(manifests, cacheCompleteMarker))
+	crs, err := ParseCredentialsRequestsInDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, crs, 1)
+	assert.Equal(t, "openshift-gcp-ccm", crs[0].Name)
+}
+
+func TestExtractCredentialRequestsFromOCITarball(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	tarball, digest := newTestOCITarball(t)
+
+	dir, err := ExtractCredentialRequests(tarball, AWS)
+	assert.NoError(t, err)
+
+	expectedCacheDir, err := credentialsRequestsCacheDir(digest, AWS)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCacheDir, dir)
+	assert.FileExists(t, filepath.Join(dir, cacheCompleteMarker))
+	tmpDirs, err := filepath.Glob(dir + ".tmp-*")
+	assert.NoError(t, err)
+	assert.Empty(t, tmpDirs)
+
+	crs, err := ParseCredentialsRequestsInDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, crs, 1)
+	assert.Equal(t, "openshift-ingress", crs[0].Name)
+
+	// The second extraction is served from the cache instead of reading the tarball again
+	assert.NoError(t, os.Remove(filepath.Join(dir, "0000_50_credentialsrequests-0.yaml")))
+	cached, err := ExtractCredentialRequests(tarball, AWS)
+	assert.NoError(t, err)
+	assert.Equal(t, dir, cached)
+	crs, err = ParseCredentialsRequestsInDir(cached)
+	assert.NoError(t, err)
+	assert.Empty(t, crs)
+}
+
+func TestExtractCredentialRequestsNoCredentialsRequests(t *testing.T) {
+	payload := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(payload, "0000_50_configmap.yaml"), []byte(testOtherManifest), 0600))
+
+	_, err := ExtractCredentialRequests(payload, AWS)
+	assert.Error(t, err)
+}
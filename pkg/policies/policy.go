@@ -13,9 +13,18 @@ import (
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 )
 
+// LocalReleasePrefix marks a version as the path of a local release payload or OCI image layout, e.g. file:./release.tar
+const LocalReleasePrefix = "file:"
+
 // DownloadCredentialRequests creates a temp directory and extracts credential request
-// manifests from a given release payload
+// manifests from a given release payload. If version starts with LocalReleasePrefix, the
+// credential requests are read from the local path with ExtractCredentialRequests instead
+// of 'oc adm release extract'.
 func DownloadCredentialRequests(version string, cloud CloudSpec) (string, error) {
+	if source, ok := strings.CutPrefix(version, LocalReleasePrefix); ok {
+		return ExtractCredentialRequests(source, cloud)
+	}
+
 	directory, err := os.MkdirTemp("", "osdctl-crs-")
 	if err != nil {
 		return "", err
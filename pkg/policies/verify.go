@@ -0,0 +1,162 @@
+package policies
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+)
+
+// IAMPolicyDocument is an AWS IAM policy document as returned by the IAM API
+type IAMPolicyDocument struct {
+	Statement iamStatements `json:"Statement"`
+}
+
+// IAMStatement is a statement of an IAM policy document
+type IAMStatement struct {
+	Effect    string          `json:"Effect"`
+	Action    stringOrSlice   `json:"Action"`
+	NotAction stringOrSlice   `json:"NotAction"`
+	Resource  stringOrSlice   `json:"Resource"`
+	Condition json.RawMessage `json:"Condition"`
+}
+
+// iamStatements accepts both a single statement and a list of statements
+type iamStatements []IAMStatement
+
+func (s *iamStatements) UnmarshalJSON(data []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		var statement IAMStatement
+		if err := json.Unmarshal(data, &statement); err != nil {
+			return err
+		}
+		*s = iamStatements{statement}
+		return nil
+	}
+	var statements []IAMStatement
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return err
+	}
+	*s = statements
+	return nil
+}
+
+// stringOrSlice accepts both a single string and a list of strings
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = stringOrSlice{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// ParseIAMPolicyDocument parses a policy document returned by the IAM API, which is URL encoded
+func ParseIAMPolicyDocument(document string) (*IAMPolicyDocument, error) {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode policy document: %w", err)
+	}
+
+	doc := &IAMPolicyDocument{}
+	if err := json.Unmarshal([]byte(decoded), doc); err != nil {
+		return nil, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	return doc, nil
+}
+
+// RequiredAWSActions returns the sorted actions the CredentialsRequest allows
+func RequiredAWSActions(credReq *cco.CredentialsRequest) ([]string, error) {
+	doc, err := AWSCredentialsRequestToPolicyDocument(credReq)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	actions := []string{}
+	for _, statement := range doc.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+		for _, action := range statement.Action {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+	sort.Strings(actions)
+	return actions, nil
+}
+
+// MissingActions returns the required actions which aren't allowed by any of the policy documents,
+// or which are denied unconditionally on all resources. Resource scopes and conditions of Allow
+// statements are not evaluated.
+func MissingActions(required []string, docs []*IAMPolicyDocument) []string {
+	missing := []string{}
+	for _, action := range required {
+		allowed := false
+		denied := false
+		for _, doc := range docs {
+			for _, statement := range doc.Statement {
+				if !statement.matches(action) {
+					continue
+				}
+				switch {
+				case strings.EqualFold(statement.Effect, "Allow"):
+					allowed = true
+				case strings.EqualFold(statement.Effect, "Deny") && statement.appliesToAllResources():
+					denied = true
+				}
+			}
+		}
+		if !allowed || denied {
+			missing = append(missing, action)
+		}
+	}
+	return missing
+}
+
+// matches returns true if the statement's Action or NotAction covers the action
+func (s IAMStatement) matches(action string) bool {
+	if len(s.NotAction) > 0 {
+		return !matchesAnyAction(s.NotAction, action)
+	}
+	return matchesAnyAction(s.Action, action)
+}
+
+func (s IAMStatement) appliesToAllResources() bool {
+	if len(s.Condition) > 0 && string(s.Condition) != "null" && string(s.Condition) != "{}" {
+		return false
+	}
+	for _, resource := range s.Resource {
+		if resource == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyAction matches the action against IAM action patterns, which are case insensitive
+// and may contain the wildcards '*' and '?'
+func matchesAnyAction(patterns []string, action string) bool {
+	action = strings.ToLower(action)
+	for _, pattern := range patterns {
+		// IAM actions never contain '/', so path.Match implements IAM's wildcard semantics
+		if ok, _ := path.Match(strings.ToLower(pattern), action); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package policies
+
+import (
+	"net/url"
+	"testing"
+
+	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIAMPolicyDocument(t *testing.T) {
+	single := url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"ec2:Describe*","Resource":"*"}}`)
+	doc, err := ParseIAMPolicyDocument(single)
+	assert.NoError(t, err)
+	assert.Len(t, doc.Statement, 1)
+	assert.Equal(t, []string{"ec2:Describe*"}, []string(doc.Statement[0].Action))
+
+	list := url.QueryEscape(`{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::a","arn:aws:s3:::b"]},{"Effect":"Deny","NotAction":"iam:*","Resource":"*"}]}`)
+	doc, err = ParseIAMPolicyDocument(list)
+	assert.NoError(t, err)
+	assert.Len(t, doc.Statement, 2)
+	assert.Equal(t, []string{"iam:*"}, []string(doc.Statement[1].NotAction))
+
+	_, err = ParseIAMPolicyDocument("not a policy")
+	assert.Error(t, err)
+}
+
+func TestRequiredAWSActions(t *testing.T) {
+	cr := newAWSCredentialsRequest(t, "openshift-ingress",
+		cco.StatementEntry{Effect: "Allow", Action: []string{"route53:ListHostedZones", "elasticloadbalancing:DescribeLoadBalancers"}, Resource: "*"},
+		cco.StatementEntry{Effect: "Allow", Action: []string{"route53:ListHostedZones"}, Resource: "arn:aws:route53:::hostedzone/*"},
+		cco.StatementEntry{Effect: "Deny", Action: []string{"iam:CreateUser"}, Resource: "*"},
+	)
+
+	actions, err := RequiredAWSActions(cr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"elasticloadbalancing:DescribeLoadBalancers", "route53:ListHostedZones"}, actions)
+}
+
+func TestMissingActions(t *testing.T) {
+	parse := func(document string) *IAMPolicyDocument {
+		doc, err := ParseIAMPolicyDocument(url.QueryEscape(document))
+		assert.NoError(t, err)
+		return doc
+	}
+
+	tests := []struct {
+		name     string
+		docs     []*IAMPolicyDocument
+		expected []string
+	}{
+		{
+			name:     "no policies",
+			expected: []string{"ec2:DescribeInstances", "s3:GetObject"},
+		},
+		{
+			name: "exact and wildcard actions",
+			docs: []*IAMPolicyDocument{
+				parse(`{"Statement":[{"Effect":"Allow","Action":"EC2:Describe*","Resource":"*"}]}`),
+				parse(`{"Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"arn:aws:s3:::bucket/*"}]}`),
+			},
+			expected: []string{},
+		},
+		{
+			name: "NotAction",
+			docs: []*IAMPolicyDocument{
+				parse(`{"Statement":[{"Effect":"Allow","NotAction":"s3:*","Resource":"*"}]}`),
+			},
+			expected: []string{"s3:GetObject"},
+		},
+		{
+			name: "unconditional deny",
+			docs: []*IAMPolicyDocument{
+				parse(`{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"s3:*","Resource":"*"}]}`),
+			},
+			expected: []string{"s3:GetObject"},
+		},
+		{
+			name: "conditional deny is ignored",
+			docs: []*IAMPolicyDocument{
+				parse(`{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"s3:*","Resource":"*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`),
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MissingActions([]string{"ec2:DescribeInstances", "s3:GetObject"}, tt.docs))
+		})
+	}
+}
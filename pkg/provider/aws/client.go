@@ -43,6 +43,8 @@ const (
 	NoProxyFlag    = "skip-aws-proxy-check"
 )
 
+// Client is the AWS client used across osdctl. New code should prefer ContextClient,
+// which allows callers to cancel in-flight requests.
 // TODO: Add more methods when needed
 type Client interface {
 	// sts
@@ -71,6 +73,10 @@ type Client interface {
 	AttachRolePolicy(*iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error)
 	DetachRolePolicy(*iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error)
 	ListAttachedRolePolicies(*iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error)
+	ListRolePolicies(*iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error)
+	GetRolePolicy(*iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error)
+	GetPolicy(*iam.GetPolicyInput) (*iam.GetPolicyOutput, error)
+	GetPolicyVersion(*iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error)
 	DeleteLoginProfile(*iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error)
 	ListSigningCertificates(*iam.ListSigningCertificatesInput) (*iam.ListSigningCertificatesOutput, error)
 	DeleteSigningCertificate(*iam.DeleteSigningCertificateInput) (*iam.DeleteSigningCertificateOutput, error)
@@ -87,6 +93,8 @@ type Client interface {
 
 	//ec2
 	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	DescribeInstanceTypes(*ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeInstanceTypeOfferings(*ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
 	DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
 	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
@@ -96,6 +104,7 @@ type Client interface {
 
 	// Service Quotas
 	ListServiceQuotas(*servicequotas.ListServiceQuotasInput) (*servicequotas.ListServiceQuotasOutput, error)
+	GetServiceQuota(*servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error)
 	RequestServiceQuotaIncrease(*servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
 
 	// Organizations
@@ -128,6 +137,7 @@ type Client interface {
 	// Route53
 	ListHostedZones(input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error)
 	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
+	GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
 
 	// ELB
 	DescribeLoadBalancers(input *elasticloadbalancing.DescribeLoadBalancersInput) (*elasticloadbalancing.DescribeLoadBalancersOutput, error)
@@ -179,6 +189,11 @@ func addProxyConfigToSessionOptConfig(config *aws.Config) {
 }
 
 func NewAwsConfig(profile, region, configFile string) (*aws.Config, error) {
+	return NewAwsConfigWithContext(context.TODO(), profile, region, configFile)
+}
+
+// NewAwsConfigWithContext loads the AWS config for the given profile, using ctx for credential retrieval
+func NewAwsConfigWithContext(ctx context.Context, profile, region, configFile string) (*aws.Config, error) {
 	var cfg aws.Config
 	var err error
 
@@ -188,17 +203,19 @@ func NewAwsConfig(profile, region, configFile string) (*aws.Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("could not load config file: %w", err)
 		}
-		cfg, err = config.LoadDefaultConfig(context.TODO(), config.WithRegion(region), config.WithSharedConfigProfile(profile), config.WithSharedConfigFiles([]string{absCfgPath}))
+		cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(region), config.WithSharedConfigProfile(profile), config.WithSharedConfigFiles([]string{absCfgPath}))
 	} else {
-		cfg, err = config.LoadDefaultConfig(context.TODO(), config.WithRegion(region), config.WithSharedConfigProfile(profile))
+		cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(region), config.WithSharedConfigProfile(profile))
 		if err != nil {
 			return nil, fmt.Errorf("error loading aws config: %w", err)
 		}
 	}
 
 	addProxyConfigToSessionOptConfig(&cfg)
+	addRetryerToConfig(&cfg)
+	addRequestMetricsToConfig(&cfg)
 
-	if _, err := cfg.Credentials.Retrieve(context.TODO()); err != nil {
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
 		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
 	}
 
@@ -212,20 +229,7 @@ func NewAwsClient(profile, region, configFile string) (Client, error) {
 		return nil, err
 	}
 
-	awsClient := &AwsClient{
-		iamClient:           *iam.NewFromConfig(*cfg),
-		ec2Client:           *ec2.NewFromConfig(*cfg),
-		stsClient:           *sts.NewFromConfig(*cfg),
-		s3Client:            *s3.NewFromConfig(*cfg),
-		servicequotasClient: *servicequotas.NewFromConfig(*cfg),
-		orgClient:           *organizations.NewFromConfig(*cfg),
-		ceClient:            *costexplorer.NewFromConfig(*cfg),
-		resClient:           *resourcegroupstaggingapi.NewFromConfig(*cfg),
-		cloudTrailClient:    *cloudtrail.NewFromConfig(*cfg),
-		route53Client:       *route53.NewFromConfig(*cfg),
-		elbClient:           *elasticloadbalancing.NewFromConfig(*cfg),
-		elbv2Client:         *elasticloadbalancingv2.NewFromConfig(*cfg),
-	}
+	awsClient := newAwsClientFromConfig(*cfg)
 
 	// Validate the creds
 	if _, err := awsClient.GetCallerIdentity(nil); err != nil {
@@ -237,7 +241,24 @@ func NewAwsClient(profile, region, configFile string) (Client, error) {
 
 // NewAwsClientWithInput creates an AWS client with input credentials
 func NewAwsClientWithInput(input *ClientInput) (Client, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
+	cfg, err := newAwsConfigWithInput(context.TODO(), input)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAwsClientFromConfig(*cfg), nil
+}
+
+// NewAwsClientFromConfig creates an AWS client from an existing config, e.g. one retrieved from backplane
+func NewAwsClientFromConfig(cfg aws.Config) Client {
+	addRetryerToConfig(&cfg)
+	addRequestMetricsToConfig(&cfg)
+
+	return newAwsClientFromConfig(cfg)
+}
+
+func newAwsConfigWithInput(ctx context.Context, input *ClientInput) (*aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithRegion(input.Region),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(input.AccessKeyID, input.SecretAccessKey, input.SessionToken)),
 	)
@@ -246,7 +267,13 @@ func NewAwsClientWithInput(input *ClientInput) (Client, error) {
 	}
 
 	addProxyConfigToSessionOptConfig(&cfg)
+	addRetryerToConfig(&cfg)
+	addRequestMetricsToConfig(&cfg)
 
+	return &cfg, nil
+}
+
+func newAwsClientFromConfig(cfg aws.Config) *AwsClient {
 	return &AwsClient{
 		iamClient:           *iam.NewFromConfig(cfg),
 		ec2Client:           *ec2.NewFromConfig(cfg),
@@ -260,7 +287,7 @@ func NewAwsClientWithInput(input *ClientInput) (Client, error) {
 		route53Client:       *route53.NewFromConfig(cfg),
 		elbClient:           *elasticloadbalancing.NewFromConfig(cfg),
 		elbv2Client:         *elasticloadbalancingv2.NewFromConfig(cfg),
-	}, nil
+	}
 }
 
 func (c *AwsClient) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
@@ -351,6 +378,22 @@ func (c *AwsClient) ListAttachedRolePolicies(input *iam.ListAttachedRolePolicies
 	return c.iamClient.ListAttachedRolePolicies(context.TODO(), input)
 }
 
+func (c *AwsClient) ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	return c.iamClient.ListRolePolicies(context.TODO(), input)
+}
+
+func (c *AwsClient) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	return c.iamClient.GetRolePolicy(context.TODO(), input)
+}
+
+func (c *AwsClient) GetPolicy(input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return c.iamClient.GetPolicy(context.TODO(), input)
+}
+
+func (c *AwsClient) GetPolicyVersion(input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	return c.iamClient.GetPolicyVersion(context.TODO(), input)
+}
+
 func (c *AwsClient) DeleteLoginProfile(input *iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error) {
 	return c.iamClient.DeleteLoginProfile(context.TODO(), input)
 }
@@ -421,6 +464,10 @@ func (c *AwsClient) ListServiceQuotas(input *servicequotas.ListServiceQuotasInpu
 	return c.servicequotasClient.ListServiceQuotas(context.TODO(), input)
 }
 
+func (c *AwsClient) GetServiceQuota(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	return c.servicequotasClient.GetServiceQuota(context.TODO(), input)
+}
+
 func (c *AwsClient) RequestServiceQuotaIncrease(input *servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error) {
 	return c.servicequotasClient.RequestServiceQuotaIncrease(context.TODO(), input)
 }
@@ -481,6 +528,14 @@ func (c *AwsClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.D
 	return c.ec2Client.DescribeInstances(context.TODO(), input)
 }
 
+func (c *AwsClient) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	return c.ec2Client.DescribeInstanceTypes(context.TODO(), input)
+}
+
+func (c *AwsClient) DescribeInstanceTypeOfferings(input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	return c.ec2Client.DescribeInstanceTypeOfferings(context.TODO(), input)
+}
+
 func (c *AwsClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
 	return c.ec2Client.DescribeRouteTables(context.TODO(), input)
 }
@@ -529,6 +584,10 @@ func (c *AwsClient) ListResourceRecordSets(input *route53.ListResourceRecordSets
 	return c.route53Client.ListResourceRecordSets(context.TODO(), input)
 }
 
+func (c *AwsClient) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	return c.route53Client.GetHostedZone(context.TODO(), input)
+}
+
 func (c *AwsClient) DescribeLoadBalancers(input *elasticloadbalancing.DescribeLoadBalancersInput) (*elasticloadbalancing.DescribeLoadBalancersOutput, error) {
 	return c.elbClient.DescribeLoadBalancers(context.TODO(), input)
 }
@@ -0,0 +1,794 @@
+package aws
+
+// Generate client mocks for testing
+//go:generate mockgen -source=context_client.go -package=mock -destination=mock/context_client.go
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// ContextClient mirrors Client, but every call takes a context.Context so that
+// cancellation (e.g. Ctrl-C) and deadlines propagate to the AWS SDK
+type ContextClient interface {
+	// sts
+	AssumeRole(ctx context.Context, input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
+	GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+	GetFederationToken(ctx context.Context, input *sts.GetFederationTokenInput) (*sts.GetFederationTokenOutput, error)
+
+	// S3
+	ListBuckets(ctx context.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error)
+	DeleteBucket(ctx context.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error)
+	ListObjects(ctx context.Context, input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error)
+	ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+	GetObject(ctx context.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	DeleteObjects(ctx context.Context, input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+
+	//iam
+	CreateAccessKey(ctx context.Context, input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error)
+	DeleteAccessKey(ctx context.Context, input *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error)
+	ListAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error)
+	GetUser(ctx context.Context, input *iam.GetUserInput) (*iam.GetUserOutput, error)
+	CreateUser(ctx context.Context, input *iam.CreateUserInput) (*iam.CreateUserOutput, error)
+	ListUsers(ctx context.Context, input *iam.ListUsersInput) (*iam.ListUsersOutput, error)
+	AttachUserPolicy(ctx context.Context, input *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error)
+	CreatePolicy(ctx context.Context, input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error)
+	DeletePolicy(ctx context.Context, input *iam.DeletePolicyInput) (*iam.DeletePolicyOutput, error)
+	AttachRolePolicy(ctx context.Context, input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error)
+	DetachRolePolicy(ctx context.Context, input *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error)
+	ListAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error)
+	ListRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error)
+	GetRolePolicy(ctx context.Context, input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error)
+	GetPolicy(ctx context.Context, input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error)
+	GetPolicyVersion(ctx context.Context, input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error)
+	DeleteLoginProfile(ctx context.Context, input *iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error)
+	ListSigningCertificates(ctx context.Context, input *iam.ListSigningCertificatesInput) (*iam.ListSigningCertificatesOutput, error)
+	DeleteSigningCertificate(ctx context.Context, input *iam.DeleteSigningCertificateInput) (*iam.DeleteSigningCertificateOutput, error)
+	ListUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error)
+	ListPolicies(ctx context.Context, input *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error)
+	DeleteUserPolicy(ctx context.Context, input *iam.DeleteUserPolicyInput) (*iam.DeleteUserPolicyOutput, error)
+	ListAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error)
+	DetachUserPolicy(ctx context.Context, input *iam.DetachUserPolicyInput) (*iam.DetachUserPolicyOutput, error)
+	ListGroupsForUser(ctx context.Context, input *iam.ListGroupsForUserInput) (*iam.ListGroupsForUserOutput, error)
+	RemoveUserFromGroup(ctx context.Context, input *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error)
+	ListRoles(ctx context.Context, input *iam.ListRolesInput) (*iam.ListRolesOutput, error)
+	DeleteRole(ctx context.Context, input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error)
+	DeleteUser(ctx context.Context, input *iam.DeleteUserInput) (*iam.DeleteUserOutput, error)
+
+	//ec2
+	DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeInstanceTypeOfferings(ctx context.Context, input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+	DescribeRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DescribeVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
+	DescribeVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	DescribeVpcEndpointConnections(ctx context.Context, input *ec2.DescribeVpcEndpointConnectionsInput) (*ec2.DescribeVpcEndpointConnectionsOutput, error)
+	DescribeVpcEndpointServices(ctx context.Context, input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error)
+
+	// Service Quotas
+	ListServiceQuotas(ctx context.Context, input *servicequotas.ListServiceQuotasInput) (*servicequotas.ListServiceQuotasOutput, error)
+	GetServiceQuota(ctx context.Context, input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error)
+	RequestServiceQuotaIncrease(ctx context.Context, input *servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
+
+	// Organizations
+	CreateAccount(ctx context.Context, input *organizations.CreateAccountInput) (*organizations.CreateAccountOutput, error)
+	DescribeCreateAccountStatus(ctx context.Context, input *organizations.DescribeCreateAccountStatusInput) (*organizations.DescribeCreateAccountStatusOutput, error)
+	ListAccounts(ctx context.Context, input *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error)
+	ListParents(ctx context.Context, input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error)
+	ListChildren(ctx context.Context, input *organizations.ListChildrenInput) (*organizations.ListChildrenOutput, error)
+	ListRoots(ctx context.Context, input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error)
+	ListAccountsForParent(ctx context.Context, input *organizations.ListAccountsForParentInput) (*organizations.ListAccountsForParentOutput, error)
+	ListOrganizationalUnitsForParent(ctx context.Context, input *organizations.ListOrganizationalUnitsForParentInput) (*organizations.ListOrganizationalUnitsForParentOutput, error)
+	DescribeOrganizationalUnit(ctx context.Context, input *organizations.DescribeOrganizationalUnitInput) (*organizations.DescribeOrganizationalUnitOutput, error)
+	TagResource(ctx context.Context, input *organizations.TagResourceInput) (*organizations.TagResourceOutput, error)
+	UntagResource(ctx context.Context, input *organizations.UntagResourceInput) (*organizations.UntagResourceOutput, error)
+	ListTagsForResource(ctx context.Context, input *organizations.ListTagsForResourceInput) (*organizations.ListTagsForResourceOutput, error)
+	MoveAccount(ctx context.Context, input *organizations.MoveAccountInput) (*organizations.MoveAccountOutput, error)
+	DescribeAccount(ctx context.Context, input *organizations.DescribeAccountInput) (*organizations.DescribeAccountOutput, error)
+
+	// Resources
+	GetResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+
+	// Cost Explorer
+	GetCostAndUsage(ctx context.Context, input *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error)
+	CreateCostCategoryDefinition(ctx context.Context, input *costexplorer.CreateCostCategoryDefinitionInput) (*costexplorer.CreateCostCategoryDefinitionOutput, error)
+	ListCostCategoryDefinitions(ctx context.Context, input *costexplorer.ListCostCategoryDefinitionsInput) (*costexplorer.ListCostCategoryDefinitionsOutput, error)
+
+	// Cloudtrail
+	LookupEvents(ctx context.Context, input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error)
+
+	// Route53
+	ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error)
+	ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
+	GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
+
+	// ELB
+	DescribeLoadBalancers(ctx context.Context, input *elasticloadbalancing.DescribeLoadBalancersInput) (*elasticloadbalancing.DescribeLoadBalancersOutput, error)
+	DescribeTags(ctx context.Context, input *elasticloadbalancing.DescribeTagsInput) (*elasticloadbalancing.DescribeTagsOutput, error)
+	DescribeV2LoadBalancers(ctx context.Context, input *elasticloadbalancingv2.DescribeLoadBalancersInput) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error)
+	DescribeV2Tags(ctx context.Context, input *elasticloadbalancingv2.DescribeTagsInput) (*elasticloadbalancingv2.DescribeTagsOutput, error)
+}
+
+// AwsContextClient is the ContextClient implementation backed by the AWS SDK.
+// It shares its underlying service clients with AwsClient.
+type AwsContextClient AwsClient
+
+// NewAwsContextClient creates a ContextClient with credentials in the environment
+func NewAwsContextClient(ctx context.Context, profile, region, configFile string) (ContextClient, error) {
+	cfg, err := NewAwsConfigWithContext(ctx, profile, region, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	awsClient := (*AwsContextClient)(newAwsClientFromConfig(*cfg))
+
+	// Validate the creds
+	if _, err := awsClient.GetCallerIdentity(ctx, nil); err != nil {
+		return nil, fmt.Errorf("error getting caller identity: %w", err)
+	}
+
+	return awsClient, nil
+}
+
+// NewAwsContextClientWithInput creates a ContextClient with input credentials
+func NewAwsContextClientWithInput(ctx context.Context, input *ClientInput) (ContextClient, error) {
+	cfg, err := newAwsConfigWithInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*AwsContextClient)(newAwsClientFromConfig(*cfg)), nil
+}
+
+// NewAwsContextClientFromConfig creates a ContextClient from an existing AWS config, e.g. one with the assumed role
+// credentials of a cluster
+func NewAwsContextClientFromConfig(cfg aws.Config) ContextClient {
+	addRetryerToConfig(&cfg)
+	addRequestMetricsToConfig(&cfg)
+
+	return (*AwsContextClient)(newAwsClientFromConfig(cfg))
+}
+
+// NewContextClientFromClient returns a ContextClient sharing the service clients of a Client created by this
+// package, e.g. one with the assumed role credentials of a cluster
+func NewContextClientFromClient(client Client) (ContextClient, error) {
+	awsClient, ok := client.(*AwsClient)
+	if !ok {
+		return nil, fmt.Errorf("unsupported AWS client %T", client)
+	}
+	return (*AwsContextClient)(awsClient), nil
+}
+
+// BindContext adapts a ContextClient to the Client interface, issuing every call with ctx.
+// This allows commands to become cancellable without rewriting helpers that still accept a Client.
+func BindContext(ctx context.Context, client ContextClient) Client {
+	return &contextBoundClient{ctx: ctx, client: client}
+}
+
+type contextBoundClient struct {
+	ctx    context.Context
+	client ContextClient
+}
+
+func (c *AwsContextClient) AssumeRole(ctx context.Context, input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	return c.stsClient.AssumeRole(ctx, input)
+}
+
+func (c *AwsContextClient) GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return c.stsClient.GetCallerIdentity(ctx, input)
+}
+
+func (c *AwsContextClient) GetFederationToken(ctx context.Context, input *sts.GetFederationTokenInput) (*sts.GetFederationTokenOutput, error) {
+	return c.stsClient.GetFederationToken(ctx, input)
+}
+
+func (c *AwsContextClient) ListBuckets(ctx context.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	return c.s3Client.ListBuckets(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteBucket(ctx context.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+	return c.s3Client.DeleteBucket(ctx, input)
+}
+
+func (c *AwsContextClient) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
+	return c.s3Client.ListObjects(ctx, input)
+}
+
+func (c *AwsContextClient) ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	return c.s3Client.ListObjectsV2(ctx, input)
+}
+
+func (c *AwsContextClient) GetObject(ctx context.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return c.s3Client.GetObject(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteObjects(ctx context.Context, input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	return c.s3Client.DeleteObjects(ctx, input)
+}
+
+func (c *AwsContextClient) CreateAccessKey(ctx context.Context, input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
+	return c.iamClient.CreateAccessKey(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteAccessKey(ctx context.Context, input *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
+	return c.iamClient.DeleteAccessKey(ctx, input)
+}
+
+func (c *AwsContextClient) ListAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error) {
+	return c.iamClient.ListAccessKeys(ctx, input)
+}
+
+func (c *AwsContextClient) GetUser(ctx context.Context, input *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	return c.iamClient.GetUser(ctx, input)
+}
+
+func (c *AwsContextClient) CreateUser(ctx context.Context, input *iam.CreateUserInput) (*iam.CreateUserOutput, error) {
+	return c.iamClient.CreateUser(ctx, input)
+}
+
+func (c *AwsContextClient) ListUsers(ctx context.Context, input *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+	return c.iamClient.ListUsers(ctx, input)
+}
+
+func (c *AwsContextClient) AttachUserPolicy(ctx context.Context, input *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error) {
+	return c.iamClient.AttachUserPolicy(ctx, input)
+}
+
+func (c *AwsContextClient) CreatePolicy(ctx context.Context, input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+	return c.iamClient.CreatePolicy(ctx, input)
+}
+
+func (c *AwsContextClient) DeletePolicy(ctx context.Context, input *iam.DeletePolicyInput) (*iam.DeletePolicyOutput, error) {
+	return c.iamClient.DeletePolicy(ctx, input)
+}
+
+func (c *AwsContextClient) AttachRolePolicy(ctx context.Context, input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
+	return c.iamClient.AttachRolePolicy(ctx, input)
+}
+
+func (c *AwsContextClient) DetachRolePolicy(ctx context.Context, input *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error) {
+	return c.iamClient.DetachRolePolicy(ctx, input)
+}
+
+func (c *AwsContextClient) ListAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	return c.iamClient.ListAttachedRolePolicies(ctx, input)
+}
+
+func (c *AwsContextClient) ListRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	return c.iamClient.ListRolePolicies(ctx, input)
+}
+
+func (c *AwsContextClient) GetRolePolicy(ctx context.Context, input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	return c.iamClient.GetRolePolicy(ctx, input)
+}
+
+func (c *AwsContextClient) GetPolicy(ctx context.Context, input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return c.iamClient.GetPolicy(ctx, input)
+}
+
+func (c *AwsContextClient) GetPolicyVersion(ctx context.Context, input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	return c.iamClient.GetPolicyVersion(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteLoginProfile(ctx context.Context, input *iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error) {
+	return c.iamClient.DeleteLoginProfile(ctx, input)
+}
+
+func (c *AwsContextClient) ListSigningCertificates(ctx context.Context, input *iam.ListSigningCertificatesInput) (*iam.ListSigningCertificatesOutput, error) {
+	return c.iamClient.ListSigningCertificates(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteSigningCertificate(ctx context.Context, input *iam.DeleteSigningCertificateInput) (*iam.DeleteSigningCertificateOutput, error) {
+	return c.iamClient.DeleteSigningCertificate(ctx, input)
+}
+
+func (c *AwsContextClient) ListUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error) {
+	return c.iamClient.ListUserPolicies(ctx, input)
+}
+
+func (c *AwsContextClient) ListPolicies(ctx context.Context, input *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error) {
+	return c.iamClient.ListPolicies(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteUserPolicy(ctx context.Context, input *iam.DeleteUserPolicyInput) (*iam.DeleteUserPolicyOutput, error) {
+	return c.iamClient.DeleteUserPolicy(ctx, input)
+}
+
+func (c *AwsContextClient) ListAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error) {
+	return c.iamClient.ListAttachedUserPolicies(ctx, input)
+}
+
+func (c *AwsContextClient) DetachUserPolicy(ctx context.Context, input *iam.DetachUserPolicyInput) (*iam.DetachUserPolicyOutput, error) {
+	return c.iamClient.DetachUserPolicy(ctx, input)
+}
+
+func (c *AwsContextClient) ListGroupsForUser(ctx context.Context, input *iam.ListGroupsForUserInput) (*iam.ListGroupsForUserOutput, error) {
+	return c.iamClient.ListGroupsForUser(ctx, input)
+}
+
+func (c *AwsContextClient) RemoveUserFromGroup(ctx context.Context, input *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error) {
+	return c.iamClient.RemoveUserFromGroup(ctx, input)
+}
+
+func (c *AwsContextClient) ListRoles(ctx context.Context, input *iam.ListRolesInput) (*iam.ListRolesOutput, error) {
+	return c.iamClient.ListRoles(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteRole(ctx context.Context, input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	return c.iamClient.DeleteRole(ctx, input)
+}
+
+func (c *AwsContextClient) DeleteUser(ctx context.Context, input *iam.DeleteUserInput) (*iam.DeleteUserOutput, error) {
+	return c.iamClient.DeleteUser(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return c.ec2Client.DescribeInstances(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	return c.ec2Client.DescribeInstanceTypes(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeInstanceTypeOfferings(ctx context.Context, input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	return c.ec2Client.DescribeInstanceTypeOfferings(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return c.ec2Client.DescribeRouteTables(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return c.ec2Client.DescribeSubnets(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	return c.ec2Client.DescribeVpcs(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return c.ec2Client.DescribeVpcEndpoints(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeVpcEndpointConnections(ctx context.Context, input *ec2.DescribeVpcEndpointConnectionsInput) (*ec2.DescribeVpcEndpointConnectionsOutput, error) {
+	return c.ec2Client.DescribeVpcEndpointConnections(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeVpcEndpointServices(ctx context.Context, input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+	return c.ec2Client.DescribeVpcEndpointServices(ctx, input)
+}
+
+func (c *AwsContextClient) ListServiceQuotas(ctx context.Context, input *servicequotas.ListServiceQuotasInput) (*servicequotas.ListServiceQuotasOutput, error) {
+	return c.servicequotasClient.ListServiceQuotas(ctx, input)
+}
+
+func (c *AwsContextClient) GetServiceQuota(ctx context.Context, input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	return c.servicequotasClient.GetServiceQuota(ctx, input)
+}
+
+func (c *AwsContextClient) RequestServiceQuotaIncrease(ctx context.Context, input *servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error) {
+	return c.servicequotasClient.RequestServiceQuotaIncrease(ctx, input)
+}
+
+func (c *AwsContextClient) CreateAccount(ctx context.Context, input *organizations.CreateAccountInput) (*organizations.CreateAccountOutput, error) {
+	return c.orgClient.CreateAccount(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeCreateAccountStatus(ctx context.Context, input *organizations.DescribeCreateAccountStatusInput) (*organizations.DescribeCreateAccountStatusOutput, error) {
+	return c.orgClient.DescribeCreateAccountStatus(ctx, input)
+}
+
+func (c *AwsContextClient) ListAccounts(ctx context.Context, input *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error) {
+	return c.orgClient.ListAccounts(ctx, input)
+}
+
+func (c *AwsContextClient) ListParents(ctx context.Context, input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	return c.orgClient.ListParents(ctx, input)
+}
+
+func (c *AwsContextClient) ListChildren(ctx context.Context, input *organizations.ListChildrenInput) (*organizations.ListChildrenOutput, error) {
+	return c.orgClient.ListChildren(ctx, input)
+}
+
+func (c *AwsContextClient) ListRoots(ctx context.Context, input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error) {
+	return c.orgClient.ListRoots(ctx, input)
+}
+
+func (c *AwsContextClient) ListAccountsForParent(ctx context.Context, input *organizations.ListAccountsForParentInput) (*organizations.ListAccountsForParentOutput, error) {
+	return c.orgClient.ListAccountsForParent(ctx, input)
+}
+
+func (c *AwsContextClient) ListOrganizationalUnitsForParent(ctx context.Context, input *organizations.ListOrganizationalUnitsForParentInput) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	return c.orgClient.ListOrganizationalUnitsForParent(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeOrganizationalUnit(ctx context.Context, input *organizations.DescribeOrganizationalUnitInput) (*organizations.DescribeOrganizationalUnitOutput, error) {
+	return c.orgClient.DescribeOrganizationalUnit(ctx, input)
+}
+
+func (c *AwsContextClient) TagResource(ctx context.Context, input *organizations.TagResourceInput) (*organizations.TagResourceOutput, error) {
+	return c.orgClient.TagResource(ctx, input)
+}
+
+func (c *AwsContextClient) UntagResource(ctx context.Context, input *organizations.UntagResourceInput) (*organizations.UntagResourceOutput, error) {
+	return c.orgClient.UntagResource(ctx, input)
+}
+
+func (c *AwsContextClient) ListTagsForResource(ctx context.Context, input *organizations.ListTagsForResourceInput) (*organizations.ListTagsForResourceOutput, error) {
+	return c.orgClient.ListTagsForResource(ctx, input)
+}
+
+func (c *AwsContextClient) MoveAccount(ctx context.Context, input *organizations.MoveAccountInput) (*organizations.MoveAccountOutput, error) {
+	return c.orgClient.MoveAccount(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeAccount(ctx context.Context, input *organizations.DescribeAccountInput) (*organizations.DescribeAccountOutput, error) {
+	return c.orgClient.DescribeAccount(ctx, input)
+}
+
+func (c *AwsContextClient) GetResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	return c.resClient.GetResources(ctx, input)
+}
+
+func (c *AwsContextClient) GetCostAndUsage(ctx context.Context, input *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error) {
+	return c.ceClient.GetCostAndUsage(ctx, input)
+}
+
+func (c *AwsContextClient) CreateCostCategoryDefinition(ctx context.Context, input *costexplorer.CreateCostCategoryDefinitionInput) (*costexplorer.CreateCostCategoryDefinitionOutput, error) {
+	return c.ceClient.CreateCostCategoryDefinition(ctx, input)
+}
+
+func (c *AwsContextClient) ListCostCategoryDefinitions(ctx context.Context, input *costexplorer.ListCostCategoryDefinitionsInput) (*costexplorer.ListCostCategoryDefinitionsOutput, error) {
+	return c.ceClient.ListCostCategoryDefinitions(ctx, input)
+}
+
+func (c *AwsContextClient) LookupEvents(ctx context.Context, input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	return c.cloudTrailClient.LookupEvents(ctx, input)
+}
+
+func (c *AwsContextClient) ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
+	return c.route53Client.ListHostedZones(ctx, input)
+}
+
+func (c *AwsContextClient) ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	return c.route53Client.ListResourceRecordSets(ctx, input)
+}
+
+func (c *AwsContextClient) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	return c.route53Client.GetHostedZone(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeLoadBalancers(ctx context.Context, input *elasticloadbalancing.DescribeLoadBalancersInput) (*elasticloadbalancing.DescribeLoadBalancersOutput, error) {
+	return c.elbClient.DescribeLoadBalancers(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeTags(ctx context.Context, input *elasticloadbalancing.DescribeTagsInput) (*elasticloadbalancing.DescribeTagsOutput, error) {
+	return c.elbClient.DescribeTags(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeV2LoadBalancers(ctx context.Context, input *elasticloadbalancingv2.DescribeLoadBalancersInput) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
+	return c.elbv2Client.DescribeLoadBalancers(ctx, input)
+}
+
+func (c *AwsContextClient) DescribeV2Tags(ctx context.Context, input *elasticloadbalancingv2.DescribeTagsInput) (*elasticloadbalancingv2.DescribeTagsOutput, error) {
+	return c.elbv2Client.DescribeTags(ctx, input)
+}
+
+func (c *contextBoundClient) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	return c.client.AssumeRole(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return c.client.GetCallerIdentity(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetFederationToken(input *sts.GetFederationTokenInput) (*sts.GetFederationTokenOutput, error) {
+	return c.client.GetFederationToken(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListBuckets(input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	return c.client.ListBuckets(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteBucket(input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+	return c.client.DeleteBucket(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListObjects(input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
+	return c.client.ListObjects(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListObjectsV2(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	return c.client.ListObjectsV2(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return c.client.GetObject(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteObjects(input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	return c.client.DeleteObjects(c.ctx, input)
+}
+
+func (c *contextBoundClient) CreateAccessKey(input *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
+	return c.client.CreateAccessKey(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteAccessKey(input *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
+	return c.client.DeleteAccessKey(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListAccessKeys(input *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error) {
+	return c.client.ListAccessKeys(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetUser(input *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	return c.client.GetUser(c.ctx, input)
+}
+
+func (c *contextBoundClient) CreateUser(input *iam.CreateUserInput) (*iam.CreateUserOutput, error) {
+	return c.client.CreateUser(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListUsers(input *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+	return c.client.ListUsers(c.ctx, input)
+}
+
+func (c *contextBoundClient) AttachUserPolicy(input *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error) {
+	return c.client.AttachUserPolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) CreatePolicy(input *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+	return c.client.CreatePolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeletePolicy(input *iam.DeletePolicyInput) (*iam.DeletePolicyOutput, error) {
+	return c.client.DeletePolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) AttachRolePolicy(input *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
+	return c.client.AttachRolePolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) DetachRolePolicy(input *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error) {
+	return c.client.DetachRolePolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListAttachedRolePolicies(input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	return c.client.ListAttachedRolePolicies(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	return c.client.ListRolePolicies(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	return c.client.GetRolePolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetPolicy(input *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return c.client.GetPolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetPolicyVersion(input *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	return c.client.GetPolicyVersion(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteLoginProfile(input *iam.DeleteLoginProfileInput) (*iam.DeleteLoginProfileOutput, error) {
+	return c.client.DeleteLoginProfile(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListSigningCertificates(input *iam.ListSigningCertificatesInput) (*iam.ListSigningCertificatesOutput, error) {
+	return c.client.ListSigningCertificates(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteSigningCertificate(input *iam.DeleteSigningCertificateInput) (*iam.DeleteSigningCertificateOutput, error) {
+	return c.client.DeleteSigningCertificate(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListUserPolicies(input *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error) {
+	return c.client.ListUserPolicies(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListPolicies(input *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error) {
+	return c.client.ListPolicies(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteUserPolicy(input *iam.DeleteUserPolicyInput) (*iam.DeleteUserPolicyOutput, error) {
+	return c.client.DeleteUserPolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListAttachedUserPolicies(input *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error) {
+	return c.client.ListAttachedUserPolicies(c.ctx, input)
+}
+
+func (c *contextBoundClient) DetachUserPolicy(input *iam.DetachUserPolicyInput) (*iam.DetachUserPolicyOutput, error) {
+	return c.client.DetachUserPolicy(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListGroupsForUser(input *iam.ListGroupsForUserInput) (*iam.ListGroupsForUserOutput, error) {
+	return c.client.ListGroupsForUser(c.ctx, input)
+}
+
+func (c *contextBoundClient) RemoveUserFromGroup(input *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error) {
+	return c.client.RemoveUserFromGroup(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListRoles(input *iam.ListRolesInput) (*iam.ListRolesOutput, error) {
+	return c.client.ListRoles(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteRole(input *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	return c.client.DeleteRole(c.ctx, input)
+}
+
+func (c *contextBoundClient) DeleteUser(input *iam.DeleteUserInput) (*iam.DeleteUserOutput, error) {
+	return c.client.DeleteUser(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	return c.client.DescribeInstances(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	return c.client.DescribeInstanceTypes(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeInstanceTypeOfferings(input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	return c.client.DescribeInstanceTypeOfferings(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return c.client.DescribeRouteTables(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return c.client.DescribeSubnets(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	return c.client.DescribeVpcs(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return c.client.DescribeVpcEndpoints(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeVpcEndpointConnections(input *ec2.DescribeVpcEndpointConnectionsInput) (*ec2.DescribeVpcEndpointConnectionsOutput, error) {
+	return c.client.DescribeVpcEndpointConnections(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeVpcEndpointServices(input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+	return c.client.DescribeVpcEndpointServices(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListServiceQuotas(input *servicequotas.ListServiceQuotasInput) (*servicequotas.ListServiceQuotasOutput, error) {
+	return c.client.ListServiceQuotas(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetServiceQuota(input *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	return c.client.GetServiceQuota(c.ctx, input)
+}
+
+func (c *contextBoundClient) RequestServiceQuotaIncrease(input *servicequotas.RequestServiceQuotaIncreaseInput) (*servicequotas.RequestServiceQuotaIncreaseOutput, error) {
+	return c.client.RequestServiceQuotaIncrease(c.ctx, input)
+}
+
+func (c *contextBoundClient) CreateAccount(input *organizations.CreateAccountInput) (*organizations.CreateAccountOutput, error) {
+	return c.client.CreateAccount(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeCreateAccountStatus(input *organizations.DescribeCreateAccountStatusInput) (*organizations.DescribeCreateAccountStatusOutput, error) {
+	return c.client.DescribeCreateAccountStatus(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListAccounts(input *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error) {
+	return c.client.ListAccounts(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListParents(input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	return c.client.ListParents(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListChildren(input *organizations.ListChildrenInput) (*organizations.ListChildrenOutput, error) {
+	return c.client.ListChildren(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListRoots(input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error) {
+	return c.client.ListRoots(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListAccountsForParent(input *organizations.ListAccountsForParentInput) (*organizations.ListAccountsForParentOutput, error) {
+	return c.client.ListAccountsForParent(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListOrganizationalUnitsForParent(input *organizations.ListOrganizationalUnitsForParentInput) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	return c.client.ListOrganizationalUnitsForParent(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeOrganizationalUnit(input *organizations.DescribeOrganizationalUnitInput) (*organizations.DescribeOrganizationalUnitOutput, error) {
+	return c.client.DescribeOrganizationalUnit(c.ctx, input)
+}
+
+func (c *contextBoundClient) TagResource(input *organizations.TagResourceInput) (*organizations.TagResourceOutput, error) {
+	return c.client.TagResource(c.ctx, input)
+}
+
+func (c *contextBoundClient) UntagResource(input *organizations.UntagResourceInput) (*organizations.UntagResourceOutput, error) {
+	return c.client.UntagResource(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListTagsForResource(input *organizations.ListTagsForResourceInput) (*organizations.ListTagsForResourceOutput, error) {
+	return c.client.ListTagsForResource(c.ctx, input)
+}
+
+func (c *contextBoundClient) MoveAccount(input *organizations.MoveAccountInput) (*organizations.MoveAccountOutput, error) {
+	return c.client.MoveAccount(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeAccount(input *organizations.DescribeAccountInput) (*organizations.DescribeAccountOutput, error) {
+	return c.client.DescribeAccount(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetResources(input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	return c.client.GetResources(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetCostAndUsage(input *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error) {
+	return c.client.GetCostAndUsage(c.ctx, input)
+}
+
+func (c *contextBoundClient) CreateCostCategoryDefinition(input *costexplorer.CreateCostCategoryDefinitionInput) (*costexplorer.CreateCostCategoryDefinitionOutput, error) {
+	return c.client.CreateCostCategoryDefinition(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListCostCategoryDefinitions(input *costexplorer.ListCostCategoryDefinitionsInput) (*costexplorer.ListCostCategoryDefinitionsOutput, error) {
+	return c.client.ListCostCategoryDefinitions(c.ctx, input)
+}
+
+func (c *contextBoundClient) LookupEvents(input *cloudtrail.LookupEventsInput) (*cloudtrail.LookupEventsOutput, error) {
+	return c.client.LookupEvents(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListHostedZones(input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
+	return c.client.ListHostedZones(c.ctx, input)
+}
+
+func (c *contextBoundClient) ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	return c.client.ListResourceRecordSets(c.ctx, input)
+}
+
+func (c *contextBoundClient) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+	return c.client.GetHostedZone(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeLoadBalancers(input *elasticloadbalancing.DescribeLoadBalancersInput) (*elasticloadbalancing.DescribeLoadBalancersOutput, error) {
+	return c.client.DescribeLoadBalancers(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeTags(input *elasticloadbalancing.DescribeTagsInput) (*elasticloadbalancing.DescribeTagsOutput, error) {
+	return c.client.DescribeTags(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeV2LoadBalancers(input *elasticloadbalancingv2.DescribeLoadBalancersInput) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
+	return c.client.DescribeV2LoadBalancers(c.ctx, input)
+}
+
+func (c *contextBoundClient) DescribeV2Tags(input *elasticloadbalancingv2.DescribeTagsInput) (*elasticloadbalancingv2.DescribeTagsOutput, error) {
+	return c.client.DescribeV2Tags(c.ctx, input)
+}
@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	. "github.com/onsi/gomega"
+	"github.com/openshift/osdctl/pkg/provider/aws/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBindContext(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mockCtrl := gomock.NewController(t)
+	mockClient := mock.NewMockContextClient(mockCtrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockClient.EXPECT().ListAccounts(ctx, gomock.Any()).Return(&organizations.ListAccountsOutput{}, nil).Times(1)
+
+	_, err := BindContext(ctx, mockClient).ListAccounts(&organizations.ListAccountsInput{})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestNewContextClientFromClient(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	client, err := NewContextClientFromClient(&AwsClient{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(client).To(BeAssignableToTypeOf(&AwsContextClient{}))
+
+	_, err = NewContextClientFromClient(mock.NewMockClient(gomock.NewController(t)))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestNewAwsContextClientFromConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	client := NewAwsContextClientFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+	})
+	g.Expect(client).To(BeAssignableToTypeOf(&AwsContextClient{}))
+
+	// Calls are issued with the given context, so a cancelled context fails them without contacting AWS
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	g.Expect(err).To(MatchError(context.Canceled))
+}
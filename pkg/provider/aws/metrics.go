@@ -0,0 +1,167 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/spf13/viper"
+)
+
+// RequestMetricsConfigKey enables per-call timing output for AWS clients created afterwards.
+// Commands set it from their --verbose flag.
+const RequestMetricsConfigKey = "aws_request_metrics"
+
+const requestMetricsMiddlewareID = "OsdctlRequestMetrics"
+
+// OperationMetrics aggregates the calls made to a single AWS API operation
+type OperationMetrics struct {
+	Operation string
+	Calls     int
+	Attempts  int
+	Errors    int
+	Throttled int
+	Total     time.Duration
+	Max       time.Duration
+}
+
+// RequestMetrics collects timing information about the AWS API calls made by a process
+type RequestMetrics struct {
+	mutex      sync.Mutex
+	out        io.Writer
+	operations map[string]*OperationMetrics
+}
+
+// requestMetrics is shared by all clients so that commands which create several clients
+// (e.g. one per assumed role) report a single summary
+var requestMetrics = NewRequestMetrics(os.Stderr)
+
+// NewRequestMetrics returns a RequestMetrics which logs every call to out
+func NewRequestMetrics(out io.Writer) *RequestMetrics {
+	return &RequestMetrics{
+		out:        out,
+		operations: map[string]*OperationMetrics{},
+	}
+}
+
+// record stores a completed call and logs it
+func (m *RequestMetrics) record(operation string, duration time.Duration, attempts int, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	op, ok := m.operations[operation]
+	if !ok {
+		op = &OperationMetrics{Operation: operation}
+		m.operations[operation] = op
+	}
+	op.Calls++
+	op.Attempts += attempts
+	op.Total += duration
+	if duration > op.Max {
+		op.Max = duration
+	}
+
+	status := "ok"
+	if err != nil {
+		op.Errors++
+		status = "error"
+		if IsThrottlingError(err) {
+			op.Throttled++
+			status = "throttled"
+		}
+	}
+
+	_, _ = fmt.Fprintf(m.out, "[aws] %s %s attempts=%d %s\n", operation, duration.Round(time.Millisecond), attempts, status)
+}
+
+// Operations returns the aggregated metrics sorted by total time spent, slowest first
+func (m *RequestMetrics) Operations() []OperationMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ops := make([]OperationMetrics, 0, len(m.operations))
+	for _, op := range m.operations {
+		ops = append(ops, *op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Total == ops[j].Total {
+			return ops[i].Operation < ops[j].Operation
+		}
+		return ops[i].Total > ops[j].Total
+	})
+	return ops
+}
+
+// PrintSummary writes a per-operation table of the recorded calls to w
+func (m *RequestMetrics) PrintSummary(w io.Writer) {
+	ops := m.Operations()
+	if len(ops) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "OPERATION\tCALLS\tATTEMPTS\tERRORS\tTHROTTLED\tTOTAL\tAVG\tMAX")
+	for _, op := range ops {
+		avg := op.Total / time.Duration(op.Calls)
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", op.Operation, op.Calls, op.Attempts, op.Errors, op.Throttled,
+			op.Total.Round(time.Millisecond), avg.Round(time.Millisecond), op.Max.Round(time.Millisecond))
+	}
+	_ = tw.Flush()
+}
+
+// PrintRequestMetrics writes the summary of all AWS calls made so far, if request metrics are enabled
+func PrintRequestMetrics(w io.Writer) {
+	if !viper.GetBool(RequestMetricsConfigKey) {
+		return
+	}
+	requestMetrics.PrintSummary(w)
+}
+
+// middleware times the whole call, including retries, and converts throttling errors
+// which survived every retry attempt into a ThrottlingError
+func (m *RequestMetrics) middleware() middleware.InitializeMiddleware {
+	return middleware.InitializeMiddlewareFunc(requestMetricsMiddlewareID, func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		operation := awsmiddleware.GetServiceID(ctx) + "." + awsmiddleware.GetOperationName(ctx)
+
+		start := time.Now()
+		out, metadata, err := next.HandleInitialize(ctx, in)
+		duration := time.Since(start)
+
+		attempts := 1
+		if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+			attempts = len(results.Results)
+		}
+
+		if err != nil && IsThrottlingError(err) {
+			err = &ThrottlingError{Operation: operation, Attempts: attempts, Err: err}
+		}
+
+		if m != nil {
+			m.record(operation, duration, attempts, err)
+		}
+
+		return out, metadata, err
+	})
+}
+
+// addRequestMetricsToConfig registers the request metrics middleware on every client built from config.
+// Throttling errors are always wrapped; timing is only recorded when RequestMetricsConfigKey is set.
+func addRequestMetricsToConfig(config *aws.Config) {
+	var metrics *RequestMetrics
+	if viper.GetBool(RequestMetricsConfigKey) {
+		metrics = requestMetrics
+	}
+
+	config.APIOptions = append(config.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(metrics.middleware(), middleware.After)
+	})
+}
@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/gomega"
+)
+
+func TestRequestMetricsMiddleware(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testCases := []struct {
+		title           string
+		err             error
+		expectThrottled bool
+		expectedStatus  string
+	}{
+		{
+			title:          "successful call",
+			err:            nil,
+			expectedStatus: "ok",
+		},
+		{
+			title:          "failed call",
+			err:            errors.New("FakeError"),
+			expectedStatus: "error",
+		},
+		{
+			title:           "throttled call",
+			err:             &smithy.GenericAPIError{Code: "Throttling"},
+			expectThrottled: true,
+			expectedStatus:  "throttled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			metrics := NewRequestMetrics(out)
+			next := middleware.InitializeHandlerFunc(func(ctx context.Context, in middleware.InitializeInput) (middleware.InitializeOutput, middleware.Metadata, error) {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, tc.err
+			})
+
+			_, _, err := metrics.middleware().HandleInitialize(context.Background(), middleware.InitializeInput{}, next)
+
+			var throttlingErr *ThrottlingError
+			g.Expect(errors.As(err, &throttlingErr)).To(Equal(tc.expectThrottled))
+			g.Expect(out.String()).To(ContainSubstring(tc.expectedStatus))
+
+			ops := metrics.Operations()
+			g.Expect(ops).To(HaveLen(1))
+			g.Expect(ops[0].Calls).To(Equal(1))
+			g.Expect(ops[0].Attempts).To(Equal(1))
+		})
+	}
+}
+
+func TestRequestMetricsPrintSummary(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	metrics := NewRequestMetrics(&bytes.Buffer{})
+	metrics.record("Organizations.ListAccounts", 100, 1, nil)
+	metrics.record("Organizations.ListAccounts", 300, 3, &ThrottlingError{Err: errors.New("FakeError")})
+	metrics.record("STS.GetCallerIdentity", 50, 1, nil)
+
+	ops := metrics.Operations()
+	g.Expect(ops).To(HaveLen(2))
+	g.Expect(ops[0].Operation).To(Equal("Organizations.ListAccounts"))
+	g.Expect(ops[0].Calls).To(Equal(2))
+	g.Expect(ops[0].Attempts).To(Equal(4))
+	g.Expect(ops[0].Errors).To(Equal(1))
+	g.Expect(ops[0].Throttled).To(Equal(1))
+
+	summary := &bytes.Buffer{}
+	metrics.PrintSummary(summary)
+	g.Expect(summary.String()).To(ContainSubstring("OPERATION"))
+	g.Expect(summary.String()).To(ContainSubstring("STS.GetCallerIdentity"))
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a throttled or otherwise retryable call is attempted
+	DefaultMaxAttempts = 10
+	// DefaultMaxBackoff caps the exponential backoff between two attempts
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// ThrottlingError is returned when an AWS API call was still throttled after all retry attempts
+type ThrottlingError struct {
+	Operation string
+	Attempts  int
+	Err       error
+}
+
+func (e *ThrottlingError) Error() string {
+	return fmt.Sprintf("AWS API call %s was still rate limited after %d attempt(s), try again later: %v", e.Operation, e.Attempts, e.Err)
+}
+
+func (e *ThrottlingError) Unwrap() error {
+	return e.Err
+}
+
+// IsThrottlingError returns true when err was caused by AWS API rate limiting
+func IsThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var throttlingErr *ThrottlingError
+	if errors.As(err, &throttlingErr) {
+		return true
+	}
+	return retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+}
+
+// newRetryer returns the shared retry policy for all osdctl AWS clients: the SDK standard
+// retryer with more attempts, a longer backoff cap and no client-side retry quota, so long
+// running commands ride out throttling instead of failing halfway through.
+func newRetryer() aws.Retryer {
+	return retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = DefaultMaxAttempts
+		o.MaxBackoff = DefaultMaxBackoff
+		o.RateLimiter = ratelimit.None
+	})
+}
+
+func addRetryerToConfig(config *aws.Config) {
+	config.Retryer = newRetryer
+}
@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/gomega"
+)
+
+func TestIsThrottlingError(t *testing.T) {
+	g := NewGomegaWithT(t)
+	testCases := []struct {
+		title    string
+		err      error
+		expected bool
+	}{
+		{
+			title:    "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			title:    "generic error",
+			err:      errors.New("FakeError"),
+			expected: false,
+		},
+		{
+			title:    "throttling API error",
+			err:      &smithy.GenericAPIError{Code: "ThrottlingException"},
+			expected: true,
+		},
+		{
+			title:    "wrapped throttling error",
+			err:      &ThrottlingError{Operation: "Organizations.ListAccounts", Attempts: 10, Err: errors.New("FakeError")},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			g.Expect(IsThrottlingError(tc.err)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	got, err := CacheDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "osdctl"); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestLoadSaveJSON(t *testing.T) {
+	type state struct {
+		Step  string   `json:"step"`
+		Items []string `json:"items"`
+	}
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	loaded := &state{Step: "unchanged"}
+	found, err := LoadJSON(path, loaded)
+	if err != nil || found {
+		t.Fatalf("expected a missing file to be reported as not found, got %v, %v", found, err)
+	}
+	if loaded.Step != "unchanged" {
+		t.Errorf("expected the value to be left untouched, got %+v", loaded)
+	}
+
+	if err := SaveJSONAtomic(path, &state{Step: "rollout", Items: []string{"a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := SaveJSONAtomic(path, &state{Step: "completed", Items: []string{"a", "b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded = &state{}
+	found, err = LoadJSON(path, loaded)
+	if err != nil || !found {
+		t.Fatalf("expected the saved file to be found, got %v, %v", found, err)
+	}
+	if loaded.Step != "completed" || len(loaded.Items) != 2 {
+		t.Errorf("unexpected state %+v", loaded)
+	}
+
+	// Only the file itself is left behind, no temporary files
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only %s in its directory, got %v", filepath.Base(path), entries)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := LoadJSON(path, &state{}); err == nil {
+		t.Error("expected an error for an invalid file")
+	}
+}